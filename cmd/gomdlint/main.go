@@ -24,6 +24,9 @@ func main() {
 It provides compatibility with markdownlint while offering superior performance
 and comprehensive rule support with plugin extensibility.`,
 		Version: fmt.Sprintf("%s (commit: %s, date: %s)", version, commit, date),
+		// Errors and exit codes are reported by HandleError
+		SilenceErrors: true,
+		SilenceUsage:  true,
 		Run: func(cmd *cobra.Command, args []string) {
 			// If no subcommand specified, show help or run default linting
 			if len(args) == 0 {
@@ -34,10 +37,10 @@ and comprehensive rule support with plugin extensibility.`,
 			// Default behavior: lint the provided files
 			ctx := context.Background()
 			lintCmd := commands.NewLintCommand()
+			commands.MarkUsageErrors(lintCmd)
 			lintCmd.SetArgs(args)
 			if err := lintCmd.ExecuteContext(ctx); err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				os.Exit(commands.HandleError(os.Stderr, err))
 			}
 		},
 	}
@@ -55,13 +58,17 @@ and comprehensive rule support with plugin extensibility.`,
 		commands.NewRulesCommand(),
 		commands.NewPluginCommand(),
 		commands.NewStyleCommand(),
+		commands.NewCacheCommand(),
+		commands.NewReportCommand(),
+		commands.NewWordsCommand(),
 		commands.NewVersionCommand(version, commit, date),
 	)
+	commands.MarkUsageErrors(rootCmd)
 
 	// Execute root command
+	// Commands report the exit code through the returned error
 	if err := rootCmd.ExecuteContext(context.Background()); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(commands.HandleError(os.Stderr, err))
 	}
 }
 
@@ -69,21 +76,25 @@ func addGlobalFlags(cmd *cobra.Command) {
 	// Configuration flags
 	cmd.PersistentFlags().StringP("config", "c", "", "Path to configuration file")
 	cmd.PersistentFlags().Bool("no-config", false, "Ignore configuration files")
+	cmd.PersistentFlags().String("profile", "", "Configuration profile to apply (default: $GOMDLINT_PROFILE)")
 
 	// Output flags
 	cmd.PersistentFlags().StringP("output", "o", "", "Output file (default: stdout)")
-	cmd.PersistentFlags().StringP("format", "f", "default", "Output format (default, json, junit, checkstyle)")
+	cmd.PersistentFlags().StringP("format", "f", "default", "Output format (default, json, junit, checkstyle, github, gitlab)")
 	cmd.PersistentFlags().Bool("color", true, "Enable colored output")
 	cmd.PersistentFlags().Bool("quiet", false, "Suppress non-error output")
 	cmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 
 	// Performance flags
 	cmd.PersistentFlags().Int("concurrency", 0, "Number of concurrent workers (0 = auto)")
-	cmd.PersistentFlags().Bool("cache", true, "Enable result caching")
+	cmd.PersistentFlags().Bool("cache", true, "Reuse results for unchanged files across runs")
+	cmd.PersistentFlags().String("cache-location", "", "Directory for cached results (default: XDG cache directory)")
+	cmd.PersistentFlags().String("cache-strategy", "content", "How changed files are detected: content or metadata")
 
-	// Rule flags
+	// Rule flags, applied over every configuration file and profile
 	cmd.PersistentFlags().StringSlice("enable", []string{}, "Enable specific rules")
 	cmd.PersistentFlags().StringSlice("disable", []string{}, "Disable specific rules")
 	cmd.PersistentFlags().StringSlice("enable-tag", []string{}, "Enable rules by tag")
 	cmd.PersistentFlags().StringSlice("disable-tag", []string{}, "Disable rules by tag")
+	cmd.PersistentFlags().StringArray("rule", []string{}, `Set rule options as NAME=JSON, e.g. 'MD013={"line_length":120}' (repeatable)`)
 }
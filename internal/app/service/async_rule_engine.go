@@ -79,10 +79,11 @@ func (are *AsyncRuleEngine) LintDocumentAsync(
 				}
 
 				params := entity.RuleParams{
-					Lines:    lines,
-					Config:   are.GetRuleConfig(r.PrimaryName()),
-					Filename: filename,
-					Tokens:   tokens,
+					Lines:       lines,
+					Config:      are.GetRuleConfig(r.PrimaryName()),
+					Filename:    filename,
+					Tokens:      tokens,
+					RuleEnabled: are.IsRuleEnabled,
 				}
 
 				start := time.Now()
@@ -124,10 +125,11 @@ func (are *AsyncRuleEngine) LintDocumentAsync(
 				defer wg.Done()
 
 				params := entity.RuleParams{
-					Lines:    lines,
-					Config:   are.GetRuleConfig(ar.PrimaryName()),
-					Filename: filename,
-					Tokens:   tokens,
+					Lines:       lines,
+					Config:      are.GetRuleConfig(ar.PrimaryName()),
+					Filename:    filename,
+					Tokens:      tokens,
+					RuleEnabled: are.IsRuleEnabled,
 				}
 
 				start := time.Now()
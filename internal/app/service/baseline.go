@@ -0,0 +1,192 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// BaselineVersion is the format version written to baseline files.
+const BaselineVersion = 1
+
+// Baseline records violations that existed when it was written so that only
+// new violations are reported. Violations are identified by file, rule and a
+// fingerprint of the offending line's text rather than by line number, so
+// edits elsewhere in a file do not invalidate the baseline.
+type Baseline struct {
+	Version    int             `json:"version"`
+	Violations []BaselineEntry `json:"violations"`
+}
+
+// BaselineEntry counts the baselined violations sharing a file, rule and
+// fingerprint.
+type BaselineEntry struct {
+	File        string `json:"file"` // Slash-separated path relative to the baseline file
+	Rule        string `json:"rule"`
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+// baselineKey identifies the violations an entry covers.
+type baselineKey struct {
+	file, rule, fingerprint string
+}
+
+func (e BaselineEntry) key() baselineKey {
+	return baselineKey{file: e.File, rule: e.Rule, fingerprint: e.Fingerprint}
+}
+
+// BaselineFingerprint fingerprints a violation of rule on a line with the
+// given text. Whitespace is normalized so re-indenting a line keeps it
+// baselined.
+func BaselineFingerprint(rule, lineText string) string {
+	normalized := strings.Join(strings.Fields(lineText), " ")
+	sum := sha256.Sum256([]byte(rule + "\x00" + normalized))
+	return hex.EncodeToString(sum[:8])
+}
+
+// NewBaseline creates a baseline from entries, combining entries with the
+// same file, rule and fingerprint. Entries are sorted for stable output.
+func NewBaseline(entries []BaselineEntry) *Baseline {
+	counts := make(map[baselineKey]int)
+	for _, entry := range entries {
+		counts[entry.key()] += entry.Count
+	}
+
+	baseline := &Baseline{Version: BaselineVersion, Violations: make([]BaselineEntry, 0, len(counts))}
+	for key, count := range counts {
+		if count > 0 {
+			baseline.Violations = append(baseline.Violations, BaselineEntry{
+				File:        key.file,
+				Rule:        key.rule,
+				Fingerprint: key.fingerprint,
+				Count:       count,
+			})
+		}
+	}
+	sortBaselineEntries(baseline.Violations)
+	return baseline
+}
+
+// LoadBaseline reads a baseline file.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if baseline.Version != BaselineVersion {
+		return nil, fmt.Errorf("unsupported baseline version %d in %s (expected %d)", baseline.Version, path, BaselineVersion)
+	}
+	return NewBaseline(baseline.Violations), nil
+}
+
+// Save writes the baseline to path as indented JSON.
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// Total returns the number of violations covered by the baseline.
+func (b *Baseline) Total() int {
+	total := 0
+	for _, entry := range b.Violations {
+		total += entry.Count
+	}
+	return total
+}
+
+// Without returns a copy of the baseline with the counts in stale removed.
+func (b *Baseline) Without(stale []BaselineEntry) *Baseline {
+	entries := make([]BaselineEntry, 0, len(b.Violations)+len(stale))
+	entries = append(entries, b.Violations...)
+	for _, entry := range stale {
+		entry.Count = -entry.Count
+		entries = append(entries, entry)
+	}
+	return NewBaseline(entries)
+}
+
+// NewFilter starts matching violations against the baseline.
+func (b *Baseline) NewFilter() *BaselineFilter {
+	filter := &BaselineFilter{
+		remaining: make(map[baselineKey]int, len(b.Violations)),
+		linted:    make(map[string]bool),
+	}
+	for _, entry := range b.Violations {
+		filter.remaining[entry.key()] += entry.Count
+	}
+	return filter
+}
+
+// BaselineFilter suppresses baselined violations, each baseline entry
+// covering at most its count of violations.
+type BaselineFilter struct {
+	remaining map[baselineKey]int
+	linted    map[string]bool
+	matched   int
+}
+
+// MarkLinted records that file was linted, so baseline entries for it that
+// match no violation are reported as stale.
+func (f *BaselineFilter) MarkLinted(file string) {
+	f.linted[file] = true
+}
+
+// Suppress reports whether a violation is covered by the baseline and, if
+// so, consumes one count of the matching entry.
+func (f *BaselineFilter) Suppress(file, rule, fingerprint string) bool {
+	key := baselineKey{file: file, rule: rule, fingerprint: fingerprint}
+	if f.remaining[key] <= 0 {
+		return false
+	}
+	f.remaining[key]--
+	f.matched++
+	return true
+}
+
+// Suppressed returns the number of violations suppressed so far.
+func (f *BaselineFilter) Suppressed() int {
+	return f.matched
+}
+
+// Stale returns the baseline entries for linted files that no longer match
+// a violation, with Count set to the number of unmatched violations. Entries
+// for files that were not linted are never stale.
+func (f *BaselineFilter) Stale() []BaselineEntry {
+	var stale []BaselineEntry
+	for key, count := range f.remaining {
+		if count > 0 && f.linted[key.file] {
+			stale = append(stale, BaselineEntry{File: key.file, Rule: key.rule, Fingerprint: key.fingerprint, Count: count})
+		}
+	}
+	sortBaselineEntries(stale)
+	return stale
+}
+
+func sortBaselineEntries(entries []BaselineEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaselineFingerprint(t *testing.T) {
+	assert.Equal(t,
+		BaselineFingerprint("MD013", "Some  long\ttext"),
+		BaselineFingerprint("MD013", "  Some long text  "),
+		"whitespace is normalized")
+	assert.NotEqual(t, BaselineFingerprint("MD013", "text"), BaselineFingerprint("MD033", "text"))
+	assert.NotEqual(t, BaselineFingerprint("MD013", "text"), BaselineFingerprint("MD013", "other text"))
+	assert.Len(t, BaselineFingerprint("MD013", "text"), 16)
+}
+
+func TestNewBaseline_MergesAndSorts(t *testing.T) {
+	baseline := NewBaseline([]BaselineEntry{
+		{File: "b.md", Rule: "MD013", Fingerprint: "1", Count: 1},
+		{File: "a.md", Rule: "MD022", Fingerprint: "2", Count: 1},
+		{File: "b.md", Rule: "MD013", Fingerprint: "1", Count: 2},
+		{File: "a.md", Rule: "MD009", Fingerprint: "3", Count: 0},
+	})
+
+	assert.Equal(t, []BaselineEntry{
+		{File: "a.md", Rule: "MD022", Fingerprint: "2", Count: 1},
+		{File: "b.md", Rule: "MD013", Fingerprint: "1", Count: 3},
+	}, baseline.Violations)
+	assert.Equal(t, 4, baseline.Total())
+}
+
+func TestBaselineFilter(t *testing.T) {
+	baseline := NewBaseline([]BaselineEntry{
+		{File: "a.md", Rule: "MD013", Fingerprint: "long", Count: 2},
+		{File: "a.md", Rule: "MD022", Fingerprint: "heading", Count: 1},
+		{File: "b.md", Rule: "MD013", Fingerprint: "long", Count: 1},
+	})
+
+	filter := baseline.NewFilter()
+	filter.MarkLinted("a.md")
+
+	assert.True(t, filter.Suppress("a.md", "MD013", "long"))
+	assert.False(t, filter.Suppress("a.md", "MD013", "other"), "different line text is new")
+	assert.False(t, filter.Suppress("a.md", "MD009", "long"), "different rule is new")
+	assert.Equal(t, 1, filter.Suppressed())
+
+	stale := filter.Stale()
+	assert.Equal(t, []BaselineEntry{
+		{File: "a.md", Rule: "MD013", Fingerprint: "long", Count: 1},
+		{File: "a.md", Rule: "MD022", Fingerprint: "heading", Count: 1},
+	}, stale, "b.md was not linted, so its entries are not stale")
+
+	assert.True(t, filter.Suppress("a.md", "MD013", "long"))
+	assert.False(t, filter.Suppress("a.md", "MD013", "long"), "each entry covers at most its count")
+
+	pruned := baseline.Without(stale)
+	assert.Equal(t, []BaselineEntry{
+		{File: "a.md", Rule: "MD013", Fingerprint: "long", Count: 1},
+		{File: "b.md", Rule: "MD013", Fingerprint: "long", Count: 1},
+	}, pruned.Violations)
+}
+
+func TestBaseline_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".gomdlint-baseline.json")
+	baseline := NewBaseline([]BaselineEntry{{File: "a.md", Rule: "MD013", Fingerprint: "f", Count: 2}})
+	require.NoError(t, baseline.Save(path))
+
+	loaded, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, baseline, loaded)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 99, "violations": []}`), 0644))
+	_, err = LoadBaseline(path)
+	assert.ErrorContains(t, err, "unsupported baseline version 99")
+
+	_, err = LoadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read baseline")
+}
@@ -0,0 +1,330 @@
+// Package codecheck validates code snippets in structured languages that
+// can be checked in-process: JSON (and JSONC), YAML, TOML, Go and XML. It
+// reports the first syntax error of a snippet at its line and column, and
+// reformats JSON and Go.
+package codecheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Languages that can be checked.
+const (
+	LanguageJSON  = "json"
+	LanguageJSONC = "jsonc" // JSON with comments and trailing commas
+	LanguageYAML  = "yaml"
+	LanguageTOML  = "toml"
+	LanguageGo    = "go"
+	LanguageXML   = "xml"
+)
+
+// aliases maps other names of the checked languages, as used in fenced code
+// block info strings, to the language.
+var aliases = map[string]string{
+	"json":    LanguageJSON,
+	"geojson": LanguageJSON,
+	"jsonc":   LanguageJSONC,
+	"yaml":    LanguageYAML,
+	"yml":     LanguageYAML,
+	"toml":    LanguageTOML,
+	"go":      LanguageGo,
+	"golang":  LanguageGo,
+	"xml":     LanguageXML,
+	"svg":     LanguageXML,
+	"xsd":     LanguageXML,
+	"xsl":     LanguageXML,
+	"xslt":    LanguageXML,
+	"plist":   LanguageXML,
+}
+
+// Problem is a syntax error in a snippet. Line and Column are 1-based, the
+// column counted in bytes; Column is 0 when the checker only reports lines.
+type Problem struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Language returns the checked language a name refers to, looking it up in
+// extra, which maps further names to languages or their names, before the
+// built-in aliases. Names are compared without case.
+func Language(name string, extra map[string]string) (string, bool) {
+	name = strings.ToLower(name)
+	if target, exists := extra[name]; exists {
+		name = strings.ToLower(target)
+	}
+	language, exists := aliases[name]
+	return language, exists
+}
+
+// Check returns the first syntax error of a snippet in a language, or nil
+// when it is valid or the language cannot be checked.
+func Check(language, src string) *Problem {
+	var problem *Problem
+	switch language {
+	case LanguageJSON:
+		problem = checkJSON(src)
+	case LanguageJSONC:
+		problem = checkJSON(stripJSONC(src))
+	case LanguageYAML:
+		problem = checkYAML(src)
+	case LanguageTOML:
+		problem = checkTOML(src)
+	case LanguageGo:
+		problem = checkGo(src)
+	case LanguageXML:
+		problem = checkXML(src)
+	}
+
+	// Errors at the end of input may be reported past the last line
+	if problem != nil {
+		lines := strings.Split(src, "\n")
+		if problem.Line > len(lines) {
+			problem.Line = len(lines)
+			problem.Column = len(lines[len(lines)-1]) + 1
+		}
+		if problem.Line < 1 {
+			problem.Line = 1
+		}
+	}
+	return problem
+}
+
+// Format returns a snippet reformatted: JSON indented with indent spaces
+// and Go formatted like gofmt. ok is false when the language cannot be
+// formatted or the snippet is invalid.
+func Format(language, src string, indent int) (formatted string, ok bool) {
+	switch language {
+	case LanguageJSON:
+		var out bytes.Buffer
+		if err := json.Indent(&out, []byte(strings.TrimSpace(src)), "", strings.Repeat(" ", indent)); err != nil {
+			return "", false
+		}
+		return out.String(), true
+	case LanguageGo:
+		out, err := format.Source([]byte(src))
+		if err != nil {
+			return "", false
+		}
+		return strings.TrimRight(string(out), "\n"), true
+	}
+	return "", false
+}
+
+// position returns the 1-based line and byte column of an offset in src.
+func position(src string, offset int) (line, column int) {
+	offset = min(max(offset, 0), len(src))
+	lineStart := strings.LastIndex(src[:offset], "\n") + 1
+	return strings.Count(src[:offset], "\n") + 1, offset - lineStart + 1
+}
+
+func checkJSON(src string) *Problem {
+	if strings.TrimSpace(src) == "" {
+		return nil
+	}
+
+	var value interface{}
+	err := json.Unmarshal([]byte(src), &value)
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return nil
+	}
+
+	// The offset follows the offending character, or is the length of
+	// the input when it ends early
+	offset := int(syntaxErr.Offset) - 1
+	if strings.HasPrefix(syntaxErr.Error(), "unexpected end") {
+		offset = len(strings.TrimRight(src, " \t\r\n"))
+	}
+	line, column := position(src, offset)
+	return &Problem{Line: line, Column: column, Message: syntaxErr.Error()}
+}
+
+// stripJSONC blanks out the comments and trailing commas of JSONC, keeping
+// the offsets of everything else.
+func stripJSONC(src string) string {
+	out := []byte(src)
+	inString := false
+	lastComma := -1 // Offset of a comma followed only by blanks and comments
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		switch {
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			lastComma = -1
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			end := bytes.Index(out[i+2:], []byte("*/"))
+			if end < 0 {
+				end = len(out)
+			} else {
+				end += i + 4
+			}
+			for ; i < end; i++ {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+			}
+			i--
+		case c == ',':
+			lastComma = i
+		case c == '}' || c == ']':
+			if lastComma >= 0 {
+				out[lastComma] = ' '
+			}
+			lastComma = -1
+		case c != ' ' && c != '\t' && c != '\r' && c != '\n':
+			lastComma = -1
+		}
+	}
+	return string(out)
+}
+
+// yamlLineRegex finds the line in a YAML error message.
+var yamlLineRegex = regexp.MustCompile(`line (\d+): `)
+
+func checkYAML(src string) *Problem {
+	decoder := yaml.NewDecoder(strings.NewReader(src))
+	for {
+		var value interface{}
+		err := decoder.Decode(&value)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		message := strings.TrimPrefix(err.Error(), "yaml: ")
+		message = strings.TrimSpace(strings.TrimPrefix(message, "unmarshal errors:\n"))
+		line := 1
+		if match := yamlLineRegex.FindStringSubmatchIndex(message); match != nil {
+			line, _ = strconv.Atoi(message[match[2]:match[3]])
+			message = message[match[1]:]
+		}
+		if first, _, found := strings.Cut(message, "\n"); found {
+			message = first
+		}
+		return &Problem{Line: line, Message: message}
+	}
+}
+
+// tomlLineRegex matches the position prefix of TOML error messages.
+var tomlLineRegex = regexp.MustCompile(`^toml: line \d+(?: \(last key "[^"]*"\))?: `)
+
+func checkTOML(src string) *Problem {
+	var value map[string]interface{}
+	_, err := toml.Decode(src, &value)
+	var parseErr toml.ParseError
+	if !errors.As(err, &parseErr) {
+		return nil
+	}
+
+	message := parseErr.Message
+	if message == "" {
+		message = tomlLineRegex.ReplaceAllString(parseErr.Error(), "")
+	}
+	// The offset is more reliable than the line, which is 1 at the end of
+	// input
+	if parseErr.Position.Start == 0 && parseErr.Position.Line > 1 {
+		return &Problem{Line: parseErr.Position.Line, Message: message}
+	}
+	line, column := position(src, parseErr.Position.Start)
+	return &Problem{Line: line, Column: column, Message: message}
+}
+
+// goPackageRegex finds the package clause of a complete Go file.
+var goPackageRegex = regexp.MustCompile(`(?m)^\s*package\s+\w+`)
+
+// Go snippets that are not complete files are parsed as declarations or as
+// statements, wrapped on their first line like go/format does.
+const (
+	goDeclarationsPrefix = "package p;"
+	goStatementsPrefix   = "package p; func _() {"
+)
+
+func checkGo(src string) *Problem {
+	if goPackageRegex.MatchString(src) {
+		return parseGo(src, "")
+	}
+
+	declarations := parseGo(src, goDeclarationsPrefix)
+	if declarations == nil {
+		return nil
+	}
+	statements := parseGo(src+"\n}", goStatementsPrefix)
+	if statements == nil {
+		return nil
+	}
+
+	// Report the error of the reading that got further
+	if statements.Line > declarations.Line || (statements.Line == declarations.Line && statements.Column > declarations.Column) {
+		return statements
+	}
+	return declarations
+}
+
+// parseGo parses src preceded by prefix on its first line and returns the
+// first error, positioned in src.
+func parseGo(src, prefix string) *Problem {
+	_, err := parser.ParseFile(token.NewFileSet(), "", prefix+src, parser.AllErrors)
+	var errList scanner.ErrorList
+	if !errors.As(err, &errList) || len(errList) == 0 {
+		return nil
+	}
+
+	first := errList[0]
+	column := first.Pos.Column
+	if first.Pos.Line == 1 {
+		column = max(column-len(prefix), 1)
+	}
+	return &Problem{Line: first.Pos.Line, Column: column, Message: first.Msg}
+}
+
+func checkXML(src string) *Problem {
+	if strings.TrimSpace(src) == "" {
+		return nil
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(src))
+	for {
+		_, err := decoder.Token()
+		if err == nil {
+			continue
+		}
+		var syntaxErr *xml.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			return nil
+		}
+
+		// The decoder stops after the offending input
+		line, column := position(src, int(decoder.InputOffset())-1)
+		if line != syntaxErr.Line {
+			line, column = syntaxErr.Line, 0
+		}
+		return &Problem{Line: line, Column: column, Message: syntaxErr.Msg}
+	}
+}
@@ -0,0 +1,86 @@
+package codecheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguage(t *testing.T) {
+	language, ok := Language("YML", nil)
+	assert.True(t, ok)
+	assert.Equal(t, LanguageYAML, language)
+
+	language, ok = Language("jsonld", map[string]string{"jsonld": "json"})
+	assert.True(t, ok)
+	assert.Equal(t, LanguageJSON, language)
+
+	language, ok = Language("golang", map[string]string{"jsonld": "json"})
+	assert.True(t, ok)
+	assert.Equal(t, LanguageGo, language)
+
+	_, ok = Language("python", nil)
+	assert.False(t, ok)
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		src      string
+		expected *Problem
+	}{
+		{"valid JSON", LanguageJSON, "{\n  \"a\": [1, 2]\n}", nil},
+		{"JSON missing comma", LanguageJSON, "{\n  \"a\": 1\n  \"b\": 2\n}", &Problem{Line: 3, Column: 3}},
+		{"JSON ending early", LanguageJSON, "{\n  \"a\": [1, 2", &Problem{Line: 2, Column: 13}},
+		{"JSON comment", LanguageJSON, "{\n  // note\n  \"a\": 1\n}", &Problem{Line: 2, Column: 3}},
+		{"valid JSONC", LanguageJSONC, "{\n  // note\n  \"a\": \"// not a comment\", /* more */\n}", nil},
+		{"invalid JSONC", LanguageJSONC, "{\n  \"a\": 1,\n  \"b\"\n}", &Problem{Line: 4, Column: 1}},
+		{"valid YAML", LanguageYAML, "a: 1\n---\nb: [1, 2]", nil},
+		{"YAML indentation", LanguageYAML, "a: 1\n  b: 2", &Problem{Line: 2}},
+		{"YAML duplicate key", LanguageYAML, "a: 1\na: 2", &Problem{Line: 2}},
+		{"valid TOML", LanguageTOML, "[server]\nport = 8080", nil},
+		{"TOML missing value", LanguageTOML, "[server]\nport = ", &Problem{Line: 2, Column: 7}},
+		{"valid Go file", LanguageGo, "package main\n\nfunc main() {}", nil},
+		{"valid Go declarations", LanguageGo, "func add(a, b int) int {\n\treturn a + b\n}", nil},
+		{"valid Go statements", LanguageGo, "x := 1\nfmt.Println(x)", nil},
+		{"Go syntax error", LanguageGo, "func main() {\n\tfmt.Println(\"hi\"\n}", &Problem{Line: 2, Column: 18}},
+		{"Go error on first line", LanguageGo, "x := [1", &Problem{Line: 1, Column: 8}},
+		{"valid XML", LanguageXML, "<?xml version=\"1.0\"?>\n<a>\n  <b/>\n</a>", nil},
+		{"XML mismatched tag", LanguageXML, "<a>\n  <b>\n</a>", &Problem{Line: 3}},
+		{"XML unquoted attribute", LanguageXML, "<a x=1/>", &Problem{Line: 1, Column: 6}},
+		{"unchecked language", "python", "def (", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := Check(tt.language, tt.src)
+			if tt.expected == nil {
+				assert.Nil(t, problem)
+				return
+			}
+			require.NotNil(t, problem)
+			assert.NotEmpty(t, problem.Message)
+			assert.Equal(t, tt.expected.Line, problem.Line, problem.Message)
+			if tt.expected.Column > 0 {
+				assert.Equal(t, tt.expected.Column, problem.Column, problem.Message)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	formatted, ok := Format(LanguageJSON, "{\"b\": [1,2], \"a\": {}}", 2)
+	require.True(t, ok)
+	assert.Equal(t, "{\n  \"b\": [\n    1,\n    2\n  ],\n  \"a\": {}\n}", formatted)
+
+	formatted, ok = Format(LanguageGo, "x:=1\nif x>0 {\nfmt.Println(x)\n}", 0)
+	require.True(t, ok)
+	assert.Equal(t, "x := 1\nif x > 0 {\n\tfmt.Println(x)\n}", formatted)
+
+	_, ok = Format(LanguageJSON, "{", 2)
+	assert.False(t, ok)
+	_, ok = Format(LanguageYAML, "a: 1", 2)
+	assert.False(t, ok)
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/shared/utils"
+)
+
+// styleReferencePrefix marks an extends entry that names a built-in style.
+const styleReferencePrefix = "style:"
+
+// packageConfigExtensions are tried, in order, when a package reference does
+// not name a file directly.
+var packageConfigExtensions = []string{".json", ".jsonc", ".yaml", ".yml", ".toml"}
+
+// ExtendsKind identifies what an extends entry refers to.
+type ExtendsKind string
+
+const (
+	ExtendsKindFile    ExtendsKind = "file"
+	ExtendsKindStyle   ExtendsKind = "style"
+	ExtendsKindPackage ExtendsKind = "package"
+)
+
+// ExtendsReference is a resolved entry of an "extends" list.
+type ExtendsReference struct {
+	Raw  string      // Entry as written in the configuration file
+	Kind ExtendsKind // What the entry refers to
+	Name string      // Style name for style references
+	Path string      // Absolute file path for file and package references
+}
+
+// Source returns a display name for the reference, used in provenance output.
+func (r ExtendsReference) Source() string {
+	if r.Kind == ExtendsKindStyle {
+		return styleReferencePrefix + r.Name
+	}
+	return r.Path
+}
+
+// ExtendsCycleError reports a configuration that (indirectly) extends itself.
+type ExtendsCycleError struct {
+	Chain []string
+}
+
+// Error implements the error interface.
+func (e *ExtendsCycleError) Error() string {
+	chain := make([]string, len(e.Chain))
+	for i, path := range e.Chain {
+		chain[i] = displayConfigPath(path)
+	}
+	return fmt.Sprintf("circular extends: %s", strings.Join(chain, " -> "))
+}
+
+// ResolveExtendsReference resolves a single extends entry found in fromFile.
+// Relative paths are resolved against the directory of fromFile (or the
+// working directory when fromFile is empty); bare names are looked up as
+// packages in node_modules directories above it.
+func ResolveExtendsReference(ref string, fromFile string) (ExtendsReference, error) {
+	result := ExtendsReference{Raw: ref}
+
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return result, fmt.Errorf("empty extends reference")
+	}
+
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		return result, fmt.Errorf("remote extends are not supported: %s", ref)
+	}
+
+	if strings.HasPrefix(ref, styleReferencePrefix) {
+		result.Kind = ExtendsKindStyle
+		result.Name = strings.TrimPrefix(ref, styleReferencePrefix)
+		if result.Name == "" {
+			return result, fmt.Errorf("missing style name in extends reference %q", ref)
+		}
+		return result, nil
+	}
+
+	baseDir, err := referenceBaseDir(fromFile)
+	if err != nil {
+		return result, err
+	}
+
+	if filepath.IsAbs(ref) || isRelativeReference(ref) {
+		result.Kind = ExtendsKindFile
+		result.Path = filepath.Clean(ref)
+		if !filepath.IsAbs(result.Path) {
+			result.Path = filepath.Join(baseDir, ref)
+		}
+		return result, nil
+	}
+
+	result.Kind = ExtendsKindPackage
+	path, err := findPackageConfig(ref, baseDir)
+	if err != nil {
+		return result, err
+	}
+	result.Path = path
+	return result, nil
+}
+
+// isRelativeReference reports whether ref is written as an explicit relative path.
+func isRelativeReference(ref string) bool {
+	return ref == "." || ref == ".." ||
+		strings.HasPrefix(ref, "./") || strings.HasPrefix(ref, "../") ||
+		strings.HasPrefix(ref, ".\\") || strings.HasPrefix(ref, "..\\")
+}
+
+// referenceBaseDir returns the directory relative references are resolved from.
+func referenceBaseDir(fromFile string) (string, error) {
+	if fromFile == "" {
+		return os.Getwd()
+	}
+	abs, err := filepath.Abs(fromFile)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(abs), nil
+}
+
+// findPackageConfig searches node_modules directories from dir upwards for
+// the named package configuration.
+func findPackageConfig(name string, dir string) (string, error) {
+	for {
+		candidate := filepath.Join(dir, "node_modules", filepath.FromSlash(name))
+		if path, ok := packageConfigCandidate(candidate); ok {
+			return path, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return "", fmt.Errorf("cannot find package %q in any node_modules directory", name)
+}
+
+// packageConfigCandidate resolves candidate to a configuration file, trying
+// the path itself, known extensions and finally an index file inside it.
+func packageConfigCandidate(candidate string) (string, bool) {
+	if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+		return candidate, true
+	}
+
+	for _, ext := range packageConfigExtensions {
+		if info, err := os.Stat(candidate + ext); err == nil && !info.IsDir() {
+			return candidate + ext, true
+		}
+	}
+
+	for _, ext := range packageConfigExtensions {
+		index := filepath.Join(candidate, "index"+ext)
+		if info, err := os.Stat(index); err == nil && !info.IsDir() {
+			return index, true
+		}
+	}
+
+	return "", false
+}
+
+// ConfigLayer is one flattened step of an extends chain.
+type ConfigLayer struct {
+	Source string                 // File path or "style:<name>"
+	Config map[string]interface{} // Configuration without its "extends" key
+}
+
+// ExtendsResolver expands "extends" references into an ordered list of layers.
+type ExtendsResolver struct {
+	styles *StyleRegistry
+	stack  []string // For circular dependency detection
+}
+
+// NewExtendsResolver creates a resolver that looks up style references in styles.
+func NewExtendsResolver(styles *StyleRegistry) *ExtendsResolver {
+	if styles == nil {
+		styles = GetGlobalStyleRegistry()
+	}
+	return &ExtendsResolver{styles: styles}
+}
+
+// ResolveFile loads path and everything it extends. Layers are returned from
+// lowest to highest precedence, so the file itself comes last.
+func (er *ExtendsResolver) ResolveFile(path string) ([]ConfigLayer, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, visited := range er.stack {
+		if visited == absPath {
+			chain := append(append([]string{}, er.stack[i:]...), absPath)
+			return nil, &ExtendsCycleError{Chain: chain}
+		}
+	}
+
+	er.stack = append(er.stack, absPath)
+	defer func() {
+		er.stack = er.stack[:len(er.stack)-1]
+	}()
+
+	rawConfig, err := ParseConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return er.ResolveConfig(path, rawConfig)
+}
+
+// ResolveConfig expands the extends references of a configuration that was
+// loaded from path, and resolves the relative file paths of its rule
+// parameters against the directory of path.
+func (er *ExtendsResolver) ResolveConfig(path string, rawConfig map[string]interface{}) ([]ConfigLayer, error) {
+	refs, err := extendsList(rawConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var layers []ConfigLayer
+	for _, raw := range refs {
+		ref, err := ResolveExtendsReference(raw, path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		if ref.Kind == ExtendsKindStyle {
+			style, err := er.styles.GetStyle(ref.Name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: extends %q: %w", path, raw, err)
+			}
+			styleConfig := style.ToMap()
+			delete(styleConfig, "extends")
+			layers = append(layers, ConfigLayer{Source: ref.Source(), Config: styleConfig})
+			continue
+		}
+
+		extended, err := er.ResolveFile(ref.Path)
+		if err != nil {
+			var cycleErr *ExtendsCycleError
+			if errors.As(err, &cycleErr) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s: extends %q: %w", path, raw, err)
+		}
+		layers = append(layers, extended...)
+	}
+
+	own := make(map[string]interface{}, len(rawConfig))
+	for key, value := range rawConfig {
+		if key != "extends" {
+			own[key] = value
+		}
+	}
+	baseDir, err := referenceBaseDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	layers = append(layers, ConfigLayer{Source: path, Config: resolveConfigPaths(own, baseDir)})
+
+	return layers, nil
+}
+
+// FlattenConfigLayers merges layers in order into a single configuration.
+func FlattenConfigLayers(layers []ConfigLayer) map[string]interface{} {
+	configs := make([]map[string]interface{}, len(layers))
+	for i, layer := range layers {
+		configs[i] = layer.Config
+	}
+	return utils.DeepMergeConfig(configs...)
+}
+
+// extendsList returns the "extends" entries of a raw configuration, which may
+// be written as a single string or a list of strings.
+func extendsList(rawConfig map[string]interface{}) ([]string, error) {
+	switch extends := rawConfig["extends"].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{extends}, nil
+	case []string:
+		return extends, nil
+	case []interface{}:
+		refs := make([]string, 0, len(extends))
+		for _, ext := range extends {
+			ref, ok := ext.(string)
+			if !ok {
+				return nil, fmt.Errorf("extends entries must be strings, got %T", ext)
+			}
+			refs = append(refs, ref)
+		}
+		return refs, nil
+	default:
+		return nil, fmt.Errorf("extends must be a string or a list of strings, got %T", extends)
+	}
+}
+
+// displayConfigPath shortens path relative to the working directory when possible.
+func displayConfigPath(path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
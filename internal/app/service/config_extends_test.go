@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestResolveExtendsReference_Scenarios(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigFiles(t, tmpDir, map[string]string{
+		"node_modules/markdownlint-config-acme/index.yaml": "MD013: false\n",
+		"node_modules/@team/style.json":                    "{}",
+	})
+	fromFile := filepath.Join(tmpDir, "project", "docs", ".markdownlint.json")
+
+	scenarios := []struct {
+		name         string
+		ref          string
+		expectedKind ExtendsKind
+		expectedPath string
+		expectedName string
+		expectError  string
+	}{
+		{name: "style", ref: "style:strict", expectedKind: ExtendsKindStyle, expectedName: "strict"},
+		{name: "relative file", ref: "./base.json", expectedKind: ExtendsKindFile, expectedPath: filepath.Join(tmpDir, "project", "docs", "base.json")},
+		{name: "parent file", ref: "../shared/.markdownlint.yaml", expectedKind: ExtendsKindFile, expectedPath: filepath.Join(tmpDir, "project", "shared", ".markdownlint.yaml")},
+		{name: "package directory", ref: "markdownlint-config-acme", expectedKind: ExtendsKindPackage, expectedPath: filepath.Join(tmpDir, "node_modules", "markdownlint-config-acme", "index.yaml")},
+		{name: "scoped package file", ref: "@team/style", expectedKind: ExtendsKindPackage, expectedPath: filepath.Join(tmpDir, "node_modules", "@team", "style.json")},
+		{name: "missing package", ref: "does-not-exist", expectError: "cannot find package"},
+		{name: "remote url", ref: "https://example.com/config.json", expectError: "remote extends are not supported"},
+		{name: "empty style", ref: "style:", expectError: "missing style name"},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			ref, err := ResolveExtendsReference(scenario.ref, fromFile)
+			if scenario.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), scenario.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expectedKind, ref.Kind)
+			assert.Equal(t, scenario.expectedPath, ref.Path)
+			assert.Equal(t, scenario.expectedName, ref.Name)
+		})
+	}
+}
+
+func TestExtendsResolver_ResolveFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigFiles(t, tmpDir, map[string]string{
+		"shared/.markdownlint.yaml": "extends: ./common.toml\nMD013:\n  line_length: 100\n",
+		"shared/common.toml":        "MD033 = false\n\n[MD013]\ntables = false\n",
+		"project/base.json":         `{"MD041": false, "MD013": {"line_length": 90}}`,
+		"project/.markdownlint.json": `{
+  "extends": ["style:strict", "./base.json", "../shared/.markdownlint.yaml"],
+  "MD013": {"code_blocks": false}
+}`,
+	})
+
+	configPath := filepath.Join(tmpDir, "project", ".markdownlint.json")
+	layers, err := NewExtendsResolver(NewStyleRegistry()).ResolveFile(configPath)
+	require.NoError(t, err)
+
+	sources := make([]string, len(layers))
+	for i, layer := range layers {
+		sources[i] = layer.Source
+		assert.NotContains(t, layer.Config, "extends")
+	}
+	assert.Equal(t, []string{
+		"style:strict",
+		filepath.Join(tmpDir, "project", "base.json"),
+		filepath.Join(tmpDir, "shared", "common.toml"),
+		filepath.Join(tmpDir, "shared", ".markdownlint.yaml"),
+		configPath,
+	}, sources)
+
+	flattened := FlattenConfigLayers(layers)
+	assert.Equal(t, false, flattened["MD041"])
+	assert.Equal(t, false, flattened["MD033"])
+	md013, ok := flattened["MD013"].(map[string]interface{})
+	require.True(t, ok)
+	assert.EqualValues(t, 100, md013["line_length"])
+	assert.Equal(t, false, md013["tables"])
+	assert.Equal(t, false, md013["code_blocks"])
+}
+
+func TestExtendsResolver_ResolvesParameterPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigFiles(t, tmpDir, map[string]string{
+		"shared/base.yaml":           "GMD003:\n  styles:\n    - ./Shared\n    - /styles/Absolute\nMD013:\n  line_length: 90\n",
+		"project/.markdownlint.yaml": "extends: ../shared/base.yaml\nprose:\n  styles: [styles/House]\nspelling:\n  dictionaries: [dict/en_US]\n  word_lists: words.txt\nfront-matter-schema:\n  schema: schemas/page.yaml\n  schemas:\n    - files: \"content/posts/**\"\n      schema: schemas/post.yaml\n    - files: \"*.draft.md\"\n      schema: schemas/draft.yaml\nprofiles:\n  docs:\n    rules:\n      prose:\n        styles: [styles/Docs]\n",
+	})
+
+	configPath := filepath.Join(tmpDir, "project", ".markdownlint.yaml")
+	layers, err := NewExtendsResolver(NewStyleRegistry()).ResolveFile(configPath)
+	require.NoError(t, err)
+	require.Len(t, layers, 2)
+
+	// Paths are relative to the file that sets them, whatever the working directory
+	assert.Equal(t, []interface{}{filepath.Join(tmpDir, "shared", "Shared"), "/styles/Absolute"},
+		layers[0].Config["GMD003"].(map[string]interface{})["styles"])
+	assert.EqualValues(t, 90, layers[0].Config["MD013"].(map[string]interface{})["line_length"])
+	assert.Equal(t, []interface{}{filepath.Join(tmpDir, "project", "styles", "House")},
+		layers[1].Config["prose"].(map[string]interface{})["styles"])
+	spelling := layers[1].Config["spelling"].(map[string]interface{})
+	assert.Equal(t, []interface{}{filepath.Join(tmpDir, "project", "dict", "en_US")}, spelling["dictionaries"])
+	assert.Equal(t, filepath.Join(tmpDir, "project", "words.txt"), spelling["word_lists"])
+
+	// Globs with a slash are anchored to the file's directory too
+	frontMatter := layers[1].Config["front-matter-schema"].(map[string]interface{})
+	assert.Equal(t, filepath.Join(tmpDir, "project", "schemas", "page.yaml"), frontMatter["schema"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"files": filepath.ToSlash(filepath.Join(tmpDir, "project", "content", "posts", "**")), "schema": filepath.Join(tmpDir, "project", "schemas", "post.yaml")},
+		map[string]interface{}{"files": "*.draft.md", "schema": filepath.Join(tmpDir, "project", "schemas", "draft.yaml")},
+	}, frontMatter["schemas"])
+
+	// Profiles without extends are applied without the file they came from
+	profileLayers, err := ProfileLayers(FlattenConfigLayers(layers), "docs", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{filepath.Join(tmpDir, "project", "styles", "Docs")},
+		profileLayers[len(profileLayers)-1].Config["prose"].(map[string]interface{})["styles"])
+}
+
+func TestExtendsResolver_CycleDetection(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigFiles(t, tmpDir, map[string]string{
+		"a.json":        `{"extends": "./b.yaml"}`,
+		"b.yaml":        "extends:\n  - ./nested/c.json\n",
+		"nested/c.json": `{"extends": ["../a.json"]}`,
+	})
+
+	_, err := NewExtendsResolver(NewStyleRegistry()).ResolveFile(filepath.Join(tmpDir, "a.json"))
+	require.Error(t, err)
+
+	var cycleErr *ExtendsCycleError
+	require.True(t, errors.As(err, &cycleErr), "expected ExtendsCycleError, got %T: %v", err, err)
+	assert.Equal(t, []string{
+		filepath.Join(tmpDir, "a.json"),
+		filepath.Join(tmpDir, "b.yaml"),
+		filepath.Join(tmpDir, "nested", "c.json"),
+		filepath.Join(tmpDir, "a.json"),
+	}, cycleErr.Chain)
+	assert.Contains(t, err.Error(), "circular extends: ")
+	assert.Contains(t, err.Error(), "b.yaml -> ")
+}
+
+func TestConfigResolver_ResolveConfigExtends(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigFiles(t, tmpDir, map[string]string{
+		"base.yaml":   "MD013:\n  line_length: 100\nMD041: false\n",
+		"config.json": `{"extends": ["style:relaxed", "./base.yaml"], "MD041": true}`,
+		"loop.json":   `{"extends": "./loop.json"}`,
+	})
+
+	resolver := NewConfigResolver(nil)
+
+	result := resolver.ResolveConfig(context.Background(), filepath.Join(tmpDir, "config.json"))
+	if result.IsErr() {
+		t.Fatalf("unexpected error: %v", result.Error())
+	}
+	config := result.Unwrap()
+	assert.True(t, config.IsRuleEnabled("MD041"))
+	md013, exists := config.GetRuleConfig("MD013")
+	require.True(t, exists)
+	assert.Equal(t, 100, md013.Options["line_length"])
+
+	loop := resolver.ResolveConfig(context.Background(), filepath.Join(tmpDir, "loop.json"))
+	require.True(t, loop.IsErr())
+	assert.Contains(t, loop.Error().Error(), "circular extends")
+}
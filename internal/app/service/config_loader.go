@@ -0,0 +1,498 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// ConfigFormat identifies the syntax of a configuration file
+type ConfigFormat string
+
+const (
+	ConfigFormatJSON  ConfigFormat = "json"  // Strict JSON
+	ConfigFormatJSONC ConfigFormat = "jsonc" // JSON with comments and trailing commas
+	ConfigFormatYAML  ConfigFormat = "yaml"  // YAML 1.2
+	ConfigFormatTOML  ConfigFormat = "toml"  // TOML 1.0
+)
+
+// ConfigParseError reports a syntax error in a configuration file together
+// with the position it occurred at.
+type ConfigParseError struct {
+	Path    string
+	Format  ConfigFormat
+	Line    int // 1-based line number (0 if unknown)
+	Column  int // 1-based column number (0 if unknown)
+	Message string
+}
+
+// Error formats the error as file:line:column so editors can jump to it.
+func (e *ConfigParseError) Error() string {
+	if e.Line <= 0 {
+		return fmt.Sprintf("%s: invalid %s: %s", e.Path, strings.ToUpper(string(e.Format)), e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: invalid %s: %s", e.Path, e.Line, e.Column, strings.ToUpper(string(e.Format)), e.Message)
+}
+
+// DetectConfigFormat determines the format of a configuration file from its
+// extension, falling back to sniffing the content for unknown extensions.
+// JSON files are read as JSONC, as markdownlint allows comments in them.
+func DetectConfigFormat(path string, data []byte) ConfigFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonc":
+		return ConfigFormatJSONC
+	case ".yaml", ".yml":
+		return ConfigFormatYAML
+	case ".toml":
+		return ConfigFormatTOML
+	}
+
+	return sniffConfigFormat(data)
+}
+
+var (
+	tomlTableRegex    = regexp.MustCompile(`^\[\[?\s*[A-Za-z0-9_."' -]+\s*\]\]?\s*(#.*)?$`)
+	tomlKeyValueRegex = regexp.MustCompile(`^[A-Za-z0-9_."'-]+(\s*\.\s*[A-Za-z0-9_."'-]+)*\s*=`)
+	yamlLineRegex     = regexp.MustCompile(`line (\d+)(?:, column (\d+))?`)
+	tomlPrefixRegex   = regexp.MustCompile(`^toml: line \d+( \(last key "[^"]*"\))?: `)
+)
+
+// sniffConfigFormat guesses the format from the first significant line.
+func sniffConfigFormat(data []byte) ConfigFormat {
+	trimmed := bytes.TrimSpace(trimBOM(data))
+	if bytes.HasPrefix(trimmed, []byte("{")) ||
+		bytes.HasPrefix(trimmed, []byte("//")) ||
+		bytes.HasPrefix(trimmed, []byte("/*")) {
+		return ConfigFormatJSONC
+	}
+
+	for _, line := range strings.Split(string(trimmed), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if tomlTableRegex.MatchString(line) || tomlKeyValueRegex.MatchString(line) {
+			return ConfigFormatTOML
+		}
+		break
+	}
+
+	return ConfigFormatYAML
+}
+
+// ParseConfigFile reads a configuration file and parses it into a generic map,
+// choosing the parser from the file extension or content.
+func ParseConfigFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseConfigData(path, data)
+}
+
+// ParseConfigData parses configuration content into a generic map. The path is
+// used to select the format and to annotate parse errors.
+func ParseConfigData(path string, data []byte) (map[string]interface{}, error) {
+	return parseConfigFormat(path, data, DetectConfigFormat(path, data))
+}
+
+// parseConfigFormat parses data using an explicit format.
+func parseConfigFormat(path string, data []byte, format ConfigFormat) (map[string]interface{}, error) {
+	data = trimBOM(data)
+
+	var (
+		raw map[string]interface{}
+		err error
+	)
+
+	switch format {
+	case ConfigFormatJSON:
+		raw, err = parseJSONConfig(path, data, format)
+	case ConfigFormatJSONC:
+		raw, err = parseJSONConfig(path, stripJSONC(data), format)
+	case ConfigFormatYAML:
+		raw, err = parseYAMLConfig(path, data)
+	case ConfigFormatTOML:
+		raw, err = parseTOMLConfig(path, data)
+	default:
+		return nil, fmt.Errorf("unsupported configuration format %q for %s", format, path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+
+	return normalizeConfigMap(raw), nil
+}
+
+// parseJSONConfig parses JSON, translating byte offsets in errors to positions.
+func parseJSONConfig(path string, data []byte, format ConfigFormat) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		parseErr := &ConfigParseError{Path: path, Format: format, Message: err.Error()}
+
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			parseErr.Line, parseErr.Column = positionFromOffset(data, int(syntaxErr.Offset)-1)
+		case errors.As(err, &typeErr):
+			parseErr.Line, parseErr.Column = positionFromOffset(data, int(typeErr.Offset)-1)
+			if typeErr.Field == "" {
+				parseErr.Message = "configuration must be an object"
+			}
+		}
+
+		return nil, parseErr
+	}
+
+	return raw, nil
+}
+
+// parseYAMLConfig parses YAML. yaml.v3 reports lines but not columns for
+// syntax errors, so the column falls back to the first non-blank character.
+func parseYAMLConfig(path string, data []byte) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		parseErr := &ConfigParseError{
+			Path:    path,
+			Format:  ConfigFormatYAML,
+			Message: strings.TrimPrefix(err.Error(), "yaml: "),
+		}
+
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+			parseErr.Message = typeErr.Errors[0]
+		}
+
+		if matches := yamlLineRegex.FindStringSubmatch(parseErr.Message); matches != nil {
+			parseErr.Line, _ = strconv.Atoi(matches[1])
+			if matches[2] != "" {
+				parseErr.Column, _ = strconv.Atoi(matches[2])
+			} else {
+				parseErr.Column = firstColumnOfLine(data, parseErr.Line)
+			}
+			parseErr.Message = strings.TrimSpace(strings.TrimPrefix(
+				yamlLineRegex.ReplaceAllString(parseErr.Message, ""), ":"))
+		}
+
+		if strings.Contains(parseErr.Message, "cannot unmarshal") && strings.Contains(parseErr.Message, "into map") {
+			parseErr.Message = "configuration must be a mapping"
+		}
+
+		return nil, parseErr
+	}
+
+	return raw, nil
+}
+
+// parseTOMLConfig parses TOML.
+func parseTOMLConfig(path string, data []byte) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		parseErr := &ConfigParseError{Path: path, Format: ConfigFormatTOML, Message: err.Error()}
+
+		var tomlErr toml.ParseError
+		if errors.As(err, &tomlErr) {
+			parseErr.Message = tomlPrefixRegex.ReplaceAllString(tomlErr.Error(), "")
+			parseErr.Line, parseErr.Column = positionFromOffset(data, tomlErr.Position.Start)
+		}
+
+		return nil, parseErr
+	}
+
+	return raw, nil
+}
+
+// stripJSONC blanks out comments and trailing commas so the result can be
+// parsed as strict JSON. Removed characters are replaced with spaces (newlines
+// are kept) so byte offsets, and therefore error positions, are preserved.
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	inString := false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			out[i], out[i+1] = ' ', ' '
+			for i += 2; i < len(out); i++ {
+				if out[i] == '*' && i+1 < len(out) && out[i+1] == '/' {
+					out[i], out[i+1] = ' ', ' '
+					i++
+					break
+				}
+				if out[i] != '\n' && out[i] != '\r' {
+					out[i] = ' '
+				}
+			}
+		}
+	}
+
+	// Second pass: drop commas directly followed by a closing bracket
+	inString = false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		if c == '"' {
+			inString = true
+			continue
+		}
+
+		if c == ',' {
+			j := i + 1
+			for j < len(out) && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j++
+			}
+			if j < len(out) && (out[j] == '}' || out[j] == ']') {
+				out[i] = ' '
+			}
+		}
+	}
+
+	return out
+}
+
+// positionFromOffset converts a 0-based byte offset into a 1-based line and
+// column (columns count characters, not bytes).
+func positionFromOffset(data []byte, offset int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(data) {
+		offset = len(data)
+	}
+
+	prefix := data[:offset]
+	line := bytes.Count(prefix, []byte("\n")) + 1
+	lineStart := bytes.LastIndexByte(prefix, '\n') + 1
+
+	return line, utf8.RuneCount(prefix[lineStart:]) + 1
+}
+
+// firstColumnOfLine returns the column of the first non-blank character on a
+// 1-based line, or 1 if the line is blank or out of range.
+func firstColumnOfLine(data []byte, line int) int {
+	lines := bytes.Split(data, []byte("\n"))
+	if line < 1 || line > len(lines) {
+		return 1
+	}
+
+	text := lines[line-1]
+	trimmed := bytes.TrimLeft(text, " \t")
+	if len(trimmed) == 0 {
+		return 1
+	}
+
+	return utf8.RuneCount(text[:len(text)-len(trimmed)]) + 1
+}
+
+// trimBOM removes a leading UTF-8 byte order mark.
+func trimBOM(data []byte) []byte {
+	return bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+}
+
+// normalizeConfigMap converts decoder-specific types (int64, map[interface{}]interface{},
+// []map[string]interface{}) into the shapes produced by encoding/json so that
+// configuration consumers see the same types regardless of the source format.
+func normalizeConfigMap(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for key, val := range m {
+		result[key] = normalizeConfigValue(val)
+	}
+	return result
+}
+
+func normalizeConfigValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return normalizeConfigMap(v)
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = normalizeConfigValue(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = normalizeConfigValue(item)
+		}
+		return converted
+	case []map[string]interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = normalizeConfigMap(item)
+		}
+		return converted
+	case int64:
+		return int(v)
+	case uint64:
+		return int(v)
+	default:
+		return v
+	}
+}
+
+// loadConfigWithFormat reads a configuration file and converts it into a Config.
+func loadConfigWithFormat(configPath string, format ConfigFormat) functional.Result[*value.Config] {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		return functional.Err[*value.Config](fmt.Errorf("config file not found: %s", configPath))
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return functional.Err[*value.Config](fmt.Errorf("failed to read config file %s: %w", configPath, err))
+	}
+
+	if format == "" {
+		format = DetectConfigFormat(configPath, data)
+	}
+
+	rawConfig, err := parseConfigFormat(configPath, data, format)
+	if err != nil {
+		return functional.Err[*value.Config](err)
+	}
+
+	config, err := configFromRawMap(rawConfig)
+	if err != nil {
+		return functional.Err[*value.Config](fmt.Errorf("%s: %w", configPath, err))
+	}
+	return functional.Ok(config)
+}
+
+// JSONCConfigLoader implements ConfigLoader for JSON files with comments and trailing commas
+type JSONCConfigLoader struct{}
+
+// NewJSONCConfigLoader creates a new JSONC config loader
+func NewJSONCConfigLoader() *JSONCConfigLoader {
+	return &JSONCConfigLoader{}
+}
+
+// LoadConfig loads a JSONC configuration file
+func (l *JSONCConfigLoader) LoadConfig(ctx context.Context, configPath string) functional.Result[*value.Config] {
+	return loadConfigWithFormat(configPath, ConfigFormatJSONC)
+}
+
+// SupportsPath checks if the loader supports the given file path
+func (l *JSONCConfigLoader) SupportsPath(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == ".jsonc"
+}
+
+// YAMLConfigLoader implements ConfigLoader for YAML files
+type YAMLConfigLoader struct{}
+
+// NewYAMLConfigLoader creates a new YAML config loader
+func NewYAMLConfigLoader() *YAMLConfigLoader {
+	return &YAMLConfigLoader{}
+}
+
+// LoadConfig loads a YAML configuration file
+func (l *YAMLConfigLoader) LoadConfig(ctx context.Context, configPath string) functional.Result[*value.Config] {
+	return loadConfigWithFormat(configPath, ConfigFormatYAML)
+}
+
+// SupportsPath checks if the loader supports the given file path
+func (l *YAMLConfigLoader) SupportsPath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// TOMLConfigLoader implements ConfigLoader for TOML files
+type TOMLConfigLoader struct{}
+
+// NewTOMLConfigLoader creates a new TOML config loader
+func NewTOMLConfigLoader() *TOMLConfigLoader {
+	return &TOMLConfigLoader{}
+}
+
+// LoadConfig loads a TOML configuration file
+func (l *TOMLConfigLoader) LoadConfig(ctx context.Context, configPath string) functional.Result[*value.Config] {
+	return loadConfigWithFormat(configPath, ConfigFormatTOML)
+}
+
+// SupportsPath checks if the loader supports the given file path
+func (l *TOMLConfigLoader) SupportsPath(path string) bool {
+	return strings.ToLower(filepath.Ext(path)) == ".toml"
+}
+
+// MultiFormatConfigLoader dispatches to the loader registered for a file's
+// extension and sniffs the content when no loader claims the path.
+type MultiFormatConfigLoader struct {
+	loaders []ConfigLoader
+}
+
+// NewMultiFormatConfigLoader creates a loader for all supported formats
+func NewMultiFormatConfigLoader() *MultiFormatConfigLoader {
+	return &MultiFormatConfigLoader{
+		loaders: []ConfigLoader{
+			NewJSONConfigLoader(),
+			NewJSONCConfigLoader(),
+			NewYAMLConfigLoader(),
+			NewTOMLConfigLoader(),
+		},
+	}
+}
+
+// LoadConfig loads a configuration file in any supported format
+func (l *MultiFormatConfigLoader) LoadConfig(ctx context.Context, configPath string) functional.Result[*value.Config] {
+	for _, loader := range l.loaders {
+		if loader.SupportsPath(configPath) {
+			return loader.LoadConfig(ctx, configPath)
+		}
+	}
+
+	// Unknown extension: detect the format from the content
+	return loadConfigWithFormat(configPath, "")
+}
+
+// SupportsPath reports true for every path since unknown extensions are sniffed
+func (l *MultiFormatConfigLoader) SupportsPath(path string) bool {
+	return true
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectConfigFormat_Scenarios(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		path     string
+		content  string
+		expected ConfigFormat
+	}{
+		{name: "json extension", path: ".markdownlint.json", content: "{}", expected: ConfigFormatJSONC},
+		{name: "jsonc extension", path: ".markdownlint.jsonc", content: "{}", expected: ConfigFormatJSONC},
+		{name: "yaml extension", path: "config.yaml", content: "MD013: false", expected: ConfigFormatYAML},
+		{name: "yml extension", path: "config.YML", content: "MD013: false", expected: ConfigFormatYAML},
+		{name: "toml extension", path: ".gomdlint.toml", content: "MD013 = false", expected: ConfigFormatTOML},
+		{name: "sniff json object", path: ".markdownlintrc", content: "\n  {\"MD013\": false}", expected: ConfigFormatJSONC},
+		{name: "sniff jsonc comment", path: ".markdownlintrc", content: "// comment\n{}", expected: ConfigFormatJSONC},
+		{name: "sniff toml table", path: ".markdownlintrc", content: "# comment\n[MD013]\nline_length = 100", expected: ConfigFormatTOML},
+		{name: "sniff toml key", path: ".markdownlintrc", content: "default = true", expected: ConfigFormatTOML},
+		{name: "sniff yaml", path: ".markdownlintrc", content: "default: true\nMD013:\n  line_length: 100", expected: ConfigFormatYAML},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, DetectConfigFormat(scenario.path, []byte(scenario.content)))
+		})
+	}
+}
+
+func TestParseConfigData_Scenarios(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		{
+			name:    "json",
+			path:    "config.json",
+			content: `{"default": true, "MD013": {"line_length": 100, "tables": false}, "MD033": false}`,
+		},
+		{
+			name: "jsonc with comments and trailing commas",
+			path: "config.jsonc",
+			content: `{
+  // Enable everything
+  "default": true,
+  /* line length */
+  "MD013": {"line_length": 100, "tables": false,},
+  "MD033": false, // "not a string"
+}`,
+		},
+		{
+			name: "json with comments, as markdownlint allows",
+			path: ".markdownlint.json",
+			content: `{ // comment
+  "default": true,
+  "MD013": {"line_length": 100, "tables": false},
+  "MD033": false
+}`,
+		},
+		{
+			name:    "yaml",
+			path:    "config.yaml",
+			content: "default: true\nMD013:\n  line_length: 100\n  tables: false\nMD033: false\n",
+		},
+		{
+			name:    "toml",
+			path:    "config.toml",
+			content: "default = true\nMD033 = false\n\n[MD013]\nline_length = 100\ntables = false\n",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config, err := ParseConfigData(scenario.path, []byte(scenario.content))
+			require.NoError(t, err)
+
+			assert.Equal(t, true, config["default"])
+			assert.Equal(t, false, config["MD033"])
+
+			md013, ok := config["MD013"].(map[string]interface{})
+			require.True(t, ok, "MD013 should be an object")
+			assert.EqualValues(t, 100, md013["line_length"])
+			assert.Equal(t, false, md013["tables"])
+		})
+	}
+}
+
+func TestParseConfigData_ErrorPositions(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		path           string
+		content        string
+		expectedLine   int
+		expectedColumn int
+	}{
+		{
+			name:           "json syntax error",
+			path:           "config.json",
+			content:        "{\n  \"MD013\": false,\n  \"MD033\" false\n}",
+			expectedLine:   3,
+			expectedColumn: 11,
+		},
+		{
+			name:           "jsonc syntax error after comment",
+			path:           "config.jsonc",
+			content:        "{\n  /* fine */ \"MD013\": false,\n  \"MD033\": ]\n}",
+			expectedLine:   3,
+			expectedColumn: 12,
+		},
+		{
+			name:           "yaml syntax error",
+			path:           "config.yaml",
+			content:        "MD013:\n  line_length: 100\n    tables: false\n",
+			expectedLine:   3,
+			expectedColumn: 5,
+		},
+		{
+			name:           "toml syntax error",
+			path:           "config.toml",
+			content:        "default = true\nMD033 = \n",
+			expectedLine:   2,
+			expectedColumn: 9,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			_, err := ParseConfigData(scenario.path, []byte(scenario.content))
+			require.Error(t, err)
+
+			var parseErr *ConfigParseError
+			require.True(t, errors.As(err, &parseErr), "expected ConfigParseError, got %T: %v", err, err)
+			assert.Equal(t, scenario.path, parseErr.Path)
+			assert.Equal(t, scenario.expectedLine, parseErr.Line, "line for %q", err.Error())
+			assert.Equal(t, scenario.expectedColumn, parseErr.Column, "column for %q", err.Error())
+			assert.Contains(t, err.Error(), scenario.path+":")
+		})
+	}
+}
+
+func TestMultiFormatConfigLoader_LoadConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"config.yaml":     "extends:\n  - base.json\nMD013:\n  line_length: 120\nMD041: false\n",
+		"config.toml":     "extends = [\"base.json\"]\nMD041 = false\n\n[MD013]\nline_length = 120\n",
+		".markdownlintrc": "extends: [base.json]\nMD041: false\nMD013:\n  line_length: 120\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644))
+	}
+
+	loader := NewMultiFormatConfigLoader()
+	for name := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, name)
+			assert.True(t, loader.SupportsPath(path))
+
+			result := loader.LoadConfig(context.Background(), path)
+			if result.IsErr() {
+				t.Fatalf("unexpected error: %v", result.Error())
+			}
+
+			config := result.Unwrap()
+			assert.Equal(t, []string{"base.json"}, config.Extends)
+			assert.False(t, config.IsRuleEnabled("MD041"))
+
+			md013, exists := config.GetRuleConfig("MD013")
+			require.True(t, exists)
+			assert.Equal(t, 120, md013.Options["line_length"])
+		})
+	}
+}
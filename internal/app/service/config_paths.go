@@ -0,0 +1,162 @@
+package service
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// configPathParameters are the rule parameters that name files or
+// directories. Relative paths in them are resolved against the directory of
+// the configuration file that sets them, so linting from another working
+// directory reads the same files.
+var configPathParameters = map[string]bool{
+	"dictionaries": true, // GMD004
+	"schema":       true, // GMD008
+	"styles":       true, // GMD003
+	"word_lists":   true, // GMD004
+}
+
+// resolveConfigPaths returns config with the relative paths in the path
+// parameters of its rules made absolute against baseDir, including the
+// rules of its profiles.
+func resolveConfigPaths(config map[string]interface{}, baseDir string) map[string]interface{} {
+	resolved := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		if nested, ok := value.(map[string]interface{}); ok {
+			switch key {
+			case "profiles":
+				profiles := make(map[string]interface{}, len(nested))
+				for name, profile := range nested {
+					if settings, ok := profile.(map[string]interface{}); ok {
+						profile = resolveConfigPaths(settings, baseDir)
+					}
+					profiles[name] = profile
+				}
+				value = profiles
+			case "rules": // Rule settings of a profile
+				value = resolveConfigPaths(nested, baseDir)
+			default:
+				value = resolveRuleConfigPaths(nested, baseDir)
+			}
+		}
+		resolved[key] = value
+	}
+	return resolved
+}
+
+// resolveRuleConfigPaths resolves the path parameters of one rule's settings.
+func resolveRuleConfigPaths(ruleConfig map[string]interface{}, baseDir string) map[string]interface{} {
+	resolved := make(map[string]interface{}, len(ruleConfig))
+	for name, value := range ruleConfig {
+		switch {
+		case configPathParameters[name]:
+			value = resolveConfigPath(value, baseDir)
+		case name == "schemas": // GMD008
+			value = resolveSchemaEntries(value, baseDir)
+		}
+		resolved[name] = value
+	}
+	return resolved
+}
+
+// resolveConfigPath makes a relative path, or each relative path in a list,
+// absolute against baseDir.
+func resolveConfigPath(value interface{}, baseDir string) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == "" || filepath.IsAbs(v) {
+			return v
+		}
+		return filepath.Join(baseDir, filepath.FromSlash(v))
+	case []string:
+		paths := make([]string, len(v))
+		for i, p := range v {
+			paths[i] = resolveConfigPath(p, baseDir).(string)
+		}
+		return paths
+	case []interface{}:
+		paths := make([]interface{}, len(v))
+		for i, p := range v {
+			paths[i] = resolveConfigPath(p, baseDir)
+		}
+		return paths
+	}
+	return value
+}
+
+// resolveSchemaEntries resolves the schema path of each GMD008 schemas entry
+// and anchors its files globs that contain a slash to baseDir. Globs without
+// a slash match file names in any directory and are left alone.
+func resolveSchemaEntries(value interface{}, baseDir string) interface{} {
+	resolveEntry := func(entry map[string]interface{}) map[string]interface{} {
+		resolved := make(map[string]interface{}, len(entry))
+		for key, setting := range entry {
+			switch key {
+			case "schema":
+				setting = resolveConfigPath(setting, baseDir)
+			case "files":
+				setting = resolveConfigGlob(setting, baseDir)
+			}
+			resolved[key] = setting
+		}
+		return resolved
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		entries := make([]interface{}, len(v))
+		for i, entry := range v {
+			if settings, ok := entry.(map[string]interface{}); ok {
+				entry = resolveEntry(settings)
+			}
+			entries[i] = entry
+		}
+		return entries
+	case []map[string]interface{}: // TOML arrays of tables
+		entries := make([]map[string]interface{}, len(v))
+		for i, entry := range v {
+			entries[i] = resolveEntry(entry)
+		}
+		return entries
+	}
+	return value
+}
+
+// resolveConfigGlob anchors a relative glob containing a slash, or each one
+// in a list, to baseDir.
+func resolveConfigGlob(value interface{}, baseDir string) interface{} {
+	switch v := value.(type) {
+	case string:
+		pattern := filepath.ToSlash(v)
+		if !strings.Contains(pattern, "/") || filepath.IsAbs(v) || strings.HasPrefix(pattern, "/") {
+			return v
+		}
+		return path.Join(escapeGlob(filepath.ToSlash(baseDir)), pattern)
+	case []string:
+		patterns := make([]string, len(v))
+		for i, pattern := range v {
+			patterns[i] = resolveConfigGlob(pattern, baseDir).(string)
+		}
+		return patterns
+	case []interface{}:
+		patterns := make([]interface{}, len(v))
+		for i, pattern := range v {
+			patterns[i] = resolveConfigGlob(pattern, baseDir)
+		}
+		return patterns
+	}
+	return value
+}
+
+// escapeGlob escapes the characters of a path that globs treat specially.
+func escapeGlob(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`*?[]{}\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProfileEnvVar names the environment variable that selects a profile when
+// none is given on the command line.
+const ProfileEnvVar = "GOMDLINT_PROFILE"
+
+// profileSourcePrefix labels the layer holding a profile's own settings.
+const profileSourcePrefix = "profile:"
+
+// ProfileInfo summarises a profile defined in a configuration.
+type ProfileInfo struct {
+	Name        string
+	Description string
+	Extends     []string
+	Rules       int // Number of rule settings the profile defines itself
+}
+
+// UnknownProfileError reports a profile name that the configuration does not define.
+type UnknownProfileError struct {
+	Name      string
+	Available []string
+}
+
+// Error implements the error interface.
+func (e *UnknownProfileError) Error() string {
+	if len(e.Available) == 0 {
+		return fmt.Sprintf("unknown profile %q: no profiles are defined", e.Name)
+	}
+	return fmt.Sprintf("unknown profile %q (available: %s)", e.Name, strings.Join(e.Available, ", "))
+}
+
+// ListProfiles returns the profiles defined in a raw configuration, sorted by name.
+func ListProfiles(rawConfig map[string]interface{}) []ProfileInfo {
+	profiles := rawProfiles(rawConfig)
+
+	infos := make([]ProfileInfo, 0, len(profiles))
+	for name, profile := range profiles {
+		extends, _ := extendsList(profile)
+		infos = append(infos, ProfileInfo{
+			Name:        name,
+			Description: getStringFromMap(profile, "description", ""),
+			Extends:     extends,
+			Rules:       len(profileSettings(profile)),
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// ProfileLayers returns the layers that activate the named profile, ordered
+// from lowest to highest precedence. They are meant to be applied on top of
+// the configuration the profile was defined in. Relative extends inside the
+// profile are resolved against fromFile.
+func ProfileLayers(rawConfig map[string]interface{}, name string, fromFile string, resolver *ExtendsResolver) ([]ConfigLayer, error) {
+	profiles := rawProfiles(rawConfig)
+
+	profile, exists := profiles[name]
+	if !exists {
+		available := make([]string, 0, len(profiles))
+		for profileName := range profiles {
+			available = append(available, profileName)
+		}
+		sort.Strings(available)
+		return nil, &UnknownProfileError{Name: name, Available: available}
+	}
+
+	settings := profileSettings(profile)
+	if extends, ok := profile["extends"]; ok {
+		settings["extends"] = extends
+	}
+
+	if resolver == nil {
+		resolver = NewExtendsResolver(nil)
+	}
+	layers, err := resolver.ResolveConfig(fromFile, settings)
+	if err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+
+	// The last layer holds the profile's own settings
+	layers[len(layers)-1].Source = profileSourcePrefix + name
+	return layers, nil
+}
+
+// rawProfiles returns the "profiles" section of a raw configuration.
+func rawProfiles(rawConfig map[string]interface{}) map[string]map[string]interface{} {
+	profiles := make(map[string]map[string]interface{})
+
+	section, ok := rawConfig["profiles"].(map[string]interface{})
+	if !ok {
+		return profiles
+	}
+
+	for name, entry := range section {
+		if profile, ok := entry.(map[string]interface{}); ok {
+			profiles[name] = profile
+		}
+	}
+	return profiles
+}
+
+// profileSettings returns the rule settings of a raw profile. Rules may be
+// listed at the top level of the profile (like a configuration file) or in a
+// nested "rules" object.
+func profileSettings(profile map[string]interface{}) map[string]interface{} {
+	settings := make(map[string]interface{})
+
+	for key, value := range profile {
+		switch key {
+		case "description", "extends", "rules":
+			continue
+		}
+		settings[key] = value
+	}
+
+	if rules, ok := profile["rules"].(map[string]interface{}); ok {
+		for key, value := range rules {
+			settings[key] = value
+		}
+	}
+
+	return settings
+}
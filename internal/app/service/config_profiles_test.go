@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListProfiles(t *testing.T) {
+	rawConfig := map[string]interface{}{
+		"MD013": false,
+		"profiles": map[string]interface{}{
+			"release": map[string]interface{}{
+				"rules": map[string]interface{}{"MD013": map[string]interface{}{"line_length": 100}},
+			},
+			"ci": map[string]interface{}{
+				"description": "Stricter rules for CI",
+				"extends":     "style:strict",
+				"MD040":       true,
+				"MD045":       true,
+			},
+			"broken": "not a profile",
+		},
+	}
+
+	assert.Equal(t, []ProfileInfo{
+		{Name: "ci", Description: "Stricter rules for CI", Extends: []string{"style:strict"}, Rules: 2},
+		{Name: "release", Rules: 1},
+	}, ListProfiles(rawConfig))
+
+	assert.Empty(t, ListProfiles(map[string]interface{}{"MD013": false}))
+}
+
+func TestProfileLayers_Scenarios(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeConfigFiles(t, tmpDir, map[string]string{
+		"ci-base.yaml": "MD041: false\nMD013:\n  line_length: 100\n",
+	})
+	fromFile := filepath.Join(tmpDir, ".markdownlint.json")
+
+	rawConfig := map[string]interface{}{
+		"MD040": false,
+		"profiles": map[string]interface{}{
+			"ci": map[string]interface{}{
+				"description": "CI",
+				"extends":     []interface{}{"style:minimal", "./ci-base.yaml"},
+				"MD040":       true,
+				"rules":       map[string]interface{}{"MD045": true},
+			},
+		},
+	}
+
+	t.Run("layers profile over extends", func(t *testing.T) {
+		layers, err := ProfileLayers(rawConfig, "ci", fromFile, NewExtendsResolver(NewStyleRegistry()))
+		require.NoError(t, err)
+		require.Len(t, layers, 3)
+
+		assert.Equal(t, "style:minimal", layers[0].Source)
+		assert.Equal(t, filepath.Join(tmpDir, "ci-base.yaml"), layers[1].Source)
+		assert.Equal(t, "profile:ci", layers[2].Source)
+		assert.Equal(t, map[string]interface{}{"MD040": true, "MD045": true}, layers[2].Config)
+
+		flattened := FlattenConfigLayers(layers)
+		assert.Equal(t, true, flattened["MD040"])
+		assert.Equal(t, false, flattened["MD041"])
+	})
+
+	t.Run("unknown profile lists available names", func(t *testing.T) {
+		_, err := ProfileLayers(rawConfig, "release", fromFile, nil)
+		require.Error(t, err)
+
+		var unknownErr *UnknownProfileError
+		require.True(t, errors.As(err, &unknownErr))
+		assert.Equal(t, []string{"ci"}, unknownErr.Available)
+		assert.Equal(t, `unknown profile "release" (available: ci)`, err.Error())
+	})
+
+	t.Run("no profiles defined", func(t *testing.T) {
+		_, err := ProfileLayers(map[string]interface{}{}, "ci", fromFile, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no profiles are defined")
+	})
+}
+
+func TestConfigFromRawMap_Profiles(t *testing.T) {
+	config, err := configFromRawMap(map[string]interface{}{
+		"MD013": false,
+		"profiles": map[string]interface{}{
+			"ci": map[string]interface{}{
+				"description": "CI",
+				"extends":     "style:strict",
+				"MD040":       true,
+				"MD013":       map[string]interface{}{"line_length": 100},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, isRule := config.Rules["profiles"]
+	assert.False(t, isRule)
+
+	profile, exists := config.GetProfile("ci")
+	require.True(t, exists)
+	assert.Equal(t, "CI", profile.Description)
+	assert.Equal(t, []string{"style:strict"}, profile.Extends)
+	assert.True(t, profile.Rules["MD040"].Enabled)
+	assert.Equal(t, 100, profile.Rules["MD013"].Options["line_length"])
+}
+
+func TestConfigFromRawMap_InvalidExtends(t *testing.T) {
+	_, err := configFromRawMap(map[string]interface{}{"extends": 5})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "extends must be a string or a list of strings")
+
+	_, err = configFromRawMap(map[string]interface{}{
+		"profiles": map[string]interface{}{"ci": map[string]interface{}{"extends": []interface{}{true}}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "ci"`)
+}
@@ -2,9 +2,7 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -18,30 +16,27 @@ type ConfigLoader interface {
 	SupportsPath(path string) bool
 }
 
-// ConfigResolver handles configuration extension and resolution
+// ConfigResolver resolves a configuration file with all its extensions into
+// a Config. The extends chain is expanded by an ExtendsResolver, the same one
+// the CLI uses, and its layers are merged in order.
 type ConfigResolver struct {
-	loader       ConfigLoader
-	cache        map[string]*value.Config
-	resolveStack []string // For circular dependency detection
+	extends *ExtendsResolver
+	cache   map[string]*value.Config
 }
 
-// NewConfigResolver creates a new configuration resolver
-func NewConfigResolver(loader ConfigLoader) *ConfigResolver {
+// NewConfigResolver creates a configuration resolver that looks up style
+// references in styles, or in the global registry when styles is nil.
+func NewConfigResolver(styles *StyleRegistry) *ConfigResolver {
 	return &ConfigResolver{
-		loader: loader,
-		cache:  make(map[string]*value.Config),
+		extends: NewExtendsResolver(styles),
+		cache:   make(map[string]*value.Config),
 	}
 }
 
 // ResolveConfig resolves a configuration with all its extensions
 func (cr *ConfigResolver) ResolveConfig(ctx context.Context, configPath string) functional.Result[*value.Config] {
-	// Check for circular dependencies
-	for _, path := range cr.resolveStack {
-		if path == configPath {
-			return functional.Err[*value.Config](
-				fmt.Errorf("circular dependency detected: %s", strings.Join(cr.resolveStack, " -> ")),
-			)
-		}
+	if absPath, err := filepath.Abs(configPath); err == nil {
+		configPath = absPath
 	}
 
 	// Check cache
@@ -49,101 +44,18 @@ func (cr *ConfigResolver) ResolveConfig(ctx context.Context, configPath string)
 		return functional.Ok(cached)
 	}
 
-	// Add to resolve stack
-	cr.resolveStack = append(cr.resolveStack, configPath)
-	defer func() {
-		if len(cr.resolveStack) > 0 {
-			cr.resolveStack = cr.resolveStack[:len(cr.resolveStack)-1]
-		}
-	}()
-
-	// Load base configuration
-	baseResult := cr.loader.LoadConfig(ctx, configPath)
-	if baseResult.IsErr() {
-		return baseResult
-	}
-
-	baseConfig := baseResult.Unwrap()
-
-	// If no extensions, return base config
-	if len(baseConfig.Extends) == 0 {
-		cr.cache[configPath] = baseConfig
-		return functional.Ok(baseConfig)
-	}
-
-	// Resolve extensions
-	resolvedConfig := &value.Config{
-		Default:  baseConfig.Default,
-		Rules:    make(map[string]value.ExtendedRuleConfiguration),
-		Plugins:  make(map[string]value.PluginConfiguration),
-		Parsers:  make(map[string]value.ParserConfiguration),
-		Profiles: make(map[string]value.ProfileConfiguration),
-		Schema:   baseConfig.Schema,
-		Version:  baseConfig.Version,
+	layers, err := cr.extends.ResolveFile(configPath)
+	if err != nil {
+		return functional.Err[*value.Config](err)
 	}
-
-	// Process extensions in order (later ones override earlier ones)
-	for _, extendPath := range baseConfig.Extends {
-		// Resolve relative paths
-		if !filepath.IsAbs(extendPath) {
-			extendPath = filepath.Join(filepath.Dir(configPath), extendPath)
-		}
-
-		extendedResult := cr.ResolveConfig(ctx, extendPath)
-		if extendedResult.IsErr() {
-			return functional.Err[*value.Config](
-				fmt.Errorf("failed to resolve extension %s: %w", extendPath, extendedResult.Error()),
-			)
-		}
-
-		extended := extendedResult.Unwrap()
-
-		// Merge configurations
-		cr.mergeConfigs(resolvedConfig, extended)
+	config, err := configFromRawMap(FlattenConfigLayers(layers))
+	if err != nil {
+		return functional.Err[*value.Config](fmt.Errorf("%s: %w", configPath, err))
 	}
 
-	// Apply base config on top
-	cr.mergeConfigs(resolvedConfig, baseConfig)
-
 	// Cache and return
-	cr.cache[configPath] = resolvedConfig
-	return functional.Ok(resolvedConfig)
-}
-
-// mergeConfigs merges source into target (source takes precedence)
-func (cr *ConfigResolver) mergeConfigs(target, source *value.Config) {
-	// Merge rules
-	for name, rule := range source.Rules {
-		target.Rules[name] = rule
-	}
-
-	// Merge plugins
-	for name, plugin := range source.Plugins {
-		target.Plugins[name] = plugin
-	}
-
-	// Merge parsers
-	for name, parser := range source.Parsers {
-		target.Parsers[name] = parser
-	}
-
-	// Merge profiles
-	for name, profile := range source.Profiles {
-		target.Profiles[name] = profile
-	}
-
-	// Override scalar values if they have meaningful values
-	if source.Default != target.Default {
-		target.Default = source.Default
-	}
-
-	if source.Schema != "" {
-		target.Schema = source.Schema
-	}
-
-	if source.Version != "" {
-		target.Version = source.Version
-	}
+	cr.cache[configPath] = config
+	return functional.Ok(config)
 }
 
 // ClearCache clears the configuration cache
@@ -155,7 +67,6 @@ func (cr *ConfigResolver) ClearCache() {
 func (cr *ConfigResolver) GetCacheStats() map[string]interface{} {
 	return map[string]interface{}{
 		"cached_configs": len(cr.cache),
-		"resolve_depth":  len(cr.resolveStack),
 	}
 }
 
@@ -167,25 +78,14 @@ func NewJSONConfigLoader() *JSONConfigLoader {
 	return &JSONConfigLoader{}
 }
 
-// LoadConfig loads a JSON configuration file
+// LoadConfig loads a JSON configuration file, which may contain comments as
+// in markdownlint
 func (jcl *JSONConfigLoader) LoadConfig(ctx context.Context, configPath string) functional.Result[*value.Config] {
-	// Check if file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return functional.Err[*value.Config](fmt.Errorf("config file not found: %s", configPath))
-	}
-
-	// Read file content
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return functional.Err[*value.Config](fmt.Errorf("failed to read config file %s: %w", configPath, err))
-	}
-
-	// Parse JSON
-	var rawConfig map[string]interface{}
-	if err := json.Unmarshal(data, &rawConfig); err != nil {
-		return functional.Err[*value.Config](fmt.Errorf("failed to parse JSON config %s: %w", configPath, err))
-	}
+	return loadConfigWithFormat(configPath, ConfigFormatJSONC)
+}
 
+// configFromRawMap converts a parsed configuration map into a Config structure
+func configFromRawMap(rawConfig map[string]interface{}) (*value.Config, error) {
 	// Convert to Config structure
 	config := &value.Config{
 		Default: true, // Default value
@@ -195,16 +95,13 @@ func (jcl *JSONConfigLoader) LoadConfig(ctx context.Context, configPath string)
 		Profiles: make(map[string]value.ProfileConfiguration),
 	}
 
-	// Process extends field
-	if extends, ok := rawConfig["extends"].([]interface{}); ok {
-		config.Extends = make([]string, len(extends))
-		for i, ext := range extends {
-			if extStr, ok := ext.(string); ok {
-				config.Extends[i] = extStr
-			}
-		}
-		delete(rawConfig, "extends")
+	// Process extends field (a single reference or a list)
+	extends, err := extendsList(rawConfig)
+	if err != nil {
+		return nil, err
 	}
+	config.Extends = extends
+	delete(rawConfig, "extends")
 
 	// Process schema and version
 	if schema, ok := rawConfig["$schema"].(string); ok {
@@ -249,42 +146,66 @@ func (jcl *JSONConfigLoader) LoadConfig(ctx context.Context, configPath string)
 		delete(rawConfig, "parsers")
 	}
 
+	// Process profiles section
+	for name, profile := range rawProfiles(rawConfig) {
+		extends, err := extendsList(profile)
+		if err != nil {
+			return nil, fmt.Errorf("profile %q: %w", name, err)
+		}
+		profileConfig := value.ProfileConfiguration{
+			Description: getStringFromMap(profile, "description", ""),
+			Extends:     extends,
+			Rules:       make(map[string]value.ExtendedRuleConfiguration),
+			Plugins:     make(map[string]value.PluginConfiguration),
+		}
+		for ruleName, ruleData := range profileSettings(profile) {
+			profileConfig.Rules[ruleName] = ruleConfigFromRaw(ruleData)
+		}
+		config.Profiles[name] = profileConfig
+	}
+	delete(rawConfig, "profiles")
+
 	// All remaining fields are rule configurations
 	for name, ruleData := range rawConfig {
-		switch rd := ruleData.(type) {
-		case bool:
-			config.Rules[name] = value.ExtendedRuleConfiguration{
-				Enabled: rd,
-				Options: make(map[string]interface{}),
-			}
-		case map[string]interface{}:
-			enabled := getBoolFromMap(rd, "enabled", true)
-			options := make(map[string]interface{})
-			for k, v := range rd {
-				if k != "enabled" {
-					options[k] = v
-				}
-			}
-			config.Rules[name] = value.ExtendedRuleConfiguration{
-				Enabled: enabled,
-				Options: options,
-			}
-		default:
-			// Treat as options for enabled rule
-			config.Rules[name] = value.ExtendedRuleConfiguration{
-				Enabled: true,
-				Options: map[string]interface{}{"value": ruleData},
+		config.Rules[name] = ruleConfigFromRaw(ruleData)
+	}
+
+	return config, nil
+}
+
+// ruleConfigFromRaw converts a rule setting in configuration file form
+// (a bool or an options object) into a rule configuration.
+func ruleConfigFromRaw(ruleData interface{}) value.ExtendedRuleConfiguration {
+	switch rd := ruleData.(type) {
+	case bool:
+		return value.ExtendedRuleConfiguration{
+			Enabled: rd,
+			Options: make(map[string]interface{}),
+		}
+	case map[string]interface{}:
+		enabled := getBoolFromMap(rd, "enabled", true)
+		options := make(map[string]interface{})
+		for k, v := range rd {
+			if k != "enabled" {
+				options[k] = v
 			}
 		}
+		return value.ExtendedRuleConfiguration{
+			Enabled: enabled,
+			Options: options,
+		}
+	default:
+		// Treat as options for enabled rule
+		return value.ExtendedRuleConfiguration{
+			Enabled: true,
+			Options: map[string]interface{}{"value": ruleData},
+		}
 	}
-
-	return functional.Ok(config)
 }
 
 // SupportsPath checks if the loader supports the given file path
 func (jcl *JSONConfigLoader) SupportsPath(path string) bool {
-	ext := strings.ToLower(filepath.Ext(path))
-	return ext == ".json" || ext == ".jsonc"
+	return strings.ToLower(filepath.Ext(path)) == ".json"
 }
 
 // Helper functions for map extraction
@@ -0,0 +1,446 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/shared/utils"
+)
+
+// configSchemaDialect is the JSON Schema draft the generated schema follows.
+const configSchemaDialect = "http://json-schema.org/draft-07/schema#"
+
+// BuiltInConfigSchema generates the configuration schema for the built-in rules.
+func BuiltInConfigSchema() (map[string]interface{}, error) {
+	engine, err := NewRuleEngine()
+	if err != nil {
+		return nil, err
+	}
+	return GenerateConfigSchema(engine.GetAllRules()), nil
+}
+
+// GenerateConfigSchema builds a JSON Schema describing the configuration
+// file format, including every parameter of the given rules.
+func GenerateConfigSchema(rules []*entity.Rule) map[string]interface{} {
+	sorted := make([]*entity.Rule, len(rules))
+	copy(sorted, rules)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].PrimaryName() < sorted[j].PrimaryName()
+	})
+
+	definitions := map[string]interface{}{
+		"extends": map[string]interface{}{
+			"description": "Configurations to build on: style:<name>, a relative file or a package",
+			"oneOf": []interface{}{
+				map[string]interface{}{"type": "string"},
+				map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			},
+		},
+	}
+
+	ruleProperties := make(map[string]interface{})
+	tags := make(map[string]bool)
+	for _, rule := range sorted {
+		name := rule.PrimaryName()
+		definitions[name] = ruleSchema(rule)
+
+		for _, alias := range rule.Names() {
+			ruleProperties[alias] = map[string]interface{}{"$ref": "#/definitions/" + name}
+		}
+		for _, tag := range rule.Tags() {
+			tags[tag] = true
+		}
+	}
+	for tag := range tags {
+		if _, exists := ruleProperties[tag]; exists {
+			continue
+		}
+		ruleProperties[tag] = map[string]interface{}{
+			"description": fmt.Sprintf("Enable or disable all rules tagged %q", tag),
+			"type":        "boolean",
+		}
+	}
+
+	definitions["rules"] = map[string]interface{}{
+		"type":                 "object",
+		"properties":           ruleProperties,
+		"additionalProperties": false,
+	}
+
+	profileProperties := copySchemaProperties(ruleProperties)
+	profileProperties["description"] = map[string]interface{}{"type": "string"}
+	profileProperties["extends"] = map[string]interface{}{"$ref": "#/definitions/extends"}
+	profileProperties["rules"] = map[string]interface{}{"$ref": "#/definitions/rules"}
+	definitions["profile"] = map[string]interface{}{
+		"type":                 "object",
+		"properties":           profileProperties,
+		"additionalProperties": false,
+	}
+
+	properties := copySchemaProperties(ruleProperties)
+	for key, schema := range settingsSchemas() {
+		properties[key] = schema
+	}
+
+	return map[string]interface{}{
+		"$schema":              configSchemaDialect,
+		"title":                "gomdlint configuration",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+		"definitions":          definitions,
+	}
+}
+
+// settingsSchemas describes the top-level keys that are not rules.
+func settingsSchemas() map[string]interface{} {
+	stringType := map[string]interface{}{"type": "string"}
+	booleanType := map[string]interface{}{"type": "boolean"}
+	objectType := map[string]interface{}{"type": "object"}
+
+	return map[string]interface{}{
+		"$schema": stringType,
+		"version": stringType,
+		"default": map[string]interface{}{
+			"description": "Default state for rules that are not configured",
+			"type":        "boolean",
+			"default":     true,
+		},
+		"extends": map[string]interface{}{"$ref": "#/definitions/extends"},
+		"theme": map[string]interface{}{
+			"description": "Output theme name, or theme settings",
+			"oneOf": []interface{}{
+				stringType,
+				map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"theme":           stringType,
+						"suppress_emojis": booleanType,
+						"custom_symbols": map[string]interface{}{
+							"type":                 "object",
+							"additionalProperties": stringType,
+						},
+					},
+					"additionalProperties": false,
+				},
+			},
+		},
+		"plugins": map[string]interface{}{
+			"type": "object",
+			"additionalProperties": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"enabled": booleanType,
+					"path":    stringType,
+					"config":  objectType,
+				},
+				"additionalProperties": false,
+			},
+		},
+		"parsers": map[string]interface{}{
+			"type": "object",
+			"additionalProperties": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"type":    stringType,
+					"options": objectType,
+				},
+				"additionalProperties": false,
+			},
+		},
+		"profiles": map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": map[string]interface{}{"$ref": "#/definitions/profile"},
+		},
+	}
+}
+
+// ruleSchema describes the accepted values for a rule: a boolean or an
+// object of parameters.
+func ruleSchema(rule *entity.Rule) map[string]interface{} {
+	parameters := map[string]interface{}{
+		"enabled": map[string]interface{}{"type": "boolean", "default": true},
+	}
+	for _, parameter := range rule.Parameters() {
+		parameters[parameter.Name] = parameterSchema(parameter)
+	}
+
+	return map[string]interface{}{
+		"description": fmt.Sprintf("%s - %s", strings.Join(rule.Names(), "/"), rule.Description()),
+		"oneOf": []interface{}{
+			map[string]interface{}{"type": "boolean"},
+			map[string]interface{}{
+				"type":                 "object",
+				"properties":           parameters,
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+// parameterSchema describes a declared rule parameter.
+func parameterSchema(parameter entity.RuleParameter) map[string]interface{} {
+	schema := map[string]interface{}{}
+
+	switch parameter.Type {
+	case entity.ParameterTypeStringList:
+		schema["type"] = "array"
+		schema["items"] = map[string]interface{}{"type": "string"}
+	case entity.ParameterTypeAny:
+		// No type constraint
+	default:
+		schema["type"] = string(parameter.Type)
+	}
+
+	if parameter.Default != nil {
+		schema["default"] = parameter.Default
+	}
+	if parameter.Description != "" {
+		schema["description"] = parameter.Description
+	}
+	if len(parameter.Allowed) > 0 {
+		values := make([]interface{}, len(parameter.Allowed))
+		for i, v := range parameter.Allowed {
+			values[i] = v
+		}
+		schema["enum"] = values
+	}
+
+	return schema
+}
+
+// schemaTypeOf returns the JSON Schema type name for a configuration value.
+func schemaTypeOf(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int, int64, int32:
+		return "integer"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case float32:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}, []string:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return "unknown"
+	}
+}
+
+// copySchemaProperties returns a shallow copy of a properties map.
+func copySchemaProperties(properties map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(properties))
+	for key, value := range properties {
+		result[key] = value
+	}
+	return result
+}
+
+// SchemaIssue is a single schema violation found in a configuration.
+type SchemaIssue struct {
+	Path       string // Dot-separated location of the offending value
+	Message    string
+	Suggestion string // Closest known key for unknown keys, if any
+}
+
+// String formats the issue for display.
+func (i SchemaIssue) String() string {
+	message := i.Message
+	if i.Suggestion != "" {
+		message += fmt.Sprintf(" (did you mean %q?)", i.Suggestion)
+	}
+	if i.Path == "" {
+		return message
+	}
+	return fmt.Sprintf("%s: %s", i.Path, message)
+}
+
+// ValidateConfigSchema checks a configuration against a schema produced by
+// GenerateConfigSchema and returns the problems found, sorted by path.
+// Property names are matched case-insensitively, like rule names.
+func ValidateConfigSchema(schema map[string]interface{}, config map[string]interface{}) []SchemaIssue {
+	validator := &schemaValidator{root: schema}
+	validator.validate("", config, schema)
+
+	sort.SliceStable(validator.issues, func(i, j int) bool {
+		return validator.issues[i].Path < validator.issues[j].Path
+	})
+	return validator.issues
+}
+
+// schemaValidator validates values against the JSON Schema subset used by
+// the generated configuration schema: $ref, type, enum, oneOf, properties,
+// additionalProperties and items.
+type schemaValidator struct {
+	root   map[string]interface{}
+	issues []SchemaIssue
+}
+
+func (sv *schemaValidator) report(path, message, suggestion string) {
+	sv.issues = append(sv.issues, SchemaIssue{Path: path, Message: message, Suggestion: suggestion})
+}
+
+func (sv *schemaValidator) validate(path string, value interface{}, schema map[string]interface{}) {
+	schema = sv.resolve(schema)
+
+	if branches, ok := schema["oneOf"].([]interface{}); ok {
+		sv.validateOneOf(path, value, branches)
+		return
+	}
+
+	if expected, ok := schema["type"].(string); ok && !schemaTypeMatches(expected, value) {
+		sv.report(path, fmt.Sprintf("expected %s, got %s", expected, schemaTypeOf(value)), "")
+		return
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && !enumContains(enum, value) {
+		sv.report(path, fmt.Sprintf("invalid value %v (allowed: %s)", value, joinEnum(enum)), "")
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		sv.validateObject(path, v, schema)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				sv.validate(fmt.Sprintf("%s[%d]", path, i), item, items)
+			}
+		}
+	}
+}
+
+// validateOneOf picks the branch whose type matches the value so that errors
+// are reported against the form the user evidently meant.
+func (sv *schemaValidator) validateOneOf(path string, value interface{}, branches []interface{}) {
+	var expected []string
+	for _, branch := range branches {
+		branchSchema := sv.resolve(asSchema(branch))
+		branchType, _ := branchSchema["type"].(string)
+		if schemaTypeMatches(branchType, value) {
+			sv.validate(path, value, branchSchema)
+			return
+		}
+		expected = append(expected, branchType)
+	}
+	sv.report(path, fmt.Sprintf("expected %s, got %s", strings.Join(expected, " or "), schemaTypeOf(value)), "")
+}
+
+func (sv *schemaValidator) validateObject(path string, object map[string]interface{}, schema map[string]interface{}) {
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+
+		if propertySchema, ok := lookupProperty(properties, key); ok {
+			sv.validate(childPath, object[key], asSchema(propertySchema))
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				sv.report(childPath, "unknown key", utils.SuggestClosest(key, propertyNames(properties)))
+			}
+		case map[string]interface{}:
+			sv.validate(childPath, object[key], additional)
+		}
+	}
+}
+
+// resolve follows a local "$ref" to its definition.
+func (sv *schemaValidator) resolve(schema map[string]interface{}) map[string]interface{} {
+	for {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			return schema
+		}
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		definitions, _ := sv.root["definitions"].(map[string]interface{})
+		target, ok := definitions[name].(map[string]interface{})
+		if !ok {
+			return map[string]interface{}{}
+		}
+		schema = target
+	}
+}
+
+// lookupProperty finds a property by exact name, then case-insensitively.
+func lookupProperty(properties map[string]interface{}, key string) (interface{}, bool) {
+	if schema, ok := properties[key]; ok {
+		return schema, true
+	}
+	for name, schema := range properties {
+		if strings.EqualFold(name, key) {
+			return schema, true
+		}
+	}
+	return nil, false
+}
+
+func propertyNames(properties map[string]interface{}) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func asSchema(v interface{}) map[string]interface{} {
+	if schema, ok := v.(map[string]interface{}); ok {
+		return schema
+	}
+	return map[string]interface{}{}
+}
+
+// schemaTypeMatches reports whether value satisfies the JSON Schema type.
+func schemaTypeMatches(expected string, value interface{}) bool {
+	actual := schemaTypeOf(value)
+	switch expected {
+	case "":
+		return true
+	case "number":
+		return actual == "number" || actual == "integer"
+	default:
+		return actual == expected
+	}
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func joinEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, v := range enum {
+		values[i] = fmt.Sprintf("%v", v)
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateConfigSchema(t *testing.T) {
+	schema, err := BuiltInConfigSchema()
+	require.NoError(t, err)
+
+	// The schema must be serialisable as JSON
+	_, err = json.Marshal(schema)
+	require.NoError(t, err)
+
+	properties := schema["properties"].(map[string]interface{})
+	for _, key := range []string{"MD013", "line-length", "headings", "default", "extends", "theme", "plugins", "parsers", "profiles"} {
+		assert.Contains(t, properties, key)
+	}
+
+	definitions := schema["definitions"].(map[string]interface{})
+	md013 := definitions["MD013"].(map[string]interface{})
+	objectForm := md013["oneOf"].([]interface{})[1].(map[string]interface{})
+	lineLength := objectForm["properties"].(map[string]interface{})["line_length"].(map[string]interface{})
+	assert.Equal(t, "integer", lineLength["type"])
+	assert.Equal(t, 80, lineLength["default"])
+
+	md003 := definitions["MD003"].(map[string]interface{})
+	style := md003["oneOf"].([]interface{})[1].(map[string]interface{})["properties"].(map[string]interface{})["style"].(map[string]interface{})
+	assert.Contains(t, style["enum"], "setext_with_atx")
+}
+
+func TestValidateConfigSchema_Scenarios(t *testing.T) {
+	schema, err := BuiltInConfigSchema()
+	require.NoError(t, err)
+
+	scenarios := []struct {
+		name     string
+		config   map[string]interface{}
+		expected []string
+	}{
+		{
+			name: "valid configuration",
+			config: map[string]interface{}{
+				"$schema":      "./schema.json",
+				"default":      true,
+				"extends":      []interface{}{"style:strict"},
+				"md013":        map[string]interface{}{"line_length": float64(100), "tables": false},
+				"no-hard-tabs": map[string]interface{}{"ignore_code_languages": []interface{}{"make"}},
+				"MD003":        map[string]interface{}{"style": "atx"},
+				"headings":     false,
+				"theme":        "minimal",
+				"profiles": map[string]interface{}{
+					"ci": map[string]interface{}{"description": "CI", "MD040": true, "rules": map[string]interface{}{"MD045": true}},
+				},
+			},
+		},
+		{
+			name: "misspelled parameter",
+			config: map[string]interface{}{
+				"MD013": map[string]interface{}{"line-length": 100},
+			},
+			expected: []string{`MD013.line-length: unknown key (did you mean "line_length"?)`},
+		},
+		{
+			name: "misspelled rule name",
+			config: map[string]interface{}{
+				"line_lenght": false,
+			},
+			expected: []string{`line_lenght: unknown key (did you mean "line-length"?)`},
+		},
+		{
+			name: "wrong types and enum values",
+			config: map[string]interface{}{
+				"MD013": map[string]interface{}{"line_length": "long"},
+				"MD003": map[string]interface{}{"style": "fancy"},
+				"MD033": "yes",
+				"MD010": map[string]interface{}{"ignore_code_languages": []interface{}{"make", 3}},
+				"theme": map[string]interface{}{"suppress_emoji": true},
+			},
+			expected: []string{
+				"MD003.style: invalid value fancy (allowed: consistent, atx, atx_closed, setext, setext_with_atx, setext_with_atx_closed)",
+				"MD010.ignore_code_languages[1]: expected string, got integer",
+				"MD013.line_length: expected integer, got string",
+				"MD033: expected boolean or object, got string",
+				`theme.suppress_emoji: unknown key (did you mean "suppress_emojis"?)`,
+			},
+		},
+		{
+			name: "unknown key in profile",
+			config: map[string]interface{}{
+				"profiles": map[string]interface{}{
+					"ci": map[string]interface{}{"descripton": "typo"},
+				},
+			},
+			expected: []string{`profiles.ci.descripton: unknown key (did you mean "description"?)`},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			issues := ValidateConfigSchema(schema, scenario.config)
+
+			messages := make([]string, len(issues))
+			for i, issue := range issues {
+				messages[i] = issue.String()
+			}
+			if len(scenario.expected) == 0 {
+				assert.Empty(t, messages)
+				return
+			}
+			assert.Equal(t, scenario.expected, messages)
+		})
+	}
+}
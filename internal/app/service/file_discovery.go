@@ -0,0 +1,480 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// IgnoreFileNames lists the ignore files honored during discovery, in the
+// order they are applied within a directory. Later files override earlier
+// ones, so tool-specific files can re-include what .gitignore excludes.
+var IgnoreFileNames = []string{".gitignore", ".markdownlintignore", ".gomdlintignore"}
+
+// PatternError reports an input glob or ignore pattern that is not valid.
+type PatternError struct {
+	Kind    string // "glob" or "ignore"
+	Pattern string
+}
+
+// Error implements the error interface.
+func (e *PatternError) Error() string {
+	return fmt.Sprintf("invalid %s pattern %q", e.Kind, e.Pattern)
+}
+
+// FileDiscoveryOptions controls how markdown files are discovered.
+type FileDiscoveryOptions struct {
+	Ignore        []string // gitignore-style patterns relative to the working directory
+	IncludeDot    bool     // Include hidden files and directories
+	NoIgnoreFiles bool     // Do not read .gitignore, .markdownlintignore or .gomdlintignore
+}
+
+// DiscoverFiles expands inputs into the markdown files to lint. Inputs may be
+// files, directories (walked recursively) or doublestar globs such as
+// "docs/**/*.md"; inputs starting with "!" exclude matching files. Ignore
+// files are honored hierarchically from the repository root down, followed by
+// the Ignore patterns. Results are de-duplicated and keep discovery order.
+func DiscoverFiles(inputs []string, options FileDiscoveryOptions) ([]string, error) {
+	if len(inputs) == 0 {
+		inputs = []string{"."}
+	}
+
+	discovery, err := newFileDiscovery(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var excludes []string
+	for _, input := range inputs {
+		if strings.HasPrefix(input, "!") {
+			pattern := filepath.ToSlash(filepath.Clean(strings.TrimPrefix(input, "!")))
+			if !doublestar.ValidatePattern(pattern) {
+				return nil, &PatternError{Kind: "glob", Pattern: input}
+			}
+			excludes = append(excludes, pattern)
+		}
+	}
+
+	for _, input := range inputs {
+		if strings.HasPrefix(input, "!") {
+			continue
+		}
+		if err := discovery.discover(input); err != nil {
+			return nil, err
+		}
+	}
+
+	files := make([]string, 0, len(discovery.files))
+	for _, file := range discovery.files {
+		if !matchesAny(excludes, filepath.ToSlash(file)) {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// IsIgnoredPath reports whether a file at path would be skipped by discovery
+// because of ignore files or Ignore patterns. The file need not exist, so
+// content read from stdin can be treated as if it were at path.
+func IsIgnoredPath(path string, options FileDiscoveryOptions) (bool, error) {
+	discovery, err := newFileDiscovery(options)
+	if err != nil {
+		return false, err
+	}
+
+	abs := discovery.absolute(path)
+	matcher, err := discovery.matcherFor(filepath.Dir(abs))
+	if err != nil {
+		return false, err
+	}
+	return matcher.ignored(abs, false) || discovery.insideIgnoredDir(abs, matcher), nil
+}
+
+// IsMarkdownFile reports whether filename has a markdown extension.
+func IsMarkdownFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".md" || ext == ".markdown" || ext == ".mkd" || ext == ".mdown"
+}
+
+// fileDiscovery holds the state of a single DiscoverFiles call.
+type fileDiscovery struct {
+	options  FileDiscoveryOptions
+	wd       string
+	cliRules []ignoreRule
+	seen     map[string]bool
+	files    []string
+}
+
+// newFileDiscovery prepares discovery relative to the working directory.
+func newFileDiscovery(options FileDiscoveryOptions) (*fileDiscovery, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine working directory: %w", err)
+	}
+
+	cliRules, err := parseIgnorePatterns(options.Ignore, wd)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileDiscovery{
+		options:  options,
+		wd:       wd,
+		cliRules: cliRules,
+		seen:     make(map[string]bool),
+	}, nil
+}
+
+// discover expands a single input.
+func (d *fileDiscovery) discover(input string) error {
+	if isGlobPattern(input) {
+		return d.discoverGlob(input)
+	}
+
+	info, err := os.Stat(input)
+	if err != nil {
+		return err
+	}
+
+	abs := d.absolute(input)
+	matcher, err := d.matcherFor(filepath.Dir(abs))
+	if err != nil {
+		return err
+	}
+	if matcher.ignored(abs, info.IsDir()) || d.insideIgnoredDir(abs, matcher) {
+		return nil
+	}
+
+	if !info.IsDir() {
+		if IsMarkdownFile(input) {
+			d.add(input)
+		}
+		return nil
+	}
+	return d.walk(input, abs, matcher, nil, nil)
+}
+
+// discoverGlob walks the static prefix of pattern and keeps matching files.
+// The pattern is cleaned first, as the walked paths are, so "./docs/*.md"
+// and "docs/../docs/*.md" match like "docs/*.md".
+func (d *fileDiscovery) discoverGlob(pattern string) error {
+	slashPattern := path.Clean(filepath.ToSlash(pattern))
+	if !doublestar.ValidatePattern(slashPattern) {
+		return &PatternError{Kind: "glob", Pattern: pattern}
+	}
+
+	base, _ := doublestar.SplitPattern(slashPattern)
+	root := filepath.FromSlash(base)
+	info, err := os.Stat(root)
+	if err != nil || !info.IsDir() {
+		// Nothing can match below a missing directory
+		return nil
+	}
+
+	abs := d.absolute(root)
+	matcher, err := d.matcherFor(filepath.Dir(abs))
+	if err != nil {
+		return err
+	}
+	return d.walk(root, abs, matcher, nil, func(file string) bool {
+		match, _ := doublestar.Match(slashPattern, filepath.ToSlash(file))
+		return match
+	})
+}
+
+// walk visits dir recursively, honoring ignore files found along the way.
+// visited holds the real paths of the directories on the current branch so
+// that symlink cycles are skipped.
+func (d *fileDiscovery) walk(dir, absDir string, matcher ignoreMatcher, visited map[string]bool, keep func(string) bool) error {
+	realDir, err := filepath.EvalSymlinks(absDir)
+	if err != nil {
+		return nil
+	}
+	if visited[realDir] {
+		return nil
+	}
+	branch := make(map[string]bool, len(visited)+1)
+	for k := range visited {
+		branch[k] = true
+	}
+	branch[realDir] = true
+
+	if !d.options.NoIgnoreFiles {
+		rules, err := loadIgnoreFiles(absDir)
+		if err != nil {
+			return err
+		}
+		matcher = matcher.with(rules)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !d.options.IncludeDot && strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		absPath := filepath.Join(absDir, entry.Name())
+
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			info, err := os.Stat(path)
+			if err != nil {
+				continue // Broken symlink
+			}
+			isDir = info.IsDir()
+		}
+
+		if matcher.ignored(absPath, isDir) {
+			continue
+		}
+
+		if isDir {
+			// Unreadable subdirectories are skipped rather than failing the run
+			_ = d.walk(path, absPath, matcher, branch, keep)
+			continue
+		}
+
+		if IsMarkdownFile(path) && (keep == nil || keep(path)) {
+			d.add(path)
+		}
+	}
+	return nil
+}
+
+// matcherFor returns the ignore rules in effect for entries of dir: ignore
+// files from the repository root down to dir itself, followed by the
+// command-line patterns.
+func (d *fileDiscovery) matcherFor(dir string) (ignoreMatcher, error) {
+	matcher := ignoreMatcher{tail: d.cliRules}
+	if d.options.NoIgnoreFiles {
+		return matcher, nil
+	}
+
+	for _, ancestor := range ignoreAncestors(dir, d.wd) {
+		rules, err := loadIgnoreFiles(ancestor)
+		if err != nil {
+			return matcher, err
+		}
+		matcher = matcher.with(rules)
+	}
+	return matcher, nil
+}
+
+// insideIgnoredDir reports whether an explicitly named path lies inside an
+// ignored directory below the working directory, mirroring what a walk from
+// the working directory would have skipped.
+func (d *fileDiscovery) insideIgnoredDir(abs string, matcher ignoreMatcher) bool {
+	rel, err := filepath.Rel(d.wd, abs)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+
+	current := d.wd
+	parts := strings.Split(rel, string(filepath.Separator))
+	for _, part := range parts[:len(parts)-1] {
+		current = filepath.Join(current, part)
+		if matcher.ignored(current, true) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *fileDiscovery) absolute(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(d.wd, path)
+}
+
+func (d *fileDiscovery) add(path string) {
+	key := d.absolute(path)
+	if d.seen[key] {
+		return
+	}
+	d.seen[key] = true
+	d.files = append(d.files, path)
+}
+
+// ignoreAncestors returns the directories whose ignore files apply to entries
+// of dir, from the outermost down to dir itself. The chain starts at the
+// nearest enclosing git repository root; outside a repository it starts at
+// the working directory, and is empty when dir is not below it.
+func ignoreAncestors(dir, wd string) []string {
+	var chain []string
+	for current := dir; ; current = filepath.Dir(current) {
+		chain = append(chain, current)
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			break
+		}
+		if current == filepath.Dir(current) {
+			// Not in a repository: only honor ignore files from wd down
+			chain = chain[:0]
+			if rel, err := filepath.Rel(wd, dir); err == nil && !strings.HasPrefix(rel, "..") {
+				for current := dir; ; current = filepath.Dir(current) {
+					chain = append(chain, current)
+					if current == wd {
+						break
+					}
+				}
+			}
+			break
+		}
+	}
+
+	// Outermost first
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
+
+// ignoreRule is a single gitignore-style pattern.
+type ignoreRule struct {
+	base    string // Absolute directory the pattern is relative to
+	pattern string // doublestar pattern with forward slashes
+	negate  bool   // "!pattern" re-includes matching paths
+	dirOnly bool   // "pattern/" only matches directories
+}
+
+// matches reports whether the rule applies to absPath.
+func (r ignoreRule) matches(absPath string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	rel, err := filepath.Rel(r.base, absPath)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	match, _ := doublestar.Match(r.pattern, filepath.ToSlash(rel))
+	return match
+}
+
+// ignoreMatcher evaluates ignore rules with gitignore precedence: the last
+// matching rule wins, and tail rules (command-line patterns) come last.
+type ignoreMatcher struct {
+	rules []ignoreRule
+	tail  []ignoreRule
+}
+
+// with returns a matcher that also applies rules, after the existing ones.
+func (m ignoreMatcher) with(rules []ignoreRule) ignoreMatcher {
+	if len(rules) == 0 {
+		return m
+	}
+	combined := make([]ignoreRule, 0, len(m.rules)+len(rules))
+	combined = append(combined, m.rules...)
+	combined = append(combined, rules...)
+	return ignoreMatcher{rules: combined, tail: m.tail}
+}
+
+// ignored reports whether absPath is excluded.
+func (m ignoreMatcher) ignored(absPath string, isDir bool) bool {
+	ignored := false
+	for _, rules := range [][]ignoreRule{m.rules, m.tail} {
+		for _, rule := range rules {
+			if rule.matches(absPath, isDir) {
+				ignored = !rule.negate
+			}
+		}
+	}
+	return ignored
+}
+
+// loadIgnoreFiles reads the ignore files in dir.
+func loadIgnoreFiles(dir string) ([]ignoreRule, error) {
+	var rules []ignoreRule
+	for _, name := range IgnoreFileNames {
+		path := filepath.Join(dir, name)
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+
+		var lines []string
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		parsed, err := parseIgnorePatterns(lines, dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		rules = append(rules, parsed...)
+	}
+	return rules, nil
+}
+
+// parseIgnorePatterns converts gitignore-style lines into rules relative to
+// base. Blank lines and comments are skipped. Patterns without a slash (other
+// than a trailing one) match at any depth; others are anchored to base.
+func parseIgnorePatterns(lines []string, base string) ([]ignoreRule, error) {
+	var rules []ignoreRule
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+			line = line[1:]
+		}
+
+		line = filepath.ToSlash(line)
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		line = strings.TrimPrefix(line, "./")
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "/"):
+			line = strings.TrimPrefix(line, "/")
+		case !strings.Contains(line, "/"):
+			line = "**/" + line
+		}
+
+		if !doublestar.ValidatePattern(line) {
+			return nil, &PatternError{Kind: "ignore", Pattern: line}
+		}
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// isGlobPattern reports whether input contains glob metacharacters.
+func isGlobPattern(input string) bool {
+	return strings.ContainsAny(input, "*?[{")
+}
+
+// matchesAny reports whether path matches one of the patterns.
+func matchesAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if match, _ := doublestar.Match(pattern, path); match {
+			return true
+		}
+	}
+	return false
+}
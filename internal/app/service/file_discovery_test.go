@@ -0,0 +1,252 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chdirTemp creates files in a temporary directory and makes it the working
+// directory for the rest of the test.
+func chdirTemp(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(oldDir) })
+	return dir
+}
+
+func toSlashPaths(paths []string) []string {
+	result := make([]string, len(paths))
+	for i, path := range paths {
+		result[i] = filepath.ToSlash(path)
+	}
+	return result
+}
+
+func TestDiscoverFiles_Scenarios(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		files    map[string]string
+		inputs   []string
+		options  FileDiscoveryOptions
+		expected []string
+	}{
+		{
+			name:     "directory walk skips hidden entries",
+			files:    map[string]string{"a.md": "", "b.txt": "", ".hidden/c.md": "", "docs/d.markdown": ""},
+			inputs:   []string{"."},
+			expected: []string{"a.md", "docs/d.markdown"},
+		},
+		{
+			name:     "include dot entries",
+			files:    map[string]string{"a.md": "", ".github/e.md": ""},
+			inputs:   []string{"."},
+			options:  FileDiscoveryOptions{IncludeDot: true},
+			expected: []string{".github/e.md", "a.md"},
+		},
+		{
+			name:     "doublestar glob",
+			files:    map[string]string{"a.md": "", "docs/b.md": "", "docs/x/y/c.md": "", "docs/x/y/c.txt": ""},
+			inputs:   []string{"docs/**/*.md"},
+			expected: []string{"docs/b.md", "docs/x/y/c.md"},
+		},
+		{
+			name:     "brace glob",
+			files:    map[string]string{"a.md": "", "b.md": "", "c.md": ""},
+			inputs:   []string{"{a,c}.md"},
+			expected: []string{"a.md", "c.md"},
+		},
+		{
+			name:     "ignore name matches whole path segments",
+			files:    map[string]string{"node/a.md": "", "docs/node-guide.md": "", "docs/node/b.md": ""},
+			inputs:   []string{"."},
+			options:  FileDiscoveryOptions{Ignore: []string{"node"}},
+			expected: []string{"docs/node-guide.md"},
+		},
+		{
+			name:     "anchored ignore pattern",
+			files:    map[string]string{"drafts/a.md": "", "docs/drafts/b.md": ""},
+			inputs:   []string{"."},
+			options:  FileDiscoveryOptions{Ignore: []string{"/drafts"}},
+			expected: []string{"docs/drafts/b.md"},
+		},
+		{
+			name:     "ignore glob with negation",
+			files:    map[string]string{"docs/a.md": "", "docs/keep.md": "", "README.md": ""},
+			inputs:   []string{"."},
+			options:  FileDiscoveryOptions{Ignore: []string{"docs/*.md", "!docs/keep.md"}},
+			expected: []string{"README.md", "docs/keep.md"},
+		},
+		{
+			name:     "negated input",
+			files:    map[string]string{"docs/a.md": "", "docs/keep.md": ""},
+			inputs:   []string{"docs", "!docs/a.md"},
+			expected: []string{"docs/keep.md"},
+		},
+		{
+			name: "ignore files apply hierarchically",
+			files: map[string]string{
+				".gitignore":           "vendor/\n# comment\n",
+				".markdownlintignore":  "CHANGELOG.md\n",
+				"docs/.gomdlintignore": "*.draft.md\n!keep.draft.md\n",
+				"vendor/lib/README.md": "",
+				"CHANGELOG.md":         "",
+				"docs/post.draft.md":   "",
+				"docs/keep.draft.md":   "",
+				"docs/CHANGELOG.md":    "",
+				"docs/guide.md":        "",
+				"other/post.draft.md":  "",
+				"README.md":            "",
+			},
+			inputs:   []string{"."},
+			expected: []string{"README.md", "docs/guide.md", "docs/keep.draft.md", "other/post.draft.md"},
+		},
+		{
+			name:     "ignore files can be disabled",
+			files:    map[string]string{".gitignore": "*.md\n", "a.md": ""},
+			inputs:   []string{"."},
+			options:  FileDiscoveryOptions{NoIgnoreFiles: true},
+			expected: []string{"a.md"},
+		},
+		{
+			name:     "explicit files honor ignored parent directories",
+			files:    map[string]string{".gitignore": "build/\n", "build/out.md": "", "a.md": ""},
+			inputs:   []string{"build/out.md", "a.md"},
+			expected: []string{"a.md"},
+		},
+		{
+			name:     "duplicates are removed",
+			files:    map[string]string{"a.md": "", "docs/b.md": ""},
+			inputs:   []string{"a.md", ".", "docs/*.md"},
+			expected: []string{"a.md", "docs/b.md"},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			chdirTemp(t, scenario.files)
+
+			files, err := DiscoverFiles(scenario.inputs, scenario.options)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, scenario.expected, toSlashPaths(files))
+		})
+	}
+}
+
+func TestDiscoverFiles_RelativeGlobs(t *testing.T) {
+	dir := chdirTemp(t, map[string]string{
+		"site/docs/a.md":    "",
+		"site/docs/x/b.md":  "",
+		"site/README.md":    "",
+		"shared/c.md":       "",
+		"shared/notes.txt":  "",
+		"shared/deep/d.md":  "",
+		"site/.hidden/e.md": "",
+	})
+	require.NoError(t, os.Chdir(filepath.Join(dir, "site")))
+
+	scenarios := []struct {
+		input    string
+		expected []string
+	}{
+		{"./docs/*.md", []string{"docs/a.md"}},
+		{"./**/*.md", []string{"README.md", "docs/a.md", "docs/x/b.md"}},
+		{"docs/x/../*.md", []string{"docs/a.md"}},
+		{"../shared/*.md", []string{"../shared/c.md"}},
+		{"../shared/**/*.md", []string{"../shared/c.md", "../shared/deep/d.md"}},
+		{"./../site/docs/*.md", []string{"../site/docs/a.md"}},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.input, func(t *testing.T) {
+			files, err := DiscoverFiles([]string{scenario.input}, FileDiscoveryOptions{})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, scenario.expected, toSlashPaths(files))
+		})
+	}
+}
+
+func TestDiscoverFiles_Errors(t *testing.T) {
+	chdirTemp(t, map[string]string{"a.md": ""})
+
+	_, err := DiscoverFiles([]string{"missing.md"}, FileDiscoveryOptions{})
+	assert.Error(t, err)
+
+	_, err = DiscoverFiles([]string{"docs/[.md"}, FileDiscoveryOptions{})
+	assert.ErrorContains(t, err, "invalid glob pattern")
+
+	files, err := DiscoverFiles([]string{"missing/**/*.md"}, FileDiscoveryOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestDiscoverFiles_SymlinkLoop(t *testing.T) {
+	dir := chdirTemp(t, map[string]string{"docs/a.md": "", "docs/sub/b.md": ""})
+	if err := os.Symlink(filepath.Join(dir, "docs"), filepath.Join(dir, "docs", "sub", "loop")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	require.NoError(t, os.Symlink(filepath.Join(dir, "docs", "sub"), filepath.Join(dir, "shared")))
+
+	files, err := DiscoverFiles([]string{"."}, FileDiscoveryOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, toSlashPaths(files), "shared/b.md", "symlinked directories are followed")
+	assert.Contains(t, toSlashPaths(files), "docs/sub/b.md")
+	assert.Less(t, len(files), 10, "symlink cycles are not followed")
+}
+
+func TestIsIgnoredPath(t *testing.T) {
+	chdirTemp(t, map[string]string{
+		".gomdlintignore":      "vendor/\n*.draft.md\n",
+		"docs/.gomdlintignore": "generated/\n",
+	})
+
+	for path, expected := range map[string]bool{
+		"README.md":                    false,
+		"docs/guide.md":                false,
+		"notes.draft.md":               true,
+		"vendor/pkg/README.md":         true,
+		"docs/generated/api.md":        true,
+		"docs/sub/generated/x.md":      true,
+		"other/generated/api.md":       false,
+		"docs/not-generated/readme.md": false,
+	} {
+		ignored, err := IsIgnoredPath(path, FileDiscoveryOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, expected, ignored, path)
+	}
+
+	ignored, err := IsIgnoredPath("docs/guide.md", FileDiscoveryOptions{Ignore: []string{"docs/"}})
+	require.NoError(t, err)
+	assert.True(t, ignored, "--ignore patterns apply")
+
+	ignored, err = IsIgnoredPath("vendor/pkg/README.md", FileDiscoveryOptions{NoIgnoreFiles: true})
+	require.NoError(t, err)
+	assert.False(t, ignored, "ignore files can be disabled")
+}
+
+func TestIsMarkdownFile(t *testing.T) {
+	for filename, expected := range map[string]bool{
+		"test.md":       true,
+		"test.markdown": true,
+		"test.mkd":      true,
+		"test.mdown":    true,
+		"test.MD":       true,
+		"test.txt":      false,
+		"test":          false,
+	} {
+		assert.Equal(t, expected, IsMarkdownFile(filename), filename)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+)
+
+// fixEdit is a normalized FixInfo.
+type fixEdit struct {
+	line         int
+	lineFix      bool   // Replace whole lines rather than characters
+	column       int    // 1-based column of a character edit
+	deleteLength int    // Characters to delete; -1 deletes the line
+	deleteLines  int    // Lines to delete for a line fix
+	text         string // Text to insert
+}
+
+// lastLine returns the last line the edit touches.
+func (e fixEdit) lastLine() int {
+	if e.lineFix && e.deleteLines > 1 {
+		return e.line + e.deleteLines - 1
+	}
+	return e.line
+}
+
+// ApplyFixes applies the fixes attached to violations to content and returns
+// the fixed content with the number of fixes applied. Fixes without a line
+// number apply to their violation's line. Duplicate fixes are applied once,
+// and a fix overlapping one already applied is skipped, so applying the
+// fixes of a single lint pass never corrupts the document. Line endings are
+// preserved.
+func ApplyFixes(content string, violations []value.Violation) (string, int) {
+	eol := "\n"
+	if strings.Contains(content, "\r\n") {
+		eol = "\r\n"
+	}
+	lines := strings.Split(content, eol)
+
+	edits := normalizeFixes(violations, len(lines))
+	applied := 0
+	touched := len(lines) + 1 // First line touched by an applied edit
+	var previous *fixEdit
+
+	for i := range edits {
+		edit := edits[i]
+
+		// Edits are applied bottom-up, so earlier edits never shift later ones
+		if edit.lastLine() > touched || (edit.lastLine() == touched && !sameLineColumnEdits(previous, edit)) {
+			continue
+		}
+		if previous != nil && sameLineColumnEdits(previous, edit) && edit.column+edit.deleteLength > previous.column {
+			continue
+		}
+
+		text := strings.ReplaceAll(edit.text, "\n", eol)
+		switch {
+		case edit.lineFix:
+			var inserted []string
+			if text != "" {
+				inserted = strings.Split(strings.TrimSuffix(text, eol), eol)
+			}
+			end := min(edit.line-1+edit.deleteLines, len(lines))
+			lines = append(lines[:edit.line-1], append(inserted, lines[end:]...)...)
+		case edit.deleteLength < 0:
+			lines = append(lines[:edit.line-1], lines[edit.line:]...)
+		default:
+			line := lines[edit.line-1]
+			start := min(edit.column-1, len(line))
+			end := min(start+edit.deleteLength, len(line))
+			lines[edit.line-1] = line[:start] + text + line[end:]
+		}
+
+		applied++
+		touched = edit.line
+		previous = &edits[i]
+	}
+
+	return strings.Join(lines, eol), applied
+}
+
+// sameLineColumnEdits reports whether two edits are character edits on the
+// same line, which may both apply when they do not overlap.
+func sameLineColumnEdits(previous *fixEdit, edit fixEdit) bool {
+	return previous != nil && !previous.lineFix && !edit.lineFix &&
+		previous.deleteLength >= 0 && edit.deleteLength >= 0 &&
+		previous.line == edit.line
+}
+
+// normalizeFixes extracts the valid fixes from violations, without
+// duplicates, sorted bottom-up and right-to-left.
+func normalizeFixes(violations []value.Violation, lineCount int) []fixEdit {
+	seen := make(map[fixEdit]bool)
+	var edits []fixEdit
+
+	for _, violation := range violations {
+		if violation.FixInfo.IsNone() {
+			continue
+		}
+		fix := violation.FixInfo.Unwrap()
+
+		edit := fixEdit{line: fix.LineNumber.UnwrapOr(violation.LineNumber)}
+		if fix.IsColumnFix() {
+			edit.column = max(fix.EditColumn.Unwrap(), 1)
+			edit.deleteLength = fix.DeleteLength.UnwrapOr(0)
+			edit.text = fix.ReplaceText.UnwrapOr("")
+		} else if fix.DeleteCount.IsSome() || fix.InsertText.IsSome() {
+			edit.lineFix = true
+			edit.deleteLines = max(fix.DeleteCount.UnwrapOr(0), 0)
+			edit.text = fix.InsertText.UnwrapOr("")
+		} else {
+			continue
+		}
+
+		if edit.line < 1 || edit.line > lineCount || seen[edit] {
+			continue
+		}
+		seen[edit] = true
+		edits = append(edits, edit)
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].line != edits[j].line {
+			return edits[i].line > edits[j].line
+		}
+		return edits[i].column > edits[j].column
+	})
+	return edits
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+)
+
+// fixViolation builds a violation on line carrying fix.
+func fixViolation(line int, fix *value.FixInfo) value.Violation {
+	violation := value.NewViolation([]string{"MD000"}, "test", nil, line)
+	return *violation.WithFixInfo(*fix)
+}
+
+func TestApplyFixes(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		content         string
+		violations      []value.Violation
+		expected        string
+		expectedApplied int
+	}{
+		{
+			name:    "insert within line",
+			content: "#Heading\ntext\n",
+			violations: []value.Violation{
+				fixViolation(1, value.NewFixInfo().WithEditColumn(2).WithDeleteLength(0).WithReplaceText(" ")),
+			},
+			expected:        "# Heading\ntext\n",
+			expectedApplied: 1,
+		},
+		{
+			name:    "line defaults to the violation line",
+			content: "a\nb  \n",
+			violations: []value.Violation{
+				fixViolation(2, value.NewFixInfo().WithEditColumn(2).WithDeleteLength(2)),
+			},
+			expected:        "a\nb\n",
+			expectedApplied: 1,
+		},
+		{
+			name:    "newline inserts a blank line and keeps later fixes in place",
+			content: "text\n- a\n-  b\n",
+			violations: []value.Violation{
+				fixViolation(2, value.NewFixInfo().WithLineNumber(2).WithEditColumn(1).WithDeleteLength(0).WithReplaceText("\n")),
+				fixViolation(3, value.NewFixInfo().WithLineNumber(3).WithEditColumn(1).WithDeleteLength(4).WithReplaceText("- b")),
+			},
+			expected:        "text\n\n- a\n- b\n",
+			expectedApplied: 2,
+		},
+		{
+			name:    "non-overlapping edits on one line",
+			content: "a  b  c",
+			violations: []value.Violation{
+				fixViolation(1, value.NewFixInfo().WithEditColumn(2).WithDeleteLength(1)),
+				fixViolation(1, value.NewFixInfo().WithEditColumn(5).WithDeleteLength(1)),
+			},
+			expected:        "a b c",
+			expectedApplied: 2,
+		},
+		{
+			name:    "overlapping edit is skipped",
+			content: "abcdef",
+			violations: []value.Violation{
+				fixViolation(1, value.NewFixInfo().WithEditColumn(2).WithDeleteLength(3).WithReplaceText("X")),
+				fixViolation(1, value.NewFixInfo().WithEditColumn(3).WithDeleteLength(1).WithReplaceText("Y")),
+			},
+			expected:        "abYdef",
+			expectedApplied: 1,
+		},
+		{
+			name:    "duplicate fixes apply once",
+			content: "a\nb",
+			violations: []value.Violation{
+				fixViolation(2, value.NewFixInfo().WithEditColumn(1).WithReplaceText("- ")),
+				fixViolation(2, value.NewFixInfo().WithEditColumn(1).WithReplaceText("- ")),
+			},
+			expected:        "a\n- b",
+			expectedApplied: 1,
+		},
+		{
+			name:    "line fix replaces lines",
+			content: "a\nb\nc\nd",
+			violations: []value.Violation{
+				fixViolation(2, value.NewFixInfo().WithLineNumber(2).WithDeleteCount(2).WithInsertText("x\ny\nz")),
+			},
+			expected:        "a\nx\ny\nz\nd",
+			expectedApplied: 1,
+		},
+		{
+			name:    "delete length -1 removes the line",
+			content: "a\n\n\nb",
+			violations: []value.Violation{
+				fixViolation(3, value.NewFixInfo().WithEditColumn(1).WithDeleteLength(-1)),
+			},
+			expected:        "a\n\nb",
+			expectedApplied: 1,
+		},
+		{
+			name:    "CRLF line endings are preserved",
+			content: "text\r\n- a\r\n",
+			violations: []value.Violation{
+				fixViolation(2, value.NewFixInfo().WithEditColumn(1).WithReplaceText("\n")),
+			},
+			expected:        "text\r\n\r\n- a\r\n",
+			expectedApplied: 1,
+		},
+		{
+			name:    "fixes outside the document and violations without fixes are ignored",
+			content: "a",
+			violations: []value.Violation{
+				fixViolation(5, value.NewFixInfo().WithEditColumn(1).WithReplaceText("x")),
+				*value.NewViolation([]string{"MD000"}, "test", nil, 1),
+			},
+			expected:        "a",
+			expectedApplied: 0,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			fixed, applied := ApplyFixes(scenario.content, scenario.violations)
+			assert.Equal(t, scenario.expected, fixed)
+			assert.Equal(t, scenario.expectedApplied, applied)
+		})
+	}
+}
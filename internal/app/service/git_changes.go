@@ -0,0 +1,242 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// LineRange is an inclusive range of 1-based line numbers.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// Contains reports whether line falls within the range.
+func (r LineRange) Contains(line int) bool {
+	return line >= r.Start && line <= r.End
+}
+
+// GitChangedFiles returns the files in the repository containing dir that
+// changed in the working tree since it diverged from rev, as absolute paths.
+// Changes are taken from the merge base of rev and HEAD, so commits made on
+// rev after the branch point are not included, and untracked files that are
+// not ignored count as changed. Deleted files are omitted.
+func GitChangedFiles(dir, rev string) ([]string, error) {
+	root, err := gitRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	base, err := gitMergeBase(root, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := runGit(root, "-c", "core.quotePath=false", "diff", "--name-only", "--no-renames", "--diff-filter=d", base, "--")
+	if err != nil {
+		return nil, err
+	}
+	names := gitPathList(output)
+
+	untracked, err := gitUntrackedFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	names = append(names, untracked...)
+
+	files := make([]string, len(names))
+	for i, name := range names {
+		files[i] = filepath.Join(root, filepath.FromSlash(name))
+	}
+	return files, nil
+}
+
+// GitChangedLines returns the added or modified lines of each file in the
+// repository containing dir since it diverged from rev, keyed by absolute
+// path. As for GitChangedFiles, changes are taken from the merge base and
+// every line of an untracked file counts as added. Files with only deletions
+// map to no ranges.
+func GitChangedLines(dir, rev string) (map[string][]LineRange, error) {
+	root, err := gitRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	base, err := gitMergeBase(root, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := runGit(root, "-c", "core.quotePath=false", "diff", "-U0", "--no-color", "--no-ext-diff", "--no-renames", "--diff-filter=d", base, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := parseUnifiedDiff(output)
+	if err != nil {
+		return nil, err
+	}
+
+	absolute := make(map[string][]LineRange, len(changes))
+	for name, ranges := range changes {
+		absolute[filepath.Join(root, filepath.FromSlash(name))] = ranges
+	}
+
+	untracked, err := gitUntrackedFiles(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range untracked {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var ranges []LineRange
+		if lines := countLines(content); lines > 0 {
+			ranges = []LineRange{{Start: 1, End: lines}}
+		}
+		absolute[path] = ranges
+	}
+	return absolute, nil
+}
+
+// gitMergeBase returns the commit where HEAD diverged from rev.
+func gitMergeBase(root, rev string) (string, error) {
+	output, err := runGit(root, "merge-base", rev, "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// gitUntrackedFiles returns the slash-separated paths, relative to root, of
+// the untracked files that are not ignored.
+func gitUntrackedFiles(root string) ([]string, error) {
+	output, err := runGit(root, "-c", "core.quotePath=false", "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	return gitPathList(output), nil
+}
+
+// gitPathList splits git output listing one path per line.
+func gitPathList(output []byte) []string {
+	var names []string
+	for _, name := range strings.Split(string(output), "\n") {
+		if name != "" {
+			names = append(names, unquoteGitPath(name))
+		}
+	}
+	return names
+}
+
+// countLines returns the number of lines in content, counting a final line
+// without a newline.
+func countLines(content []byte) int {
+	lines := bytes.Count(content, []byte("\n"))
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
+// parseUnifiedDiff extracts the new-side line ranges of each hunk from
+// `git diff -U0` output, keyed by the slash-separated path in the diff.
+func parseUnifiedDiff(diff []byte) (map[string][]LineRange, error) {
+	changes := make(map[string][]LineRange)
+	current := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(diff))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			name := unquoteGitPath(strings.TrimPrefix(line, "+++ "))
+			if name == "/dev/null" {
+				current = ""
+				continue
+			}
+			current = strings.TrimPrefix(name, "b/")
+			if _, exists := changes[current]; !exists {
+				changes[current] = nil
+			}
+		case strings.HasPrefix(line, "@@ ") && current != "":
+			hunk, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, err
+			}
+			if hunk.End >= hunk.Start {
+				changes[current] = append(changes[current], hunk)
+			}
+		}
+	}
+	return changes, scanner.Err()
+}
+
+// parseHunkHeader returns the new-side range of a hunk header such as
+// "@@ -10,2 +12,3 @@ func". A zero count, for pure deletions, yields an
+// empty range.
+func parseHunkHeader(header string) (LineRange, error) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return LineRange{}, fmt.Errorf("malformed hunk header %q", header)
+	}
+
+	spec := strings.TrimPrefix(fields[2], "+")
+	startText, countText, hasCount := strings.Cut(spec, ",")
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return LineRange{}, fmt.Errorf("malformed hunk header %q", header)
+	}
+	count := 1
+	if hasCount {
+		if count, err = strconv.Atoi(countText); err != nil {
+			return LineRange{}, fmt.Errorf("malformed hunk header %q", header)
+		}
+	}
+	return LineRange{Start: start, End: start + count - 1}, nil
+}
+
+// unquoteGitPath decodes a path that git quoted because it contains special
+// characters.
+func unquoteGitPath(path string) string {
+	if strings.HasPrefix(path, `"`) {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			return unquoted
+		}
+	}
+	return path
+}
+
+// gitRoot returns the top-level directory of the repository containing dir.
+func gitRoot(dir string) (string, error) {
+	output, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return filepath.FromSlash(strings.TrimSpace(string(output))), nil
+}
+
+// runGit runs git in dir and returns its standard output. Failures include
+// git's own error message.
+func runGit(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("git failed: %s", message)
+		}
+		return nil, fmt.Errorf("git failed: %w", err)
+	}
+	return output, nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitTestRepo creates a throwaway git repository with an initial commit of
+// files and returns its resolved root.
+func gitTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	gitTestRun(t, root, "init", "--quiet")
+	writeGitTestFiles(t, root, files)
+	gitTestRun(t, root, "add", "--all")
+	gitTestRun(t, root, "commit", "--quiet", "-m", "initial")
+	return root
+}
+
+// gitTestRun runs a git command in dir with a fixed identity.
+func gitTestRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
+func writeGitTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestGitChangedFiles(t *testing.T) {
+	root := gitTestRepo(t, map[string]string{
+		"README.md":          "# Readme\n",
+		"docs/guide.md":      "# Guide\n",
+		"docs/unchanged.md":  "# Unchanged\n",
+		"docs/removed.md":    "# Removed\n",
+		"docs/with space.md": "# Space\n",
+	})
+
+	writeGitTestFiles(t, root, map[string]string{
+		".gitignore":         "*.tmp.md\n",
+		"docs/guide.md":      "# Guide\n\nMore\n",
+		"docs/with space.md": "# Space\n\nMore\n",
+		"docs/new.md":        "# New\n",
+		"docs/untracked.md":  "# Untracked\n",
+		"docs/draft.tmp.md":  "# Ignored\n",
+	})
+	require.NoError(t, os.Remove(filepath.Join(root, "docs", "removed.md")))
+	gitTestRun(t, root, "add", "docs/new.md")
+
+	files, err := GitChangedFiles(filepath.Join(root, "docs"), "HEAD")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(root, ".gitignore"),
+		filepath.Join(root, "docs", "guide.md"),
+		filepath.Join(root, "docs", "new.md"),
+		filepath.Join(root, "docs", "untracked.md"),
+		filepath.Join(root, "docs", "with space.md"),
+	}, files, "paths are absolute, staged and untracked files are included, and deleted and ignored files are not")
+}
+
+func TestGitChangedFiles_MergeBase(t *testing.T) {
+	root := gitTestRepo(t, map[string]string{
+		"feature.md": "# Feature\n",
+		"trunk.md":   "# Trunk\n",
+	})
+	gitTestRun(t, root, "branch", "trunk")
+	gitTestRun(t, root, "checkout", "--quiet", "-b", "feature")
+	writeGitTestFiles(t, root, map[string]string{"feature.md": "# Feature\n\nWork\n"})
+	gitTestRun(t, root, "commit", "--quiet", "-am", "feature work")
+
+	// Commits on trunk after the branch point are not changes of the branch
+	gitTestRun(t, root, "checkout", "--quiet", "trunk")
+	writeGitTestFiles(t, root, map[string]string{"trunk.md": "# Trunk\n\nLater\n"})
+	gitTestRun(t, root, "commit", "--quiet", "-am", "trunk work")
+	gitTestRun(t, root, "checkout", "--quiet", "feature")
+
+	files, err := GitChangedFiles(root, "trunk")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "feature.md")}, files)
+
+	lines, err := GitChangedLines(root, "trunk")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]LineRange{
+		filepath.Join(root, "feature.md"): {{Start: 2, End: 3}},
+	}, lines)
+}
+
+func TestGitChangedLines(t *testing.T) {
+	root := gitTestRepo(t, map[string]string{
+		"doc.md": "# Title\n\nline 3\nline 4\nline 5\nline 6\n",
+	})
+	gitTestRun(t, root, "tag", "base")
+
+	// Modify line 3, delete line 5 and append two lines
+	writeGitTestFiles(t, root, map[string]string{
+		"doc.md": "# Title\n\nline 3 changed\nline 4\nline 6\nline 7\nline 8\n",
+	})
+	gitTestRun(t, root, "commit", "--quiet", "-am", "edit")
+	writeGitTestFiles(t, root, map[string]string{"new.md": "# New\n\nno final newline"})
+
+	lines, err := GitChangedLines(root, "base")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]LineRange{
+		filepath.Join(root, "doc.md"): {{Start: 3, End: 3}, {Start: 6, End: 7}},
+		filepath.Join(root, "new.md"): {{Start: 1, End: 3}},
+	}, lines, "every line of an untracked file is added")
+}
+
+func TestGitChangedFiles_Errors(t *testing.T) {
+	root := gitTestRepo(t, map[string]string{"README.md": "# Readme\n"})
+
+	_, err := GitChangedFiles(root, "no-such-revision")
+	assert.ErrorContains(t, err, "git failed")
+
+	_, err = GitChangedLines(t.TempDir(), "HEAD")
+	assert.ErrorContains(t, err, "git failed", "directories outside a repository are rejected")
+}
+
+func TestParseUnifiedDiff(t *testing.T) {
+	diff := `diff --git a/a.md b/a.md
+index 1111111..2222222 100644
+--- a/a.md
++++ b/a.md
+@@ -1 +1 @@
+-old
++new
+@@ -4,0 +5,3 @@ heading
++added
++added
++added
+@@ -10,2 +12,0 @@
+-gone
+-gone
+diff --git a/"q\"uote.md" b/"q\"uote.md"
+--- /dev/null
++++ "b/q\"uote.md"
+@@ -0,0 +1,2 @@
++one
++two
+diff --git a/removed.md b/removed.md
+--- a/removed.md
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+`
+
+	changes, err := parseUnifiedDiff([]byte(diff))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]LineRange{
+		"a.md":      {{Start: 1, End: 1}, {Start: 5, End: 7}},
+		`q"uote.md`: {{Start: 1, End: 2}},
+	}, changes)
+
+	_, err = parseUnifiedDiff([]byte("+++ b/a.md\n@@ -1 +x @@\n"))
+	assert.ErrorContains(t, err, "malformed hunk header")
+}
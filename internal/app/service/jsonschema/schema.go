@@ -0,0 +1,709 @@
+// Package jsonschema validates data against JSON Schema documents written in
+// JSON or YAML. It supports the validation keywords of drafts 7 to 2020-12
+// that apply to configuration-like data, and $ref to definitions in the same
+// document or in other local files.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxRefDepth bounds the $ref chains followed, so that a schema referring
+// to itself without consuming data fails instead of recursing forever.
+const maxRefDepth = 64
+
+// Schema is a JSON Schema loaded from a file, with the local files it
+// refers to.
+type Schema struct {
+	path      string
+	documents map[string]interface{} // Loaded documents by absolute path
+	files     map[string]os.FileInfo // Loaded files, to notice changes
+	mutex     sync.Mutex             // Guards patterns
+	patterns  map[string]*regexp.Regexp
+}
+
+// Error is a validation failure at a location in the data.
+type Error struct {
+	Path    []string // Object keys and array indices from the root of the data
+	Message string
+}
+
+// Error returns the message with its location, such as "tags[1]: must be
+// one of: go, markdown".
+func (e Error) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return FormatPath(e.Path) + ": " + e.Message
+}
+
+// FormatPath formats a data location as keys joined by dots with array
+// indices in brackets.
+func FormatPath(path []string) string {
+	var b strings.Builder
+	for _, element := range path {
+		if _, err := strconv.Atoi(element); err == nil {
+			b.WriteString("[" + element + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(element)
+	}
+	return b.String()
+}
+
+// schemaCache holds loaded schemas by path, reloaded when one of their
+// files changes.
+var schemaCache = struct {
+	sync.Mutex
+	schemas map[string]*Schema
+}{schemas: make(map[string]*Schema)}
+
+// Load reads a schema from a JSON or YAML file, with the local files it
+// refers to with $ref.
+func Load(path string) (*Schema, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaCache.Lock()
+	defer schemaCache.Unlock()
+	if schema, exists := schemaCache.schemas[abs]; exists && !schema.changed() {
+		return schema, nil
+	}
+
+	schema := &Schema{
+		path:      abs,
+		documents: make(map[string]interface{}),
+		files:     make(map[string]os.FileInfo),
+		patterns:  make(map[string]*regexp.Regexp),
+	}
+	if err := schema.load(abs); err != nil {
+		return nil, err
+	}
+	schemaCache.schemas[abs] = schema
+	return schema, nil
+}
+
+// Files returns the files the schema was read from, its own first.
+func (s *Schema) Files() []string {
+	files := []string{s.path}
+	for path := range s.files {
+		if path != s.path {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files[1:])
+	return files
+}
+
+// changed reports whether one of the schema's files changed since loading.
+func (s *Schema) changed() bool {
+	for path, loaded := range s.files {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(loaded.ModTime()) || info.Size() != loaded.Size() {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads a schema document and the files its $refs point to.
+func (s *Schema) load(path string) error {
+	if _, exists := s.documents[path]; exists {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+	var document interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &document)
+	default:
+		err = json.Unmarshal(data, &document)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid schema %s: %w", path, err)
+	}
+	switch document.(type) {
+	case map[string]interface{}, bool:
+	default:
+		return fmt.Errorf("invalid schema %s: expected an object or a boolean", path)
+	}
+	s.documents[path] = document
+	s.files[path] = info
+
+	for _, file := range refFiles(document) {
+		if parsed, err := url.Parse(file); err == nil && parsed.Scheme != "" {
+			return fmt.Errorf("schema %s: remote $ref %q is not supported", path, file)
+		}
+		if err := s.load(filepath.Join(filepath.Dir(path), filepath.FromSlash(file))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refFiles returns the files named by the $refs in a schema document.
+func refFiles(node interface{}) []string {
+	var files []string
+	switch node := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := node["$ref"].(string); ok {
+			if file, _, _ := strings.Cut(ref, "#"); file != "" {
+				files = append(files, file)
+			}
+		}
+		for _, child := range node {
+			files = append(files, refFiles(child)...)
+		}
+	case []interface{}:
+		for _, child := range node {
+			files = append(files, refFiles(child)...)
+		}
+	}
+	return files
+}
+
+// Validate returns the validation errors of data, ordered by location. Data
+// is the result of decoding JSON, YAML or TOML into interface{}; dates and
+// times are validated as their RFC 3339 text.
+func (s *Schema) Validate(data interface{}) ([]Error, error) {
+	v := &validator{schema: s}
+	v.validate(s.documents[s.path], s.path, normalize(data), nil, 0)
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		return strings.Join(v.errors[i].Path, "\x00") < strings.Join(v.errors[j].Path, "\x00")
+	})
+	return v.errors, nil
+}
+
+// normalize converts decoded data to JSON types: float64 numbers, strings
+// for dates and times, and map[string]interface{} objects.
+func normalize(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			result[k] = normalize(v)
+		}
+		return result
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			result[fmt.Sprint(k)] = normalize(v)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = normalize(v)
+		}
+		return result
+	case []map[string]interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			result[i] = normalize(v)
+		}
+		return result
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return value.String()
+	case nil, bool, string, float64:
+		return value
+	}
+
+	number := reflect.ValueOf(data)
+	switch number.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(number.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(number.Uint())
+	case reflect.Float32:
+		return number.Float()
+	}
+	return fmt.Sprint(data)
+}
+
+// validator collects the errors of one validation.
+type validator struct {
+	schema *Schema
+	errors []Error
+	err    error // Schema error, such as an unresolvable $ref
+}
+
+func (v *validator) fail(path []string, format string, args ...interface{}) {
+	v.errors = append(v.errors, Error{Path: append([]string{}, path...), Message: fmt.Sprintf(format, args...)})
+}
+
+// valid reports whether data matches a subschema, without recording errors.
+func (v *validator) valid(schema interface{}, base string, data interface{}, depth int) bool {
+	sub := &validator{schema: v.schema}
+	sub.validate(schema, base, data, nil, depth)
+	if sub.err != nil && v.err == nil {
+		v.err = sub.err
+	}
+	return len(sub.errors) == 0
+}
+
+// validate checks data at path against schema, read from the document at
+// base.
+func (v *validator) validate(schema interface{}, base string, data interface{}, path []string, depth int) {
+	if v.err != nil {
+		return
+	}
+
+	rules, ok := schema.(map[string]interface{})
+	if !ok {
+		if allowed, isBool := schema.(bool); isBool && !allowed {
+			v.fail(path, "is not allowed")
+		}
+		return
+	}
+
+	if ref, exists := rules["$ref"].(string); exists {
+		if depth >= maxRefDepth {
+			v.err = fmt.Errorf("schema %s: $ref %q nests too deeply", base, ref)
+			return
+		}
+		target, targetBase, err := v.resolve(ref, base)
+		if err != nil {
+			v.err = err
+			return
+		}
+		v.validate(target, targetBase, data, path, depth+1)
+	}
+
+	if types, exists := rules["type"]; exists {
+		names := stringList(types)
+		if !matchesAnyType(names, data) {
+			v.fail(path, "must be %s", strings.Join(names, " or "))
+			return
+		}
+	}
+
+	if values, exists := rules["enum"].([]interface{}); exists {
+		if !containsValue(values, data) {
+			v.fail(path, "must be one of: %s", formatValues(values))
+		}
+	}
+	if value, exists := rules["const"]; exists && !equal(normalize(value), data) {
+		v.fail(path, "must be %s", formatValue(value))
+	}
+
+	switch value := data.(type) {
+	case string:
+		v.validateString(rules, value, path)
+	case float64:
+		v.validateNumber(rules, value, path)
+	case []interface{}:
+		v.validateArray(rules, base, value, path, depth)
+	case map[string]interface{}:
+		v.validateObject(rules, base, value, path, depth)
+	}
+
+	if all, exists := rules["allOf"].([]interface{}); exists {
+		for _, sub := range all {
+			v.validate(sub, base, data, path, depth)
+		}
+	}
+	if any, exists := rules["anyOf"].([]interface{}); exists {
+		matched := false
+		for _, sub := range any {
+			if v.valid(sub, base, data, depth) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(path, "must match at least one schema in anyOf")
+		}
+	}
+	if one, exists := rules["oneOf"].([]interface{}); exists {
+		matches := 0
+		for _, sub := range one {
+			if v.valid(sub, base, data, depth) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			v.fail(path, "must match exactly one schema in oneOf (matched %d)", matches)
+		}
+	}
+	if not, exists := rules["not"]; exists && v.valid(not, base, data, depth) {
+		v.fail(path, "must not match the schema in not")
+	}
+	if condition, exists := rules["if"]; exists {
+		if v.valid(condition, base, data, depth) {
+			if then, exists := rules["then"]; exists {
+				v.validate(then, base, data, path, depth)
+			}
+		} else if otherwise, exists := rules["else"]; exists {
+			v.validate(otherwise, base, data, path, depth)
+		}
+	}
+}
+
+func (v *validator) validateString(rules map[string]interface{}, value string, path []string) {
+	length := utf8.RuneCountInString(value)
+	if limit, exists := number(rules["minLength"]); exists && float64(length) < limit {
+		v.fail(path, "must be at least %s characters long", formatNumber(limit))
+	}
+	if limit, exists := number(rules["maxLength"]); exists && float64(length) > limit {
+		v.fail(path, "must be at most %s characters long", formatNumber(limit))
+	}
+	if pattern, exists := rules["pattern"].(string); exists {
+		regex, err := v.schema.pattern(pattern)
+		if err != nil {
+			v.err = err
+			return
+		}
+		if !regex.MatchString(value) {
+			v.fail(path, "must match pattern '%s'", pattern)
+		}
+	}
+	if format, exists := rules["format"].(string); exists {
+		if description, ok := checkFormat(format, value); !ok {
+			v.fail(path, "must be a valid %s", description)
+		}
+	}
+}
+
+func (v *validator) validateNumber(rules map[string]interface{}, value float64, path []string) {
+	if limit, exists := number(rules["minimum"]); exists && value < limit {
+		v.fail(path, "must be >= %s", formatNumber(limit))
+	}
+	if limit, exists := number(rules["maximum"]); exists && value > limit {
+		v.fail(path, "must be <= %s", formatNumber(limit))
+	}
+	if limit, exists := number(rules["exclusiveMinimum"]); exists && value <= limit {
+		v.fail(path, "must be > %s", formatNumber(limit))
+	}
+	if limit, exists := number(rules["exclusiveMaximum"]); exists && value >= limit {
+		v.fail(path, "must be < %s", formatNumber(limit))
+	}
+	if divisor, exists := number(rules["multipleOf"]); exists && divisor > 0 {
+		if quotient := value / divisor; math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			v.fail(path, "must be a multiple of %s", formatNumber(divisor))
+		}
+	}
+}
+
+func (v *validator) validateArray(rules map[string]interface{}, base string, items []interface{}, path []string, depth int) {
+	if limit, exists := number(rules["minItems"]); exists && float64(len(items)) < limit {
+		v.fail(path, "must have at least %s items", formatNumber(limit))
+	}
+	if limit, exists := number(rules["maxItems"]); exists && float64(len(items)) > limit {
+		v.fail(path, "must have at most %s items", formatNumber(limit))
+	}
+	if unique, _ := rules["uniqueItems"].(bool); unique {
+		for i := range items {
+			for j := 0; j < i; j++ {
+				if equal(items[i], items[j]) {
+					v.fail(append(path, strconv.Itoa(i)), "duplicates item %d", j)
+					break
+				}
+			}
+		}
+	}
+
+	// Tuples, as prefixItems (2020-12) or an items array (draft 7)
+	tuple, _ := rules["prefixItems"].([]interface{})
+	rest := rules["items"]
+	if array, isArray := rest.([]interface{}); isArray {
+		tuple, rest = array, rules["additionalItems"]
+	}
+	for i, item := range items {
+		itemPath := append(path[:len(path):len(path)], strconv.Itoa(i))
+		switch {
+		case i < len(tuple):
+			v.validate(tuple[i], base, item, itemPath, depth)
+		case rest != nil:
+			v.validate(rest, base, item, itemPath, depth)
+		}
+	}
+
+	if contains, exists := rules["contains"]; exists {
+		found := false
+		for _, item := range items {
+			if v.valid(contains, base, item, depth) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "must contain an item matching the schema in contains")
+		}
+	}
+}
+
+func (v *validator) validateObject(rules map[string]interface{}, base string, object map[string]interface{}, path []string, depth int) {
+	for _, name := range stringList(rules["required"]) {
+		if _, exists := object[name]; !exists {
+			v.fail(path, "missing required property '%s'", name)
+		}
+	}
+	if limit, exists := number(rules["minProperties"]); exists && float64(len(object)) < limit {
+		v.fail(path, "must have at least %s properties", formatNumber(limit))
+	}
+	if limit, exists := number(rules["maxProperties"]); exists && float64(len(object)) > limit {
+		v.fail(path, "must have at most %s properties", formatNumber(limit))
+	}
+
+	properties, _ := rules["properties"].(map[string]interface{})
+	patternProperties, _ := rules["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := rules["additionalProperties"]
+	propertyNames, hasPropertyNames := rules["propertyNames"]
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := append(path[:len(path):len(path)], key)
+		if hasPropertyNames && !v.valid(propertyNames, base, key, depth) {
+			v.fail(keyPath, "is not an allowed property name")
+		}
+
+		matched := false
+		if property, exists := properties[key]; exists {
+			matched = true
+			v.validate(property, base, object[key], keyPath, depth)
+		}
+		for pattern, property := range patternProperties {
+			regex, err := v.schema.pattern(pattern)
+			if err != nil {
+				v.err = err
+				return
+			}
+			if regex.MatchString(key) {
+				matched = true
+				v.validate(property, base, object[key], keyPath, depth)
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if allowed, isBool := additional.(bool); isBool {
+			if !allowed {
+				v.fail(keyPath, "is not an allowed property")
+			}
+			continue
+		}
+		v.validate(additional, base, object[key], keyPath, depth)
+	}
+}
+
+// resolve returns the schema a $ref points to and the document it is in.
+// References are to other local files, relative to the referring document,
+// and to JSON pointers within a document.
+func (v *validator) resolve(ref, base string) (interface{}, string, error) {
+	file, fragment, _ := strings.Cut(ref, "#")
+	target := base
+	if file != "" {
+		target = filepath.Join(filepath.Dir(base), filepath.FromSlash(file))
+	}
+
+	document := v.schema.documents[target]
+	if fragment == "" {
+		return document, target, nil
+	}
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, "", fmt.Errorf("schema %s: $ref %q: only JSON pointer fragments are supported", base, ref)
+	}
+
+	current := document
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token, _ = url.PathUnescape(token)
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, exists := node[token]
+			if !exists {
+				return nil, "", fmt.Errorf("schema %s: $ref %q not found", base, ref)
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, "", fmt.Errorf("schema %s: $ref %q not found", base, ref)
+			}
+			current = node[index]
+		default:
+			return nil, "", fmt.Errorf("schema %s: $ref %q not found", base, ref)
+		}
+	}
+	return current, target, nil
+}
+
+// pattern returns a compiled schema pattern.
+func (s *Schema) pattern(pattern string) (*regexp.Regexp, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if regex, exists := s.patterns[pattern]; exists {
+		return regex, nil
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("schema %s: invalid pattern %q: %w", s.path, pattern, err)
+	}
+	s.patterns[pattern] = regex
+	return regex, nil
+}
+
+// checkFormat reports whether a string has a format, with the format's
+// description. Unknown formats always match.
+func checkFormat(format, value string) (string, bool) {
+	var err error
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+		return "date-time (RFC 3339)", err == nil
+	case "date":
+		_, err = time.Parse(time.DateOnly, value)
+		return "date (YYYY-MM-DD)", err == nil
+	case "time":
+		_, err = time.Parse("15:04:05Z07:00", value)
+		return "time (RFC 3339)", err == nil
+	case "email":
+		_, err = mail.ParseAddress(value)
+		return "email address", err == nil && !strings.ContainsAny(value, "<> ")
+	case "uri":
+		parsed, err := url.Parse(value)
+		return "URI", err == nil && parsed.Scheme != ""
+	case "uri-reference":
+		_, err = url.Parse(value)
+		return "URI reference", err == nil
+	case "regex":
+		_, err = regexp.Compile(value)
+		return "regular expression", err == nil
+	}
+	return format, true
+}
+
+func matchesAnyType(types []string, data interface{}) bool {
+	for _, name := range types {
+		switch value := data.(type) {
+		case nil:
+			if name == "null" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case float64:
+			if name == "number" || (name == "integer" && value == math.Trunc(value)) {
+				return true
+			}
+		case []interface{}:
+			if name == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if name == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringList returns a string or a list of strings as a list.
+func stringList(value interface{}) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var result []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func number(value interface{}) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	n, ok := normalize(value).(float64)
+	return n, ok
+}
+
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func containsValue(values []interface{}, data interface{}) bool {
+	for _, value := range values {
+		if equal(value, data) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func formatValue(value interface{}) string {
+	data, err := json.Marshal(normalize(value))
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func formatValues(values []interface{}) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		if s, ok := value.(string); ok {
+			formatted[i] = s
+		} else {
+			formatted[i] = formatValue(value)
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,163 @@
+package jsonschema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func validate(t *testing.T, schema *Schema, data interface{}) []string {
+	t.Helper()
+	errors, err := schema.Validate(data)
+	require.NoError(t, err)
+	var messages []string
+	for _, e := range errors {
+		messages = append(messages, e.Error())
+	}
+	return messages
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "post.json", `{
+  "type": "object",
+  "required": ["title", "date", "tags", "draft"],
+  "properties": {
+    "title": {"type": "string", "minLength": 1},
+    "date": {"type": "string", "format": "date-time"},
+    "tags": {"type": "array", "items": {"$ref": "defs/tags.yaml#/$defs/tag"}, "uniqueItems": true},
+    "draft": {"type": "boolean"},
+    "weight": {"type": "integer", "minimum": 0, "multipleOf": 5}
+  },
+  "additionalProperties": false
+}`)
+	writeFile(t, dir, "defs/tags.yaml", "$defs:\n  tag:\n    enum: [go, markdown, release]\n")
+
+	schema, err := Load(path)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		data     map[string]interface{}
+		expected []string
+	}{
+		{
+			name: "valid",
+			data: map[string]interface{}{"title": "Hello", "date": "2024-05-01T10:00:00Z", "tags": []interface{}{"go"}, "draft": false, "weight": 10},
+		},
+		{
+			name: "time value",
+			data: map[string]interface{}{"title": "Hello", "date": time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), "tags": []interface{}{}, "draft": true},
+		},
+		{
+			name:     "missing properties",
+			data:     map[string]interface{}{"title": "Hello"},
+			expected: []string{"missing required property 'date'", "missing required property 'tags'", "missing required property 'draft'"},
+		},
+		{
+			name: "invalid values",
+			data: map[string]interface{}{"title": "", "date": "2024-05-01", "tags": []interface{}{"go", "rust", "go"}, "draft": "no", "weight": 7, "author": "me"},
+			expected: []string{
+				"author: is not an allowed property",
+				"date: must be a valid date-time (RFC 3339)",
+				"draft: must be boolean",
+				"tags[1]: must be one of: go, markdown, release",
+				"tags[2]: duplicates item 0",
+				"title: must be at least 1 characters long",
+				"weight: must be a multiple of 5",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validate(t, schema, tt.data))
+		})
+	}
+
+	assert.Equal(t, []string{path, filepath.Join(dir, "defs", "tags.yaml")}, schema.Files())
+}
+
+func TestValidate_Combinators(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "schema.yaml", `
+definitions:
+  name: {type: string, pattern: "^[a-z]+$"}
+type: object
+properties:
+  id:
+    oneOf: [{type: integer}, {$ref: "#/definitions/name"}]
+  kind:
+    not: {const: legacy}
+  size:
+    anyOf: [{type: "null"}, {type: number, exclusiveMaximum: 10}]
+patternProperties:
+  "^x-": {type: string}
+`)
+	schema, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Empty(t, validate(t, schema, map[string]interface{}{"id": "abc", "kind": "new", "size": nil, "x-note": "ok"}))
+	assert.Equal(t, []string{
+		"id: must match exactly one schema in oneOf (matched 0)",
+		"kind: must not match the schema in not",
+		"size: must match at least one schema in anyOf",
+		"x-note: must be string",
+	}, validate(t, schema, map[string]interface{}{"id": "ABC", "kind": "legacy", "size": 10, "x-note": 1}))
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	_, err = Load(writeFile(t, dir, "broken.json", `{"type": `))
+	assert.Error(t, err)
+
+	_, err = Load(writeFile(t, dir, "ref.json", `{"$ref": "other.json"}`))
+	assert.Error(t, err)
+
+	_, err = Load(writeFile(t, dir, "remote.json", `{"$ref": "https://example.com/schema.json"}`))
+	assert.ErrorContains(t, err, "remote $ref")
+
+	schema, err := Load(writeFile(t, dir, "loop.json", `{"$ref": "#"}`))
+	require.NoError(t, err)
+	_, err = schema.Validate(map[string]interface{}{})
+	assert.ErrorContains(t, err, "nests too deeply")
+}
+
+func TestLoad_ReloadsChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "schema.json", `{"$ref": "base.json"}`)
+	base := writeFile(t, dir, "base.json", `{"required": ["title"]}`)
+
+	schema, err := Load(path)
+	require.NoError(t, err)
+	assert.Len(t, validate(t, schema, map[string]interface{}{}), 1)
+
+	require.NoError(t, os.WriteFile(base, []byte(`{"required": []}`), 0o644))
+	require.NoError(t, os.Chtimes(base, time.Now(), time.Now().Add(time.Hour)))
+
+	schema, err = Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, validate(t, schema, map[string]interface{}{}))
+}
+
+func TestFormatPath(t *testing.T) {
+	assert.Equal(t, "", FormatPath(nil))
+	assert.Equal(t, "tags[1]", FormatPath([]string{"tags", "1"}))
+	assert.Equal(t, "authors[0].name", FormatPath([]string{"authors", "0", "name"}))
+}
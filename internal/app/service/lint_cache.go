@@ -0,0 +1,575 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gomdlint/gomdlint/internal/app/service/jsonschema"
+	"github.com/gomdlint/gomdlint/internal/app/service/spelling"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+	"github.com/gomdlint/gomdlint/internal/shared/utils"
+)
+
+const (
+	// cacheFormatVersion is bumped whenever the cache file layout changes.
+	cacheFormatVersion = 1
+
+	cacheFilePrefix = "lint-"
+	cacheFileSuffix = ".json"
+)
+
+// DefaultCacheMaxAge is how long unused cache entries and cache files are
+// kept before automatic pruning removes them.
+const DefaultCacheMaxAge = 30 * 24 * time.Hour
+
+// LintCache persists lint results between runs. Results are stored in one
+// file per cache key, so a change in configuration, gomdlint build or loaded
+// plugins starts a fresh file instead of serving stale results. Within a file,
+// entries are keyed by absolute path and validated against the file's content
+// hash or metadata, depending on the strategy.
+type LintCache struct {
+	path     string
+	key      string
+	strategy value.CacheStrategy
+	maxAge   time.Duration
+	now      time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*cacheEntry
+	dirty   bool
+	hits    int
+	misses  int
+}
+
+// cacheFile is the on-disk layout of a cache file.
+type cacheFile struct {
+	Format  int                    `json:"format"`
+	Key     string                 `json:"key"`
+	Entries map[string]*cacheEntry `json:"entries"`
+}
+
+// cacheEntry records the results for one file.
+type cacheEntry struct {
+	Hash       string            `json:"hash"`
+	Size       int64             `json:"size"`
+	ModTime    int64             `json:"modTime"`  // Unix nanoseconds
+	LastUsed   int64             `json:"lastUsed"` // Unix seconds
+	Violations []cachedViolation `json:"violations"`
+}
+
+// cachedViolation is the serialized form of value.Violation.
+type cachedViolation struct {
+	RuleNames       []string                         `json:"ruleNames"`
+	RuleDescription string                           `json:"ruleDescription"`
+	RuleInformation string                           `json:"ruleInformation,omitempty"`
+	LineNumber      int                              `json:"lineNumber"`
+	ColumnNumber    functional.Option[int]           `json:"columnNumber"`
+	Length          functional.Option[int]           `json:"length"`
+	Severity        value.Severity                   `json:"severity"`
+	ErrorDetail     functional.Option[string]        `json:"errorDetail"`
+	ErrorContext    functional.Option[string]        `json:"errorContext"`
+	ErrorRange      functional.Option[value.Range]   `json:"errorRange"`
+	FixInfo         functional.Option[cachedFixInfo] `json:"fixInfo"`
+}
+
+// cachedFixInfo is the serialized form of value.FixInfo.
+type cachedFixInfo struct {
+	LineNumber   functional.Option[int]    `json:"lineNumber"`
+	DeleteCount  functional.Option[int]    `json:"deleteCount"`
+	InsertText   functional.Option[string] `json:"insertText"`
+	EditColumn   functional.Option[int]    `json:"editColumn"`
+	DeleteLength functional.Option[int]    `json:"deleteLength"`
+	ReplaceText  functional.Option[string] `json:"replaceText"`
+}
+
+// CacheStats summarizes the cache files in a cache directory.
+type CacheStats struct {
+	Location string
+	Files    int
+	Entries  int
+	Bytes    int64
+	Oldest   time.Time // Modification time of the least recently written file
+	Newest   time.Time // Modification time of the most recently written file
+}
+
+// DefaultCacheLocation returns the directory used when no cache location is
+// configured, creating it if needed.
+func DefaultCacheLocation() (string, error) {
+	dir, err := utils.EnsureCacheDir("gomdlint")
+	if err != nil {
+		return "", fmt.Errorf("failed to determine cache directory: %w", err)
+	}
+	return dir, nil
+}
+
+// ParseCacheStrategy validates a cache strategy name. An empty name selects
+// the content strategy.
+func ParseCacheStrategy(name string) (value.CacheStrategy, error) {
+	switch value.CacheStrategy(name) {
+	case "", value.CacheStrategyContent:
+		return value.CacheStrategyContent, nil
+	case value.CacheStrategyMetadata:
+		return value.CacheStrategyMetadata, nil
+	default:
+		return "", fmt.Errorf("invalid cache strategy %q (allowed: content, metadata)", name)
+	}
+}
+
+// OpenLintCache loads the cache file for key from the configured location,
+// starting empty when it is missing, unreadable or from another format.
+func OpenLintCache(options value.CacheOptions, key string) (*LintCache, error) {
+	strategy, err := ParseCacheStrategy(string(options.Strategy))
+	if err != nil {
+		return nil, err
+	}
+
+	location := options.Location
+	if location == "" {
+		if location, err = DefaultCacheLocation(); err != nil {
+			return nil, err
+		}
+	} else if err := os.MkdirAll(location, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	cache := &LintCache{
+		path:     filepath.Join(location, cacheFilePrefix+key[:16]+cacheFileSuffix),
+		key:      key,
+		strategy: strategy,
+		maxAge:   DefaultCacheMaxAge,
+		now:      time.Now(),
+		entries:  make(map[string]*cacheEntry),
+	}
+
+	if data, err := os.ReadFile(cache.path); err == nil {
+		var file cacheFile
+		if json.Unmarshal(data, &file) == nil && file.Format == cacheFormatVersion && file.Key == key && file.Entries != nil {
+			cache.entries = file.Entries
+		}
+	}
+
+	return cache, nil
+}
+
+// Path returns the cache file backing this cache.
+func (c *LintCache) Path() string {
+	return c.path
+}
+
+// Lookup returns the cached violations for filename when the file is
+// unchanged. With the content strategy the file is read to hash it; the
+// contents are returned on a miss so the caller does not read it twice.
+func (c *LintCache) Lookup(filename string) ([]value.Violation, []byte, bool) {
+	absPath, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	c.mutex.Lock()
+	entry := c.entries[absPath]
+	c.mutex.Unlock()
+
+	var content []byte
+	hit := false
+	if entry != nil {
+		switch c.strategy {
+		case value.CacheStrategyMetadata:
+			if info, err := os.Stat(filename); err == nil {
+				hit = info.Size() == entry.Size && info.ModTime().UnixNano() == entry.ModTime
+			}
+		default:
+			if content, err = os.ReadFile(filename); err == nil {
+				hit = hashContent(content) == entry.Hash
+			}
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !hit {
+		c.misses++
+		return nil, content, false
+	}
+
+	c.hits++
+	// Only refresh the last-used time once a day, so warm runs over an
+	// unchanged tree do not rewrite the cache file
+	if c.now.Unix()-entry.LastUsed > int64(24*time.Hour/time.Second) {
+		entry.LastUsed = c.now.Unix()
+		c.dirty = true
+	}
+	return restoreViolations(entry.Violations), nil, true
+}
+
+// Store records the violations found in filename, whose contents were
+// content.
+func (c *LintCache) Store(filename string, content []byte, violations []value.Violation) {
+	absPath, err := filepath.Abs(filename)
+	if err != nil {
+		return
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		return
+	}
+
+	entry := &cacheEntry{
+		Hash:       hashContent(content),
+		Size:       info.Size(),
+		ModTime:    info.ModTime().UnixNano(),
+		LastUsed:   c.now.Unix(),
+		Violations: storeViolations(violations),
+	}
+
+	c.mutex.Lock()
+	c.entries[absPath] = entry
+	c.dirty = true
+	c.mutex.Unlock()
+}
+
+// Stats returns the hits and misses since the cache was opened.
+func (c *LintCache) Stats() (hits, misses int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.hits, c.misses
+}
+
+// Save prunes stale entries and writes the cache file if anything changed.
+// Cache files for other keys that have not been written within the maximum
+// age are removed.
+func (c *LintCache) Save() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cutoff := c.now.Add(-c.maxAge)
+	for path, entry := range c.entries {
+		if entry.LastUsed < cutoff.Unix() {
+			delete(c.entries, path)
+			c.dirty = true
+		}
+	}
+
+	if c.dirty {
+		data, err := json.Marshal(cacheFile{Format: cacheFormatVersion, Key: c.key, Entries: c.entries})
+		if err != nil {
+			return fmt.Errorf("failed to encode cache: %w", err)
+		}
+
+		// Write to a temporary file first so concurrent runs never read a
+		// partially written cache
+		tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+		if err != nil {
+			return fmt.Errorf("failed to write cache: %w", err)
+		}
+		if _, err := tmp.Write(data); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return fmt.Errorf("failed to write cache: %w", err)
+		}
+		if err := tmp.Close(); err != nil {
+			os.Remove(tmp.Name())
+			return fmt.Errorf("failed to write cache: %w", err)
+		}
+		if err := os.Rename(tmp.Name(), c.path); err != nil {
+			os.Remove(tmp.Name())
+			return fmt.Errorf("failed to write cache: %w", err)
+		}
+		c.dirty = false
+	}
+
+	return pruneCacheFiles(filepath.Dir(c.path), c.path, cutoff)
+}
+
+// pruneCacheFiles removes cache files other than keep last written before
+// cutoff.
+func pruneCacheFiles(dir, keep string, cutoff time.Time) error {
+	files, err := listCacheFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file == keep {
+			continue
+		}
+		if info, err := os.Stat(file); err == nil && info.ModTime().Before(cutoff) {
+			os.Remove(file)
+		}
+	}
+	return nil
+}
+
+// ClearCacheFiles removes every cache file in location and returns how many
+// were removed. Other files in the directory are left alone.
+func ClearCacheFiles(location string) (int, error) {
+	files, err := listCacheFiles(location)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, file := range files {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// ReadCacheStats summarizes the cache files in location.
+func ReadCacheStats(location string) (CacheStats, error) {
+	stats := CacheStats{Location: location}
+
+	files, err := listCacheFiles(location)
+	if err != nil {
+		return stats, err
+	}
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		stats.Files++
+		stats.Bytes += info.Size()
+		if stats.Oldest.IsZero() || info.ModTime().Before(stats.Oldest) {
+			stats.Oldest = info.ModTime()
+		}
+		if info.ModTime().After(stats.Newest) {
+			stats.Newest = info.ModTime()
+		}
+
+		if data, err := os.ReadFile(file); err == nil {
+			var contents cacheFile
+			if json.Unmarshal(data, &contents) == nil {
+				stats.Entries += len(contents.Entries)
+			}
+		}
+	}
+	return stats, nil
+}
+
+// listCacheFiles returns the cache files in dir. A missing directory has no
+// cache files.
+func listCacheFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, cacheFilePrefix) && strings.HasSuffix(name, cacheFileSuffix) {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+	return files, nil
+}
+
+// LintCacheKey identifies everything besides a file's contents that affects
+// its lint results: the effective configuration, the files rules read (word
+// lists, dictionaries and prose styles), the gomdlint build, the registered
+// rules and the loaded plugins.
+func LintCacheKey(options *value.LintOptions, engine *RuleEngine) string {
+	var rules []string
+	for _, rule := range engine.GetAllRules() {
+		rules = append(rules, rule.PrimaryName())
+	}
+
+	var plugins []string
+	for _, info := range GetGlobalPluginManager().ListPlugins() {
+		plugins = append(plugins, info.Name+"@"+info.Version)
+	}
+	sort.Strings(plugins)
+
+	frontMatter := ""
+	if options.FrontMatter.IsSome() {
+		frontMatter = options.FrontMatter.Unwrap().String()
+	}
+
+	// Maps are marshaled with sorted keys, so equal configurations hash equally
+	data, _ := json.Marshal(map[string]interface{}{
+		"format":         cacheFormatVersion,
+		"build":          buildFingerprint(),
+		"config":         options.Config,
+		"ruleInputs":     ruleInputFingerprint(options.Config, options.Files),
+		"frontMatter":    frontMatter,
+		"noInlineConfig": options.NoInlineConfig,
+		"rules":          rules,
+		"plugins":        plugins,
+	})
+	return hashContent(data)
+}
+
+// ruleInputParameters are the rule parameters naming files that rules read.
+var ruleInputParameters = map[string]bool{"dictionaries": true, "styles": true, "word_lists": true}
+
+// ruleInputFingerprint returns the size and modification time of the files
+// rules read, so that editing a word list, style or schema invalidates the
+// cache. The project word lists found from the directories of the linted
+// files are included whether configured or not, and schemas with the files
+// they refer to.
+func ruleInputFingerprint(config map[string]interface{}, files []string) []string {
+	// The project word lists that apply to the files and the working directory
+	var paths []string
+	dirs := map[string]bool{".": true}
+	for _, file := range files {
+		dirs[filepath.Dir(file)] = true
+	}
+	for dir := range dirs {
+		if path := spelling.FindWordList(dir); path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	var collect func(config map[string]interface{})
+	collect = func(config map[string]interface{}) {
+		for key, setting := range config {
+			switch setting := setting.(type) {
+			case map[string]interface{}:
+				collect(setting)
+			case string:
+				if key == "schema" && setting != "" {
+					if schema, err := jsonschema.Load(setting); err == nil {
+						paths = append(paths, schema.Files()...)
+					} else {
+						paths = append(paths, setting)
+					}
+				}
+			case []interface{}:
+				for _, item := range setting {
+					if path, ok := item.(string); ok && ruleInputParameters[key] {
+						paths = append(paths, path, path+".dic", path+".aff")
+					}
+					// Lists of settings, such as GMD008 schemas
+					if item, ok := item.(map[string]interface{}); ok {
+						collect(item)
+					}
+				}
+			case []string:
+				if ruleInputParameters[key] {
+					for _, path := range setting {
+						paths = append(paths, path, path+".dic", path+".aff")
+					}
+				}
+			}
+		}
+	}
+	collect(config)
+
+	var fingerprint []string
+	stat := func(path string) {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			fingerprint = append(fingerprint, fmt.Sprintf("%s %d %d", path, info.Size(), info.ModTime().UnixNano()))
+		}
+	}
+	for _, path := range paths {
+		stat(path)
+		// Style directories hold one file per rule
+		if entries, err := os.ReadDir(path); err == nil {
+			for _, entry := range entries {
+				stat(filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	sort.Strings(fingerprint)
+	return fingerprint
+}
+
+// buildFingerprint identifies the running gomdlint build. Development builds
+// without a version also include the executable's size and modification time
+// so rebuilding invalidates the cache.
+func buildFingerprint() string {
+	fingerprint := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fingerprint = info.Main.Version
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+				fingerprint += " " + setting.Value
+			}
+		}
+		if info.Main.Version != "(devel)" && info.Main.Version != "" {
+			return fingerprint
+		}
+	}
+
+	if executable, err := os.Executable(); err == nil {
+		if stat, err := os.Stat(executable); err == nil {
+			fingerprint += fmt.Sprintf(" %d %d", stat.Size(), stat.ModTime().UnixNano())
+		}
+	}
+	return fingerprint
+}
+
+func hashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func storeViolations(violations []value.Violation) []cachedViolation {
+	stored := make([]cachedViolation, len(violations))
+	for i, v := range violations {
+		stored[i] = cachedViolation{
+			RuleNames:       v.RuleNames,
+			RuleDescription: v.RuleDescription,
+			LineNumber:      v.LineNumber,
+			ColumnNumber:    v.ColumnNumber,
+			Length:          v.Length,
+			Severity:        v.Severity,
+			ErrorDetail:     v.ErrorDetail,
+			ErrorContext:    v.ErrorContext,
+			ErrorRange:      v.ErrorRange,
+			FixInfo:         functional.None[cachedFixInfo](),
+		}
+		if v.RuleInformation != nil {
+			stored[i].RuleInformation = v.RuleInformation.String()
+		}
+		if v.FixInfo.IsSome() {
+			fix := v.FixInfo.Unwrap()
+			stored[i].FixInfo = functional.Some(cachedFixInfo(fix))
+		}
+	}
+	return stored
+}
+
+func restoreViolations(stored []cachedViolation) []value.Violation {
+	violations := make([]value.Violation, len(stored))
+	for i, v := range stored {
+		violations[i] = value.Violation{
+			RuleNames:       v.RuleNames,
+			RuleDescription: v.RuleDescription,
+			LineNumber:      v.LineNumber,
+			ColumnNumber:    v.ColumnNumber,
+			Length:          v.Length,
+			Severity:        v.Severity,
+			ErrorDetail:     v.ErrorDetail,
+			ErrorContext:    v.ErrorContext,
+			ErrorRange:      v.ErrorRange,
+			FixInfo:         functional.None[value.FixInfo](),
+		}
+		if v.RuleInformation != "" {
+			if info, err := url.Parse(v.RuleInformation); err == nil {
+				violations[i].RuleInformation = info
+			}
+		}
+		if v.FixInfo.IsSome() {
+			violations[i].FixInfo = functional.Some(value.FixInfo(v.FixInfo.Unwrap()))
+		}
+	}
+	return violations
+}
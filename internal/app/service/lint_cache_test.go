@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+)
+
+func testViolation() value.Violation {
+	info, _ := url.Parse("https://example.com/md013")
+	fix := value.NewFixInfo().WithEditColumn(81).WithDeleteLength(4)
+	return *value.NewViolation([]string{"MD013", "line-length"}, "Line length", info, 3).
+		WithColumn(81).
+		WithErrorDetail("Expected: <=80, Actual: 84").
+		WithFixInfo(*fix)
+}
+
+func TestLintCache_RoundTrip(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		strategy value.CacheStrategy
+	}{
+		{name: "content strategy", strategy: value.CacheStrategyContent},
+		{name: "metadata strategy", strategy: value.CacheStrategyMetadata},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "doc.md")
+			content := []byte("# Title\n")
+			require.NoError(t, os.WriteFile(file, content, 0644))
+
+			options := value.CacheOptions{Enabled: true, Location: filepath.Join(dir, "cache"), Strategy: scenario.strategy}
+			key := hashContent([]byte("key"))
+
+			cache, err := OpenLintCache(options, key)
+			require.NoError(t, err)
+			_, _, hit := cache.Lookup(file)
+			assert.False(t, hit)
+			cache.Store(file, content, []value.Violation{testViolation()})
+			require.NoError(t, cache.Save())
+
+			reopened, err := OpenLintCache(options, key)
+			require.NoError(t, err)
+			violations, _, hit := reopened.Lookup(file)
+			require.True(t, hit)
+			assert.Equal(t, []value.Violation{testViolation()}, violations)
+
+			// Another key never sees these results
+			other, err := OpenLintCache(options, hashContent([]byte("other")))
+			require.NoError(t, err)
+			_, _, hit = other.Lookup(file)
+			assert.False(t, hit)
+
+			// Changing the file invalidates the entry
+			require.NoError(t, os.WriteFile(file, []byte("# Changed title\n"), 0644))
+			_, _, hit = reopened.Lookup(file)
+			assert.False(t, hit)
+
+			hits, misses := reopened.Stats()
+			assert.Equal(t, 1, hits)
+			assert.Equal(t, 1, misses)
+		})
+	}
+}
+
+func TestLintCache_ContentStrategyReturnsContentOnMiss(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "doc.md")
+	require.NoError(t, os.WriteFile(file, []byte("# Old\n"), 0644))
+
+	cache, err := OpenLintCache(value.CacheOptions{Location: dir}, hashContent(nil))
+	require.NoError(t, err)
+	cache.Store(file, []byte("# Old\n"), nil)
+
+	require.NoError(t, os.WriteFile(file, []byte("# New\n"), 0644))
+	_, content, hit := cache.Lookup(file)
+	assert.False(t, hit)
+	assert.Equal(t, "# New\n", string(content))
+}
+
+func TestLintCache_Pruning(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "doc.md")
+	require.NoError(t, os.WriteFile(file, []byte("# Title\n"), 0644))
+
+	stale := filepath.Join(dir, "lint-0000000000000000.json")
+	require.NoError(t, os.WriteFile(stale, []byte("{}"), 0644))
+	old := time.Now().Add(-2 * DefaultCacheMaxAge)
+	require.NoError(t, os.Chtimes(stale, old, old))
+	unrelated := filepath.Join(dir, "notes.txt")
+	require.NoError(t, os.WriteFile(unrelated, nil, 0644))
+
+	cache, err := OpenLintCache(value.CacheOptions{Location: dir}, hashContent([]byte("key")))
+	require.NoError(t, err)
+	cache.Store(file, []byte("# Title\n"), nil)
+	cache.Store(filepath.Join(dir, "gone.md"), nil, nil) // Not stored: the file does not exist
+	cache.entries["/old/file.md"] = &cacheEntry{LastUsed: old.Unix()}
+	require.NoError(t, cache.Save())
+
+	assert.NoFileExists(t, stale)
+	assert.FileExists(t, unrelated)
+
+	stats, err := ReadCacheStats(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Files)
+	assert.Equal(t, 1, stats.Entries, "entries unused for longer than the maximum age are pruned")
+
+	removed, err := ClearCacheFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.FileExists(t, unrelated)
+}
+
+func TestLintCache_CorruptFileStartsEmpty(t *testing.T) {
+	dir := t.TempDir()
+	key := hashContent([]byte("key"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, cacheFilePrefix+key[:16]+cacheFileSuffix), []byte("not json"), 0644))
+
+	cache, err := OpenLintCache(value.CacheOptions{Location: dir}, key)
+	require.NoError(t, err)
+	assert.Empty(t, cache.entries)
+}
+
+func TestParseCacheStrategy(t *testing.T) {
+	strategy, err := ParseCacheStrategy("")
+	require.NoError(t, err)
+	assert.Equal(t, value.CacheStrategyContent, strategy)
+
+	strategy, err = ParseCacheStrategy("metadata")
+	require.NoError(t, err)
+	assert.Equal(t, value.CacheStrategyMetadata, strategy)
+
+	_, err = ParseCacheStrategy("mtime")
+	assert.EqualError(t, err, `invalid cache strategy "mtime" (allowed: content, metadata)`)
+}
+
+func TestLintCacheKey(t *testing.T) {
+	engine, err := NewRuleEngine()
+	require.NoError(t, err)
+
+	base := value.NewLintOptions().WithConfig(map[string]interface{}{"MD013": false, "MD033": true})
+	same := value.NewLintOptions().WithConfig(map[string]interface{}{"MD033": true, "MD013": false})
+	changed := value.NewLintOptions().WithConfig(map[string]interface{}{"MD013": true, "MD033": true})
+
+	assert.Equal(t, LintCacheKey(base, engine), LintCacheKey(same, engine))
+	assert.NotEqual(t, LintCacheKey(base, engine), LintCacheKey(changed, engine))
+	assert.NotEqual(t, LintCacheKey(base, engine), LintCacheKey(base.WithNoInlineConfig(true), engine))
+
+	// Files read by rules are part of the key
+	wordList := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(wordList, []byte("gomdlint\n"), 0644))
+	withList := value.NewLintOptions().WithConfig(map[string]interface{}{"spelling": map[string]interface{}{"word_lists": []interface{}{wordList}}})
+	key := LintCacheKey(withList, engine)
+	require.NoError(t, os.WriteFile(wordList, []byte("gomdlint\nHugo\n"), 0644))
+	assert.NotEqual(t, key, LintCacheKey(withList, engine))
+
+	// So is the project word list of the linted files' directories
+	project := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(project, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(project, "docs"), 0755))
+	projectList := filepath.Join(project, ".gomdlint-words.txt")
+	require.NoError(t, os.WriteFile(projectList, []byte("gomdlint\n"), 0644))
+	withFiles := value.NewLintOptions().WithFiles([]string{filepath.Join(project, "docs", "guide.md")})
+	key = LintCacheKey(withFiles, engine)
+	require.NoError(t, os.WriteFile(projectList, []byte("gomdlint\nHugo\n"), 0644))
+	assert.NotEqual(t, key, LintCacheKey(withFiles, engine))
+
+	// So are schemas selected per glob and the files they refer to
+	schemaDir := t.TempDir()
+	definitions := filepath.Join(schemaDir, "definitions.json")
+	require.NoError(t, os.WriteFile(filepath.Join(schemaDir, "post.json"), []byte(`{"$ref": "definitions.json"}`), 0644))
+	require.NoError(t, os.WriteFile(definitions, []byte(`{"required": ["title"]}`), 0644))
+	withSchema := value.NewLintOptions().WithConfig(map[string]interface{}{"GMD008": map[string]interface{}{
+		"schemas": []interface{}{map[string]interface{}{"files": "posts/*.md", "schema": filepath.Join(schemaDir, "post.json")}},
+	}})
+	key = LintCacheKey(withSchema, engine)
+	require.NoError(t, os.WriteFile(definitions, []byte(`{"required": ["title", "date"]}`), 0644))
+	assert.NotEqual(t, key, LintCacheKey(withSchema, engine))
+}
+
+func TestLinterService_PersistentCache(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "doc.md")
+	require.NoError(t, os.WriteFile(file, []byte("# Title\n\nA line that is definitely going to be longer than eighty characters in total.\n"), 0644))
+
+	options := value.NewLintOptions().
+		WithFiles([]string{file}).
+		WithCache(value.CacheOptions{Enabled: true, Location: filepath.Join(dir, "cache")})
+
+	cold, err := NewLinterService(options)
+	require.NoError(t, err)
+	coldResult := cold.Lint(context.Background()).Unwrap()
+	assert.Equal(t, 0, cold.Stats()["cache_hits"])
+
+	warm, err := NewLinterService(options)
+	require.NoError(t, err)
+	warmResult := warm.Lint(context.Background()).Unwrap()
+	assert.Equal(t, 1, warm.Stats()["cache_hits"])
+	assert.Equal(t, coldResult.Results, warmResult.Results)
+}
@@ -2,11 +2,21 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
 	"github.com/gomdlint/gomdlint/internal/domain/value"
 	"github.com/gomdlint/gomdlint/internal/shared/functional"
 )
@@ -24,6 +34,9 @@ type LinterService struct {
 	concurrency int
 	resultCache map[string]*value.LintResult
 	cacheMutex  sync.RWMutex
+
+	// Persistent result cache, nil unless enabled in the options
+	lintCache *LintCache
 }
 
 // NewLinterService creates a new linting service with the specified options.
@@ -46,20 +59,58 @@ func NewLinterService(options *value.LintOptions) (*LinterService, error) {
 		}
 	}
 
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
 	linter := &LinterService{
 		parser:      parser,
 		ruleEngine:  ruleEngine,
 		options:     options,
-		concurrency: 4, // Default concurrency
+		concurrency: concurrency,
 		resultCache: make(map[string]*value.LintResult),
 	}
 
+	if err := linter.openLintCache(); err != nil {
+		return nil, err
+	}
+
 	return linter, nil
 }
 
-// LintFiles lints the specified markdown files.
+// openLintCache opens the persistent result cache for the current options.
+func (ls *LinterService) openLintCache() error {
+	ls.lintCache = nil
+	if !ls.options.Cache.Enabled {
+		return nil
+	}
+
+	cache, err := OpenLintCache(ls.options.Cache, LintCacheKey(ls.options, ls.ruleEngine))
+	if err != nil {
+		return fmt.Errorf("failed to open result cache: %w", err)
+	}
+	ls.lintCache = cache
+	return nil
+}
+
+// LintFiles lints the specified markdown files. With FailFast set, the result
+// stops at the first file with an error-severity violation and outstanding
+// workers are cancelled. Document set rules run once every file has been
+// linted, over the files that could be parsed.
 func (ls *LinterService) LintFiles(ctx context.Context, files []string) functional.Result[*value.LintResult] {
 	result := value.NewLintResult()
+	timer := &phaseTimer{}
+
+	// Keep the parsed documents only when a document set rule needs them
+	var documents *documentCollector
+	if ls.ruleEngine.HasDocumentSetRules() {
+		documents = &documentCollector{}
+	}
+
+	parentCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	// Use concurrent processing for better performance
 	type fileResult struct {
@@ -83,7 +134,10 @@ func (ls *LinterService) LintFiles(ctx context.Context, files []string) function
 		go func() {
 			defer wg.Done()
 			for filename := range fileChan {
-				violations, err := ls.lintFile(ctx, filename)
+				if ctx.Err() != nil {
+					continue
+				}
+				violations, err := ls.lintFile(ctx, filename, timer, documents)
 				resultChan <- fileResult{
 					identifier: filename,
 					violations: violations,
@@ -112,28 +166,146 @@ func (ls *LinterService) LintFiles(ctx context.Context, files []string) function
 	}()
 
 	// Process results
+	fileViolations := make(map[string][]value.Violation, len(files))
 	for fileResult := range resultChan {
+		if ctx.Err() != nil && (ls.options.FailFast || errors.Is(fileResult.err, ctx.Err())) {
+			// Drain results finished after fail-fast or interrupted by cancellation
+			continue
+		}
 		if fileResult.err != nil {
 			// Create an error violation for files that couldn't be processed
 			errorViolation := value.NewViolation(
-				[]string{"FILE_ERROR"},
+				[]string{value.FileErrorRuleName},
 				"File processing error",
 				nil,
 				1,
 			)
 			errorViolation = errorViolation.WithErrorDetail(fileResult.err.Error())
-			result.AddViolations(fileResult.identifier, []value.Violation{*errorViolation})
+			fileViolations[fileResult.identifier] = []value.Violation{*errorViolation}
 		} else {
-			result.AddViolations(fileResult.identifier, fileResult.violations)
+			fileViolations[fileResult.identifier] = fileResult.violations
+			if ls.options.FailFast && hasErrorViolation(fileResult.violations) {
+				cancel()
+			}
 		}
 	}
 
+	if documents != nil && ctx.Err() == nil {
+		if err := ls.lintDocumentSet(ctx, documents, fileViolations, timer); err != nil && parentCtx.Err() == nil {
+			return functional.Err[*value.LintResult](err)
+		}
+	}
+	for identifier, violations := range fileViolations {
+		result.AddViolations(identifier, violations)
+	}
+
+	if ls.lintCache != nil {
+		// A cache that cannot be written only costs speed on the next run
+		_ = ls.lintCache.Save()
+	}
+
+	if err := parentCtx.Err(); err != nil {
+		return functional.Err[*value.LintResult](err)
+	}
+	result.Timings, result.RuleTimings = timer.timings(), timer.ruleTimings()
 	return functional.Ok(result)
 }
 
+// documentCollector gathers the parsed documents of a run for document set
+// rules, across concurrent workers.
+type documentCollector struct {
+	mutex     sync.Mutex
+	documents []entity.Document
+}
+
+// add records a parsed document.
+func (dc *documentCollector) add(document entity.Document) {
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+	dc.documents = append(dc.documents, document)
+}
+
+// lintDocumentSet runs the document set rules over the collected documents
+// in filename order and appends their violations to fileViolations.
+func (ls *LinterService) lintDocumentSet(ctx context.Context, documents *documentCollector, fileViolations map[string][]value.Violation, timer *phaseTimer) error {
+	sort.Slice(documents.documents, func(i, j int) bool {
+		return documents.documents[i].Filename < documents.documents[j].Filename
+	})
+
+	var onRule func(string, time.Duration)
+	if ls.options.Timing {
+		onRule = timer.recordRule
+	}
+	rulesStart := time.Now()
+	violationsResult := ls.ruleEngine.LintDocumentSet(ctx, documents.documents, onRule)
+	timer.rules.Add(int64(time.Since(rulesStart)))
+	if violationsResult.IsErr() {
+		return fmt.Errorf("failed to execute document set rules: %w", violationsResult.Error())
+	}
+
+	frontMatterLines := make(map[string]int, len(documents.documents))
+	for _, document := range documents.documents {
+		frontMatterLines[document.Filename] = document.FrontMatterLines
+	}
+	for filename, violations := range violationsResult.Unwrap() {
+		offsetViolationLines(violations, frontMatterLines[filename])
+		fileViolations[filename] = append(fileViolations[filename], violations...)
+	}
+	return nil
+}
+
+// phaseTimer accumulates the time spent in each linting phase, and with
+// timing enabled in each rule, across concurrent workers.
+type phaseTimer struct {
+	read  atomic.Int64
+	parse atomic.Int64
+	rules atomic.Int64
+
+	ruleMutex sync.Mutex
+	byRule    map[string]value.RuleTiming
+}
+
+// timings returns the accumulated phase durations.
+func (pt *phaseTimer) timings() value.PhaseTimings {
+	return value.PhaseTimings{
+		Read:  time.Duration(pt.read.Load()),
+		Parse: time.Duration(pt.parse.Load()),
+		Rules: time.Duration(pt.rules.Load()),
+	}
+}
+
+// recordRule adds the time a rule spent on one document.
+func (pt *phaseTimer) recordRule(rule string, duration time.Duration) {
+	pt.ruleMutex.Lock()
+	defer pt.ruleMutex.Unlock()
+	if pt.byRule == nil {
+		pt.byRule = make(map[string]value.RuleTiming)
+	}
+	pt.byRule[rule] = pt.byRule[rule].Add(value.RuleTiming{Duration: duration, Documents: 1})
+}
+
+// ruleTimings returns the accumulated rule durations, or nil when no rule
+// was timed.
+func (pt *phaseTimer) ruleTimings() map[string]value.RuleTiming {
+	pt.ruleMutex.Lock()
+	defer pt.ruleMutex.Unlock()
+	return pt.byRule
+}
+
+// hasErrorViolation reports whether any violation has error severity.
+func hasErrorViolation(violations []value.Violation) bool {
+	for _, violation := range violations {
+		if violation.Severity == value.SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
 // LintStrings lints the specified string content.
 func (ls *LinterService) LintStrings(ctx context.Context, content map[string]string) functional.Result[*value.LintResult] {
 	result := value.NewLintResult()
+	timer := &phaseTimer{}
 
 	// Process each string
 	for identifier, text := range content {
@@ -144,11 +316,11 @@ func (ls *LinterService) LintStrings(ctx context.Context, content map[string]str
 		default:
 		}
 
-		violations, err := ls.lintString(ctx, text, identifier)
+		violations, err := ls.lintString(ctx, text, identifier, timer, nil)
 		if err != nil {
 			// Create an error violation for strings that couldn't be processed
 			errorViolation := value.NewViolation(
-				[]string{"STRING_ERROR"},
+				[]string{value.StringErrorRuleName},
 				"String processing error",
 				nil,
 				1,
@@ -160,6 +332,7 @@ func (ls *LinterService) LintStrings(ctx context.Context, content map[string]str
 		}
 	}
 
+	result.Timings, result.RuleTimings = timer.timings(), timer.ruleTimings()
 	return functional.Ok(result)
 }
 
@@ -179,6 +352,7 @@ func (ls *LinterService) Lint(ctx context.Context) functional.Result[*value.Lint
 		for identifier, violations := range fileResults.Results {
 			finalResult.AddViolations(identifier, violations)
 		}
+		finalResult.AddTimings(fileResults)
 	}
 
 	// Lint strings if specified
@@ -193,39 +367,108 @@ func (ls *LinterService) Lint(ctx context.Context) functional.Result[*value.Lint
 		for identifier, violations := range stringResults.Results {
 			finalResult.AddViolations(identifier, violations)
 		}
+		finalResult.AddTimings(stringResults)
 	}
 
+	finalResult.Warnings = append(ls.ruleEngine.ConfigWarnings(), ls.ruleEngine.UnusedSettings(ls.options.Files)...)
 	return functional.Ok(finalResult)
 }
 
-// lintFile processes a single file and returns violations.
-func (ls *LinterService) lintFile(ctx context.Context, filename string) ([]value.Violation, error) {
+// lintFile processes a single file and returns violations. The parsed
+// document is added to documents when it is not nil, including for cached
+// results.
+func (ls *LinterService) lintFile(ctx context.Context, filename string, timer *phaseTimer, documents *documentCollector) ([]value.Violation, error) {
 	// Check cache first
 	ls.cacheMutex.RLock()
 	if cached, exists := ls.resultCache[filename]; exists {
 		ls.cacheMutex.RUnlock()
-		return cached.GetViolations(filename), nil
+		return cached.GetViolations(filename), ls.collectDocument(ctx, filename, timer, documents)
 	}
 	ls.cacheMutex.RUnlock()
 
+	var content []byte
+	if ls.lintCache != nil {
+		violations, cachedContent, hit := ls.lintCache.Lookup(filename)
+		if hit {
+			return violations, ls.collectDocument(ctx, filename, timer, documents)
+		}
+		content = cachedContent
+	}
+
 	// Read file content
+	if content == nil {
+		var err error
+		readStart := time.Now()
+		content, err = os.ReadFile(filename)
+		timer.read.Add(int64(time.Since(readStart)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+		}
+	}
+
+	violations, err := ls.lintString(ctx, string(content), filename, timer, documents)
+	if err == nil && ls.lintCache != nil {
+		ls.lintCache.Store(filename, content, violations)
+	}
+	return violations, err
+}
+
+// collectDocument reads and parses a file whose violations came from a
+// cache, so that document set rules still see it. It does nothing when
+// documents is nil.
+func (ls *LinterService) collectDocument(ctx context.Context, filename string, timer *phaseTimer, documents *documentCollector) error {
+	if documents == nil {
+		return nil
+	}
+
+	readStart := time.Now()
 	content, err := os.ReadFile(filename)
+	timer.read.Add(int64(time.Since(readStart)))
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	parsed, err := ls.parseContent(ctx, string(content), filename, timer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+		return err
 	}
+	documents.add(parsed.document(filename))
+	return nil
+}
 
-	return ls.lintString(ctx, string(content), filename)
+// parsedContent is a document prepared for the rules: its tokens and lines
+// without front matter, the parsed front matter and its lines, by whose
+// number reported line numbers are shifted back.
+type parsedContent struct {
+	tokens           []value.Token
+	lines            []string
+	frontMatter      functional.Option[map[string]interface{}]
+	frontMatterLines []string
 }
 
-// lintString processes string content and returns violations.
-func (ls *LinterService) lintString(ctx context.Context, content string, identifier string) ([]value.Violation, error) {
+// document returns the parsed content as a document for document set rules.
+func (pc parsedContent) document(filename string) entity.Document {
+	return entity.Document{
+		Filename:         filename,
+		Lines:            pc.lines,
+		Tokens:           pc.tokens,
+		FrontMatter:      pc.frontMatter,
+		FrontMatterLines: len(pc.frontMatterLines),
+	}
+}
+
+// parseContent removes front matter from content, parses it and applies
+// inline configuration, recording the time spent parsing in timer.
+func (ls *LinterService) parseContent(ctx context.Context, content string, identifier string, timer *phaseTimer) (parsedContent, error) {
 	// Remove front matter if configured
-	processedContent := ls.removeFrontMatter(content)
+	frontMatter, processedContent := ls.removeFrontMatter(content)
 
 	// Parse the content
+	parseStart := time.Now()
 	tokensResult := ls.parser.ParseDocument(ctx, processedContent, identifier)
+	timer.parse.Add(int64(time.Since(parseStart)))
 	if tokensResult.IsErr() {
-		return nil, fmt.Errorf("failed to parse content: %w", tokensResult.Error())
+		return parsedContent{}, fmt.Errorf("failed to parse content: %w", tokensResult.Error())
 	}
 
 	tokens := tokensResult.Unwrap()
@@ -236,8 +479,34 @@ func (ls *LinterService) lintString(ctx context.Context, content string, identif
 		tokens, lines = ls.processInlineConfig(tokens, lines)
 	}
 
+	return parsedContent{
+		tokens:           tokens,
+		lines:            lines,
+		frontMatter:      parseFrontMatter(frontMatter),
+		frontMatterLines: strings.Split(frontMatter, "\n")[:strings.Count(frontMatter, "\n")],
+	}, nil
+}
+
+// lintString processes string content and returns violations, recording the
+// time spent parsing and running rules in timer. The parsed document is added
+// to documents when it is not nil.
+func (ls *LinterService) lintString(ctx context.Context, content string, identifier string, timer *phaseTimer, documents *documentCollector) ([]value.Violation, error) {
+	parsed, err := ls.parseContent(ctx, content, identifier, timer)
+	if err != nil {
+		return nil, err
+	}
+	if documents != nil {
+		documents.add(parsed.document(identifier))
+	}
+
 	// Run rules against the parsed content
-	violationsResult := ls.ruleEngine.LintDocument(ctx, tokens, lines, identifier)
+	var onRule func(string, time.Duration)
+	if ls.options.Timing {
+		onRule = timer.recordRule
+	}
+	rulesStart := time.Now()
+	violationsResult := ls.ruleEngine.LintDocumentTimed(ctx, parsed.tokens, parsed.lines, parsed.frontMatter, parsed.frontMatterLines, identifier, onRule)
+	timer.rules.Add(int64(time.Since(rulesStart)))
 	if violationsResult.IsErr() {
 		return nil, fmt.Errorf("failed to execute rules: %w", violationsResult.Error())
 	}
@@ -245,7 +514,8 @@ func (ls *LinterService) lintString(ctx context.Context, content string, identif
 	violations := violationsResult.Unwrap()
 
 	// Filter violations based on inline config (markdownlint-disable comments)
-	filteredViolations := ls.filterViolationsByInlineConfig(violations, lines)
+	filteredViolations := ls.filterViolationsByInlineConfig(violations, parsed.lines)
+	offsetViolationLines(filteredViolations, len(parsed.frontMatterLines))
 
 	// Cache the result
 	ls.cacheMutex.Lock()
@@ -257,14 +527,62 @@ func (ls *LinterService) lintString(ctx context.Context, content string, identif
 	return filteredViolations, nil
 }
 
-// removeFrontMatter removes front matter from the beginning of content.
-func (ls *LinterService) removeFrontMatter(content string) string {
+// removeFrontMatter splits front matter at the beginning of content from the
+// rest of the document.
+func (ls *LinterService) removeFrontMatter(content string) (frontMatter, rest string) {
 	if ls.options.FrontMatter.IsNone() {
-		return content
+		return "", content
+	}
+
+	match := ls.options.FrontMatter.Unwrap().FindStringIndex(content)
+	if match == nil || match[0] != 0 {
+		return "", content
+	}
+	return content[:match[1]], content[match[1]:]
+}
+
+// parseFrontMatter parses YAML (---), TOML (+++) or JSON ({) front matter,
+// returning None when there is none or it is invalid.
+func parseFrontMatter(frontMatter string) functional.Option[map[string]interface{}] {
+	lines := strings.Split(strings.TrimRight(frontMatter, " \t\r\n"), "\n")
+	if len(lines) < 2 {
+		return functional.None[map[string]interface{}]()
+	}
+
+	data := make(map[string]interface{})
+	var err error
+	switch strings.TrimSpace(lines[0]) {
+	case "---":
+		err = yaml.Unmarshal([]byte(strings.Join(lines[1:len(lines)-1], "\n")), &data)
+	case "+++":
+		_, err = toml.Decode(strings.Join(lines[1:len(lines)-1], "\n"), &data)
+	case "{":
+		err = json.Unmarshal([]byte(strings.Join(lines, "\n")), &data)
+	default:
+		return functional.None[map[string]interface{}]()
 	}
+	if err != nil {
+		return functional.None[map[string]interface{}]()
+	}
+	return functional.Some(data)
+}
 
-	regex := ls.options.FrontMatter.Unwrap()
-	return regex.ReplaceAllString(content, "")
+// offsetViolationLines shifts the line numbers of violations and their fixes
+// by the number of front matter lines removed before linting.
+func offsetViolationLines(violations []value.Violation, offset int) {
+	if offset == 0 {
+		return
+	}
+	for i := range violations {
+		violations[i].LineNumber += offset
+		if violations[i].FixInfo.IsSome() {
+			fixInfo := violations[i].FixInfo.Unwrap()
+			if fixInfo.LineNumber.IsSome() {
+				fixInfo.LineNumber = functional.Some(fixInfo.LineNumber.Unwrap() + offset)
+				violations[i].FixInfo = functional.Some(fixInfo)
+			}
+		}
+	}
 }
 
 // processInlineConfig processes inline configuration comments.
@@ -319,7 +637,8 @@ func (ls *LinterService) UpdateOptions(options *value.LintOptions) error {
 	ls.resultCache = make(map[string]*value.LintResult)
 	ls.cacheMutex.Unlock()
 
-	return nil
+	// The persistent cache is keyed by configuration, so reopen it
+	return ls.openLintCache()
 }
 
 // ClearCache clears the internal result cache.
@@ -340,6 +659,11 @@ func (ls *LinterService) Stats() map[string]interface{} {
 
 	stats := ls.ruleEngine.Stats()
 	stats["cache_size"] = cacheSize
+	if ls.lintCache != nil {
+		hits, misses := ls.lintCache.Stats()
+		stats["cache_hits"] = hits
+		stats["cache_misses"] = misses
+	}
 	stats["concurrency"] = ls.concurrency
 
 	return stats
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -407,11 +408,137 @@ func TestLinterService_Concurrency(t *testing.T) {
 	require.NotNil(t, lintResult)
 
 	assert.Equal(t, 10, lintResult.TotalFiles)
+	assert.Positive(t, lintResult.Timings.Parse, "parse time is recorded")
+	assert.Positive(t, lintResult.Timings.Rules, "rule time is recorded")
 
 	// Concurrent processing should be reasonably fast
 	assert.Less(t, duration, 5*time.Second, "Processing took too long, concurrency may not be working")
 }
 
+func TestLinterService_ConcurrencyOption(t *testing.T) {
+	assert.Equal(t, runtime.GOMAXPROCS(0), createTestLinterService(t).Stats()["concurrency"], "one worker per CPU by default")
+	assert.Equal(t, 3, createTestLinterService(t, value.NewLintOptions().WithConcurrency(3)).Stats()["concurrency"])
+}
+
+func TestLinterService_LintFiles_FailFast(t *testing.T) {
+	ctx := context.Background()
+
+	files := make(map[string]string)
+	for i := 0; i < 10; i++ {
+		files[fmt.Sprintf("file%d.md", i)] = fmt.Sprintf("#File %d\n\nContent for file %d.\n", i, i)
+	}
+	testFiles := createTempMarkdownFiles(t, files)
+
+	service := createTestLinterService(t, value.NewLintOptions().WithFailFast(true))
+	result := service.LintFiles(ctx, testFiles)
+	require.True(t, result.IsOk(), "fail-fast is not an error")
+
+	lintResult := result.Unwrap()
+	assert.Equal(t, 1, lintResult.TotalFiles, "linting stops after the first file with an error")
+	assert.Greater(t, lintResult.TotalErrors, 0)
+
+	service = createTestLinterService(t)
+	result = service.LintFiles(ctx, testFiles)
+	require.True(t, result.IsOk())
+	assert.Equal(t, 10, result.Unwrap().TotalFiles)
+}
+
+func TestLinterService_Timing(t *testing.T) {
+	ctx := context.Background()
+	content := map[string]string{
+		"one": "# One\n\nContent.\n",
+		"two": "# Two\n\nContent.\n",
+	}
+
+	service := createTestLinterService(t)
+	result := service.LintStrings(ctx, content)
+	require.True(t, result.IsOk())
+	assert.Nil(t, result.Unwrap().RuleTimings, "rules are timed only on request")
+
+	service = createTestLinterService(t, value.NewLintOptions().WithTiming(true))
+	result = service.LintStrings(ctx, content)
+	require.True(t, result.IsOk())
+
+	ruleTimings := result.Unwrap().RuleTimings
+	require.Contains(t, ruleTimings, "MD001")
+	assert.Equal(t, 2, ruleTimings["MD001"].Documents)
+	for rule, timing := range ruleTimings {
+		assert.Equal(t, 2, timing.Documents, "%s runs once per document", rule)
+	}
+}
+
+func TestLinterService_FrontMatter(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	wordList := filepath.Join(dir, "words.txt")
+	require.NoError(t, os.WriteFile(wordList, nil, 0644))
+	dictionary := filepath.Join(dir, "en")
+	require.NoError(t, os.WriteFile(dictionary+".aff", []byte("SET UTF-8\n"), 0644))
+	require.NoError(t, os.WriteFile(dictionary+".dic", []byte("the\nend\n"), 0644))
+
+	content := map[string]string{
+		"doc.md": "---\nwords: [Hugo]\n---\n\n# The end\n\nHugo  \n\n---\n\nThe end\n\n---\n",
+	}
+	config := map[string]interface{}{
+		"default":  false,
+		"MD009":    true,
+		"spelling": map[string]interface{}{"dictionaries": []interface{}{dictionary}, "word_lists": []interface{}{wordList}},
+	}
+
+	service := createTestLinterService(t, value.NewLintOptions().WithConfig(config))
+	result := service.LintStrings(ctx, content)
+	require.True(t, result.IsOk())
+
+	// Rules see the front matter, thematic breaks later in the document are
+	// not front matter, and lines are numbered as in the source
+	violations := result.Unwrap().Results["doc.md"]
+	require.Len(t, violations, 1)
+	assert.Equal(t, "MD009", violations[0].RuleNames[0])
+	assert.Equal(t, 7, violations[0].LineNumber)
+
+	// Rules report problems in the removed front matter at its source lines
+	schema := filepath.Join(dir, "schema.json")
+	require.NoError(t, os.WriteFile(schema, []byte(`{"properties": {"draft": {"type": "boolean"}}}`), 0644))
+	content = map[string]string{"post.md": "---\ntitle: Post\ndraft: \"no\"\n---\n\n# Post\n"}
+	config = map[string]interface{}{"default": false, "front-matter-schema": map[string]interface{}{"schema": schema}}
+
+	service = createTestLinterService(t, value.NewLintOptions().WithConfig(config))
+	result = service.LintStrings(ctx, content)
+	require.True(t, result.IsOk())
+	violations = result.Unwrap().Results["post.md"]
+	require.Len(t, violations, 1)
+	assert.Equal(t, 3, violations[0].LineNumber)
+}
+
+func TestLinterService_LintFiles_DocumentSetRules(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	index := filepath.Join(dir, "index.md")
+	guide := filepath.Join(dir, "guide.md")
+	require.NoError(t, os.WriteFile(index, []byte("# Index\n\nSee [guide](guide.md#usage) and [setup](setup.md).\n"), 0644))
+	require.NoError(t, os.WriteFile(guide, []byte("# Guide\n\nContent.\n"), 0644))
+
+	config := map[string]interface{}{"default": false, "cross-file-links": true}
+	options := value.NewLintOptions().
+		WithConfig(config).
+		WithCache(value.CacheOptions{Enabled: true, Location: filepath.Join(dir, "cache")})
+
+	// The second run takes per-document results from the cache; the document
+	// set rules must still see every document
+	for _, run := range []string{"cold", "cached"} {
+		service := createTestLinterService(t, options)
+		result := service.LintFiles(ctx, []string{index, guide})
+		require.True(t, result.IsOk(), run)
+
+		lintResult := result.Unwrap()
+		assert.Equal(t, 2, lintResult.TotalFiles, run)
+		assert.Empty(t, lintResult.Results[guide], run)
+		require.Len(t, lintResult.Results[index], 2, run)
+		assert.Equal(t, "Link fragment does not exist in guide.md: #usage", lintResult.Results[index][0].ErrorDetail.Unwrap(), run)
+		assert.Equal(t, "Link target does not exist: setup.md", lintResult.Results[index][1].ErrorDetail.Unwrap(), run)
+	}
+}
+
 func TestLinterService_CacheOperations(t *testing.T) {
 	ctx := context.Background()
 	service := createTestLinterService(t)
@@ -0,0 +1,190 @@
+package prose
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Capitalization styles accepted by ApplyCase and by capitalization rules.
+const (
+	CaseTitle    = "$title"
+	CaseSentence = "$sentence"
+	CaseLower    = "$lower"
+	CaseUpper    = "$upper"
+)
+
+// wordRegex matches words, including contractions and dotted or hyphenated
+// names such as "Node.js" and "built-in", without surrounding punctuation.
+var wordRegex = regexp.MustCompile(`[\p{L}\p{N}](?:[\p{L}\p{N}'’.\-]*[\p{L}\p{N}])?`)
+
+// titleSmallWords are the articles, conjunctions and short prepositions that
+// title case leaves lowercase unless they start or end the title.
+var titleSmallWords = map[string]bool{
+	"a": true, "an": true, "and": true, "as": true, "at": true, "but": true, "by": true,
+	"for": true, "from": true, "in": true, "into": true, "nor": true, "of": true, "on": true,
+	"onto": true, "or": true, "per": true, "so": true, "the": true, "to": true, "up": true,
+	"via": true, "vs": true, "with": true, "yet": true,
+}
+
+// CaseEdit replaces the word at text[Start:End] with Text.
+type CaseEdit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// CaseEdits returns the word replacements that put text in a
+// capitalization style. Words and phrases matching an exception, compared
+// without case, are spelled like the exception. Acronyms and names with
+// capitals after their first letter, such as "API" or "iPhone", are left
+// alone, as is a word after a colon in sentence case, which may start a
+// sentence or not. The first word is the first with a letter, so numbered
+// headings such as "1. Getting started" are capitalized after the number.
+func CaseEdits(text, style string, exceptions []string) []CaseEdit {
+	return caseEdits(text, text, style, exceptions)
+}
+
+// caseEdits is CaseEdits finding the first word by its letters in source,
+// which is text before masking, so that masked code still counts as a word.
+func caseEdits(text, source, style string, exceptions []string) []CaseEdit {
+	if len(source) != len(text) {
+		source = text
+	}
+	exceptionForms := make(map[string]string, len(exceptions))
+	var edits []CaseEdit
+	var protected [][2]int // Phrases spelled like a multi-word exception
+	for _, exception := range exceptions {
+		if !strings.ContainsAny(exception, " \t") {
+			exceptionForms[strings.ToLower(exception)] = exception
+			continue
+		}
+		phrase := regexp.MustCompile(`(?i)` + strings.Join(strings.Fields(regexp.QuoteMeta(exception)), `\s+`))
+		for _, match := range phrase.FindAllStringIndex(text, -1) {
+			if !isWordBoundary(text, match[0], match[1]) || overlapsSpan(protected, match) {
+				continue
+			}
+			protected = append(protected, [2]int{match[0], match[1]})
+			if text[match[0]:match[1]] != exception {
+				edits = append(edits, CaseEdit{Start: match[0], End: match[1], Text: exception})
+			}
+		}
+	}
+
+	matches := wordRegex.FindAllStringIndex(text, -1)
+	first := 0
+	for first < len(matches)-1 && strings.IndexFunc(source[matches[first][0]:matches[first][1]], unicode.IsLetter) < 0 {
+		first++
+	}
+	for i, match := range matches {
+		if overlapsSpan(protected, match) {
+			continue
+		}
+		word := text[match[0]:match[1]]
+		afterColon := i > 0 && strings.Contains(text[matches[i-1][1]:match[0]], ":")
+
+		expected := word
+		if exception, exists := exceptionForms[strings.ToLower(word)]; exists {
+			expected = exception
+		} else {
+			switch style {
+			case CaseTitle:
+				switch {
+				case hasInnerCapital(word):
+				case i == first || i == len(matches)-1 || afterColon:
+					expected = capitalize(word)
+				case titleSmallWords[strings.ToLower(word)]:
+					expected = strings.ToLower(word)
+				default:
+					expected = capitalize(word)
+				}
+			case CaseSentence:
+				switch {
+				case hasInnerCapital(word) || afterColon || word == "I" || strings.HasPrefix(word, "I'") || strings.HasPrefix(word, "I’"):
+				case i <= first:
+					expected = capitalize(word)
+				default:
+					expected = strings.ToLower(word)
+				}
+			case CaseLower:
+				expected = strings.ToLower(word)
+			case CaseUpper:
+				expected = strings.ToUpper(word)
+			}
+		}
+
+		if expected != word {
+			edits = append(edits, CaseEdit{Start: match[0], End: match[1], Text: expected})
+		}
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Start < edits[j].Start })
+	return edits
+}
+
+// CaseFix returns the fix putting the words of a line in a capitalization
+// style (see CaseEdits), spanning from the first word that changes to the
+// last, or nil when the line is in that style. Words are found in the
+// line's Text and replaced in its Source.
+func CaseFix(line Line, style string, exceptions []string) *Fix {
+	edits := caseEdits(line.Text, line.Source, style, exceptions)
+	if len(edits) == 0 {
+		return nil
+	}
+	start, end := edits[0].Start, edits[len(edits)-1].End
+	shifted := make([]CaseEdit, len(edits))
+	for i, edit := range edits {
+		shifted[i] = CaseEdit{Start: edit.Start - start, End: edit.End - start, Text: edit.Text}
+	}
+	return &Fix{Line: line.Number, Column: start + 1, Length: end - start, Text: applyCaseEdits(line.Source[start:end], shifted)}
+}
+
+// ApplyCase returns text in a capitalization style (see CaseEdits).
+func ApplyCase(text, style string, exceptions []string) string {
+	return applyCaseEdits(text, CaseEdits(text, style, exceptions))
+}
+
+// applyCaseEdits applies edits, which are in order and do not overlap.
+func applyCaseEdits(text string, edits []CaseEdit) string {
+	var b strings.Builder
+	last := 0
+	for _, edit := range edits {
+		b.WriteString(text[last:edit.Start])
+		b.WriteString(edit.Text)
+		last = edit.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// capitalize upper-cases the first letter of word.
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+// isWordBoundary reports whether text[start:end] is not part of a longer word.
+func isWordBoundary(text string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	return (start == 0 || !isWordRune(before)) && (end == len(text) || !isWordRune(after))
+}
+
+// overlapsSpan reports whether match overlaps any of the spans.
+func overlapsSpan(spans [][2]int, match []int) bool {
+	for _, span := range spans {
+		if match[0] < span[1] && span[0] < match[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasInnerCapital reports whether word has an uppercase letter after its
+// first character, as acronyms and names like "GitHub" do.
+func hasInnerCapital(word string) bool {
+	_, size := utf8.DecodeRuneInString(word)
+	return strings.IndexFunc(word[size:], unicode.IsUpper) >= 0
+}
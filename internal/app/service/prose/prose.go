@@ -0,0 +1,479 @@
+// Package prose checks the wording of markdown documents against rules
+// written as data, in the spirit of Vale: substitutions, banned words,
+// heading capitalization, repeated words and consistent spelling.
+//
+// Rules only see prose. Extract splits a document into blocks of text with
+// code, URLs, link destinations, HTML markup and markdown syntax blanked
+// out, keeping every remaining character at its original line and column so
+// that alerts and fixes point at the source.
+//
+// Extract reads the source lines rather than the parser's tokens. The line
+// parser classifies each line on its own: a paragraph line holding an inline
+// HTML tag becomes an HTML block, indented list continuations become code,
+// and pipe tables without outer pipes stay paragraphs. Its inline tokens are
+// also positioned relative to the heading, list or quote content rather than
+// the line. Prose taken from the tokens would lose text and misplace alerts.
+package prose
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Block scopes, which rules select with their scope key. ScopeText selects
+// every block of prose.
+const (
+	ScopeText       = "text"
+	ScopeHeading    = "heading"
+	ScopeParagraph  = "paragraph"
+	ScopeList       = "list"
+	ScopeBlockquote = "blockquote"
+	ScopeTable      = "table"
+	ScopeCode       = "code" // Only extracted with Options.Code
+	ScopeHTML       = "html" // Only extracted with Options.HTML
+)
+
+// Options selects content that is not prose but is extracted anyway.
+type Options struct {
+	Code bool // Code blocks and code spans
+	HTML bool // Text inside HTML blocks
+}
+
+// Document is the prose of a markdown document.
+type Document struct {
+	Blocks []Block
+}
+
+// Block is a run of consecutive lines of the same kind: a heading, a
+// paragraph, a list item, a blockquote paragraph or a table.
+type Block struct {
+	Scope string
+	Level int // Heading level, 0 for other blocks
+	Lines []Line
+}
+
+// Line is the prose on one line of a block. Text has the length of the
+// source line, with everything that is not prose replaced by spaces.
+type Line struct {
+	Number int    // 1-based line number
+	Text   string // Prose with everything else blanked out
+	Source string // Source line, which fixes edit
+}
+
+// Text returns the prose of the block on one line, with markup and line
+// breaks collapsed into single spaces.
+func (b Block) Text() string {
+	var parts []string
+	for _, line := range b.Lines {
+		parts = append(parts, strings.Fields(line.Text)...)
+	}
+	return strings.Join(parts, " ")
+}
+
+// InScope reports whether the block is selected by any of the scopes. An
+// empty list selects every prose block; "heading.h2" selects level 2
+// headings only.
+func (b Block) InScope(scopes []string) bool {
+	if len(scopes) == 0 {
+		return b.Scope != ScopeCode && b.Scope != ScopeHTML
+	}
+	for _, scope := range scopes {
+		switch {
+		case scope == ScopeText:
+			if b.Scope != ScopeCode && b.Scope != ScopeHTML {
+				return true
+			}
+		case scope == b.Scope:
+			return true
+		case b.Scope == ScopeHeading && scope == "heading.h"+string(rune('0'+b.Level)):
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	frontMatterRegex    = regexp.MustCompile(`^(---|\+\+\+)\s*$`)
+	atxHeadingRegex     = regexp.MustCompile(`^(\s{0,3}#{1,6})(?:\s+(.*?))??(\s+#+)?\s*$`)
+	headingIDRegex      = regexp.MustCompile(`\s*\{#[^}]*\}\s*$`)
+	setextRegex         = regexp.MustCompile(`^\s{0,3}(=+|-+)\s*$`)
+	thematicBreakRegex  = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	definitionRegex     = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*\S`)
+	tableDelimiterRegex = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	blockquoteRegex     = regexp.MustCompile(`^(\s{0,3}>\s?)+`)
+	listItemRegex       = regexp.MustCompile(`^\s*([*+-]|\d{1,9}[.)])(\s+\[[ xX]\])?(\s+|$)`)
+	htmlBlockRegex      = regexp.MustCompile(`(?i)^\s{0,3}</?(?:address|article|aside|blockquote|details|dialog|dd|div|dl|dt|fieldset|figcaption|figure|footer|form|h[1-6]|header|hr|li|main|nav|ol|p|pre|section|summary|table|tbody|td|th|thead|tr|ul|script|style)(?:\s|/?>|$)`)
+	htmlTagLineRegex    = regexp.MustCompile(`^\s{0,3}</?[a-zA-Z][a-zA-Z0-9-]*(?:\s[^<>]*)?/?>\s*$`)
+
+	htmlCommentRegex   = regexp.MustCompile(`<!--.*?-->`)
+	autolinkRegex      = regexp.MustCompile(`<(?:[a-zA-Z][a-zA-Z0-9+.\-]*:[^\s<>]*|[^\s@<>]+@[^\s@<>]+)>`)
+	htmlTagRegex       = regexp.MustCompile(`</?[a-zA-Z][^<>]*>`)
+	linkDestRegex      = regexp.MustCompile(`\]\((?:[^()\s]|\([^()]*\))*(?:\s+(?:"[^"]*"|'[^']*'|\([^)]*\)))?\s*\)`)
+	linkReferenceRegex = regexp.MustCompile(`\]\[[^\]]*\]`)
+	bareURLRegex       = regexp.MustCompile(`(?:[a-zA-Z][a-zA-Z0-9+.\-]*://|www\.)[^\s<>()\[\]]+`)
+	emailRegex         = regexp.MustCompile(`[\w.+\-]+@[\w\-]+\.[\w.\-]+`)
+	escapeRegex        = regexp.MustCompile(`\\[!-/:-@\[-` + "`" + `{-~]`)
+)
+
+// Extract returns the prose of a document.
+func Extract(lines []string, options Options) *Document {
+	e := &extractor{options: options, doc: &Document{}}
+	e.run(lines)
+	return e.doc
+}
+
+// extractor walks the lines of a document, tracking the block being built.
+type extractor struct {
+	options     Options
+	doc         *Document
+	current     *Block
+	inComment   bool // Inside a multi-line HTML comment
+	inHTMLBlock bool // Inside an HTML block, until the next blank line
+	inList      bool // Indented lines continue a list item
+}
+
+func (e *extractor) run(lines []string) {
+	fence := ""
+
+	for i := frontMatterEnd(lines); i < len(lines); i++ {
+		line := lines[i]
+		number := i + 1
+		trimmed := strings.TrimSpace(line)
+
+		// Fenced code
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+				e.flush()
+				continue
+			}
+			e.add(number, line, e.maskCode(line))
+			continue
+		}
+		if marker := openingFence(trimmed); marker != "" {
+			e.flush()
+			fence = marker
+			e.startCode()
+			continue
+		}
+
+		if trimmed == "" {
+			e.flush()
+			e.inHTMLBlock = false
+			continue
+		}
+
+		// HTML blocks, started by a block-level tag or by a line holding
+		// only a tag outside a paragraph; their text is only prose with
+		// Options.HTML
+		if e.inHTMLBlock || (!e.inComment && (htmlBlockRegex.MatchString(line) || (e.current == nil && htmlTagLineRegex.MatchString(line)))) {
+			if !e.inHTMLBlock {
+				e.flush()
+				e.inHTMLBlock = true
+				if e.options.HTML {
+					e.current = &Block{Scope: ScopeHTML}
+				}
+			}
+			if e.options.HTML {
+				e.add(number, line, e.maskInline(line, 0, len(line)))
+			}
+			continue
+		}
+
+		// Indented code, unless it continues a paragraph or list item
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "    ") {
+			if (e.current == nil || e.current.Scope == ScopeCode) && !e.inList {
+				if e.current == nil {
+					e.startCode()
+				}
+				e.add(number, line, e.maskCode(line))
+				continue
+			}
+		}
+
+		// Setext heading underlines turn the paragraph above into a heading
+		if match := setextRegex.FindStringSubmatch(line); match != nil && e.current != nil && e.current.Scope == ScopeParagraph {
+			e.current.Scope = ScopeHeading
+			e.current.Level = 1
+			if match[1][0] == '-' {
+				e.current.Level = 2
+			}
+			e.flush()
+			continue
+		}
+
+		if thematicBreakRegex.MatchString(line) || definitionRegex.MatchString(line) {
+			e.flush()
+			continue
+		}
+
+		if match := atxHeadingRegex.FindStringSubmatchIndex(line); match != nil {
+			e.flush()
+			e.inList = false
+			start, end := len(line), len(line)
+			if match[4] >= 0 {
+				start, end = match[4], match[5]
+				if id := headingIDRegex.FindStringIndex(line[start:end]); id != nil {
+					end = start + id[0]
+				}
+			}
+			e.current = &Block{Scope: ScopeHeading, Level: strings.Count(line[match[2]:match[3]], "#")}
+			e.add(number, line, e.maskInline(line, start, end))
+			e.flush()
+			continue
+		}
+
+		// Tables, with pipes blanked out and delimiter rows skipped
+		if strings.Contains(line, "|") {
+			if e.current != nil && e.current.Scope == ScopeTable {
+				if !tableDelimiterRegex.MatchString(line) {
+					e.add(number, line, maskPipes(e.maskInline(line, 0, len(line))))
+				}
+				continue
+			}
+			if i+1 < len(lines) && tableDelimiterRegex.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-") {
+				e.flush()
+				e.current = &Block{Scope: ScopeTable}
+				e.add(number, line, maskPipes(e.maskInline(line, 0, len(line))))
+				continue
+			}
+		}
+
+		if match := blockquoteRegex.FindStringIndex(line); match != nil {
+			if e.current == nil || e.current.Scope != ScopeBlockquote {
+				e.flush()
+				e.current = &Block{Scope: ScopeBlockquote}
+			}
+			if strings.TrimSpace(line[match[1]:]) == "" {
+				e.flush()
+				continue
+			}
+			e.add(number, line, e.maskInline(line, match[1], len(line)))
+			continue
+		}
+
+		if match := listItemRegex.FindStringIndex(line); match != nil {
+			e.flush()
+			e.inList = true
+			e.current = &Block{Scope: ScopeList}
+			e.add(number, line, e.maskInline(line, match[1], len(line)))
+			continue
+		}
+
+		// Paragraph text, or the continuation of the current block
+		if e.current == nil || e.current.Scope == ScopeCode || e.current.Scope == ScopeTable {
+			e.flush()
+			scope := ScopeParagraph
+			if e.inList && indent > 0 {
+				scope = ScopeList
+			} else {
+				e.inList = false
+			}
+			e.current = &Block{Scope: scope}
+		}
+		e.add(number, line, e.maskInline(line, 0, len(line)))
+	}
+
+	e.flush()
+}
+
+// startCode starts a code block when code is extracted.
+func (e *extractor) startCode() {
+	if e.options.Code {
+		e.current = &Block{Scope: ScopeCode}
+	}
+}
+
+// add appends a line to the current block, skipping lines without prose.
+func (e *extractor) add(number int, source, text string) {
+	if e.current == nil || strings.TrimSpace(text) == "" {
+		return
+	}
+	e.current.Lines = append(e.current.Lines, Line{Number: number, Text: text, Source: source})
+}
+
+// flush ends the current block.
+func (e *extractor) flush() {
+	if e.current != nil && len(e.current.Lines) > 0 {
+		e.doc.Blocks = append(e.doc.Blocks, *e.current)
+	}
+	e.current = nil
+}
+
+// maskCode returns a code line as text, or a blank line when code is not
+// extracted.
+func (e *extractor) maskCode(line string) string {
+	if e.options.Code {
+		return line
+	}
+	return ""
+}
+
+// maskInline blanks out everything before start and from end, and the
+// inline markup in between.
+func (e *extractor) maskInline(line string, start, end int) string {
+	text := []byte(line)
+	blank(text, 0, start)
+	blank(text, end, len(text))
+
+	// HTML comments may span lines
+	if e.inComment {
+		if closing := strings.Index(line, "-->"); closing >= 0 {
+			blank(text, 0, closing+3)
+			e.inComment = false
+		} else {
+			return strings.Repeat(" ", len(line))
+		}
+	}
+	for _, match := range htmlCommentRegex.FindAllStringIndex(string(text), -1) {
+		blank(text, match[0], match[1])
+	}
+	if opening := strings.Index(string(text), "<!--"); opening >= 0 {
+		blank(text, opening, len(text))
+		e.inComment = true
+	}
+
+	maskCodeSpans(text, e.options.Code)
+	for _, regex := range []*regexp.Regexp{autolinkRegex, htmlTagRegex, linkDestRegex, linkReferenceRegex, bareURLRegex, emailRegex, escapeRegex} {
+		for _, match := range regex.FindAllIndex(text, -1) {
+			if regex == escapeRegex {
+				// Keep the escaped character
+				match[1] = match[0] + 1
+			}
+			blank(text, match[0], match[1])
+		}
+	}
+	maskEmphasis(text)
+
+	return string(text)
+}
+
+// maskCodeSpans blanks out code spans, or only their backticks when keep is
+// set.
+func maskCodeSpans(text []byte, keep bool) {
+	for i := 0; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		run := i
+		for run < len(text) && text[run] == '`' {
+			run++
+		}
+		ticks := run - i
+
+		// Find the closing run of the same length
+		closing := -1
+		for j := run; j < len(text); {
+			if text[j] != '`' {
+				j++
+				continue
+			}
+			k := j
+			for k < len(text) && text[k] == '`' {
+				k++
+			}
+			if k-j == ticks {
+				closing = j
+				break
+			}
+			j = k
+		}
+		if closing < 0 {
+			i = run
+			continue
+		}
+
+		if keep {
+			blank(text, i, run)
+			blank(text, closing, closing+ticks)
+		} else {
+			blank(text, i, closing+ticks)
+		}
+		i = closing + ticks
+	}
+}
+
+// maskEmphasis blanks out emphasis and strikethrough markers, link and image
+// brackets, and underscores that do not join word characters.
+func maskEmphasis(text []byte) {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '*', '~', '[', ']':
+			text[i] = ' '
+		case '!':
+			if i+1 < len(text) && text[i+1] == '[' {
+				text[i] = ' '
+			}
+		case '_':
+			previous, _ := utf8.DecodeLastRune(text[:i])
+			next := i + 1
+			for next < len(text) && text[next] == '_' {
+				next++
+			}
+			following, _ := utf8.DecodeRune(text[next:])
+			if !isWordRune(previous) || !isWordRune(following) {
+				blank(text, i, next)
+			}
+			i = next - 1
+		}
+	}
+}
+
+// maskPipes blanks out the unescaped pipes of a table row.
+func maskPipes(line string) string {
+	text := []byte(line)
+	for i := range text {
+		if text[i] == '|' && (i == 0 || text[i-1] != '\\') {
+			text[i] = ' '
+		}
+	}
+	return string(text)
+}
+
+// blank replaces text[start:end] with spaces.
+func blank(text []byte, start, end int) {
+	for i := max(start, 0); i < min(end, len(text)); i++ {
+		text[i] = ' '
+	}
+}
+
+// isWordRune reports whether r is a letter, digit or underscore.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// openingFence returns the fence marker a trimmed line opens a fenced code
+// block with, or "".
+func openingFence(trimmed string) string {
+	for _, char := range []string{"`", "~"} {
+		if strings.HasPrefix(trimmed, strings.Repeat(char, 3)) {
+			marker := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, char))]
+			if char == "`" && strings.Contains(trimmed[len(marker):], "`") {
+				// Backtick fences cannot have backticks in their info string
+				return ""
+			}
+			return marker
+		}
+	}
+	return ""
+}
+
+// frontMatterEnd returns the index of the first line after YAML or TOML
+// front matter, or 0 when there is none.
+func frontMatterEnd(lines []string) int {
+	if len(lines) == 0 || !frontMatterRegex.MatchString(lines[0]) {
+		return 0
+	}
+	delimiter := strings.TrimSpace(lines[0])
+	for i := 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == delimiter || (delimiter == "---" && trimmed == "...") {
+			return i + 1
+		}
+	}
+	return 0
+}
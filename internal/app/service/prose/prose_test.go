@@ -0,0 +1,278 @@
+package prose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtract(t *testing.T) {
+	lines := []string{
+		"---",
+		"title: utilize",
+		"---",
+		"",
+		"# Getting *started* {#start}",
+		"",
+		"Read the [guide](https://example.com/utilize) or `utilize()` now.",
+		"See <https://example.com> and <b>bold</b> text.",
+		"",
+		"```go",
+		"utilize()",
+		"```",
+		"",
+		"- [ ] Task item",
+		"> Quoted text",
+		"",
+		"| Name | Value |",
+		"| ---- | ----- |",
+		"| one  | two   |",
+	}
+
+	doc := Extract(lines, Options{})
+
+	var scopes, texts []string
+	for _, block := range doc.Blocks {
+		scopes = append(scopes, block.Scope)
+		texts = append(texts, block.Text())
+	}
+	assert.Equal(t, []string{ScopeHeading, ScopeParagraph, ScopeList, ScopeBlockquote, ScopeTable}, scopes)
+	assert.Equal(t, []string{
+		"Getting started",
+		"Read the guide or now. See and bold text.",
+		"Task item",
+		"Quoted text",
+		"Name Value one two",
+	}, texts)
+
+	// Masked text keeps every character at its column
+	paragraph := doc.Blocks[1].Lines[0]
+	assert.Equal(t, 7, paragraph.Number)
+	assert.Len(t, paragraph.Text, len(lines[6]))
+	assert.Equal(t, "guide", paragraph.Text[10:15])
+}
+
+func TestExtract_Options(t *testing.T) {
+	lines := []string{
+		"Call `utilize()` here.",
+		"",
+		"```",
+		"utilize()",
+		"```",
+		"",
+		"<div>",
+		"Utilize HTML",
+		"</div>",
+	}
+
+	doc := Extract(lines, Options{})
+	require.Len(t, doc.Blocks, 1)
+	assert.Equal(t, "Call here.", doc.Blocks[0].Text())
+
+	doc = Extract(lines, Options{Code: true, HTML: true})
+	var texts []string
+	for _, block := range doc.Blocks {
+		texts = append(texts, block.Scope+": "+block.Text())
+	}
+	assert.Contains(t, texts, "paragraph: Call utilize() here.")
+	assert.Contains(t, texts, "code: utilize()")
+	assert.Contains(t, texts, "html: Utilize HTML")
+}
+
+func TestCaseEdits(t *testing.T) {
+	tests := []struct {
+		text       string
+		style      string
+		exceptions []string
+		expected   string
+	}{
+		{"getting started with the api", CaseTitle, nil, "Getting Started with the Api"},
+		{"getting started with the API", CaseTitle, nil, "Getting Started with the API"},
+		{"Setup: a guide to go", CaseTitle, nil, "Setup: A Guide to Go"},
+		{"Getting Started With GitHub", CaseSentence, nil, "Getting started with GitHub"},
+		{"Installing On Linux", CaseSentence, []string{"Linux"}, "Installing on Linux"},
+		{"Why I'm Here", CaseSentence, nil, "Why I'm here"},
+		{"Migrating: From Version One", CaseSentence, nil, "Migrating: From version one"},
+		{"editing in visual studio code", CaseSentence, []string{"Visual Studio Code"}, "Editing in Visual Studio Code"},
+		{"visual studio code tips", CaseTitle, []string{"Visual Studio Code"}, "Visual Studio Code Tips"},
+		{"Shout", CaseUpper, nil, "SHOUT"},
+		{"1. getting started", CaseSentence, nil, "1. Getting started"},
+		{"1. Getting Started", CaseSentence, nil, "1. Getting started"},
+		{"2.3 the next step", CaseTitle, nil, "2.3 The Next Step"},
+		{"what to do in 2024", CaseTitle, nil, "What to Do in 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ApplyCase(tt.text, tt.style, tt.exceptions))
+		})
+	}
+}
+
+func checkRule(t *testing.T, rule *Rule, lines ...string) []Alert {
+	t.Helper()
+	require.NoError(t, rule.Compile())
+	return rule.Check(Extract(lines, Options{}))
+}
+
+func TestRule_Existence(t *testing.T) {
+	rule := &Rule{Name: "Weasel", Extends: Existence, Tokens: []string{"[Vv]ery", "obviously"}, Exceptions: []string{"Very"}}
+
+	alerts := checkRule(t, rule, "This is very, obviously good.", "Very Large Array, everything `very` fine.")
+
+	require.Len(t, alerts, 2)
+	assert.Equal(t, Alert{Rule: "Weasel", Level: LevelWarning, Message: "'very' should be avoided", Line: 1, Column: 9, Length: 4, Match: "very"}, alerts[0])
+	assert.Equal(t, "obviously", alerts[1].Match)
+}
+
+func TestRule_Substitution(t *testing.T) {
+	rule := &Rule{
+		Name:       "Simple",
+		Extends:    Substitution,
+		Message:    "Prefer '%s' over '%s'",
+		IgnoreCase: true,
+		Swap:       map[string]string{"utilize": "use", "in order to": "to", "e-?mail": "email|e-mail"},
+	}
+
+	alerts := checkRule(t, rule, "Utilize it in order to send email or e-mail or Email.", "See [utilize](https://example.com/utilize).")
+
+	require.Len(t, alerts, 4)
+	assert.Equal(t, "Prefer 'use' over 'Utilize'", alerts[0].Message)
+	assert.Equal(t, &Fix{Line: 1, Column: 1, Length: 7, Text: "Use"}, alerts[0].Fix)
+	assert.Equal(t, &Fix{Line: 1, Column: 12, Length: 11, Text: "to"}, alerts[1].Fix)
+	assert.Equal(t, "Email", alerts[2].Match)
+	assert.Nil(t, alerts[2].Fix, "Alternatives are not fixed automatically")
+	assert.Equal(t, 2, alerts[3].Line)
+	assert.Equal(t, 6, alerts[3].Column)
+}
+
+func TestRule_SubstitutionNonword(t *testing.T) {
+	rule := &Rule{Name: "Names", Extends: Substitution, IgnoreCase: true, Swap: map[string]string{`\.net`: ".NET"}}
+
+	alerts := checkRule(t, rule, "Build with .net and .NET, not dotnet.")
+
+	require.Len(t, alerts, 1)
+	assert.Equal(t, ".net", alerts[0].Match)
+	assert.Equal(t, ".NET", alerts[0].Fix.Text)
+}
+
+func TestRule_Capitalization(t *testing.T) {
+	rule := &Rule{Name: "Headings", Extends: Capitalization, Match: CaseSentence, Exceptions: []string{"GitHub", "Go"}}
+
+	alerts := checkRule(t, rule, "## Getting Started With `go` On github", "", "Some Title Case Paragraph.")
+
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "'Getting Started With On github' should be 'Getting started with on GitHub'", alerts[0].Message)
+	assert.Equal(t, &Fix{Line: 1, Column: 12, Length: 27, Text: "started with `go` on GitHub"}, alerts[0].Fix)
+}
+
+func TestRule_CapitalizationPattern(t *testing.T) {
+	rule := &Rule{Name: "Headings", Extends: Capitalization, Match: `^[A-Z]`, Scope: Scopes{"heading.h1"}}
+
+	alerts := checkRule(t, rule, "# lowercase", "", "## lowercase too")
+
+	require.Len(t, alerts, 1)
+	assert.Equal(t, 1, alerts[0].Line)
+	assert.Nil(t, alerts[0].Fix)
+}
+
+func TestRule_Repetition(t *testing.T) {
+	rule := &Rule{Name: "Repeat", Extends: Repetition, Alpha: true, IgnoreCase: true}
+
+	alerts := checkRule(t, rule, "This is the the answer, 42 42 times, The", "the end. Now now.")
+
+	require.Len(t, alerts, 3)
+	assert.Equal(t, &Fix{Line: 1, Column: 12, Length: 4}, alerts[0].Fix)
+	assert.Equal(t, 2, alerts[1].Line)
+	assert.Nil(t, alerts[1].Fix, "Repetitions across lines are not fixed")
+	assert.Equal(t, "now", alerts[2].Match)
+}
+
+func TestRule_Consistency(t *testing.T) {
+	rule := &Rule{Name: "Spelling", Extends: Consistency, IgnoreCase: true, Either: map[string]string{"e-mail": "email"}}
+
+	alerts := checkRule(t, rule, "Send an email.", "", "E-mail us, or email us.")
+
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "Inconsistent spelling of 'E-mail', the document uses 'email'", alerts[0].Message)
+	assert.Equal(t, "Email", alerts[0].Fix.Text)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	style := filepath.Join(dir, "House")
+	require.NoError(t, os.Mkdir(style, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(style, "Utilize.yml"), []byte("extends: substitution\nlevel: error\nswap:\n  utilize: use\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(style, "README.md"), []byte("# Not a rule\n"), 0o644))
+	single := filepath.Join(dir, "Weasel.yaml")
+	require.NoError(t, os.WriteFile(single, []byte("extends: existence\nscope: heading\ntokens: [very]\n"), 0o644))
+
+	rules, err := Load([]string{style, single})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "House.Utilize", rules[0].Name)
+	assert.Equal(t, LevelError, rules[0].Level)
+	assert.Equal(t, "Weasel", rules[1].Name)
+	assert.Equal(t, Scopes{ScopeHeading}, rules[1].Scope)
+
+	again, err := Load([]string{style})
+	require.NoError(t, err)
+	assert.Same(t, rules[0], again[0], "Unchanged files are cached")
+}
+
+func TestLoad_StylesRoot(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "styles")
+	for file, content := range map[string]string{
+		"House/Utilize.yml":                    "extends: substitution\nswap:\n  utilize: use\n",
+		"Other/Very.yaml":                      "extends: existence\ntokens: [very]\n",
+		"config/vocabularies/House/accept.txt": "gomdlint\n",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	rules, err := Load([]string{root})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "House.Utilize", rules[0].Name)
+	assert.Equal(t, "Other.Very", rules[1].Name)
+
+	// A path that yields no rules is a mistake in the configuration
+	_, err = Load([]string{filepath.Join(root, "config")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no .yml or .yaml rule files")
+}
+
+func TestLoad_Errors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"Unknown", "extends: spelling\n", "extends must be"},
+		{"Key", "extends: existence\ntoken: [very]\n", "field token not found"},
+		{"Pattern", "extends: existence\ntokens: ['(']\n", "invalid pattern"},
+		{"Swap", "extends: substitution\n", "need swap"},
+		{"Level", "extends: existence\nlevel: fatal\ntokens: [very]\n", "level must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".yml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+
+			_, err := Load([]string{path})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+			assert.Contains(t, err.Error(), tt.name)
+		})
+	}
+
+	_, err := Load([]string{filepath.Join(dir, "missing.yml")})
+	assert.Error(t, err)
+}
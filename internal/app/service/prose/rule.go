@@ -0,0 +1,666 @@
+package prose
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rule types, selected by a rule's extends key.
+const (
+	Existence      = "existence"
+	Substitution   = "substitution"
+	Capitalization = "capitalization"
+	Repetition     = "repetition"
+	Consistency    = "consistency"
+)
+
+// Alert levels.
+const (
+	LevelError      = "error"
+	LevelWarning    = "warning"
+	LevelSuggestion = "suggestion"
+)
+
+// Rule is a prose rule, written in YAML with the keys of its fields. Token
+// patterns are regular expressions that match whole words unless nonword
+// is set.
+type Rule struct {
+	Name       string            `yaml:"-"`
+	Extends    string            `yaml:"extends"`
+	Message    string            `yaml:"message"`    // Alert text; %s are replaced by the rule's arguments
+	Level      string            `yaml:"level"`      // error, warning (default) or suggestion
+	Scope      Scopes            `yaml:"scope"`      // Blocks the rule checks (default: text, or heading for capitalization)
+	Link       string            `yaml:"link"`       // Page explaining the rule
+	IgnoreCase bool              `yaml:"ignorecase"` // Match tokens without regard to case
+	Nonword    bool              `yaml:"nonword"`    // Tokens may match inside words
+	Tokens     []string          `yaml:"tokens"`     // existence: banned patterns; repetition: what counts as a token
+	Exceptions []string          `yaml:"exceptions"` // existence: allowed matches; capitalization: words spelled as given
+	Swap       map[string]string `yaml:"swap"`       // substitution: pattern to replacement ("a|b" offers alternatives)
+	Match      string            `yaml:"match"`      // capitalization: $title, $sentence, $lower, $upper or a pattern
+	Alpha      bool              `yaml:"alpha"`      // repetition: only repeated alphabetic tokens count
+	Either     map[string]string `yaml:"either"`     // consistency: pairs of patterns, of which a document uses one
+
+	compiled compiledRule
+}
+
+// Scopes is a list of block scopes, written in YAML as a list or a string.
+type Scopes []string
+
+// UnmarshalYAML accepts a single scope or a list.
+func (s *Scopes) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*s = Scopes{node.Value}
+		return nil
+	}
+	var scopes []string
+	if err := node.Decode(&scopes); err != nil {
+		return err
+	}
+	*s = scopes
+	return nil
+}
+
+// compiledRule holds the patterns of a validated rule.
+type compiledRule struct {
+	tokens  []*regexp.Regexp
+	swaps   []swap
+	either  [][2]eitherForm
+	pattern *regexp.Regexp // capitalization with a pattern
+}
+
+type swap struct {
+	pattern      *regexp.Regexp
+	replacements []string
+}
+
+type eitherForm struct {
+	pattern *regexp.Regexp
+	literal string // Replacement text when the pattern is a plain word
+}
+
+// Alert is a problem a rule found.
+type Alert struct {
+	Rule    string
+	Level   string
+	Message string
+	Line    int // 1-based line number
+	Column  int // 1-based byte column
+	Length  int // Length in bytes
+	Match   string
+	Fix     *Fix // Nil when the alert cannot be fixed automatically
+}
+
+// Fix replaces Length bytes at Column of Line with Text.
+type Fix struct {
+	Line   int
+	Column int
+	Length int
+	Text   string
+}
+
+// Compile validates the rule and prepares its patterns. Rules returned by
+// Load are compiled already.
+func (r *Rule) Compile() error {
+	fail := func(format string, args ...interface{}) error {
+		return fmt.Errorf("prose rule %s: %s", r.Name, fmt.Sprintf(format, args...))
+	}
+
+	switch r.Level {
+	case "":
+		r.Level = LevelWarning
+	case LevelError, LevelWarning, LevelSuggestion:
+	default:
+		return fail("level must be error, warning or suggestion, not %q", r.Level)
+	}
+	if len(r.Scope) == 0 && r.Extends == Capitalization {
+		r.Scope = Scopes{ScopeHeading}
+	}
+
+	compile := func(pattern string) (*regexp.Regexp, error) {
+		if r.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fail("invalid pattern %q: %v", pattern, err)
+		}
+		return regex, nil
+	}
+
+	r.compiled = compiledRule{}
+	switch r.Extends {
+	case Existence, Repetition:
+		if len(r.Tokens) == 0 && r.Extends == Existence {
+			return fail("existence rules need tokens")
+		}
+		for _, token := range r.Tokens {
+			regex, err := compile("(?:" + token + ")")
+			if err != nil {
+				return err
+			}
+			r.compiled.tokens = append(r.compiled.tokens, regex)
+		}
+	case Substitution:
+		if len(r.Swap) == 0 {
+			return fail("substitution rules need swap")
+		}
+		// Longer patterns first, so the most specific substitution wins
+		patterns := make([]string, 0, len(r.Swap))
+		for pattern := range r.Swap {
+			patterns = append(patterns, pattern)
+		}
+		sort.Slice(patterns, func(i, j int) bool {
+			if len(patterns[i]) != len(patterns[j]) {
+				return len(patterns[i]) > len(patterns[j])
+			}
+			return patterns[i] < patterns[j]
+		})
+		for _, pattern := range patterns {
+			regex, err := compile("(?:" + pattern + ")")
+			if err != nil {
+				return err
+			}
+			r.compiled.swaps = append(r.compiled.swaps, swap{pattern: regex, replacements: strings.Split(r.Swap[pattern], "|")})
+		}
+	case Capitalization:
+		switch r.Match {
+		case CaseTitle, CaseSentence, CaseLower, CaseUpper:
+		case "":
+			return fail("capitalization rules need match")
+		default:
+			regex, err := compile(r.Match)
+			if err != nil {
+				return err
+			}
+			r.compiled.pattern = regex
+		}
+	case Consistency:
+		if len(r.Either) == 0 {
+			return fail("consistency rules need either")
+		}
+		firsts := make([]string, 0, len(r.Either))
+		for first := range r.Either {
+			firsts = append(firsts, first)
+		}
+		sort.Strings(firsts)
+		for _, first := range firsts {
+			var pair [2]eitherForm
+			for i, pattern := range []string{first, r.Either[first]} {
+				regex, err := compile("(?:" + pattern + ")")
+				if err != nil {
+					return err
+				}
+				pair[i] = eitherForm{pattern: regex}
+				if regexp.QuoteMeta(pattern) == pattern {
+					pair[i].literal = pattern
+				}
+			}
+			r.compiled.either = append(r.compiled.either, pair)
+		}
+	default:
+		return fail("extends must be existence, substitution, capitalization, repetition or consistency, not %q", r.Extends)
+	}
+
+	return nil
+}
+
+// Check returns the alerts of the rule for a document.
+func (r *Rule) Check(doc *Document) []Alert {
+	var blocks []Block
+	for _, block := range doc.Blocks {
+		if block.InScope(r.Scope) {
+			blocks = append(blocks, block)
+		}
+	}
+
+	switch r.Extends {
+	case Existence:
+		return r.checkExistence(blocks)
+	case Substitution:
+		return r.checkSubstitution(blocks)
+	case Capitalization:
+		return r.checkCapitalization(blocks)
+	case Repetition:
+		return r.checkRepetition(blocks)
+	case Consistency:
+		return r.checkConsistency(blocks)
+	}
+	return nil
+}
+
+func (r *Rule) checkExistence(blocks []Block) []Alert {
+	var alerts []Alert
+	for _, block := range blocks {
+		for _, line := range block.Lines {
+			for _, regex := range r.compiled.tokens {
+				for _, match := range r.findAll(regex, line.Text) {
+					observed := line.Text[match[0]:match[1]]
+					if r.isException(observed) {
+						continue
+					}
+					alerts = append(alerts, r.alert(line.Number, match, observed, r.message("'%s' should be avoided", observed)))
+				}
+			}
+		}
+	}
+	return alerts
+}
+
+func (r *Rule) checkSubstitution(blocks []Block) []Alert {
+	var alerts []Alert
+	for _, block := range blocks {
+		for _, line := range block.Lines {
+			var matched [][2]int
+			for _, swap := range r.compiled.swaps {
+				for _, match := range r.findAll(swap.pattern, line.Text) {
+					if overlapsSpan(matched, match) {
+						continue
+					}
+					matched = append(matched, [2]int{match[0], match[1]})
+
+					observed := line.Text[match[0]:match[1]]
+					if containsString(swap.replacements, observed) {
+						continue
+					}
+
+					expected := strings.Join(swap.replacements, "' or '")
+					alert := r.alert(line.Number, match, observed, r.message("Use '%s' instead of '%s'", expected, observed))
+					if len(swap.replacements) == 1 {
+						alert.Fix = &Fix{Line: line.Number, Column: match[0] + 1, Length: match[1] - match[0], Text: matchCapital(observed, swap.replacements[0])}
+					}
+					alerts = append(alerts, alert)
+				}
+			}
+		}
+	}
+	sortAlerts(alerts)
+	return alerts
+}
+
+func (r *Rule) checkCapitalization(blocks []Block) []Alert {
+	var alerts []Alert
+	for _, block := range blocks {
+		text := block.Text()
+		if text == "" {
+			continue
+		}
+
+		if r.compiled.pattern != nil {
+			if !r.compiled.pattern.MatchString(text) {
+				line := block.Lines[0]
+				alerts = append(alerts, r.alert(line.Number, proseSpan(line.Text), text, r.message("'%s' does not match '%s'", text, r.Match)))
+			}
+			continue
+		}
+
+		expected := ApplyCase(text, r.Match, r.Exceptions)
+		if expected == text {
+			continue
+		}
+
+		// Headings and other short blocks are fixed word by word on their
+		// only line
+		line := block.Lines[0]
+		alert := r.alert(line.Number, proseSpan(line.Text), text, r.message("'%s' should be '%s'", text, expected))
+		if len(block.Lines) == 1 {
+			alert.Fix = CaseFix(line, r.Match, r.Exceptions)
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+// defaultRepetitionToken matches words.
+var defaultRepetitionToken = regexp.MustCompile(`[\p{L}\p{N}]+(?:['’][\p{L}\p{N}]+)*`)
+
+func (r *Rule) checkRepetition(blocks []Block) []Alert {
+	type token struct {
+		line  Line
+		start int
+		end   int
+	}
+
+	var alerts []Alert
+	for _, block := range blocks {
+		var tokens []token
+		for _, line := range block.Lines {
+			var matches [][]int
+			if len(r.compiled.tokens) == 0 {
+				matches = defaultRepetitionToken.FindAllStringIndex(line.Text, -1)
+			} else {
+				for _, regex := range r.compiled.tokens {
+					matches = append(matches, r.findAll(regex, line.Text)...)
+				}
+				sort.Slice(matches, func(i, j int) bool { return matches[i][0] < matches[j][0] })
+			}
+			for _, match := range matches {
+				tokens = append(tokens, token{line: line, start: match[0], end: match[1]})
+			}
+		}
+
+		for i := 1; i < len(tokens); i++ {
+			previous, current := tokens[i-1], tokens[i]
+			previousText := previous.line.Text[previous.start:previous.end]
+			currentText := current.line.Text[current.start:current.end]
+
+			same := previousText == currentText || (r.IgnoreCase && strings.EqualFold(previousText, currentText))
+			if !same || (r.Alpha && strings.IndexFunc(currentText, func(r rune) bool { return !unicode.IsLetter(r) }) >= 0) {
+				continue
+			}
+
+			// Only whitespace may separate the repeated tokens
+			var gap string
+			if previous.line.Number == current.line.Number {
+				gap = current.line.Text[previous.end:current.start]
+			} else {
+				gap = previous.line.Text[previous.end:] + current.line.Text[:current.start]
+			}
+			if strings.TrimSpace(gap) != "" {
+				continue
+			}
+
+			alert := r.alert(current.line.Number, []int{current.start, current.end}, currentText, r.message("'%s' is repeated", currentText))
+			if previous.line.Number == current.line.Number {
+				alert.Fix = &Fix{Line: current.line.Number, Column: previous.end + 1, Length: current.end - previous.end}
+			}
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+func (r *Rule) checkConsistency(blocks []Block) []Alert {
+	type occurrence struct {
+		line  Line
+		match []int
+	}
+
+	var alerts []Alert
+	for _, pair := range r.compiled.either {
+		var found [2][]occurrence
+		first := -1
+		for _, block := range blocks {
+			for _, line := range block.Lines {
+				for i, form := range pair {
+					for _, match := range r.findAll(form.pattern, line.Text) {
+						found[i] = append(found[i], occurrence{line: line, match: match})
+					}
+				}
+				// The form the document uses first wins
+				if first < 0 {
+					switch {
+					case len(found[0]) > 0 && len(found[1]) > 0:
+						first = 0
+						if found[1][0].match[0] < found[0][0].match[0] {
+							first = 1
+						}
+					case len(found[0]) > 0:
+						first = 0
+					case len(found[1]) > 0:
+						first = 1
+					}
+				}
+			}
+		}
+		if first < 0 {
+			continue
+		}
+
+		chosen := pair[first]
+		for _, occurrence := range found[1-first] {
+			observed := occurrence.line.Text[occurrence.match[0]:occurrence.match[1]]
+			expected := chosen.literal
+			if expected == "" {
+				expected = chosen.pattern.String()
+			}
+			alert := r.alert(occurrence.line.Number, occurrence.match, observed, r.message("Inconsistent spelling of '%s', the document uses '%s'", observed, expected))
+			if chosen.literal != "" {
+				alert.Fix = &Fix{
+					Line:   occurrence.line.Number,
+					Column: occurrence.match[0] + 1,
+					Length: occurrence.match[1] - occurrence.match[0],
+					Text:   matchCapital(observed, chosen.literal),
+				}
+			}
+			alerts = append(alerts, alert)
+		}
+	}
+	sortAlerts(alerts)
+	return alerts
+}
+
+// findAll returns the matches of regex in text, keeping only whole words
+// unless the rule is nonword.
+func (r *Rule) findAll(regex *regexp.Regexp, text string) [][]int {
+	matches := regex.FindAllStringIndex(text, -1)
+	if r.Nonword {
+		return matches
+	}
+
+	words := matches[:0]
+	for _, match := range matches {
+		if match[0] == match[1] {
+			continue
+		}
+		first, _ := utf8.DecodeRuneInString(text[match[0]:])
+		last, _ := utf8.DecodeLastRuneInString(text[:match[1]])
+		before, _ := utf8.DecodeLastRuneInString(text[:match[0]])
+		after, _ := utf8.DecodeRuneInString(text[match[1]:])
+		if (isWordRune(first) && match[0] > 0 && isWordRune(before)) ||
+			(isWordRune(last) && match[1] < len(text) && isWordRune(after)) {
+			continue
+		}
+		words = append(words, match)
+	}
+	return words
+}
+
+// isException reports whether observed is one of the rule's exceptions.
+func (r *Rule) isException(observed string) bool {
+	for _, exception := range r.Exceptions {
+		if exception == observed || (r.IgnoreCase && strings.EqualFold(exception, observed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// alert creates an alert for the match [start, end) on a line.
+func (r *Rule) alert(line int, match []int, observed, message string) Alert {
+	return Alert{
+		Rule:    r.Name,
+		Level:   r.Level,
+		Message: message,
+		Line:    line,
+		Column:  match[0] + 1,
+		Length:  match[1] - match[0],
+		Match:   observed,
+	}
+}
+
+// message formats the rule's message, or fallback when it has none, with
+// args replacing its %s placeholders in order.
+func (r *Rule) message(fallback string, args ...string) string {
+	message := r.Message
+	if message == "" {
+		message = fallback
+	}
+	for _, arg := range args {
+		if !strings.Contains(message, "%s") {
+			break
+		}
+		message = strings.Replace(message, "%s", arg, 1)
+	}
+	return message
+}
+
+// matchCapital capitalizes a lowercase replacement when the text it
+// replaces starts a sentence with a capital. Replacements with capitals,
+// such as names, are used as they are.
+func matchCapital(observed, replacement string) string {
+	first, _ := utf8.DecodeRuneInString(observed)
+	if unicode.IsUpper(first) && strings.ToLower(replacement) == replacement {
+		return capitalize(replacement)
+	}
+	return replacement
+}
+
+// proseSpan returns the span of text from its first to its last non-space
+// character.
+func proseSpan(text string) []int {
+	start := len(text) - len(strings.TrimLeft(text, " "))
+	end := len(strings.TrimRight(text, " "))
+	return []int{start, max(start, end)}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortAlerts orders alerts by position.
+func sortAlerts(alerts []Alert) {
+	sort.SliceStable(alerts, func(i, j int) bool {
+		if alerts[i].Line != alerts[j].Line {
+			return alerts[i].Line < alerts[j].Line
+		}
+		return alerts[i].Column < alerts[j].Column
+	})
+}
+
+// loadedFile caches the rules of a YAML file until it changes.
+type loadedFile struct {
+	modTime int64
+	size    int64
+	rule    *Rule
+}
+
+var (
+	loadedMutex sync.Mutex
+	loadedFiles = make(map[string]loadedFile)
+)
+
+// Load reads the rules in the given YAML files and directories, one rule
+// per file. Rules are named after their file; rules in a directory are
+// prefixed with the directory name, like Vale styles ("House.Utilize"). A
+// directory without YAML files is a styles root, like Vale's StylesPath,
+// whose subdirectories are styles. A path without rules is an error. Files
+// are parsed once and reused until they change.
+func Load(paths []string) ([]*Rule, error) {
+	var rules []*Rule
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("prose rules: %w", err)
+		}
+
+		if !info.IsDir() {
+			rule, err := loadFile(path, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+			continue
+		}
+
+		styleRules, styles, err := loadStyle(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(styleRules) == 0 {
+			for _, style := range styles {
+				nested, _, err := loadStyle(style)
+				if err != nil {
+					return nil, err
+				}
+				styleRules = append(styleRules, nested...)
+			}
+		}
+		if len(styleRules) == 0 {
+			return nil, fmt.Errorf("prose rules: no .yml or .yaml rule files in %s or its style directories", path)
+		}
+		rules = append(rules, styleRules...)
+	}
+	return rules, nil
+}
+
+// loadStyle reads the rules in the YAML files of a style directory and
+// returns them with the subdirectories of the directory.
+func loadStyle(path string) ([]*Rule, []string, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("prose rules: %w", err)
+	}
+
+	var rules []*Rule
+	var subdirectories []string
+	style := filepath.Base(filepath.Clean(path))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			subdirectories = append(subdirectories, filepath.Join(path, entry.Name()))
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+		rule, err := loadFile(filepath.Join(path, entry.Name()), style+"."+strings.TrimSuffix(entry.Name(), ext))
+		if err != nil {
+			return nil, nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, subdirectories, nil
+}
+
+// loadFile reads and compiles the rule in a YAML file.
+func loadFile(path, name string) (*Rule, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("prose rules: %w", err)
+	}
+
+	key := name + "\x00" + path
+	loadedMutex.Lock()
+	cached, exists := loadedFiles[key]
+	loadedMutex.Unlock()
+	if exists && cached.modTime == info.ModTime().UnixNano() && cached.size == info.Size() {
+		return cached.rule, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("prose rules: %w", err)
+	}
+
+	rule := &Rule{Name: name}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(rule); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("prose rule %s (%s): %w", name, path, err)
+	}
+	if err := rule.Compile(); err != nil {
+		return nil, fmt.Errorf("%w (%s)", err, path)
+	}
+
+	loadedMutex.Lock()
+	loadedFiles[key] = loadedFile{modTime: info.ModTime().UnixNano(), size: info.Size(), rule: rule}
+	loadedMutex.Unlock()
+	return rule, nil
+}
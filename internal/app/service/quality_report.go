@@ -0,0 +1,204 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+// QualityReportVersion is the format version written to quality reports.
+const QualityReportVersion = 1
+
+// QualityReport aggregates the violations found over a corpus of documents.
+// Its JSON form is stable so that a saved report can be compared with a
+// later one to show the trend.
+type QualityReport struct {
+	Version     int       `json:"version"`
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// Totals
+	Files               int `json:"files"`
+	FilesWithViolations int `json:"filesWithViolations"`
+	Violations          int `json:"violations"`
+	Errors              int `json:"errors"`
+	Warnings            int `json:"warnings"`
+	Fixable             int `json:"fixable"`
+	NonFixable          int `json:"nonFixable"`
+
+	// Breakdowns, most violations first
+	ByRule      []ReportCount `json:"byRule"`
+	ByTag       []ReportCount `json:"byTag"`
+	ByDirectory []ReportCount `json:"byDirectory"`
+	TopFiles    []ReportCount `json:"topFiles"`
+
+	// Change since a previous report; set by Compare
+	Trend *ReportTrend `json:"trend,omitempty"`
+}
+
+// ReportCount is the number of violations attributed to a rule, tag,
+// directory or file.
+type ReportCount struct {
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+	Fixable int    `json:"fixable"`
+	Change  *int   `json:"change,omitempty"` // Change since the compared report
+}
+
+// ReportTrend is the change in the totals since a previous report.
+type ReportTrend struct {
+	PreviousGeneratedAt time.Time `json:"previousGeneratedAt"`
+	Files               int       `json:"files"`
+	Violations          int       `json:"violations"`
+	Errors              int       `json:"errors"`
+	Warnings            int       `json:"warnings"`
+	Fixable             int       `json:"fixable"`
+}
+
+// ReportViolation is a violation as counted by a quality report.
+type ReportViolation struct {
+	File    string   // Slash-separated path of the document
+	Rule    string   // Primary rule name
+	Tags    []string // Tags of the rule
+	Warning bool     // Warning rather than error severity
+	Fixable bool     // Carries fix information
+}
+
+// NewQualityReport aggregates violations found in files, keeping the top
+// offending files up to topFiles (all files when topFiles <= 0).
+func NewQualityReport(files []string, violations []ReportViolation, topFiles int, generatedAt time.Time) *QualityReport {
+	report := &QualityReport{
+		Version:     QualityReportVersion,
+		GeneratedAt: generatedAt,
+		Files:       len(files),
+		Violations:  len(violations),
+	}
+
+	byRule := make(map[string]*ReportCount)
+	byTag := make(map[string]*ReportCount)
+	byDirectory := make(map[string]*ReportCount)
+	byFile := make(map[string]*ReportCount)
+	count := func(counts map[string]*ReportCount, name string, fixable bool) {
+		c, exists := counts[name]
+		if !exists {
+			c = &ReportCount{Name: name}
+			counts[name] = c
+		}
+		c.Count++
+		if fixable {
+			c.Fixable++
+		}
+	}
+
+	for _, violation := range violations {
+		if violation.Warning {
+			report.Warnings++
+		} else {
+			report.Errors++
+		}
+		if violation.Fixable {
+			report.Fixable++
+		} else {
+			report.NonFixable++
+		}
+
+		count(byRule, violation.Rule, violation.Fixable)
+		for _, tag := range violation.Tags {
+			count(byTag, tag, violation.Fixable)
+		}
+		count(byDirectory, path.Dir(violation.File), violation.Fixable)
+		count(byFile, violation.File, violation.Fixable)
+	}
+
+	report.FilesWithViolations = len(byFile)
+	report.ByRule = sortedReportCounts(byRule)
+	report.ByTag = sortedReportCounts(byTag)
+	report.ByDirectory = sortedReportCounts(byDirectory)
+	report.TopFiles = sortedReportCounts(byFile)
+	if topFiles > 0 && len(report.TopFiles) > topFiles {
+		report.TopFiles = report.TopFiles[:topFiles]
+	}
+
+	return report
+}
+
+// sortedReportCounts returns counts with the most violations first, then by
+// name.
+func sortedReportCounts(counts map[string]*ReportCount) []ReportCount {
+	sorted := make([]ReportCount, 0, len(counts))
+	for _, c := range counts {
+		sorted = append(sorted, *c)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// LoadQualityReport reads a report written as JSON.
+func LoadQualityReport(path string) (*QualityReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+
+	var report QualityReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid report %s: %w", path, err)
+	}
+	if report.Version != QualityReportVersion {
+		return nil, fmt.Errorf("unsupported report version %d in %s", report.Version, path)
+	}
+	return &report, nil
+}
+
+// Compare records the change since previous: the trend of the totals and the
+// change of every rule, tag and directory count. Rules, tags and directories
+// that no longer have violations are kept with a count of zero so that their
+// improvement is visible.
+func (r *QualityReport) Compare(previous *QualityReport) {
+	r.Trend = &ReportTrend{
+		PreviousGeneratedAt: previous.GeneratedAt,
+		Files:               r.Files - previous.Files,
+		Violations:          r.Violations - previous.Violations,
+		Errors:              r.Errors - previous.Errors,
+		Warnings:            r.Warnings - previous.Warnings,
+		Fixable:             r.Fixable - previous.Fixable,
+	}
+
+	r.ByRule = compareReportCounts(r.ByRule, previous.ByRule, true)
+	r.ByTag = compareReportCounts(r.ByTag, previous.ByTag, true)
+	r.ByDirectory = compareReportCounts(r.ByDirectory, previous.ByDirectory, true)
+	r.TopFiles = compareReportCounts(r.TopFiles, previous.TopFiles, false)
+}
+
+// compareReportCounts sets the change of each current count against the
+// previous ones, appending resolved entries when keepResolved is set.
+func compareReportCounts(current, previous []ReportCount, keepResolved bool) []ReportCount {
+	previousCounts := make(map[string]int, len(previous))
+	for _, c := range previous {
+		previousCounts[c.Name] = c.Count
+	}
+
+	seen := make(map[string]bool, len(current))
+	for i := range current {
+		change := current[i].Count - previousCounts[current[i].Name]
+		current[i].Change = &change
+		seen[current[i].Name] = true
+	}
+
+	if keepResolved {
+		for _, c := range previous {
+			if !seen[c.Name] && c.Count > 0 {
+				change := -c.Count
+				current = append(current, ReportCount{Name: c.Name, Change: &change})
+			}
+		}
+	}
+	return current
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQualityReport(t *testing.T) {
+	generatedAt := time.Date(2024, 5, 6, 7, 0, 0, 0, time.UTC)
+	files := []string{"README.md", "docs/a.md", "docs/b.md", "docs/api/c.md"}
+	violations := []ReportViolation{
+		{File: "docs/a.md", Rule: "MD013", Tags: []string{"line_length"}},
+		{File: "docs/a.md", Rule: "MD009", Tags: []string{"whitespace"}, Fixable: true},
+		{File: "docs/a.md", Rule: "MD009", Tags: []string{"whitespace"}, Fixable: true},
+		{File: "docs/api/c.md", Rule: "MD009", Tags: []string{"whitespace"}, Fixable: true, Warning: true},
+	}
+
+	report := NewQualityReport(files, violations, 1, generatedAt)
+
+	assert.Equal(t, QualityReportVersion, report.Version)
+	assert.Equal(t, 4, report.Files)
+	assert.Equal(t, 2, report.FilesWithViolations)
+	assert.Equal(t, 4, report.Violations)
+	assert.Equal(t, 3, report.Errors)
+	assert.Equal(t, 1, report.Warnings)
+	assert.Equal(t, 3, report.Fixable)
+	assert.Equal(t, 1, report.NonFixable)
+
+	assert.Equal(t, []ReportCount{{Name: "MD009", Count: 3, Fixable: 3}, {Name: "MD013", Count: 1}}, report.ByRule)
+	assert.Equal(t, []ReportCount{{Name: "whitespace", Count: 3, Fixable: 3}, {Name: "line_length", Count: 1}}, report.ByTag)
+	assert.Equal(t, []ReportCount{{Name: "docs", Count: 3, Fixable: 2}, {Name: "docs/api", Count: 1, Fixable: 1}}, report.ByDirectory)
+	assert.Equal(t, []ReportCount{{Name: "docs/a.md", Count: 3, Fixable: 2}}, report.TopFiles, "limited to the top file")
+}
+
+func TestQualityReport_Compare(t *testing.T) {
+	lastWeek := time.Date(2024, 5, 6, 7, 0, 0, 0, time.UTC)
+	previous := NewQualityReport([]string{"a.md", "b.md"}, []ReportViolation{
+		{File: "a.md", Rule: "MD013"},
+		{File: "a.md", Rule: "MD013"},
+		{File: "b.md", Rule: "MD041"},
+	}, 0, lastWeek)
+
+	// Round-trip through JSON as --compare does
+	path := filepath.Join(t.TempDir(), "report.json")
+	data, err := json.Marshal(previous)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	loaded, err := LoadQualityReport(path)
+	require.NoError(t, err)
+
+	current := NewQualityReport([]string{"a.md", "b.md", "c.md"}, []ReportViolation{
+		{File: "a.md", Rule: "MD013"},
+		{File: "c.md", Rule: "MD009", Fixable: true},
+	}, 0, lastWeek.Add(7*24*time.Hour))
+	current.Compare(loaded)
+
+	require.NotNil(t, current.Trend)
+	assert.Equal(t, lastWeek, current.Trend.PreviousGeneratedAt)
+	assert.Equal(t, 1, current.Trend.Files)
+	assert.Equal(t, -1, current.Trend.Violations)
+	assert.Equal(t, 1, current.Trend.Fixable)
+
+	changes := make(map[string]int)
+	for _, c := range current.ByRule {
+		require.NotNil(t, c.Change, c.Name)
+		changes[c.Name] = *c.Change
+	}
+	assert.Equal(t, map[string]int{"MD013": -1, "MD009": 1, "MD041": -1}, changes, "resolved rules are kept")
+	assert.Len(t, current.TopFiles, 2, "resolved files are not listed")
+}
+
+func TestLoadQualityReport_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadQualityReport(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "results.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"README.md": []}`), 0644))
+	_, err = LoadQualityReport(path)
+	assert.ErrorContains(t, err, "unsupported report version 0")
+}
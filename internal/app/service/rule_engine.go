@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gomdlint/gomdlint/internal/app/service/rules"
 	"github.com/gomdlint/gomdlint/internal/domain/entity"
@@ -23,6 +26,8 @@ type RuleEngine struct {
 	// Configuration
 	enabledRules map[string]bool
 	ruleConfigs  map[string]map[string]interface{}
+	optInRules   map[string]bool // Rules that "default" does not enable
+	warnings     []string        // Non-fatal problems found by ConfigureRules
 
 	// Performance
 	mutex sync.RWMutex
@@ -36,6 +41,7 @@ func NewRuleEngine() (*RuleEngine, error) {
 		tagIndex:     make(map[string][]*entity.Rule),
 		enabledRules: make(map[string]bool),
 		ruleConfigs:  make(map[string]map[string]interface{}),
+		optInRules:   make(map[string]bool),
 	}
 
 	// Register all built-in rules
@@ -107,6 +113,21 @@ func (re *RuleEngine) registerBuiltInRules() error {
 		rules.NewMD056Rule, // table-column-count
 		rules.NewMD058Rule, // blanks-around-tables
 		rules.NewMD059Rule, // descriptive-link-text
+		rules.NewMD060Rule, // table-column-style
+	}
+
+	// gomdlint-specific rules, which have no markdownlint counterpart and are
+	// only enabled when configured by name, alias or tag
+	optInRuleConstructors := []func() functional.Result[*entity.Rule]{
+		rules.NewGMD001Rule, // cross-file-links
+		rules.NewGMD002Rule, // table-format
+		rules.NewGMD003Rule, // prose
+		rules.NewGMD004Rule, // spelling
+		rules.NewGMD005Rule, // heading-case
+		rules.NewGMD006Rule, // fenced-code-syntax
+		rules.NewGMD007Rule, // fenced-code-language-style
+		rules.NewGMD008Rule, // front-matter-schema
+		rules.NewGMD009Rule, // front-matter-key-order
 	}
 
 	// Register core rules (enabled by default)
@@ -135,6 +156,20 @@ func (re *RuleEngine) registerBuiltInRules() error {
 		}
 	}
 
+	// Register opt-in rules (disabled unless configured explicitly)
+	for _, constructor := range optInRuleConstructors {
+		ruleResult := constructor()
+		if ruleResult.IsErr() {
+			return fmt.Errorf("failed to create rule: %w", ruleResult.Error())
+		}
+
+		rule := ruleResult.Unwrap()
+		if err := re.RegisterRuleDisabled(rule); err != nil {
+			return fmt.Errorf("failed to register opt-in rule %s: %w", rule.PrimaryName(), err)
+		}
+		re.optInRules[rule.PrimaryName()] = true
+	}
+
 	return nil
 }
 
@@ -210,6 +245,8 @@ func (re *RuleEngine) ConfigureRules(config map[string]interface{}) error {
 	re.mutex.Lock()
 	defer re.mutex.Unlock()
 
+	re.warnings = nil
+
 	// Handle "default" rule
 	defaultEnabled := true
 	if defaultValue, exists := config["default"]; exists {
@@ -218,16 +255,32 @@ func (re *RuleEngine) ConfigureRules(config map[string]interface{}) error {
 		}
 	}
 
-	// Set default state for all rules
+	// Set default state for all rules; opt-in rules stay disabled
 	for _, rule := range re.rules {
-		re.enabledRules[rule.PrimaryName()] = defaultEnabled
+		re.enabledRules[rule.PrimaryName()] = defaultEnabled && !re.optInRules[rule.PrimaryName()]
 	}
 
-	// Process individual rule configurations
-	for key, value := range config {
-		if key == "default" {
-			continue
+	// Process individual rule configurations in a stable order: tags first,
+	// then aliases, then primary rule names, so that the most specific key
+	// for a rule wins and errors are reported deterministically
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		if key != "default" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		rankI, rankJ := re.configKeyRank(keys[i]), re.configKeyRank(keys[j])
+		if rankI != rankJ {
+			return rankI < rankJ
 		}
+		return keys[i] < keys[j]
+	})
+
+	var errs []error
+	configured := make(map[string]map[string]bool) // Parameters set per rule
+	for _, key := range keys {
+		value := config[key]
 
 		// Find rules by name or tag
 		matchingRules := re.findRulesByNameOrTag(key)
@@ -235,6 +288,7 @@ func (re *RuleEngine) ConfigureRules(config map[string]interface{}) error {
 			// Warn about unknown rule/tag, but don't error
 			continue
 		}
+		isTag := len(matchingRules) > 1 || !matchingRules[0].HasName(key)
 
 		// Configure each matching rule
 		for _, rule := range matchingRules {
@@ -245,16 +299,124 @@ func (re *RuleEngine) ConfigureRules(config map[string]interface{}) error {
 				// Simple enable/disable
 				re.enabledRules[ruleName] = v
 			case map[string]interface{}:
-				// Rule configuration
-				re.enabledRules[ruleName] = true
-				re.ruleConfigs[ruleName] = v
+				// Rule configuration, validated against the declared parameters
+				enabled, options, err := splitRuleOptions(rule, v, isTag)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+
+				coerced, err := rule.CoerceConfig(options)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				// Unknown parameters, such as those of newer markdownlint
+				// versions, are ignored with a warning rather than rejected
+				for _, unknown := range rule.UnknownParameters(options) {
+					re.warnings = append(re.warnings, unknown.Error())
+				}
+
+				re.enabledRules[ruleName] = enabled
+				re.ruleConfigs[ruleName] = coerced
+				configured[ruleName] = make(map[string]bool, len(coerced))
+				for parameter := range coerced {
+					configured[ruleName][parameter] = true
+				}
 			default:
-				return fmt.Errorf("invalid configuration value for rule %s: %v", key, value)
+				errs = append(errs, &entity.RuleConfigError{Rule: key, Message: fmt.Sprintf("invalid configuration value: expected boolean or object, got %v", value)})
 			}
 		}
 	}
 
-	return nil
+	re.inheritParameters(configured)
+
+	return errors.Join(errs...)
+}
+
+// ConfigWarnings returns the non-fatal problems found by the last call to
+// ConfigureRules, such as parameters that a rule does not declare.
+func (re *RuleEngine) ConfigWarnings() []string {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+
+	return append([]string(nil), re.warnings...)
+}
+
+// UnusedSettings describes the configured settings that select files, such
+// as GMD008's schemas, that apply to none of files.
+func (re *RuleEngine) UnusedSettings(files []string) []string {
+	if len(files) == 0 || !re.IsRuleEnabled("GMD008") {
+		return nil
+	}
+	return rules.GMD008UnusedSchemas(re.GetRuleConfig("GMD008"), files)
+}
+
+// inheritParameters gives parameters that inherit from another rule, such
+// as the proper names shared with MD044, that rule's configured value unless
+// they are configured themselves.
+func (re *RuleEngine) inheritParameters(configured map[string]map[string]bool) {
+	for _, rule := range re.rules {
+		ruleName := rule.PrimaryName()
+		for _, parameter := range rule.Parameters() {
+			if parameter.Inherit == "" || configured[ruleName][parameter.Name] {
+				continue
+			}
+			source, exists := re.ruleIndex[strings.ToLower(parameter.Inherit)]
+			if !exists || !configured[source.PrimaryName()][parameter.Name] {
+				continue
+			}
+
+			inherited := make(map[string]interface{}, len(re.ruleConfigs[ruleName])+1)
+			for key, value := range re.ruleConfigs[ruleName] {
+				inherited[key] = value
+			}
+			inherited[parameter.Name] = re.ruleConfigs[source.PrimaryName()][parameter.Name]
+			re.ruleConfigs[ruleName] = inherited
+		}
+	}
+}
+
+// configKeyRank orders configuration keys for ConfigureRules: tags and
+// unknown keys (0), rule aliases (1) and primary rule names (2).
+func (re *RuleEngine) configKeyRank(key string) int {
+	rule, exists := re.ruleIndex[strings.ToLower(key)]
+	if !exists {
+		return 0
+	}
+	if strings.EqualFold(rule.PrimaryName(), key) {
+		return 2
+	}
+	return 1
+}
+
+// splitRuleOptions separates the "enabled" switch from the parameters of a
+// rule configuration object. Options given for a tag apply to every rule in
+// it, so parameters a particular rule does not declare are skipped there.
+func splitRuleOptions(rule *entity.Rule, config map[string]interface{}, isTag bool) (bool, map[string]interface{}, error) {
+	enabled := true
+	declared := make(map[string]bool)
+	for _, parameter := range rule.Parameters() {
+		declared[parameter.Name] = true
+	}
+
+	options := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		if key == "enabled" && !declared[key] {
+			flag, ok := value.(bool)
+			if !ok {
+				return false, nil, &entity.RuleConfigError{Rule: rule.PrimaryName(), Key: key, Message: fmt.Sprintf("expected boolean, got %v", value)}
+			}
+			enabled = flag
+			continue
+		}
+		if isTag && !declared[key] {
+			continue
+		}
+		options[key] = value
+	}
+
+	return enabled, options, nil
 }
 
 // findRulesByNameOrTag finds rules that match a name or tag (case-insensitive).
@@ -278,17 +440,31 @@ func (re *RuleEngine) findRulesByNameOrTag(nameOrTag string) []*entity.Rule {
 
 // LintDocument runs all enabled rules against a parsed document.
 func (re *RuleEngine) LintDocument(ctx context.Context, tokens []value.Token, lines []string, filename string) functional.Result[[]value.Violation] {
+	return re.LintDocumentTimed(ctx, tokens, lines, functional.None[map[string]interface{}](), nil, filename, nil)
+}
+
+// LintDocumentTimed runs all enabled rules against a parsed document like
+// LintDocument, passing the front matter removed before lines, parsed and as
+// lines, to the rules and reporting the time each rule took to onRule when
+// it is not nil.
+func (re *RuleEngine) LintDocumentTimed(ctx context.Context, tokens []value.Token, lines []string, frontMatter functional.Option[map[string]interface{}], frontMatterLines []string, filename string, onRule func(rule string, duration time.Duration)) functional.Result[[]value.Violation] {
 	re.mutex.RLock()
 	defer re.mutex.RUnlock()
 
 	allViolations := make([]value.Violation, 0)
 
+	// Rules ask about other rules while the read lock is held
+	ruleEnabled := func(name string) bool {
+		rule, exists := re.ruleIndex[strings.ToLower(name)]
+		return exists && re.enabledRules[rule.PrimaryName()]
+	}
+
 	// Run each enabled rule
 	for _, rule := range re.rules {
 		ruleName := rule.PrimaryName()
 
-		// Skip disabled rules
-		if !re.enabledRules[ruleName] {
+		// Skip disabled rules and rules that run over the whole document set
+		if !re.enabledRules[ruleName] || rule.IsDocumentSetRule() {
 			continue
 		}
 
@@ -301,20 +477,26 @@ func (re *RuleEngine) LintDocument(ctx context.Context, tokens []value.Token, li
 
 		// Prepare rule parameters
 		params := entity.RuleParams{
-			Lines:       lines,
-			Config:      re.ruleConfigs[ruleName],
-			Filename:    filename,
-			Tokens:      tokens,
-			FrontMatter: functional.None[map[string]interface{}](), // TODO: Extract from tokens
+			Lines:            lines,
+			Config:           re.ruleConfigs[ruleName],
+			Filename:         filename,
+			Tokens:           tokens,
+			FrontMatter:      frontMatter,
+			FrontMatterLines: frontMatterLines,
+			RuleEnabled:      ruleEnabled,
 		}
 
 		// Execute the rule
+		start := time.Now()
 		violationResult := rule.Execute(ctx, params)
+		if onRule != nil {
+			onRule(ruleName, time.Since(start))
+		}
 		if violationResult.IsErr() {
 			// Handle rule execution errors
 			errorViolation := value.NewViolation(
 				rule.Names(),
-				"Rule execution error",
+				value.RuleFailureDescription,
 				rule.Information(),
 				1,
 			)
@@ -330,11 +512,7 @@ func (re *RuleEngine) LintDocument(ctx context.Context, tokens []value.Token, li
 		for i := range violations {
 			// Ensure rule information is set
 			if violations[i].RuleInformation == nil {
-				violations[i] = *violations[i].WithErrorContext("").WithFixInfo(value.FixInfo{})
-				// Copy the struct and set the information
-				updatedViolation := violations[i]
-				updatedViolation.RuleInformation = rule.Information()
-				violations[i] = updatedViolation
+				violations[i].RuleInformation = rule.Information()
 			}
 		}
 
@@ -344,6 +522,84 @@ func (re *RuleEngine) LintDocument(ctx context.Context, tokens []value.Token, li
 	return functional.Ok(allViolations)
 }
 
+// HasDocumentSetRules reports whether any enabled rule runs over the whole
+// document set, in which case callers must keep the parsed documents for
+// LintDocumentSet.
+func (re *RuleEngine) HasDocumentSetRules() bool {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+
+	for _, rule := range re.rules {
+		if re.enabledRules[rule.PrimaryName()] && rule.IsDocumentSetRule() {
+			return true
+		}
+	}
+	return false
+}
+
+// LintDocumentSet runs the enabled document set rules once over all the
+// documents, after per-document linting. Violations are keyed by filename.
+// The time each rule took is reported to onRule when it is not nil.
+func (re *RuleEngine) LintDocumentSet(ctx context.Context, documents []entity.Document, onRule func(rule string, duration time.Duration)) functional.Result[map[string][]value.Violation] {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+
+	allViolations := make(map[string][]value.Violation)
+	if len(documents) == 0 {
+		return functional.Ok(allViolations)
+	}
+
+	for _, rule := range re.rules {
+		ruleName := rule.PrimaryName()
+		if !re.enabledRules[ruleName] || !rule.IsDocumentSetRule() {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return functional.Err[map[string][]value.Violation](ctx.Err())
+		default:
+		}
+
+		params := entity.DocumentSetParams{
+			Documents: documents,
+			Config:    re.ruleConfigs[ruleName],
+		}
+
+		start := time.Now()
+		violationResult := rule.ExecuteDocumentSet(ctx, params)
+		if onRule != nil {
+			onRule(ruleName, time.Since(start))
+		}
+		if violationResult.IsErr() {
+			// Report the failure once, against the first document
+			errorViolation := value.NewViolation(
+				rule.Names(),
+				value.RuleFailureDescription,
+				rule.Information(),
+				1,
+			)
+			errorViolation = errorViolation.WithErrorDetail(violationResult.Error().Error())
+			errorViolation = errorViolation.WithSeverity(value.SeverityError)
+
+			filename := documents[0].Filename
+			allViolations[filename] = append(allViolations[filename], *errorViolation)
+			continue
+		}
+
+		for filename, violations := range violationResult.Unwrap() {
+			for i := range violations {
+				if violations[i].RuleInformation == nil {
+					violations[i].RuleInformation = rule.Information()
+				}
+			}
+			allViolations[filename] = append(allViolations[filename], violations...)
+		}
+	}
+
+	return functional.Ok(allViolations)
+}
+
 // GetRuleByName returns a rule by its name or alias (case-insensitive).
 func (re *RuleEngine) GetRuleByName(name string) functional.Option[*entity.Rule] {
 	re.mutex.RLock()
@@ -142,9 +142,10 @@ func TestNewRuleEngine(t *testing.T) {
 
 func TestRuleEngine_ConfigureRules_Scenarios(t *testing.T) {
 	scenarios := []struct {
-		name        string
-		config      map[string]interface{}
-		expectError bool
+		name          string
+		config        map[string]interface{}
+		expectError   bool
+		errorContains []string
 	}{
 		{
 			name:        "empty config",
@@ -206,6 +207,41 @@ func TestRuleEngine_ConfigureRules_Scenarios(t *testing.T) {
 			config:      nil,
 			expectError: false,
 		},
+		{
+			name: "wrong parameter type",
+			config: map[string]interface{}{
+				"MD013": map[string]interface{}{"line_length": "long"},
+			},
+			expectError:   true,
+			errorContains: []string{`rule MD013: parameter "line_length": expected integer, got string`},
+		},
+		{
+			name: "value outside the allowed set",
+			config: map[string]interface{}{
+				"MD003": map[string]interface{}{"style": "fancy"},
+			},
+			expectError:   true,
+			errorContains: []string{`rule MD003: parameter "style": invalid value "fancy"`},
+		},
+		{
+			name: "all problems are reported",
+			config: map[string]interface{}{
+				"MD007": map[string]interface{}{"indent": 2.5},
+				"MD010": map[string]interface{}{"ignore_code_languages": []interface{}{"go", 1}},
+			},
+			expectError: true,
+			errorContains: []string{
+				`rule MD007: parameter "indent": expected integer, got number`,
+				`rule MD010: parameter "ignore_code_languages": expected a list of strings, item 1 is integer`,
+			},
+		},
+		{
+			name: "tag options apply to rules declaring them",
+			config: map[string]interface{}{
+				"whitespace": map[string]interface{}{"spaces_per_tab": 2},
+			},
+			expectError: false,
+		},
 	}
 
 	for _, scenario := range scenarios {
@@ -216,6 +252,9 @@ func TestRuleEngine_ConfigureRules_Scenarios(t *testing.T) {
 
 			if scenario.expectError {
 				require.Error(t, err)
+				for _, expected := range scenario.errorContains {
+					assert.Contains(t, err.Error(), expected)
+				}
 			} else {
 				require.NoError(t, err)
 			}
@@ -223,6 +262,128 @@ func TestRuleEngine_ConfigureRules_Scenarios(t *testing.T) {
 	}
 }
 
+func TestRuleEngine_ConfigureRules_CoercesValues(t *testing.T) {
+	engine := createTestRuleEngine(t)
+
+	err := engine.ConfigureRules(map[string]interface{}{
+		"MD013": map[string]interface{}{"line_length": float64(120), "enabled": false},
+		"MD033": map[string]interface{}{"allowed_elements": []interface{}{"br", "kbd"}},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, engine.IsRuleEnabled("MD013"))
+	assert.Equal(t, 120, engine.GetRuleConfig("MD013")["line_length"])
+	assert.True(t, engine.IsRuleEnabled("MD033"))
+	assert.Equal(t, []string{"br", "kbd"}, engine.GetRuleConfig("MD033")["allowed_elements"])
+}
+
+func TestRuleEngine_ConfigureRules_WarnsAboutUnknownParameters(t *testing.T) {
+	engine := createTestRuleEngine(t)
+
+	err := engine.ConfigureRules(map[string]interface{}{
+		"line-length": map[string]interface{}{"line_lenght": 100, "strict": true},
+		"MD051":       map[string]interface{}{"ignore_case": true},
+	})
+	require.NoError(t, err, "unknown parameters are not fatal")
+
+	assert.Equal(t, []string{`rule MD013: parameter "line_lenght": unknown parameter (did you mean "line_length"?)`}, engine.ConfigWarnings())
+	assert.Equal(t, true, engine.GetRuleConfig("MD013")["strict"])
+	assert.NotContains(t, engine.GetRuleConfig("MD013"), "line_lenght")
+
+	require.NoError(t, engine.ConfigureRules(map[string]interface{}{}))
+	assert.Empty(t, engine.ConfigWarnings(), "warnings are reset on reconfiguration")
+}
+
+func TestRuleEngine_UnusedSettings(t *testing.T) {
+	engine := createTestRuleEngine(t)
+	schemas := []interface{}{map[string]interface{}{"files": "posts/**", "schema": "post.json"}}
+
+	require.NoError(t, engine.ConfigureRules(map[string]interface{}{"GMD008": map[string]interface{}{"schemas": schemas}}))
+	assert.Equal(t, []string{"GMD008 schemas[0] (files posts/**) matches no linted file"}, engine.UnusedSettings([]string{"README.md"}))
+	assert.Empty(t, engine.UnusedSettings([]string{"posts/a.md"}))
+	assert.Empty(t, engine.UnusedSettings(nil))
+
+	require.NoError(t, engine.ConfigureRules(map[string]interface{}{"GMD008": map[string]interface{}{"enabled": false, "schemas": schemas}}))
+	assert.Empty(t, engine.UnusedSettings([]string{"README.md"}), "disabled rules have no unused settings")
+}
+
+func TestRuleEngine_ConfigureRules_SpecificKeysWin(t *testing.T) {
+	engine := createTestRuleEngine(t)
+
+	// Tags apply first, then aliases, then primary names
+	err := engine.ConfigureRules(map[string]interface{}{
+		"MD003":         map[string]interface{}{"enabled": true},
+		"headings":      false,
+		"heading-style": false,
+		"line-length":   false,
+		"MD013":         true,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, engine.IsRuleEnabled("MD003"))
+	assert.False(t, engine.IsRuleEnabled("MD001"))
+	assert.True(t, engine.IsRuleEnabled("MD013"))
+}
+
+func TestRuleEngine_ConfigureRules_InheritsParameters(t *testing.T) {
+	engine := createTestRuleEngine(t)
+
+	err := engine.ConfigureRules(map[string]interface{}{
+		"MD044":        map[string]interface{}{"names": []interface{}{"GitHub"}},
+		"heading-case": map[string]interface{}{"style": "title"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GitHub"}, engine.GetRuleConfig("GMD005")["names"], "GMD005 shares MD044's names")
+	assert.Equal(t, "title", engine.GetRuleConfig("GMD005")["style"])
+
+	err = engine.ConfigureRules(map[string]interface{}{
+		"MD044":  map[string]interface{}{"names": []interface{}{"GitHub"}},
+		"GMD005": map[string]interface{}{"names": []interface{}{"gomdlint"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gomdlint"}, engine.GetRuleConfig("GMD005")["names"], "configured parameters are not inherited")
+}
+
+func TestRuleEngine_ConfigureRules_InheritsLineLength(t *testing.T) {
+	engine := createTestRuleEngine(t)
+
+	// Only MD013 sets line_length, so GMD002 compacts tables wider than it
+	err := engine.ConfigureRules(map[string]interface{}{
+		"MD013":  map[string]interface{}{"line_length": 20},
+		"GMD002": map[string]interface{}{"compact_wide_tables": true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 20, engine.GetRuleConfig("GMD002")["line_length"])
+
+	lines := []string{"| Name | Description |", "| :--- | --- |", "| a | A rather long description |"}
+	result := engine.LintDocument(context.Background(), nil, lines, "test.md")
+	require.True(t, result.IsOk())
+	for _, violation := range result.Unwrap() {
+		assert.NotContains(t, violation.RuleNames, "GMD002", "compact table within MD013's line_length")
+	}
+}
+
+func TestRuleEngine_ConfigureRules_OptInRules(t *testing.T) {
+	engine := createTestRuleEngine(t)
+	assert.False(t, engine.IsRuleEnabled("GMD001"))
+	assert.False(t, engine.HasDocumentSetRules())
+
+	require.NoError(t, engine.ConfigureRules(map[string]interface{}{"default": true, "MD013": false}))
+	assert.True(t, engine.IsRuleEnabled("MD051"), "optional markdownlint rules follow default")
+	assert.False(t, engine.IsRuleEnabled("GMD001"), "gomdlint rules are not enabled by default")
+
+	require.NoError(t, engine.ConfigureRules(map[string]interface{}{"cross-file-links": true}))
+	assert.True(t, engine.IsRuleEnabled("GMD001"))
+	assert.True(t, engine.HasDocumentSetRules())
+
+	// Document set rules do not run per document
+	result := engine.LintDocument(context.Background(), nil, []string{"[missing](missing.md)"}, "test.md")
+	require.True(t, result.IsOk())
+	for _, violation := range result.Unwrap() {
+		assert.NotContains(t, violation.RuleNames, "GMD001")
+	}
+}
+
 func TestRuleEngine_LintDocument_Scenarios(t *testing.T) {
 	scenarios := []ruleEngineTestScenario{
 		{
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/shared/slug"
+)
+
+var (
+	anchorATXRegex        = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)(?:\s+#+)?\s*$`)
+	anchorSetextRegex     = regexp.MustCompile(`^\s{0,3}(=+|-+)\s*$`)
+	anchorHeadingIDRegex  = regexp.MustCompile(`\s*\{#([^}\s]+)[^}]*\}\s*$`)
+	anchorHTMLAnchorRegex = regexp.MustCompile(`<[a-zA-Z][^>]*?\s(?:id|name)\s*=\s*["']([^"']+)["']`)
+)
+
+// slugStyleParameter declares the slug_style parameter shared by the rules
+// that check link fragments against heading anchors.
+func slugStyleParameter() entity.RuleParameter {
+	return entity.RuleParameter{
+		Name:        "slug_style",
+		Type:        entity.ParameterTypeString,
+		Default:     slug.StyleGitHub,
+		Allowed:     slug.Styles(),
+		Description: "How the renderer turns headings into fragments (github, gitlab, hugo, python-markdown or mkdocs)",
+	}
+}
+
+// collectAnchors returns the fragment identifiers a document defines:
+// heading slugs generated by the named slug style, explicit {#id} heading
+// attributes and HTML id and name attributes outside fenced code.
+func collectAnchors(lines []string, slugStyle string) (map[string]bool, error) {
+	slugger, err := slug.New(slugStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	anchors := make(map[string]bool)
+	addHeading := func(text string) {
+		if match := anchorHeadingIDRegex.FindStringSubmatch(text); match != nil {
+			anchors[match[1]] = true
+			return
+		}
+		if id := slugger.Slug(slug.PlainText(text)); id != "" {
+			anchors[id] = true
+		}
+	}
+
+	inCode := fencedCodeLines(lines)
+	for i, line := range lines {
+		if inCode[i] {
+			continue
+		}
+
+		if match := anchorATXRegex.FindStringSubmatch(line); match != nil {
+			addHeading(match[1])
+		} else if anchorSetextRegex.MatchString(line) && i > 0 && !inCode[i-1] {
+			previous := strings.TrimSpace(lines[i-1])
+			if previous != "" && !anchorATXRegex.MatchString(lines[i-1]) {
+				addHeading(previous)
+			}
+		}
+
+		for _, match := range anchorHTMLAnchorRegex.FindAllStringSubmatch(line, -1) {
+			anchors[match[1]] = true
+		}
+	}
+
+	return anchors, nil
+}
+
+// fencedCodeLines reports for each line whether it belongs to a fenced code
+// block, fences included.
+func fencedCodeLines(lines []string) []bool {
+	inCode := make([]bool, len(lines))
+	fenceMarker := ""
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fenceMarker != "" {
+			inCode[i] = true
+			if strings.HasPrefix(trimmed, fenceMarker) && strings.TrimLeft(trimmed, fenceMarker[:1]) == "" {
+				fenceMarker = ""
+			}
+			continue
+		}
+
+		for _, char := range []string{"`", "~"} {
+			if strings.HasPrefix(trimmed, strings.Repeat(char, 3)) {
+				fenceMarker = trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, char))]
+				inCode[i] = true
+				break
+			}
+		}
+	}
+
+	return inCode
+}
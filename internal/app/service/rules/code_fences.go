@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"strings"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint/helpers"
+)
+
+// fencedCodeBlock is a fenced code block found by fencedCodeBlocks.
+type fencedCodeBlock struct {
+	open     int    // Index of the opening fence line
+	close    int    // Index of the closing fence line, or -1 when the block is not closed
+	indent   int    // Indentation of the opening fence, removed from content lines
+	info     string // Info string of the opening fence
+	language string // First word of the info string
+}
+
+// languageColumn returns the 1-based column of the block's language on its
+// opening fence line, or 0 without a language.
+func (b fencedCodeBlock) languageColumn(lines []string) int {
+	if b.language == "" {
+		return 0
+	}
+	line := lines[b.open]
+	fence := strings.TrimLeft(line, " \t")
+	afterFence := strings.TrimLeft(fence, fence[:1])
+	return len(line) - len(strings.TrimLeft(afterFence, " \t")) + 1
+}
+
+// fencedCodeBlocks returns the fenced code blocks of a document, including
+// those indented in list items.
+func fencedCodeBlocks(lines []string) []fencedCodeBlock {
+	var blocks []fencedCodeBlock
+	var current *fencedCodeBlock
+	marker := ""
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if current != nil {
+			if strings.HasPrefix(trimmed, marker) && strings.TrimLeft(trimmed, marker[:1]) == "" {
+				current.close = i
+				blocks = append(blocks, *current)
+				current = nil
+			}
+			continue
+		}
+
+		for _, char := range []string{"`", "~"} {
+			if !strings.HasPrefix(trimmed, strings.Repeat(char, 3)) {
+				continue
+			}
+			marker = trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, char))]
+			info := strings.TrimSpace(trimmed[len(marker):])
+			if char == "`" && strings.Contains(info, "`") {
+				break // Inline code, not a fence
+			}
+			language, _ := helpers.GetCodeFenceInfo(line)
+			current = &fencedCodeBlock{
+				open:     i,
+				close:    -1,
+				indent:   len(line) - len(strings.TrimLeft(line, " ")),
+				info:     info,
+				language: language,
+			}
+			break
+		}
+	}
+
+	if current != nil {
+		blocks = append(blocks, *current)
+	}
+	return blocks
+}
+
+// content returns the content lines of the block, without up to the
+// fence's indentation, and the number of characters removed from each.
+func (b fencedCodeBlock) content(lines []string) (content []string, removed []int) {
+	end := b.close
+	if end < 0 {
+		end = len(lines)
+	}
+	for _, line := range lines[b.open+1 : end] {
+		n := min(b.indent, len(line)-len(strings.TrimLeft(line, " ")))
+		content = append(content, line[n:])
+		removed = append(removed, n)
+	}
+	return content, removed
+}
@@ -0,0 +1,335 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+)
+
+// frontMatterLines returns the lines between the front matter delimiters
+// at the start of a document (--- for YAML, +++ for TOML), the delimiter and
+// the index of the closing delimiter, or ok false without front matter.
+func frontMatterLines(lines []string) (content []string, delimiter string, end int, ok bool) {
+	if len(lines) == 0 {
+		return nil, "", 0, false
+	}
+	delimiter = strings.TrimRight(lines[0], " \t")
+	if delimiter != "---" && delimiter != "+++" {
+		return nil, "", 0, false
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], " \t") == delimiter {
+			return lines[1:i], delimiter, i, true
+		}
+	}
+	return nil, "", 0, false
+}
+
+// frontMatterData returns the parsed front matter of a document, from the
+// parser when it provides it and otherwise from the document's lines. It is
+// nil without front matter or when the front matter is invalid.
+func frontMatterData(params entity.RuleParams) map[string]interface{} {
+	if params.FrontMatter.IsSome() {
+		return params.FrontMatter.Unwrap()
+	}
+
+	content, delimiter, _, ok := frontMatterLines(params.Lines)
+	if !ok {
+		return nil
+	}
+
+	data := make(map[string]interface{})
+	source := strings.Join(content, "\n")
+	var err error
+	if delimiter == "+++" {
+		_, err = toml.Decode(source, &data)
+	} else {
+		err = yaml.Unmarshal([]byte(source), &data)
+	}
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// frontMatterSource is the front matter of a document as written, for rules
+// reporting problems at its lines.
+type frontMatterSource struct {
+	lines  []string // Front matter lines, delimiters included
+	first  int      // Line number of lines[0] in the numbering of RuleParams.Lines
+	format string   // "yaml", "toml" or "json"
+}
+
+// frontMatterSourceOf returns the front matter removed before the lines of a
+// document, or at their start when it was not removed, or ok false without
+// front matter.
+func frontMatterSourceOf(params entity.RuleParams) (source frontMatterSource, ok bool) {
+	if len(params.FrontMatterLines) > 0 {
+		source = frontMatterSource{lines: params.FrontMatterLines, first: 1 - len(params.FrontMatterLines)}
+	} else if _, _, end, found := frontMatterLines(params.Lines); found {
+		source = frontMatterSource{lines: params.Lines[:end+1], first: 1}
+	} else {
+		return frontMatterSource{}, false
+	}
+
+	switch strings.TrimSpace(source.lines[0]) {
+	case "---":
+		source.format = "yaml"
+	case "+++":
+		source.format = "toml"
+	case "{":
+		source.format = "json"
+	default:
+		return frontMatterSource{}, false
+	}
+	return source, true
+}
+
+// content returns the front matter without its delimiters; JSON front
+// matter has none.
+func (s frontMatterSource) content() (content []string, offset int) {
+	if s.format == "json" {
+		return s.lines, 0
+	}
+	delimiter := strings.TrimSpace(s.lines[0])
+	end := len(s.lines) - 1
+	for end > 0 {
+		closing := strings.TrimSpace(s.lines[end])
+		if closing == delimiter || (delimiter == "+++" && closing == "...") {
+			break
+		}
+		end--
+	}
+	if end == 0 {
+		end = len(s.lines)
+	}
+	return s.lines[1:end], 1
+}
+
+// lineNumber returns the line number of a front matter line, by index.
+func (s frontMatterSource) lineNumber(index int) int {
+	return s.first + index
+}
+
+// decode parses the front matter, keeping dates and times as written, and
+// returns the index of the line of each key, by its path joined with
+// frontMatterPathSeparator.
+func (s frontMatterSource) decode() (data map[string]interface{}, keyLines map[string]int, err error) {
+	content, offset := s.content()
+	source := strings.Join(content, "\n")
+	keyLines = make(map[string]int)
+
+	switch s.format {
+	case "toml":
+		data = make(map[string]interface{})
+		if _, err = toml.Decode(source, &data); err != nil {
+			return nil, nil, err
+		}
+		tomlKeyLines(content, offset, keyLines)
+	case "json":
+		data = make(map[string]interface{})
+		if err = json.Unmarshal([]byte(source), &data); err != nil {
+			return nil, nil, err
+		}
+		err = jsonKeyLines(source, keyLines)
+	default:
+		var document yaml.Node
+		if err = yaml.Unmarshal([]byte(source), &document); err != nil {
+			return nil, nil, err
+		}
+		var value interface{}
+		if len(document.Content) > 0 {
+			value, err = yamlValue(document.Content[0], nil, offset-1, keyLines)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if value == nil {
+			value = map[string]interface{}{}
+		}
+		var isMap bool
+		if data, isMap = value.(map[string]interface{}); !isMap {
+			return nil, nil, fmt.Errorf("front matter must be a mapping")
+		}
+	}
+	return data, keyLines, err
+}
+
+// frontMatterPathSeparator joins the keys and indices of front matter paths.
+const frontMatterPathSeparator = "\x00"
+
+// keyLine returns the index of the line of the deepest key along a path
+// found in keyLines, or of the opening delimiter.
+func keyLine(keyLines map[string]int, path []string) int {
+	for n := len(path); n > 0; n-- {
+		if line, exists := keyLines[strings.Join(path[:n], frontMatterPathSeparator)]; exists {
+			return line
+		}
+	}
+	return 0
+}
+
+// yamlValue decodes a YAML node like yaml.Unmarshal into interface{}, but
+// keeps timestamps as strings, recording the line index of each key and
+// sequence item. Line indices are node lines plus offset.
+func yamlValue(node *yaml.Node, path []string, offset int, keyLines map[string]int) (interface{}, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlValue(node.Alias, path, offset, make(map[string]int))
+	case yaml.MappingNode:
+		result := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, item := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				merged, err := yamlValue(item, path, offset, make(map[string]int))
+				if err != nil {
+					return nil, err
+				}
+				if merged, ok := merged.(map[string]interface{}); ok {
+					for k, v := range merged {
+						if _, exists := result[k]; !exists {
+							result[k] = v
+						}
+					}
+				}
+				continue
+			}
+			itemPath := append(path[:len(path):len(path)], key.Value)
+			keyLines[strings.Join(itemPath, frontMatterPathSeparator)] = key.Line + offset
+			value, err := yamlValue(item, itemPath, offset, keyLines)
+			if err != nil {
+				return nil, err
+			}
+			result[key.Value] = value
+		}
+		return result, nil
+	case yaml.SequenceNode:
+		result := make([]interface{}, len(node.Content))
+		for i, item := range node.Content {
+			itemPath := append(path[:len(path):len(path)], strconv.Itoa(i))
+			keyLines[strings.Join(itemPath, frontMatterPathSeparator)] = item.Line + offset
+			value, err := yamlValue(item, itemPath, offset, keyLines)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!timestamp" {
+			return node.Value, nil
+		}
+	}
+
+	var value interface{}
+	err := node.Decode(&value)
+	return value, err
+}
+
+// tomlKeyRegex matches a TOML key/value line and tomlTableRegex a table
+// header, capturing the (possibly dotted) key or table name.
+var (
+	tomlKeyRegex   = regexp.MustCompile(`^\s*((?:[A-Za-z0-9_-]+|"[^"]*"|'[^']*')(?:\s*\.\s*(?:[A-Za-z0-9_-]+|"[^"]*"|'[^']*'))*)\s*=`)
+	tomlTableRegex = regexp.MustCompile(`^\s*\[\[?\s*([^\]]+?)\s*\]\]?\s*(?:#.*)?$`)
+)
+
+// tomlKeyLines records the line index of each key of TOML front matter.
+// Keys in arrays of tables are recorded without their index, and the
+// parents of dotted table names at their first table.
+func tomlKeyLines(content []string, offset int, keyLines map[string]int) {
+	var table []string
+	for i, line := range content {
+		if match := tomlTableRegex.FindStringSubmatch(line); match != nil {
+			table = tomlKeyPath(match[1])
+			for n := 1; n <= len(table); n++ {
+				if _, exists := keyLines[strings.Join(table[:n], frontMatterPathSeparator)]; !exists || n == len(table) {
+					keyLines[strings.Join(table[:n], frontMatterPathSeparator)] = i + offset
+				}
+			}
+			continue
+		}
+		if match := tomlKeyRegex.FindStringSubmatch(line); match != nil {
+			path := append(table[:len(table):len(table)], tomlKeyPath(match[1])...)
+			keyLines[strings.Join(path, frontMatterPathSeparator)] = i + offset
+		}
+	}
+}
+
+// tomlKeyPath splits a dotted TOML key into its parts, unquoted.
+func tomlKeyPath(key string) []string {
+	var parts []string
+	for _, part := range strings.Split(key, ".") {
+		parts = append(parts, strings.Trim(strings.TrimSpace(part), `"'`))
+	}
+	return parts
+}
+
+// jsonKeyLines records the line index of each key and array item of JSON
+// front matter.
+func jsonKeyLines(source string, keyLines map[string]int) error {
+	type container struct {
+		object bool
+		key    string // Last key read, in objects
+		index  int    // Next item index, in arrays
+		path   []string
+	}
+	var stack []*container
+	decoder := json.NewDecoder(strings.NewReader(source))
+	lineAt := func(offset int64) int {
+		return strings.Count(source[:offset], "\n")
+	}
+
+	expectingKey := false
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// The offset before a token may precede separators and space
+		for int(start) < len(source) && strings.ContainsRune(" \t\r\n,:", rune(source[start])) {
+			start++
+		}
+
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			expectingKey = len(stack) > 0 && stack[len(stack)-1].object
+			continue
+		}
+
+		var path []string
+		if len(stack) > 0 {
+			parent := stack[len(stack)-1]
+			if parent.object && expectingKey {
+				parent.key = token.(string)
+				keyLines[strings.Join(append(parent.path[:len(parent.path):len(parent.path)], parent.key), frontMatterPathSeparator)] = lineAt(start)
+				expectingKey = false
+				continue
+			}
+			if parent.object {
+				path = append(parent.path[:len(parent.path):len(parent.path)], parent.key)
+				expectingKey = true
+			} else {
+				path = append(parent.path[:len(parent.path):len(parent.path)], strconv.Itoa(parent.index))
+				keyLines[strings.Join(path, frontMatterPathSeparator)] = lineAt(start)
+				parent.index++
+			}
+		}
+
+		if delim, ok := token.(json.Delim); ok {
+			stack = append(stack, &container{object: delim == '{', path: path})
+			expectingKey = delim == '{'
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package rules
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+	"github.com/gomdlint/gomdlint/internal/shared/slug"
+)
+
+var (
+	gmd001InlineLinkRegex = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)]*)\)`)
+	gmd001DefinitionRegex = regexp.MustCompile(`^\s{0,3}\[([^\]]+)\]:\s*(\S+)`)
+	gmd001CodeSpanRegex   = regexp.MustCompile("`+[^`]*`+")
+	gmd001SchemeRegex     = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.\-]*:`)
+)
+
+// GMD001 - Links to other files should resolve
+func NewGMD001Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/gmd001.md")
+
+	return entity.NewDocumentSetRule(
+		[]string{"GMD001", "cross-file-links"},
+		"Links to other files should resolve",
+		[]string{"links"},
+		infoURL,
+		[]entity.RuleParameter{
+			{
+				Name:        "fragments",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check fragments of links to other linted documents",
+			},
+			{
+				Name:        "images",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check image sources",
+			},
+			{
+				Name:        "case_sensitive",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Report paths whose case differs from the file system",
+			},
+			slugStyleParameter(),
+			{
+				Name:        "ignored_paths",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "Glob patterns of link paths that are not checked",
+			},
+		},
+		gmd001Function,
+	)
+}
+
+func gmd001Function(ctx context.Context, params entity.DocumentSetParams) functional.Result[map[string][]value.Violation] {
+	checkFragments := getBoolConfig(params.Config, "fragments", true)
+	checkImages := getBoolConfig(params.Config, "images", true)
+	caseSensitive := getBoolConfig(params.Config, "case_sensitive", true)
+	ignoredPaths := getStringSliceConfig(params.Config, "ignored_paths")
+	slugStyle := getStringConfig(params.Config, "slug_style", slug.StyleGitHub)
+
+	// Index the anchors of every linted document by absolute path
+	anchors := make(map[string]map[string]bool, len(params.Documents))
+	for _, document := range params.Documents {
+		absPath, err := filepath.Abs(document.Filename)
+		if err != nil {
+			continue
+		}
+		documentAnchors, err := collectAnchors(document.Lines, slugStyle)
+		if err != nil {
+			return functional.Err[map[string][]value.Violation](err)
+		}
+		anchors[absPath] = documentAnchors
+	}
+
+	files := newDirectoryCache()
+	violations := make(map[string][]value.Violation)
+
+	for _, document := range params.Documents {
+		select {
+		case <-ctx.Done():
+			return functional.Err[map[string][]value.Violation](ctx.Err())
+		default:
+		}
+
+		sourceDir, err := filepath.Abs(filepath.Dir(document.Filename))
+		if err != nil {
+			continue
+		}
+
+		for _, link := range collectFileLinks(document.Lines) {
+			if link.image && !checkImages {
+				continue
+			}
+
+			target, fragment, ok := splitLinkDestination(link.destination)
+			if !ok || isIgnoredLinkPath(target, ignoredPaths) {
+				continue
+			}
+
+			newViolation := func(detail string) value.Violation {
+				violation := value.NewViolation(
+					[]string{"GMD001", "cross-file-links"},
+					"Links to other files should resolve",
+					nil,
+					link.line,
+				)
+				violation = violation.WithErrorDetail(detail)
+				violation = violation.WithErrorContext(link.text)
+				violation = violation.WithColumn(link.column)
+				violation = violation.WithLength(len(link.text))
+				return *violation
+			}
+
+			resolved, actual, exists := files.resolve(sourceDir, target)
+			if !exists {
+				violations[document.Filename] = append(violations[document.Filename],
+					newViolation("Link target does not exist: "+target))
+				continue
+			}
+			if caseSensitive && actual != target {
+				violations[document.Filename] = append(violations[document.Filename],
+					newViolation("Link path case does not match the file system: "+target+" (found "+actual+")"))
+			}
+
+			// Fragments can only be checked in documents that were linted
+			targetAnchors, linted := anchors[resolved]
+			if checkFragments && fragment != "" && linted && !targetAnchors[fragment] {
+				violations[document.Filename] = append(violations[document.Filename],
+					newViolation("Link fragment does not exist in "+target+": #"+fragment))
+			}
+		}
+	}
+
+	return functional.Ok(violations)
+}
+
+// fileLink is a link or image destination found in a document.
+type fileLink struct {
+	destination string
+	text        string // Full link or definition text for context
+	line        int
+	column      int
+	image       bool
+}
+
+// collectFileLinks returns the inline links, images and reference
+// definitions outside code.
+func collectFileLinks(lines []string) []fileLink {
+	var links []fileLink
+	inCode := fencedCodeLines(lines)
+
+	for i, line := range lines {
+		if inCode[i] {
+			continue
+		}
+
+		// Blank out code spans so their contents are not taken as links,
+		// keeping columns intact
+		masked := gmd001CodeSpanRegex.ReplaceAllStringFunc(line, func(span string) string {
+			return strings.Repeat(" ", len(span))
+		})
+
+		if match := gmd001DefinitionRegex.FindStringSubmatchIndex(masked); match != nil {
+			links = append(links, fileLink{
+				destination: masked[match[4]:match[5]],
+				text:        strings.TrimSpace(line[match[0]:match[1]]),
+				line:        i + 1,
+				column:      match[0] + 1,
+			})
+			continue
+		}
+
+		for _, match := range gmd001InlineLinkRegex.FindAllStringSubmatchIndex(masked, -1) {
+			links = append(links, fileLink{
+				destination: masked[match[4]:match[5]],
+				text:        line[match[0]:match[1]],
+				line:        i + 1,
+				column:      match[0] + 1,
+				image:       masked[match[0]] == '!',
+			})
+		}
+	}
+
+	return links
+}
+
+// splitLinkDestination splits a link destination into its unescaped file
+// path and fragment. It returns false for destinations that do not refer to
+// a file relative to the document: URLs with a scheme, site-root and
+// protocol-relative paths, and same-document fragments, which MD051 checks.
+func splitLinkDestination(destination string) (string, string, bool) {
+	destination = strings.TrimSpace(destination)
+	if strings.HasPrefix(destination, "<") {
+		destination = strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+	} else if fields := strings.Fields(destination); len(fields) > 0 {
+		// Drop a link title
+		destination = fields[0]
+	}
+
+	if destination == "" || strings.HasPrefix(destination, "#") || strings.HasPrefix(destination, "/") ||
+		gmd001SchemeRegex.MatchString(destination) || strings.Contains(destination, "{{") {
+		return "", "", false
+	}
+
+	target, fragment, _ := strings.Cut(destination, "#")
+	target, _, _ = strings.Cut(target, "?")
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		fragment = unescaped
+	}
+	if target == "" {
+		return "", "", false
+	}
+
+	return target, fragment, true
+}
+
+// isIgnoredLinkPath reports whether target matches one of the glob patterns.
+func isIgnoredLinkPath(target string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, target); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// directoryCache resolves link paths against the file system, listing each
+// directory once so that the case of every path component can be compared
+// even on case-insensitive file systems.
+type directoryCache struct {
+	entries map[string][]string
+}
+
+// newDirectoryCache creates an empty directory cache.
+func newDirectoryCache() *directoryCache {
+	return &directoryCache{entries: make(map[string][]string)}
+}
+
+// list returns the names in dir, or nil when it cannot be read.
+func (dc *directoryCache) list(dir string) []string {
+	if names, exists := dc.entries[dir]; exists {
+		return names
+	}
+
+	var names []string
+	if entries, err := os.ReadDir(dir); err == nil {
+		names = make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name()
+		}
+	}
+	dc.entries[dir] = names
+	return names
+}
+
+// resolve follows the slash-separated relative path target from dir. It
+// returns the absolute path, target spelled with the case found on disk and
+// whether the path exists.
+func (dc *directoryCache) resolve(dir, target string) (string, string, bool) {
+	current := dir
+	components := strings.Split(target, "/")
+	actual := make([]string, len(components))
+
+	for i, component := range components {
+		actual[i] = component
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			current = filepath.Dir(current)
+			continue
+		}
+
+		found := ""
+		for _, name := range dc.list(current) {
+			if name == component {
+				found = name
+				break
+			}
+			if found == "" && strings.EqualFold(name, component) {
+				found = name
+			}
+		}
+		if found == "" {
+			return "", "", false
+		}
+
+		actual[i] = found
+		current = filepath.Join(current, found)
+	}
+
+	return current, strings.Join(actual, "/"), true
+}
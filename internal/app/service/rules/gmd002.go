@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// GMD002 - Tables should be formatted
+func NewGMD002Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/gmd002.md")
+
+	return entity.NewRuleWithParameters(
+		[]string{"GMD002", "table-format"},
+		"Tables should be formatted",
+		[]string{"table"},
+		infoURL,
+		"commonmark",
+		[]entity.RuleParameter{
+			{
+				Name:        "compact_wide_tables",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Format tables compact instead of aligned when aligned rows would be longer than line_length",
+			},
+			{
+				Name:        "line_length",
+				Type:        entity.ParameterTypeInteger,
+				Default:     80,
+				Description: "Row length beyond which a table is wide; defaults to MD013's line_length",
+				Inherit:     "MD013",
+			},
+		},
+		gmd002Function,
+	)
+}
+
+// gmd002WidthCondition measures table cells independently of the locale:
+// East Asian wide characters and emoji take two columns, and characters of
+// ambiguous width one, as in most editors and on GitHub.
+var gmd002WidthCondition = &runewidth.Condition{EastAsianWidth: false, StrictEmojiNeutral: true}
+
+// gmd002Measure makes each column wide enough for a delimiter cell with
+// three dashes plus its colons.
+var gmd002Measure = tableMeasure{
+	width: gmd002WidthCondition.StringWidth,
+	minimumWidth: func(alignment string) int {
+		switch alignment {
+		case alignCenter:
+			return 5
+		case alignLeft, alignRight:
+			return 4
+		default:
+			return 3
+		}
+	},
+}
+
+func gmd002Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	var violations []value.Violation
+
+	compactWideTables := getBoolConfig(params.Config, "compact_wide_tables", false)
+	lineLength := getIntConfig(params.Config, "line_length", 80)
+
+	for _, table := range findTables(params.Lines, gmd002Measure) {
+		consistent := true
+		for _, row := range table.rows {
+			if len(row.cells) != len(table.alignments) {
+				consistent = false
+				break
+			}
+		}
+		if !consistent {
+			// Tables with missing or extra cells are reported by MD056
+			continue
+		}
+
+		expected := table.alignedRows()
+		detail := "Table is not aligned"
+		if compactWideTables && widestLine(expected) > lineLength {
+			expected = table.compactRows()
+			detail = fmt.Sprintf("Table is not compact (aligned rows would be longer than %d)", lineLength)
+		}
+
+		for i, row := range table.rows {
+			if strings.TrimRight(row.line, " \t") == expected[i] {
+				continue
+			}
+
+			violation := value.NewViolation(
+				[]string{"GMD002", "table-format"},
+				"Tables should be formatted",
+				nil,
+				row.index+1,
+			)
+			violation = violation.WithErrorDetail(detail)
+			violation = violation.WithErrorContext(strings.TrimSpace(row.line))
+
+			// Replace the whole table so that every row is formatted with
+			// the same column widths
+			fixInfo := value.NewFixInfo().
+				WithLineNumber(table.rows[0].index + 1).
+				WithDeleteCount(len(table.rows)).
+				WithInsertText(strings.Join(expected, "\n") + "\n")
+
+			violation = violation.WithFixInfo(*fixInfo)
+
+			violations = append(violations, *violation)
+			break
+		}
+	}
+
+	return functional.Ok(violations)
+}
+
+// widestLine returns the display width of the widest line.
+func widestLine(lines []string) int {
+	widest := 0
+	for _, line := range lines {
+		widest = max(widest, gmd002WidthCondition.StringWidth(line))
+	}
+	return widest
+}
@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gomdlint/gomdlint/internal/app/service/prose"
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// GMD003 - Prose should follow the configured style rules
+func NewGMD003Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/gmd003.md")
+
+	return entity.NewRuleWithParameters(
+		[]string{"GMD003", "prose"},
+		"Prose should follow the configured style rules",
+		[]string{"prose"},
+		infoURL,
+		"commonmark",
+		[]entity.RuleParameter{
+			{
+				Name:        "styles",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "YAML prose rule files, or directories of them, to check documents against",
+			},
+		},
+		gmd003Function,
+	)
+}
+
+func gmd003Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	var violations []value.Violation
+
+	styles := getStringSliceConfig(params.Config, "styles")
+	if len(styles) == 0 {
+		return functional.Ok(violations)
+	}
+
+	proseRules, err := prose.Load(styles)
+	if err != nil {
+		return functional.Err[[]value.Violation](err)
+	}
+
+	doc := prose.Extract(params.Lines, prose.Options{})
+	for _, proseRule := range proseRules {
+		for _, alert := range proseRule.Check(doc) {
+			violations = append(violations, proseViolation([]string{"GMD003", "prose"}, "Prose should follow the configured style rules", alert.Rule+": "+alert.Message, alert))
+		}
+	}
+
+	return functional.Ok(violations)
+}
+
+// proseViolation converts a prose alert into a violation of a rule.
+func proseViolation(names []string, description, detail string, alert prose.Alert) value.Violation {
+	violation := value.NewViolation(names, description, nil, alert.Line)
+	violation = violation.WithErrorDetail(detail)
+	violation = violation.WithErrorContext(alert.Match)
+	violation = violation.WithColumn(alert.Column)
+	violation = violation.WithLength(alert.Length)
+
+	switch alert.Level {
+	case prose.LevelError:
+		violation = violation.WithSeverity(value.SeverityError)
+	case prose.LevelSuggestion:
+		violation = violation.WithSeverity(value.SeverityInfo)
+	default:
+		violation = violation.WithSeverity(value.SeverityWarning)
+	}
+
+	if alert.Fix != nil {
+		fixInfo := value.NewFixInfo().
+			WithLineNumber(alert.Fix.Line).
+			WithEditColumn(alert.Fix.Column).
+			WithDeleteLength(alert.Fix.Length).
+			WithReplaceText(alert.Fix.Text)
+
+		violation = violation.WithFixInfo(*fixInfo)
+	}
+
+	return *violation
+}
@@ -0,0 +1,184 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gomdlint/gomdlint/internal/app/service/prose"
+	"github.com/gomdlint/gomdlint/internal/app/service/spelling"
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// gmd004WordRegex matches words, including contractions.
+var gmd004WordRegex = regexp.MustCompile(`[\p{L}\p{M}\p{N}_]+(?:['’][\p{L}\p{M}\p{N}_]+)*`)
+
+// gmd004FrontMatterKey is the front matter key listing words a document
+// accepts.
+const gmd004FrontMatterKey = "words"
+
+// GMD004 - Words should be spelled correctly
+func NewGMD004Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/gmd004.md")
+
+	return entity.NewRuleWithParameters(
+		[]string{"GMD004", "spelling"},
+		"Words should be spelled correctly",
+		[]string{"spelling", "prose"},
+		infoURL,
+		"commonmark",
+		[]entity.RuleParameter{
+			{
+				Name:        "dictionaries",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "Hunspell dictionaries, as paths to their .dic files or to their base name (\"/usr/share/hunspell/en_US\")",
+			},
+			{
+				Name:        "words",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "Additional accepted words",
+			},
+			{
+				Name:        "word_lists",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{spelling.DefaultWordList},
+				Description: "Files of accepted words, one per line; missing files are ignored. " + spelling.DefaultWordList + " is looked up from the document's directory up to the repository root",
+			},
+			{
+				Name:        "suggestions",
+				Type:        entity.ParameterTypeInteger,
+				Default:     5,
+				Description: "Maximum number of corrections suggested per word (0 to disable)",
+			},
+		},
+		gmd004Function,
+	)
+}
+
+func gmd004Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	var violations []value.Violation
+
+	dictionaryPaths := getStringSliceConfig(params.Config, "dictionaries")
+	if len(dictionaryPaths) == 0 {
+		return functional.Ok(violations)
+	}
+	suggestionLimit := getIntConfig(params.Config, "suggestions", 5)
+
+	var dictionaries []*spelling.Dictionary
+	for _, path := range dictionaryPaths {
+		dictionary, err := spelling.LoadDictionary(path)
+		if err != nil {
+			return functional.Err[[]value.Violation](err)
+		}
+		dictionaries = append(dictionaries, dictionary)
+	}
+
+	words := getStringSliceConfig(params.Config, "words")
+	wordLists := []string{spelling.DefaultWordList}
+	if _, exists := params.Config["word_lists"]; exists {
+		wordLists = getStringSliceConfig(params.Config, "word_lists")
+	}
+	for _, path := range wordLists {
+		if path == spelling.DefaultWordList {
+			// The project list is looked up from the document's directory
+			if path = spelling.FindWordList(filepath.Dir(params.Filename)); path == "" {
+				continue
+			}
+		}
+		listed, err := spelling.ReadWordList(path)
+		if err != nil {
+			return functional.Err[[]value.Violation](err)
+		}
+		words = append(words, listed...)
+	}
+	words = append(words, frontMatterWords(frontMatterData(params))...)
+
+	checker := spelling.NewChecker(dictionaries, words)
+	details := make(map[string]string)
+
+	for _, block := range prose.Extract(params.Lines, prose.Options{}).Blocks {
+		for _, line := range block.Lines {
+			for _, match := range gmd004WordRegex.FindAllStringIndex(line.Text, -1) {
+				word := line.Text[match[0]:match[1]]
+				if isIdentifierWord(line.Text, match[0], match[1]) || checker.Check(word) {
+					continue
+				}
+
+				detail, exists := details[word]
+				if !exists {
+					detail = fmt.Sprintf("Unknown word '%s'", word)
+					if suggestions := checker.Suggest(word, suggestionLimit); len(suggestions) > 0 {
+						detail += " (suggestions: " + strings.Join(suggestions, ", ") + ")"
+					}
+					details[word] = detail
+				}
+
+				violation := value.NewViolation(
+					[]string{"GMD004", "spelling"},
+					"Words should be spelled correctly",
+					nil,
+					line.Number,
+				)
+				violation = violation.WithErrorDetail(detail)
+				violation = violation.WithErrorContext(word)
+				violation = violation.WithColumn(match[0] + 1)
+				violation = violation.WithLength(match[1] - match[0])
+
+				violations = append(violations, *violation)
+			}
+		}
+	}
+
+	return functional.Ok(violations)
+}
+
+// frontMatterWords returns the accepted words a document lists in its front
+// matter, as a list or a space-separated string.
+func frontMatterWords(frontMatter map[string]interface{}) []string {
+	switch words := frontMatter[gmd004FrontMatterKey].(type) {
+	case string:
+		return strings.Fields(words)
+	case []interface{}:
+		var result []string
+		for _, word := range words {
+			if s, ok := word.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// isIdentifierWord reports whether text[start:end] is an identifier rather
+// than a word: a single letter, CamelCase, snake_case, containing digits,
+// or part of a dotted name or path such as "config.yaml" or "src/main".
+func isIdentifierWord(text string, start, end int) bool {
+	word := text[start:end]
+	if utf8.RuneCountInString(word) < 2 || strings.ContainsAny(word, "_0123456789") {
+		return true
+	}
+
+	_, size := utf8.DecodeRuneInString(word)
+	if strings.IndexFunc(word[size:], unicode.IsUpper) >= 0 {
+		return true
+	}
+
+	isJoiner := func(b byte) bool { return b == '.' || b == '/' || b == '\\' }
+	if start >= 2 && isJoiner(text[start-1]) && text[start-2] != ' ' {
+		return true
+	}
+	if end+1 < len(text) && isJoiner(text[end]) && text[end+1] != ' ' {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/app/service/prose"
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// gmd005StyleNone turns the case check off for a heading level.
+const gmd005StyleNone = "none"
+
+// gmd005Styles maps the configurable styles to prose capitalization styles.
+var gmd005Styles = map[string]string{
+	"title":    prose.CaseTitle,
+	"sentence": prose.CaseSentence,
+}
+
+// GMD005 - Headings should use the configured capitalization
+func NewGMD005Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/gmd005.md")
+
+	parameters := []entity.RuleParameter{
+		{
+			Name:        "style",
+			Type:        entity.ParameterTypeString,
+			Default:     "sentence",
+			Allowed:     []string{"title", "sentence"},
+			Description: "Capitalization of headings: title or sentence case",
+		},
+	}
+	for level := 1; level <= 6; level++ {
+		parameters = append(parameters, entity.RuleParameter{
+			Name:        fmt.Sprintf("h%d", level),
+			Type:        entity.ParameterTypeString,
+			Default:     "",
+			Allowed:     []string{"", "title", "sentence", gmd005StyleNone},
+			Description: fmt.Sprintf("Capitalization of level %d headings, overriding style (none to skip them)", level),
+		})
+	}
+	parameters = append(parameters, entity.RuleParameter{
+		Name:        "names",
+		Type:        entity.ParameterTypeStringList,
+		Default:     []string{},
+		Description: "Proper names and other words spelled as given in any position; defaults to MD044's names",
+		Inherit:     "MD044",
+	})
+
+	return entity.NewRuleWithParameters(
+		[]string{"GMD005", "heading-case"},
+		"Headings should use the configured capitalization",
+		[]string{"headings"},
+		infoURL,
+		"commonmark",
+		parameters,
+		gmd005Function,
+	)
+}
+
+func gmd005Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	var violations []value.Violation
+
+	headings := filterHeadings(params.Tokens)
+	if len(headings) == 0 {
+		return functional.Ok(violations)
+	}
+
+	style := getStringConfig(params.Config, "style", "sentence")
+	names := getStringSliceConfig(params.Config, "names")
+
+	// Code spans are blanked out of the prose text; they are filled back in
+	// so that they still count as words without being recased
+	blocks := headingBlocks(prose.Extract(params.Lines, prose.Options{}))
+	withCode := headingBlocks(prose.Extract(params.Lines, prose.Options{Code: true}))
+
+	for _, heading := range headings {
+		block, exists := blocks[heading.StartLine()]
+		if !exists {
+			continue
+		}
+		level, exists := heading.GetIntProperty("level")
+		if !exists {
+			level = block.Level
+		}
+
+		levelStyle := style
+		if override := getStringConfig(params.Config, fmt.Sprintf("h%d", level), ""); override != "" {
+			levelStyle = override
+		}
+		if levelStyle == gmd005StyleNone {
+			continue
+		}
+
+		// The heading's text on each of its lines, joined by spaces
+		var text, source strings.Builder
+		var firstSpan [2]int
+		codeLines := withCode[heading.StartLine()].Lines
+		for i, line := range block.Lines {
+			masked := line.Text
+			if len(codeLines) == len(block.Lines) {
+				masked = fillCodeSpans(line.Text, codeLines[i].Text, line.Source)
+			}
+			span := headingSpan(masked)
+			if i == 0 {
+				firstSpan = span
+			} else {
+				text.WriteByte(' ')
+				source.WriteByte(' ')
+			}
+			text.WriteString(masked[span[0]:span[1]])
+			source.WriteString(line.Source[span[0]:span[1]])
+		}
+		if strings.TrimSpace(text.String()) == "" {
+			continue
+		}
+
+		fix := prose.CaseFix(prose.Line{Number: block.Lines[0].Number, Text: text.String(), Source: source.String()}, gmd005Styles[levelStyle], names)
+		if fix == nil {
+			continue
+		}
+		original := source.String()
+		expected := original[:fix.Column-1] + fix.Text + original[fix.Column-1+fix.Length:]
+
+		first, span := block.Lines[0], firstSpan
+		violation := value.NewViolation(
+			[]string{"GMD005", "heading-case"},
+			"Headings should use the configured capitalization",
+			nil,
+			first.Number,
+		)
+		violation = violation.WithErrorDetail(fmt.Sprintf("Heading should be in %s case: '%s'", levelStyle, expected))
+		violation = violation.WithErrorContext(original)
+		violation = violation.WithColumn(span[0] + 1)
+		violation = violation.WithLength(span[1] - span[0])
+
+		// Headings on a single line are fixed word by word
+		if len(block.Lines) == 1 {
+			fixInfo := value.NewFixInfo().
+				WithLineNumber(first.Number).
+				WithEditColumn(span[0] + fix.Column).
+				WithDeleteLength(fix.Length).
+				WithReplaceText(fix.Text)
+			violation = violation.WithFixInfo(*fixInfo)
+		}
+
+		violations = append(violations, *violation)
+	}
+
+	return functional.Ok(violations)
+}
+
+// headingBlocks indexes the heading blocks of a document by the number of
+// their first line.
+func headingBlocks(doc *prose.Document) map[int]prose.Block {
+	blocks := make(map[int]prose.Block)
+	for _, block := range doc.Blocks {
+		if block.Scope == prose.ScopeHeading && len(block.Lines) > 0 {
+			blocks[block.Lines[0].Number] = block
+		}
+	}
+	return blocks
+}
+
+// headingSpan returns the start and end of the text on a masked heading
+// line, without the surrounding markers and spaces.
+func headingSpan(text string) [2]int {
+	start := len(text) - len(strings.TrimLeft(text, " "))
+	end := len(strings.TrimRight(text, " "))
+	return [2]int{start, max(start, end)}
+}
+
+// fillCodeSpans fills the code spans blanked out of a masked line, found by
+// comparing it with the line masked with code kept, and their backticks
+// with zeros.
+func fillCodeSpans(text, withCode, source string) string {
+	if len(text) != len(withCode) || len(text) != len(source) {
+		return text
+	}
+	filled := []byte(text)
+	for i := range filled {
+		if filled[i] == ' ' && (withCode[i] != ' ' || source[i] == '`') {
+			filled[i] = '0'
+		}
+	}
+	return string(filled)
+}
@@ -0,0 +1,173 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/app/service/codecheck"
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// gmd006Languages lists the checked languages with the parameter enabling
+// them and their name in messages.
+var gmd006Languages = map[string]struct{ parameter, name string }{
+	codecheck.LanguageJSON:  {"json", "JSON"},
+	codecheck.LanguageJSONC: {"json", "JSONC"},
+	codecheck.LanguageYAML:  {"yaml", "YAML"},
+	codecheck.LanguageTOML:  {"toml", "TOML"},
+	codecheck.LanguageGo:    {"go", "Go"},
+	codecheck.LanguageXML:   {"xml", "XML"},
+}
+
+// GMD006 - Fenced code should be valid in its language
+func NewGMD006Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/gmd006.md")
+
+	return entity.NewRuleWithParameters(
+		[]string{"GMD006", "fenced-code-syntax"},
+		"Fenced code should be valid in its language",
+		[]string{"code", "language"},
+		infoURL,
+		"commonmark",
+		[]entity.RuleParameter{
+			{
+				Name:        "json",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check JSON and JSONC code blocks",
+			},
+			{
+				Name:        "yaml",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check YAML code blocks",
+			},
+			{
+				Name:        "toml",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check TOML code blocks",
+			},
+			{
+				Name:        "go",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check Go code blocks, which may be files, declarations or statements",
+			},
+			{
+				Name:        "xml",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check XML code blocks",
+			},
+			{
+				Name:        "gofmt",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Report Go code blocks that gofmt would change",
+			},
+			{
+				Name:        "json_format",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Report JSON code blocks not indented with json_indent spaces",
+			},
+			{
+				Name:        "json_indent",
+				Type:        entity.ParameterTypeInteger,
+				Default:     2,
+				Description: "Indentation of formatted JSON",
+			},
+			{
+				Name:        "aliases",
+				Type:        entity.ParameterTypeAny,
+				Default:     map[string]interface{}{},
+				Description: "Further code block languages mapped to a checked language, such as {\"jsonld\": \"json\"}",
+			},
+		},
+		gmd006Function,
+	)
+}
+
+func gmd006Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	var violations []value.Violation
+
+	aliases := getStringMapConfig(params.Config, "aliases")
+	gofmt := getBoolConfig(params.Config, "gofmt", false)
+	jsonFormat := getBoolConfig(params.Config, "json_format", false)
+	jsonIndent := getIntConfig(params.Config, "json_indent", 2)
+
+	for _, block := range fencedCodeBlocks(params.Lines) {
+		language, exists := codecheck.Language(block.language, aliases)
+		if !exists || !getBoolConfig(params.Config, gmd006Languages[language].parameter, true) {
+			continue
+		}
+
+		content, removed := block.content(params.Lines)
+		src := strings.Join(content, "\n")
+		if strings.TrimSpace(src) == "" {
+			continue
+		}
+
+		if problem := codecheck.Check(language, src); problem != nil {
+			violation := value.NewViolation(
+				[]string{"GMD006", "fenced-code-syntax"},
+				"Fenced code should be valid in its language",
+				nil,
+				block.open+1+problem.Line,
+			)
+			violation = violation.WithErrorDetail(fmt.Sprintf("Invalid %s: %s", gmd006Languages[language].name, problem.Message))
+			violation = violation.WithErrorContext(strings.TrimSpace(content[problem.Line-1]))
+			if problem.Column > 0 {
+				violation = violation.WithColumn(removed[problem.Line-1] + problem.Column)
+			}
+
+			violations = append(violations, *violation)
+			continue
+		}
+
+		// Valid JSON and Go blocks are reformatted when enabled; blocks
+		// without a closing fence are left alone
+		if block.close < 0 || !((language == codecheck.LanguageJSON && jsonFormat) || (language == codecheck.LanguageGo && gofmt)) {
+			continue
+		}
+		formatted, ok := codecheck.Format(language, src, jsonIndent)
+		if !ok || formatted == strings.Trim(src, "\n") {
+			continue
+		}
+
+		detail := "Go code is not formatted like gofmt"
+		if language == codecheck.LanguageJSON {
+			detail = fmt.Sprintf("JSON is not formatted with %d-space indentation", jsonIndent)
+		}
+		violation := value.NewViolation(
+			[]string{"GMD006", "fenced-code-syntax"},
+			"Fenced code should be valid in its language",
+			nil,
+			block.open+2,
+		)
+		violation = violation.WithErrorDetail(detail)
+		violation = violation.WithErrorContext(strings.TrimSpace(params.Lines[block.open]))
+
+		indent := strings.Repeat(" ", block.indent)
+		formattedLines := strings.Split(formatted, "\n")
+		for i, line := range formattedLines {
+			if line != "" {
+				formattedLines[i] = indent + line
+			}
+		}
+		fixInfo := value.NewFixInfo().
+			WithLineNumber(block.open + 2).
+			WithDeleteCount(len(content)).
+			WithInsertText(strings.Join(formattedLines, "\n") + "\n")
+		violation = violation.WithFixInfo(*fixInfo)
+
+		violations = append(violations, *violation)
+	}
+
+	return functional.Ok(violations)
+}
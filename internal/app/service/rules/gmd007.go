@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// gmd007DefaultAliases are the language names normalized by default.
+var gmd007DefaultAliases = map[string]interface{}{
+	"sh":     "bash",
+	"shell":  "bash",
+	"yml":    "yaml",
+	"golang": "go",
+}
+
+// gmd007ShellLanguages are the languages whose code blocks hold shell
+// commands.
+var gmd007ShellLanguages = map[string]bool{
+	"bash": true, "sh": true, "shell": true, "zsh": true, "ksh": true, "fish": true,
+}
+
+// GMD007 - Fenced code languages should be allowed and normalized
+func NewGMD007Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/gmd007.md")
+
+	return entity.NewRuleWithParameters(
+		[]string{"GMD007", "fenced-code-language-style"},
+		"Fenced code languages should be allowed and normalized",
+		[]string{"code", "language"},
+		infoURL,
+		"commonmark",
+		[]entity.RuleParameter{
+			{
+				Name:        "allowed",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "Languages code fences may declare, after normalization (empty = any)",
+			},
+			{
+				Name:        "denied",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "Languages code fences may not declare, after normalization",
+			},
+			{
+				Name:        "aliases",
+				Type:        entity.ParameterTypeAny,
+				Default:     gmd007DefaultAliases,
+				Description: "Language names and the name they are normalized to, replacing the defaults (sh and shell to bash, yml to yaml, golang to go)",
+			},
+			{
+				Name:        "shell_prompts",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Allow '$ ' prompts before commands in shell code blocks; they are only reported when MD014 is disabled",
+			},
+		},
+		gmd007Function,
+	)
+}
+
+func gmd007Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	var violations []value.Violation
+
+	aliases := getStringMapConfig(params.Config, "aliases")
+	allowed := getStringSliceConfig(params.Config, "allowed")
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, language := range allowed {
+		allowedSet[strings.ToLower(language)] = true
+	}
+	deniedSet := make(map[string]bool)
+	for _, language := range getStringSliceConfig(params.Config, "denied") {
+		deniedSet[strings.ToLower(language)] = true
+	}
+
+	// MD014 reports prompts in blocks without output itself
+	checkPrompts := !getBoolConfig(params.Config, "shell_prompts", false) &&
+		(params.RuleEnabled == nil || !params.RuleEnabled("MD014"))
+
+	newViolation := func(lineNumber int, detail, context string) *value.Violation {
+		violation := value.NewViolation(
+			[]string{"GMD007", "fenced-code-language-style"},
+			"Fenced code languages should be allowed and normalized",
+			nil,
+			lineNumber,
+		)
+		violation = violation.WithErrorDetail(detail)
+		return violation.WithErrorContext(context)
+	}
+
+	for _, block := range fencedCodeBlocks(params.Lines) {
+		if block.language == "" {
+			continue
+		}
+		fenceLine := params.Lines[block.open]
+		column := block.languageColumn(params.Lines)
+
+		language := strings.ToLower(block.language)
+		if normalized, exists := aliases[language]; exists && normalized != block.language {
+			violation := newViolation(block.open+1, fmt.Sprintf("Language '%s' should be written as '%s'", block.language, normalized), strings.TrimSpace(fenceLine))
+			violation = violation.WithColumn(column)
+			violation = violation.WithLength(len(block.language))
+
+			fixInfo := value.NewFixInfo().
+				WithLineNumber(block.open + 1).
+				WithEditColumn(column).
+				WithDeleteLength(len(block.language)).
+				WithReplaceText(normalized)
+			violation = violation.WithFixInfo(*fixInfo)
+
+			violations = append(violations, *violation)
+			language = strings.ToLower(normalized)
+		}
+
+		switch {
+		case deniedSet[language]:
+			violation := newViolation(block.open+1, fmt.Sprintf("Language '%s' is not allowed", language), strings.TrimSpace(fenceLine))
+			violations = append(violations, *violation.WithColumn(column).WithLength(len(block.language)))
+		case len(allowedSet) > 0 && !allowedSet[language]:
+			violation := newViolation(block.open+1, fmt.Sprintf("Language '%s' is not in the allowed list (%s)", language, strings.Join(allowed, ", ")), strings.TrimSpace(fenceLine))
+			violations = append(violations, *violation.WithColumn(column).WithLength(len(block.language)))
+		}
+
+		if !checkPrompts || !gmd007ShellLanguages[language] {
+			continue
+		}
+		content, removed := block.content(params.Lines)
+		for i, line := range content {
+			trimmed := strings.TrimLeft(line, " \t")
+			if !strings.HasPrefix(trimmed, "$ ") {
+				continue
+			}
+
+			lineNumber := block.open + 2 + i
+			promptColumn := removed[i] + len(line) - len(trimmed) + 1
+			violation := newViolation(lineNumber, "Shell commands should not be preceded by a '$ ' prompt", strings.TrimSpace(line))
+			violation = violation.WithColumn(promptColumn)
+			violation = violation.WithLength(2)
+
+			fixInfo := value.NewFixInfo().
+				WithLineNumber(lineNumber).
+				WithEditColumn(promptColumn).
+				WithDeleteLength(2).
+				WithReplaceText("")
+			violation = violation.WithFixInfo(*fixInfo)
+
+			violations = append(violations, *violation)
+		}
+	}
+
+	return functional.Ok(violations)
+}
@@ -0,0 +1,255 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/gomdlint/gomdlint/internal/app/service/jsonschema"
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// GMD008 - Front matter should match its schema
+func NewGMD008Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/gmd008.md")
+
+	return entity.NewRuleWithParameters(
+		[]string{"GMD008", "front-matter-schema"},
+		"Front matter should match its schema",
+		[]string{"front_matter"},
+		infoURL,
+		"commonmark",
+		[]entity.RuleParameter{
+			{
+				Name:        "schema",
+				Type:        entity.ParameterTypeString,
+				Default:     "",
+				Description: "JSON Schema file (JSON or YAML) for the front matter of documents not matched by schemas",
+			},
+			{
+				Name:        "schemas",
+				Type:        entity.ParameterTypeAny,
+				Default:     []interface{}{},
+				Description: "Schemas for documents matching globs, as a list of {\"files\": glob or list of globs, \"schema\": path}; the first match applies",
+			},
+		},
+		gmd008Function,
+	)
+}
+
+func gmd008Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	var violations []value.Violation
+
+	schemaPath, err := gmd008SchemaPath(params.Config, params.Filename)
+	if err != nil {
+		return functional.Err[[]value.Violation](err)
+	}
+	if schemaPath == "" {
+		return functional.Ok(violations)
+	}
+	schema, err := jsonschema.Load(schemaPath)
+	if err != nil {
+		return functional.Err[[]value.Violation](err)
+	}
+
+	newViolation := func(lineNumber int, detail, context string) *value.Violation {
+		violation := value.NewViolation(
+			[]string{"GMD008", "front-matter-schema"},
+			"Front matter should match its schema",
+			nil,
+			lineNumber,
+		)
+		violation = violation.WithErrorDetail(detail)
+		return violation.WithErrorContext(context)
+	}
+
+	// Documents without front matter are validated as empty front matter
+	source, exists := frontMatterSourceOf(params)
+	data := map[string]interface{}{}
+	keyLines := map[string]int{}
+	if exists {
+		data, keyLines, err = source.decode()
+		if err != nil {
+			violation := newViolation(source.lineNumber(0), fmt.Sprintf("Front matter is not valid %s: %v", strings.ToUpper(source.format), err), strings.TrimSpace(source.lines[0]))
+			return functional.Ok(append(violations, *violation))
+		}
+	}
+
+	errors, err := schema.Validate(data)
+	if err != nil {
+		return functional.Err[[]value.Violation](err)
+	}
+	for _, schemaError := range errors {
+		if !exists {
+			violations = append(violations, *newViolation(1, "Front matter is missing: "+schemaError.Error(), ""))
+			continue
+		}
+		index := keyLine(keyLines, schemaError.Path)
+		violation := newViolation(source.lineNumber(index), schemaError.Error(), strings.TrimSpace(source.lines[index]))
+		violations = append(violations, *violation)
+	}
+
+	return functional.Ok(violations)
+}
+
+// gmd008SchemaPath returns the schema for a document: that of the first
+// schemas entry whose globs match its filename, or the schema parameter.
+func gmd008SchemaPath(config map[string]interface{}, filename string) (string, error) {
+	entries, err := gmd008Entries(config)
+	if err != nil {
+		return "", err
+	}
+	candidates := gmd008Candidates(filename)
+	for _, entry := range entries {
+		if entry.matches(candidates) {
+			return entry.schema, nil
+		}
+	}
+	return getStringConfig(config, "schema", ""), nil
+}
+
+// GMD008UnusedSchemas describes the settings of GMD008 that apply to none
+// of files: schemas entries that are not the first to match any of them,
+// and the schema parameter when a schemas entry matches each of them.
+func GMD008UnusedSchemas(config map[string]interface{}, files []string) []string {
+	entries, err := gmd008Entries(config)
+	if err != nil {
+		return nil // Reported when linting
+	}
+	used := make([]bool, len(entries))
+	fallback := false
+	for _, file := range files {
+		candidates := gmd008Candidates(file)
+		matched := false
+		for i, entry := range entries {
+			if entry.matches(candidates) {
+				used[i], matched = true, true
+				break
+			}
+		}
+		fallback = fallback || !matched
+	}
+
+	var unused []string
+	for i, entry := range entries {
+		if !used[i] {
+			patterns := make([]string, len(entry.patterns))
+			for j, pattern := range entry.patterns {
+				patterns[j] = displayPath(pattern)
+			}
+			unused = append(unused, fmt.Sprintf("GMD008 schemas[%d] (files %s) matches no linted file", i, strings.Join(patterns, ", ")))
+		}
+	}
+	if schema := getStringConfig(config, "schema", ""); schema != "" && !fallback && len(files) > 0 {
+		unused = append(unused, fmt.Sprintf("GMD008 schema %s applies to no linted file: schemas match them all", displayPath(schema)))
+	}
+	return unused
+}
+
+// gmd008Entry is an entry of the schemas parameter.
+type gmd008Entry struct {
+	schema   string
+	patterns []string
+}
+
+// gmd008Entries returns the entries of the schemas parameter, with globs
+// without a slash made to match file names in any directory.
+func gmd008Entries(config map[string]interface{}) ([]gmd008Entry, error) {
+	var settings []interface{}
+	switch schemas := config["schemas"].(type) {
+	case []interface{}:
+		settings = schemas
+	case []map[string]interface{}: // TOML arrays of tables
+		for _, entry := range schemas {
+			settings = append(settings, entry)
+		}
+	case nil:
+	default:
+		return nil, fmt.Errorf("GMD008: schemas must be a list of {files, schema} objects")
+	}
+
+	entries := make([]gmd008Entry, 0, len(settings))
+	for i, setting := range settings {
+		entrySettings, ok := setting.(map[string]interface{})
+		schema, _ := entrySettings["schema"].(string)
+		if !ok || schema == "" {
+			return nil, fmt.Errorf("GMD008: schemas must be a list of {files, schema} objects: schemas[%d] has no schema path", i)
+		}
+		var patterns []string
+		switch files := entrySettings["files"].(type) {
+		case string:
+			patterns = []string{files}
+		default:
+			patterns = getStringSliceConfig(entrySettings, "files")
+		}
+
+		entry := gmd008Entry{schema: schema}
+		for _, pattern := range patterns {
+			pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+			if !strings.Contains(pattern, "/") {
+				pattern = "**/" + pattern
+			}
+			if !doublestar.ValidatePattern(pattern) {
+				return nil, fmt.Errorf("GMD008: invalid files pattern %q", pattern)
+			}
+			entry.patterns = append(entry.patterns, pattern)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// matches reports whether any of the entry's globs matches a candidate.
+func (e gmd008Entry) matches(candidates []string) bool {
+	for _, pattern := range e.patterns {
+		for _, candidate := range candidates {
+			if match, _ := doublestar.Match(pattern, candidate); match {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// gmd008Candidates returns the forms of filename that globs are matched
+// against: as given, absolute for globs anchored to a configuration file's
+// directory, and relative to the working directory.
+func gmd008Candidates(filename string) []string {
+	candidates := []string{filepath.ToSlash(filepath.Clean(filename))}
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return candidates
+	}
+	if !filepath.IsAbs(filename) {
+		candidates = append(candidates, filepath.ToSlash(abs))
+	} else if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, abs); err == nil {
+			candidates = append(candidates, filepath.ToSlash(rel))
+		}
+	}
+	return candidates
+}
+
+// displayPath returns an absolute path or glob relative to the working
+// directory when it is inside it.
+func displayPath(path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
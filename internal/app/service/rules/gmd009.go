@@ -0,0 +1,173 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// GMD009 - Front matter keys should be in order
+func NewGMD009Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/gmd009.md")
+
+	return entity.NewRuleWithParameters(
+		[]string{"GMD009", "front-matter-key-order"},
+		"Front matter keys should be in order",
+		[]string{"front_matter"},
+		infoURL,
+		"commonmark",
+		[]entity.RuleParameter{
+			{
+				Name:        "order",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "Top-level keys that come first, in this order, before any other key",
+			},
+			{
+				Name:        "alphabetical",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Require the keys not listed in order to be sorted alphabetically",
+			},
+		},
+		gmd009Function,
+	)
+}
+
+// frontMatterKeyBlock is a top-level front matter key with the lines of its
+// value and the comment lines right above it.
+type frontMatterKeyBlock struct {
+	key        string
+	keyLine    int // Index of the key's line in the front matter lines
+	start, end int // Indices of the block's first and last line
+}
+
+func gmd009Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	var violations []value.Violation
+
+	order := getStringSliceConfig(params.Config, "order")
+	alphabetical := getBoolConfig(params.Config, "alphabetical", false)
+	if len(order) == 0 && !alphabetical {
+		return functional.Ok(violations)
+	}
+
+	source, exists := frontMatterSourceOf(params)
+	if !exists {
+		return functional.Ok(violations)
+	}
+	// Invalid front matter is left to GMD008 and other tools
+	_, keyLines, err := source.decode()
+	if err != nil {
+		return functional.Ok(violations)
+	}
+	blocks := source.keyBlocks(keyLines)
+
+	rank := make(map[string]int, len(order))
+	for i, key := range order {
+		rank[key] = i + 1
+	}
+	less := func(a, b string) bool {
+		switch {
+		case rank[a] > 0 && rank[b] > 0:
+			return rank[a] < rank[b]
+		case rank[a] > 0 || rank[b] > 0:
+			return rank[a] > 0
+		case alphabetical:
+			return strings.ToLower(a) < strings.ToLower(b)
+		}
+		return false
+	}
+
+	for i, block := range blocks {
+		for _, earlier := range blocks[:i] {
+			if !less(block.key, earlier.key) {
+				continue
+			}
+			violation := value.NewViolation(
+				[]string{"GMD009", "front-matter-key-order"},
+				"Front matter keys should be in order",
+				nil,
+				source.lineNumber(block.keyLine),
+			)
+			violation = violation.WithErrorDetail(fmt.Sprintf("Key '%s' should come before '%s'", block.key, earlier.key))
+			violation = violation.WithErrorContext(strings.TrimSpace(source.lines[block.keyLine]))
+
+			// One fix reorders all keys
+			if len(violations) == 0 && source.reorderable(blocks) {
+				sorted := append([]frontMatterKeyBlock{}, blocks...)
+				sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i].key, sorted[j].key) })
+				var reordered []string
+				for _, b := range sorted {
+					reordered = append(reordered, source.lines[b.start:b.end+1]...)
+				}
+				first, last := blocks[0].start, blocks[len(blocks)-1].end
+				fixInfo := value.NewFixInfo().
+					WithLineNumber(source.lineNumber(first)).
+					WithDeleteCount(last - first + 1).
+					WithInsertText(strings.Join(reordered, "\n") + "\n")
+				violation = violation.WithFixInfo(*fixInfo)
+			}
+
+			violations = append(violations, *violation)
+			break
+		}
+	}
+
+	return functional.Ok(violations)
+}
+
+// keyBlocks returns the top-level keys of the front matter in the order
+// they are written. Each block ends before the next one or at the last
+// non-blank line of the front matter.
+func (s frontMatterSource) keyBlocks(keyLines map[string]int) []frontMatterKeyBlock {
+	var blocks []frontMatterKeyBlock
+	for path, line := range keyLines {
+		if !strings.Contains(path, frontMatterPathSeparator) {
+			blocks = append(blocks, frontMatterKeyBlock{key: path, keyLine: line, start: line})
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].keyLine < blocks[j].keyLine })
+
+	content, offset := s.content()
+	end := offset + len(content) - 1
+	for end >= offset && strings.TrimSpace(s.lines[end]) == "" {
+		end--
+	}
+	for i := len(blocks) - 1; i >= 0; i-- {
+		// Comments right above a key move with it
+		for blocks[i].start > offset && strings.HasPrefix(strings.TrimSpace(s.lines[blocks[i].start-1]), "#") {
+			blocks[i].start--
+		}
+		blocks[i].end = end
+		end = blocks[i].start - 1
+	}
+	return blocks
+}
+
+// reorderable reports whether the key blocks can be moved as a whole: in
+// YAML and in TOML without tables, whose keys could not follow them, with
+// one key per line.
+func (s frontMatterSource) reorderable(blocks []frontMatterKeyBlock) bool {
+	if len(blocks) == 0 || (s.format != "yaml" && s.format != "toml") {
+		return false
+	}
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i].keyLine == blocks[i-1].keyLine {
+			return false
+		}
+	}
+	if s.format == "toml" {
+		for _, line := range s.lines[blocks[0].start : blocks[len(blocks)-1].end+1] {
+			if tomlTableRegex.MatchString(line) {
+				return false
+			}
+		}
+	}
+	return true
+}
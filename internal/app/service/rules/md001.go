@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/gomdlint/gomdlint/internal/domain/entity"
@@ -15,13 +16,20 @@ import (
 func NewMD001Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md001.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD001", "heading-increment"},
 		"Heading levels should only increment by one level at a time",
 		[]string{"headings"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{},
+		[]entity.RuleParameter{
+			{
+				Name:        "front_matter_title",
+				Type:        entity.ParameterTypeString,
+				Default:     `^\s*title\s*[:=]`,
+				Description: "RegExp for matching title in front matter, which then counts as a level 1 heading",
+			},
+		},
 		md001Function,
 	)
 }
@@ -32,8 +40,20 @@ func md001Function(ctx context.Context, params entity.RuleParams) functional.Res
 	// Filter tokens to find heading tokens
 	headingTokens := filterHeadings(params.Tokens)
 
-	// Track the previous heading level
+	// Track the previous heading level; a front matter title is level 1
 	prevLevel := 0
+	if pattern := getStringConfig(params.Config, "front_matter_title", `^\s*title\s*[:=]`); pattern != "" {
+		titleRegex, err := regexp.Compile(`(?i)` + pattern)
+		if err != nil {
+			return functional.Err[[]value.Violation](fmt.Errorf("MD001: invalid front_matter_title: %w", err))
+		}
+		for _, line := range params.FrontMatterLines {
+			if titleRegex.MatchString(line) {
+				prevLevel = 1
+				break
+			}
+		}
+	}
 
 	for _, heading := range headingTokens {
 		level := getHeadingLevel(heading)
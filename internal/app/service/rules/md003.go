@@ -16,14 +16,20 @@ import (
 func NewMD003Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md003.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD003", "heading-style"},
 		"Heading style",
 		[]string{"headings"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"style": "consistent", // consistent|atx|atx_closed|setext|setext_with_atx|setext_with_atx_closed
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     "consistent",
+				Allowed:     []string{"consistent", "atx", "atx_closed", "setext", "setext_with_atx", "setext_with_atx_closed"},
+				Description: "Heading style (consistent uses the first style found)",
+			},
 		},
 		md003Function,
 	)
@@ -16,14 +16,20 @@ import (
 func NewMD004Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md004.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD004", "ul-style"},
 		"Unordered list style",
 		[]string{"bullet", "ul"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"style": "consistent", // consistent|asterisk|plus|dash|sublist
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     "consistent",
+				Allowed:     []string{"consistent", "asterisk", "plus", "dash", "sublist"},
+				Description: "List marker style (consistent uses the first marker found)",
+			},
 		},
 		md004Function,
 	)
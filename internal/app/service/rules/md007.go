@@ -16,16 +16,31 @@ import (
 func NewMD007Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md007.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD007", "ul-indent"},
 		"Unordered list indentation",
 		[]string{"bullet", "indentation", "ul"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"indent":         2,     // Spaces for indent
-			"start_indented": false, // Whether to indent the first level
-			"start_indent":   2,     // Spaces for first level indent (when start_indented is true)
+		[]entity.RuleParameter{
+			{
+				Name:        "indent",
+				Type:        entity.ParameterTypeInteger,
+				Default:     2,
+				Description: "Spaces for indent",
+			},
+			{
+				Name:        "start_indented",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Whether to indent the first level",
+			},
+			{
+				Name:        "start_indent",
+				Type:        entity.ParameterTypeInteger,
+				Default:     2,
+				Description: "Spaces for first level indent (when start_indented is true)",
+			},
 		},
 		md007Function,
 	)
@@ -16,16 +16,31 @@ import (
 func NewMD009Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md009.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD009", "no-trailing-spaces"},
 		"Trailing spaces",
 		[]string{"whitespace"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"br_spaces":             2,     // Spaces for line breaks (2+ spaces = <br>)
-			"list_item_empty_lines": false, // Allow trailing spaces in empty list item lines
-			"strict":                false, // Include unnecessary breaks (even when using br_spaces)
+		[]entity.RuleParameter{
+			{
+				Name:        "br_spaces",
+				Type:        entity.ParameterTypeInteger,
+				Default:     2,
+				Description: "Spaces for line breaks (2+ spaces = <br>)",
+			},
+			{
+				Name:        "list_item_empty_lines",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Allow trailing spaces in empty list item lines",
+			},
+			{
+				Name:        "strict",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Include unnecessary breaks (even when using br_spaces)",
+			},
 		},
 		md009Function,
 	)
@@ -15,16 +15,31 @@ import (
 func NewMD010Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md010.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD010", "no-hard-tabs"},
 		"Hard tabs",
 		[]string{"whitespace", "hard_tab"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"code_blocks":           true,            // Check code blocks by default
-			"ignore_code_languages": []interface{}{}, // Languages to ignore
-			"spaces_per_tab":        4,               // Number of spaces to replace tabs with
+		[]entity.RuleParameter{
+			{
+				Name:        "code_blocks",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check code blocks by default",
+			},
+			{
+				Name:        "ignore_code_languages",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "Languages to ignore",
+			},
+			{
+				Name:        "spaces_per_tab",
+				Type:        entity.ParameterTypeInteger,
+				Default:     4,
+				Description: "Number of spaces to replace tabs with",
+			},
 		},
 		md010Function,
 	)
@@ -185,3 +200,22 @@ func getIntConfig(config map[string]interface{}, key string, defaultValue int) i
 	}
 	return defaultValue
 }
+
+// getStringMapConfig returns a configured map of strings with lowercase
+// keys, skipping values that are not strings.
+func getStringMapConfig(config map[string]interface{}, key string) map[string]string {
+	result := make(map[string]string)
+	switch values := config[key].(type) {
+	case map[string]string:
+		for k, v := range values {
+			result[strings.ToLower(k)] = v
+		}
+	case map[string]interface{}:
+		for k, v := range values {
+			if s, ok := v.(string); ok {
+				result[strings.ToLower(k)] = s
+			}
+		}
+	}
+	return result
+}
@@ -15,14 +15,19 @@ import (
 func NewMD012Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md012.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD012", "no-multiple-blanks"},
 		"Multiple consecutive blank lines",
 		[]string{"blank_lines", "whitespace"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"maximum": 1, // Maximum number of consecutive blank lines
+		[]entity.RuleParameter{
+			{
+				Name:        "maximum",
+				Type:        entity.ParameterTypeInteger,
+				Default:     1,
+				Description: "Maximum number of consecutive blank lines",
+			},
 		},
 		md012Function,
 	)
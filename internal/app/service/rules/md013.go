@@ -17,21 +17,67 @@ import (
 func NewMD013Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md013.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD013", "line-length"},
 		"Line length",
 		[]string{"line_length"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"line_length":            80,    // Maximum line length
-			"heading_line_length":    80,    // Maximum heading line length
-			"code_block_line_length": 80,    // Maximum code block line length
-			"code_blocks":            true,  // Check code blocks
-			"tables":                 true,  // Check tables
-			"headings":               true,  // Check headings
-			"headers":                true,  // Alias for headings
-			"stern":                  false, // Strict mode - no exceptions
+		[]entity.RuleParameter{
+			{
+				Name:        "line_length",
+				Type:        entity.ParameterTypeInteger,
+				Default:     80,
+				Description: "Maximum line length",
+			},
+			{
+				Name:        "heading_line_length",
+				Type:        entity.ParameterTypeInteger,
+				Default:     80,
+				Description: "Maximum heading line length",
+			},
+			{
+				Name:        "code_block_line_length",
+				Type:        entity.ParameterTypeInteger,
+				Default:     80,
+				Description: "Maximum code block line length",
+			},
+			{
+				Name:        "code_blocks",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check code blocks",
+			},
+			{
+				Name:        "tables",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check tables",
+			},
+			{
+				Name:        "headings",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Check headings",
+			},
+			{
+				Name:        "headers",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Alias for headings",
+			},
+			{
+				Name:        "strict",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Strict mode - report every line longer than the limit",
+			},
+			{
+				Name:        "stern",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Stern mode - like strict, but allow lines without whitespace such as long URLs",
+			},
 		},
 		md013Function,
 	)
@@ -47,10 +93,11 @@ func md013Function(ctx context.Context, params entity.RuleParams) functional.Res
 	checkCodeBlocks := getBoolConfig(params.Config, "code_blocks", true)
 	checkTables := getBoolConfig(params.Config, "tables", true)
 	checkHeadings := getBoolConfig(params.Config, "headings", true) || getBoolConfig(params.Config, "headers", true)
+	strict := getBoolConfig(params.Config, "strict", false)
 	stern := getBoolConfig(params.Config, "stern", false)
 
 	// Pre-compile regex for URL detection (URLs are often exempt from length limits)
-	urlRegex := regexp.MustCompile(`https?/\S+`)
+	urlRegex := regexp.MustCompile(`https?://\S+`)
 
 	// Check each line
 	for i, line := range params.Lines {
@@ -72,8 +119,13 @@ func md013Function(ctx context.Context, params entity.RuleParams) functional.Res
 			continue
 		}
 
+		// Stern mode allows a single long word after the line's markers
+		if stern && !strict && md013SternExemptRegex.MatchString(line) {
+			continue
+		}
+
 		// Calculate the effective line length
-		effectiveLength := calculateEffectiveLength(line, lineContext, stern, urlRegex)
+		effectiveLength := calculateEffectiveLength(line, lineContext, strict || stern, urlRegex)
 
 		// Check if line exceeds maximum length
 		if effectiveLength > maxLength {
@@ -102,6 +154,10 @@ func md013Function(ctx context.Context, params entity.RuleParams) functional.Res
 	return functional.Ok(violations)
 }
 
+// md013SternExemptRegex matches lines that are one word after heading,
+// blockquote or indentation markers, which stern mode does not report.
+var md013SternExemptRegex = regexp.MustCompile(`^(?:[#>\s]*\s)?\S*$`)
+
 // LineContext represents the context/type of a line
 type LineContext int
 
@@ -167,12 +223,12 @@ func shouldCheckLineType(context LineContext, checkCodeBlocks, checkTables, chec
 }
 
 // calculateEffectiveLength calculates the effective length of a line considering various factors
-func calculateEffectiveLength(line string, context LineContext, stern bool, urlRegex *regexp.Regexp) int {
+func calculateEffectiveLength(line string, context LineContext, exact bool, urlRegex *regexp.Regexp) int {
 	// Start with the actual character count (UTF-8 aware)
 	length := utf8.RuneCountInString(line)
 
-	// In stern mode, use actual length without any adjustments
-	if stern {
+	// In strict and stern modes, use actual length without any adjustments
+	if exact {
 		return length
 	}
 
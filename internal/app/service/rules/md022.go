@@ -16,15 +16,25 @@ import (
 func NewMD022Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md022.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD022", "blanks-around-headings"},
 		"Headings should be surrounded by blank lines",
 		[]string{"blank_lines", "headings"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"lines_above": 1, // Blank lines above heading (int or []int for per-level)
-			"lines_below": 1, // Blank lines below heading (int or []int for per-level)
+		[]entity.RuleParameter{
+			{
+				Name:        "lines_above",
+				Type:        entity.ParameterTypeInteger,
+				Default:     1,
+				Description: "Blank lines required above each heading",
+			},
+			{
+				Name:        "lines_below",
+				Type:        entity.ParameterTypeInteger,
+				Default:     1,
+				Description: "Blank lines required below each heading",
+			},
 		},
 		md022Function,
 	)
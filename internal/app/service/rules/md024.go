@@ -15,14 +15,19 @@ import (
 func NewMD024Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md024.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD024", "no-duplicate-heading"},
 		"Multiple headings with the same content",
 		[]string{"headings"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"siblings_only": false, // Only check sibling headings (same parent level)
+		[]entity.RuleParameter{
+			{
+				Name:        "siblings_only",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Only check sibling headings (same parent level)",
+			},
 		},
 		md024Function,
 	)
@@ -16,15 +16,25 @@ import (
 func NewMD025Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md025.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD025", "single-h1", "single-title"},
 		"Multiple top-level headings in the same document",
 		[]string{"headings"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"level":              1,                  // Heading level to check (default: 1)
-			"front_matter_title": `^\s*title\s*[:=]`, // RegExp for matching title in front matter
+		[]entity.RuleParameter{
+			{
+				Name:        "level",
+				Type:        entity.ParameterTypeInteger,
+				Default:     1,
+				Description: "Heading level to check (default: 1)",
+			},
+			{
+				Name:        "front_matter_title",
+				Type:        entity.ParameterTypeString,
+				Default:     `^\s*title\s*[:=]`,
+				Description: "RegExp for matching title in front matter",
+			},
 		},
 		md025Function,
 	)
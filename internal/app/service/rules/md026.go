@@ -15,14 +15,19 @@ import (
 func NewMD026Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md026.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD026", "no-trailing-punctuation"},
 		"Trailing punctuation in heading",
 		[]string{"headings"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"punctuation": ".,;:!。，；：！", // Punctuation characters to check for
+		[]entity.RuleParameter{
+			{
+				Name:        "punctuation",
+				Type:        entity.ParameterTypeString,
+				Default:     ".,;:!。，；：！",
+				Description: "Punctuation characters to check for",
+			},
 		},
 		md026Function,
 	)
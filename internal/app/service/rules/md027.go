@@ -16,14 +16,19 @@ import (
 func NewMD027Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md027.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD027", "no-multiple-space-blockquote"},
 		"Multiple spaces after blockquote symbol",
 		[]string{"blockquote", "indentation", "whitespace"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"list_items": true, // Include list items in blockquotes
+		[]entity.RuleParameter{
+			{
+				Name:        "list_items",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Include list items in blockquotes",
+			},
 		},
 		md027Function,
 	)
@@ -17,14 +17,20 @@ import (
 func NewMD029Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md029.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD029", "ol-prefix"},
 		"Ordered list item prefix",
 		[]string{"ol"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"style": "one_or_ordered", // one|ordered|zero|one_or_ordered
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     "one_or_ordered",
+				Allowed:     []string{"one", "ordered", "zero", "one_or_ordered"},
+				Description: "Ordered list numbering style",
+			},
 		},
 		md029Function,
 	)
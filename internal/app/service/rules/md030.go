@@ -16,17 +16,37 @@ import (
 func NewMD030Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md030.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD030", "list-marker-space"},
 		"Spaces after list markers",
 		[]string{"ol", "ul", "whitespace"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"ul_single": 1, // Spaces for single-line unordered list items
-			"ol_single": 1, // Spaces for single-line ordered list items
-			"ul_multi":  1, // Spaces for multi-line unordered list items
-			"ol_multi":  1, // Spaces for multi-line ordered list items
+		[]entity.RuleParameter{
+			{
+				Name:        "ul_single",
+				Type:        entity.ParameterTypeInteger,
+				Default:     1,
+				Description: "Spaces for single-line unordered list items",
+			},
+			{
+				Name:        "ol_single",
+				Type:        entity.ParameterTypeInteger,
+				Default:     1,
+				Description: "Spaces for single-line ordered list items",
+			},
+			{
+				Name:        "ul_multi",
+				Type:        entity.ParameterTypeInteger,
+				Default:     1,
+				Description: "Spaces for multi-line unordered list items",
+			},
+			{
+				Name:        "ol_multi",
+				Type:        entity.ParameterTypeInteger,
+				Default:     1,
+				Description: "Spaces for multi-line ordered list items",
+			},
 		},
 		md030Function,
 	)
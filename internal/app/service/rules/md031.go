@@ -14,14 +14,19 @@ import (
 func NewMD031Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md031.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD031", "blanks-around-fences"},
 		"Fenced code blocks should be surrounded by blank lines",
 		[]string{"blank_lines", "code"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"list_items": true, // Include list items
+		[]entity.RuleParameter{
+			{
+				Name:        "list_items",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Include list items",
+			},
 		},
 		md031Function,
 	)
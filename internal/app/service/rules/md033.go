@@ -15,14 +15,24 @@ import (
 func NewMD033Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md033.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD033", "no-inline-html"},
 		"Inline HTML",
 		[]string{"html"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"allowed_elements": []interface{}{}, // List of allowed HTML elements
+		[]entity.RuleParameter{
+			{
+				Name:        "allowed_elements",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "List of allowed HTML elements",
+			},
+			{
+				Name:        "table_allowed_elements",
+				Type:        entity.ParameterTypeStringList,
+				Description: "List of allowed HTML elements in tables, allowed_elements if not set",
+			},
 		},
 		md033Function,
 	)
@@ -34,10 +44,25 @@ func md033Function(ctx context.Context, params entity.RuleParams) functional.Res
 	// Get configuration
 	allowedElements := getStringSliceConfig(params.Config, "allowed_elements")
 
-	// Create set for fast lookup (case-insensitive)
-	allowedSet := make(map[string]bool)
+	tableAllowedElements := allowedElements
+	if _, exists := params.Config["table_allowed_elements"]; exists {
+		tableAllowedElements = getStringSliceConfig(params.Config, "table_allowed_elements")
+	}
+
+	// Create sets for fast lookup (case-insensitive)
+	lineAllowedSet := make(map[string]bool)
 	for _, element := range allowedElements {
-		allowedSet[strings.ToLower(element)] = true
+		lineAllowedSet[strings.ToLower(element)] = true
+	}
+	tableAllowedSet := make(map[string]bool)
+	for _, element := range tableAllowedElements {
+		tableAllowedSet[strings.ToLower(element)] = true
+	}
+	inTable := make(map[int]bool)
+	for _, table := range findTables(params.Lines, md060Measure) {
+		for _, row := range table.rows {
+			inTable[row.index] = true
+		}
 	}
 
 	// Regex patterns for HTML elements
@@ -54,6 +79,11 @@ func md033Function(ctx context.Context, params entity.RuleParams) functional.Res
 			continue
 		}
 
+		allowedSet := lineAllowedSet
+		if inTable[i] {
+			allowedSet = tableAllowedSet
+		}
+
 		// Find HTML tags
 		matches := htmlTagRegex.FindAllStringSubmatch(line, -1)
 		positions := htmlTagRegex.FindAllStringIndex(line, -1)
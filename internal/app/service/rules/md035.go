@@ -16,14 +16,19 @@ import (
 func NewMD035Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md035.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD035", "hr-style"},
 		"Horizontal rule style",
 		[]string{"hr"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"style": "consistent", // consistent|specific style string
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     "consistent",
+				Description: "Horizontal rule style (consistent uses the first rule found)",
+			},
 		},
 		md035Function,
 	)
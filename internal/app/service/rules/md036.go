@@ -15,14 +15,19 @@ import (
 func NewMD036Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md036.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD036", "no-emphasis-as-heading"},
 		"Emphasis used instead of a heading",
 		[]string{"emphasis", "headings"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"punctuation": ".,;:!?。，；：！？", // Punctuation that suggests it's not a heading
+		[]entity.RuleParameter{
+			{
+				Name:        "punctuation",
+				Type:        entity.ParameterTypeString,
+				Default:     ".,;:!?。，；：！？",
+				Description: "Punctuation that suggests it's not a heading",
+			},
 		},
 		md036Function,
 	)
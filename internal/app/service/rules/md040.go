@@ -14,15 +14,25 @@ import (
 func NewMD040Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md040.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD040", "fenced-code-language"},
 		"Fenced code blocks should have a language specified",
 		[]string{"code", "language"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"allowed_languages": []interface{}{}, // List of allowed languages (empty = any)
-			"language_only":     false,           // Require language only (no extra info)
+		[]entity.RuleParameter{
+			{
+				Name:        "allowed_languages",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "List of allowed languages (empty = any)",
+			},
+			{
+				Name:        "language_only",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Require language only (no extra info)",
+			},
 		},
 		md040Function,
 	)
@@ -15,16 +15,31 @@ import (
 func NewMD041Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md041.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD041", "first-line-h1", "first-line-heading"},
 		"First line in a file should be a top-level heading",
 		[]string{"headings"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"level":              1,                  // Heading level to require
-			"front_matter_title": `^\s*title\s*[:=]`, // RegExp for front matter title
-			"allow_preamble":     false,              // Allow content before first heading
+		[]entity.RuleParameter{
+			{
+				Name:        "level",
+				Type:        entity.ParameterTypeInteger,
+				Default:     1,
+				Description: "Heading level to require",
+			},
+			{
+				Name:        "front_matter_title",
+				Type:        entity.ParameterTypeString,
+				Default:     `^\s*title\s*[:=]`,
+				Description: "RegExp for front matter title",
+			},
+			{
+				Name:        "allow_preamble",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Allow content before first heading",
+			},
 		},
 		md041Function,
 	)
@@ -15,15 +15,25 @@ import (
 func NewMD043Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md043.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD043", "required-headings"},
 		"Required heading structure",
 		[]string{"headings"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"headings":   []interface{}{}, // Array of required heading texts or patterns
-			"match_case": false,           // Whether to match case
+		[]entity.RuleParameter{
+			{
+				Name:        "headings",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "Array of required heading texts or patterns",
+			},
+			{
+				Name:        "match_case",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Whether to match case",
+			},
 		},
 		md043Function,
 	)
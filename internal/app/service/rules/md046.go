@@ -15,14 +15,20 @@ import (
 func NewMD046Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md046.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD046", "code-block-style"},
 		"Code block style",
 		[]string{"code"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"style": "consistent", // consistent|fenced|indented
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     "consistent",
+				Allowed:     []string{"consistent", "fenced", "indented"},
+				Description: "Code block style (consistent uses the first block found)",
+			},
 		},
 		md046Function,
 	)
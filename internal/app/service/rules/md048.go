@@ -15,14 +15,20 @@ import (
 func NewMD048Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md048.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD048", "code-fence-style"},
 		"Code fence style",
 		[]string{"code"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"style": "consistent", // consistent|backtick|tilde
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     "consistent",
+				Allowed:     []string{"consistent", "backtick", "tilde"},
+				Description: "Code fence style (consistent uses the first fence found)",
+			},
 		},
 		md048Function,
 	)
@@ -16,14 +16,20 @@ import (
 func NewMD049Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md049.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD049", "emphasis-style"},
 		"Emphasis style",
 		[]string{"emphasis"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"style": "consistent", // consistent|asterisk|underscore
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     "consistent",
+				Allowed:     []string{"consistent", "asterisk", "underscore"},
+				Description: "Emphasis style (consistent uses the first emphasis found)",
+			},
 		},
 		md049Function,
 	)
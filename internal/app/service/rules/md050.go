@@ -16,14 +16,20 @@ import (
 func NewMD050Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md050.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD050", "strong-style"},
 		"Strong style",
 		[]string{"emphasis"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"style": "consistent", // consistent|asterisk|underscore
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     "consistent",
+				Allowed:     []string{"consistent", "asterisk", "underscore"},
+				Description: "Strong style (consistent uses the first strong emphasis found)",
+			},
 		},
 		md050Function,
 	)
@@ -2,6 +2,7 @@ package rules
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 	"regexp"
 	"strings"
@@ -9,19 +10,34 @@ import (
 	"github.com/gomdlint/gomdlint/internal/domain/entity"
 	"github.com/gomdlint/gomdlint/internal/domain/value"
 	"github.com/gomdlint/gomdlint/internal/shared/functional"
+	"github.com/gomdlint/gomdlint/internal/shared/slug"
 )
 
 // MD051 - Link fragments should be valid
 func NewMD051Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md051.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD051", "link-fragments"},
 		"Link fragments should be valid",
 		[]string{"links"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{},
+		[]entity.RuleParameter{
+			slugStyleParameter(),
+			{
+				Name:        "ignore_case",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Match fragments to anchors without regard to case",
+			},
+			{
+				Name:        "ignored_pattern",
+				Type:        entity.ParameterTypeString,
+				Default:     "",
+				Description: "Regular expression for fragments to ignore",
+			},
+		},
 		md051Function,
 	)
 }
@@ -29,47 +45,29 @@ func NewMD051Rule() functional.Result[*entity.Rule] {
 func md051Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
 	var violations []value.Violation
 
-	// Collect all headings that could be valid fragment targets
-	validFragments := make(map[string]bool)
-
-	// Regex for headings
-	atxRegex := regexp.MustCompile(`^(\s*)(#{1,6})(\s+)(.*)$`)
-	setextRegex := regexp.MustCompile(`^(=+|-+)\s*$`)
-
-	// First pass: collect all possible fragment targets
-	for i, line := range params.Lines {
-		trimmed := strings.TrimSpace(line)
-
-		if trimmed == "" {
-			continue
-		}
-
-		var headingText string
-		var isHeading bool
-
-		// Check for ATX headings
-		if matches := atxRegex.FindStringSubmatch(line); matches != nil {
-			headingText = strings.TrimSpace(matches[4])
-			isHeading = true
-		}
+	// Collect all headings and anchors that could be valid fragment targets
+	validFragments, err := collectAnchors(params.Lines, getStringConfig(params.Config, "slug_style", slug.StyleGitHub))
+	if err != nil {
+		return functional.Err[[]value.Violation](err)
+	}
 
-		// Check for Setext headings
-		if !isHeading && setextRegex.MatchString(line) && i > 0 {
-			prevLine := strings.TrimSpace(params.Lines[i-1])
-			if prevLine != "" {
-				headingText = prevLine
-				isHeading = true
-			}
+	ignoreCase := getBoolConfig(params.Config, "ignore_case", false)
+	if ignoreCase {
+		folded := make(map[string]bool, len(validFragments))
+		for fragment := range validFragments {
+			folded[strings.ToLower(fragment)] = true
 		}
-
-		if isHeading && headingText != "" {
-			// Convert heading text to fragment identifier
-			fragment := headingToFragment(headingText)
-			validFragments[fragment] = true
+		validFragments = folded
+	}
+	var ignoredPattern *regexp.Regexp
+	if pattern := getStringConfig(params.Config, "ignored_pattern", ""); pattern != "" {
+		ignoredPattern, err = regexp.Compile(pattern)
+		if err != nil {
+			return functional.Err[[]value.Violation](fmt.Errorf("MD051: invalid ignored_pattern: %w", err))
 		}
 	}
 
-	// Second pass: check link fragments
+	// Check link fragments
 	linkRegex := regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\)`)
 
 	for i, line := range params.Lines {
@@ -87,13 +85,17 @@ func md051Function(ctx context.Context, params entity.RuleParams) functional.Res
 			if strings.HasPrefix(linkURL, "#") {
 				fragment := linkURL[1:] // Remove the #
 
-				// Skip empty fragments
-				if fragment == "" {
+				// Skip empty and ignored fragments
+				if fragment == "" || (ignoredPattern != nil && ignoredPattern.MatchString(fragment)) {
 					continue
 				}
 
 				// Check if fragment exists as a valid heading
-				if !validFragments[fragment] {
+				target := fragment
+				if ignoreCase {
+					target = strings.ToLower(fragment)
+				}
+				if !validFragments[target] {
 					violation := value.NewViolation(
 						[]string{"MD051", "link-fragments"},
 						"Link fragments should be valid",
@@ -114,24 +116,3 @@ func md051Function(ctx context.Context, params entity.RuleParams) functional.Res
 
 	return functional.Ok(violations)
 }
-
-// headingToFragment converts a heading text to a URL fragment identifier
-// This follows GitHub-style fragment generation rules
-func headingToFragment(heading string) string {
-	// Convert to lowercase
-	fragment := strings.ToLower(heading)
-
-	// Replace spaces with hyphens
-	fragment = regexp.MustCompile(`\s+`).ReplaceAllString(fragment, "-")
-
-	// Remove non-alphanumeric characters except hyphens
-	fragment = regexp.MustCompile(`[^a-z0-9\-]`).ReplaceAllString(fragment, "")
-
-	// Remove leading/trailing hyphens
-	fragment = strings.Trim(fragment, "-")
-
-	// Collapse multiple hyphens
-	fragment = regexp.MustCompile(`-+`).ReplaceAllString(fragment, "-")
-
-	return fragment
-}
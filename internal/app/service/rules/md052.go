@@ -15,14 +15,25 @@ import (
 func NewMD052Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md052.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD052", "reference-links-images"},
 		"Reference links and images should use defined labels",
 		[]string{"links", "images"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"shortcut_syntax": false, // Allow shortcut reference syntax [label] without [label][]
+		[]entity.RuleParameter{
+			{
+				Name:        "shortcut_syntax",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Allow shortcut reference syntax [label] without [label][]",
+			},
+			{
+				Name:        "ignored_labels",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{"x"},
+				Description: "Labels to accept without a definition, such as the x of task list items",
+			},
 		},
 		md052Function,
 	)
@@ -47,12 +58,17 @@ func md052Function(ctx context.Context, params entity.RuleParams) functional.Res
 		}
 	}
 
+	// Ignored labels count as defined
+	for _, label := range getStringSliceConfig(params.Config, "ignored_labels") {
+		definedLabels[strings.ToLower(strings.TrimSpace(label))] = true
+	}
+
 	// Second pass: check reference links and images
 	referenceLinkRegex := regexp.MustCompile(`\[([^\]]*)\]\[([^\]]*)\]`)   // [text][label]
 	referenceImageRegex := regexp.MustCompile(`!\[([^\]]*)\]\[([^\]]*)\]`) // ![alt][label]
 
 	// Shortcut reference syntax (if not allowed, we'll check these too)
-	shortcutLinkRegex := regexp.MustCompile(`\[([^\]]+)\]`)   // [label] (we'll check manually if not followed by [ ( or 
+	shortcutLinkRegex := regexp.MustCompile(`\[([^\]]+)\]`)   // [label] (we'll check manually if not followed by [ ( or
 	shortcutImageRegex := regexp.MustCompile(`!\[([^\]]+)\]`) // ![label] (we'll check manually if not followed by [ or ()
 
 	for i, line := range params.Lines {
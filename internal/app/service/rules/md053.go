@@ -15,14 +15,19 @@ import (
 func NewMD053Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md053.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD053", "link-image-reference-definitions"},
 		"Link and image reference definitions should be needed",
 		[]string{"links", "images"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"ignored_definitions": []interface{}{}, // Array of reference labels to ignore
+		[]entity.RuleParameter{
+			{
+				Name:        "ignored_definitions",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{},
+				Description: "Array of reference labels to ignore",
+			},
 		},
 		md053Function,
 	)
@@ -57,7 +62,7 @@ func md053Function(ctx context.Context, params entity.RuleParams) functional.Res
 	// Regex patterns for different reference types
 	referenceLinkRegex := regexp.MustCompile(`\[([^\]]*)\]\[([^\]]*)\]`)   // [text][label]
 	referenceImageRegex := regexp.MustCompile(`!\[([^\]]*)\]\[([^\]]*)\]`) // ![alt][label]
-	shortcutLinkRegex := regexp.MustCompile(`\[([^\]]+)\](?!\[|\(|`)     // [label] (not followed by [ ( or 
+	shortcutLinkRegex := regexp.MustCompile(`\[([^\]]+)\](?!\[|\(|`)       // [label] (not followed by [ ( or
 	shortcutImageRegex := regexp.MustCompile(`!\[([^\]]+)\](?!\[|\()`)     // ![label] (not followed by [ or ()
 
 	for _, line := range params.Lines {
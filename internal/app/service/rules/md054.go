@@ -14,19 +14,54 @@ import (
 func NewMD054Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md054.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD054", "link-image-style"},
 		"Link and image style",
 		[]string{"images", "links"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"autolink":       true, // Allow autolinks
-			"inline":         true, // Allow inline links
-			"full_reference": true, // Allow full reference links [text][label]
-			"collapsed":      true, // Allow collapsed reference links [text][]
-			"shortcut":       true, // Allow shortcut reference links [text]
-			"url_inline":     true, // Allow inline URLs in angle brackets
+		[]entity.RuleParameter{
+			{
+				Name:        "autolink",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Allow autolinks",
+			},
+			{
+				Name:        "inline",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Allow inline links",
+			},
+			{
+				Name:        "full",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Allow full reference links [text][label]",
+			},
+			{
+				Name:        "full_reference",
+				Type:        entity.ParameterTypeBoolean,
+				Description: "Alias for full",
+			},
+			{
+				Name:        "collapsed",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Allow collapsed reference links [text][]",
+			},
+			{
+				Name:        "shortcut",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Allow shortcut reference links [text]",
+			},
+			{
+				Name:        "url_inline",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     true,
+				Description: "Allow inline URLs in angle brackets",
+			},
 		},
 		md054Function,
 	)
@@ -38,7 +73,10 @@ func md054Function(ctx context.Context, params entity.RuleParams) functional.Res
 	// Get configuration
 	allowAutolink := getBoolConfig(params.Config, "autolink", true)
 	allowInline := getBoolConfig(params.Config, "inline", true)
-	allowFullReference := getBoolConfig(params.Config, "full_reference", true)
+	allowFullReference := getBoolConfig(params.Config, "full", true)
+	if fullReference, ok := params.Config["full_reference"].(bool); ok {
+		allowFullReference = fullReference
+	}
 	allowCollapsed := getBoolConfig(params.Config, "collapsed", true)
 	allowShortcut := getBoolConfig(params.Config, "shortcut", true)
 
@@ -50,8 +88,10 @@ func md054Function(ctx context.Context, params entity.RuleParams) functional.Res
 	fullReferenceImageRegex := regexp.MustCompile(`!\[([^\]]*)\]\[([^\]]+)\]`)
 	collapsedReferenceLinkRegex := regexp.MustCompile(`\[([^\]]+)\]\[\s*\]`)
 	collapsedReferenceImageRegex := regexp.MustCompile(`!\[([^\]]+)\]\[\s*\]`)
-	shortcutLinkRegex := regexp.MustCompile(`\[([^\]]+)\](?!\[|\(|`)
-	shortcutImageRegex := regexp.MustCompile(`!\[([^\]]+)\](?!\[|\()`)
+	// Go regexps have no lookahead, so the character after a shortcut
+	// reference is matched too
+	shortcutLinkRegex := regexp.MustCompile(`\[([^\]]+)\](?:[^\[(]|$)`)
+	shortcutImageRegex := regexp.MustCompile(`!\[([^\]]+)\](?:[^\[(]|$)`)
 
 	// Process each line
 	for i, line := range params.Lines {
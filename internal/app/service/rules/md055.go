@@ -15,14 +15,20 @@ import (
 func NewMD055Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md055.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD055", "table-pipe-style"},
 		"Table pipe style",
 		[]string{"table"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"style": "consistent", // consistent|leading_and_trailing|leading_only|trailing_only|no_leading_or_trailing
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     "consistent",
+				Allowed:     []string{"consistent", "leading_and_trailing", "leading_only", "trailing_only", "no_leading_or_trailing"},
+				Description: "Table pipe style (consistent uses the first table found)",
+			},
 		},
 		md055Function,
 	)
@@ -15,14 +15,19 @@ import (
 func NewMD059Rule() functional.Result[*entity.Rule] {
 	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md059.md")
 
-	return entity.NewRule(
+	return entity.NewRuleWithParameters(
 		[]string{"MD059", "descriptive-link-text"},
 		"Link text should be descriptive",
 		[]string{"accessibility", "links"},
 		infoURL,
 		"commonmark",
-		map[string]interface{}{
-			"prohibited_texts": []interface{}{"click here", "here", "link", "more"}, // Prohibited link texts
+		[]entity.RuleParameter{
+			{
+				Name:        "prohibited_texts",
+				Type:        entity.ParameterTypeStringList,
+				Default:     []string{"click here", "here", "link", "more"},
+				Description: "Prohibited link texts",
+			},
 		},
 		md059Function,
 	)
@@ -0,0 +1,201 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// Table column styles accepted by MD060.
+const (
+	tableStyleAny     = "any"
+	tableStyleAligned = "aligned"
+	tableStyleCompact = "compact"
+	tableStyleTight   = "tight"
+)
+
+// MD060 - Table column style
+func NewMD060Rule() functional.Result[*entity.Rule] {
+	infoURL, _ := url.Parse("https://github.com/gomdlint/gomdlint/blob/main/docs/rules/md060.md")
+
+	return entity.NewRuleWithParameters(
+		[]string{"MD060", "table-column-style"},
+		"Table column style",
+		[]string{"table"},
+		infoURL,
+		"commonmark",
+		[]entity.RuleParameter{
+			{
+				Name:        "style",
+				Type:        entity.ParameterTypeString,
+				Default:     tableStyleAny,
+				Allowed:     []string{tableStyleAny, tableStyleAligned, tableStyleCompact, tableStyleTight},
+				Description: "Table column style (any, aligned, compact or tight)",
+			},
+			{
+				Name:        "aligned_delimiter",
+				Type:        entity.ParameterTypeBoolean,
+				Default:     false,
+				Description: "Align the pipes of the delimiter row with the header row in compact and tight tables",
+			},
+		},
+		md060Function,
+	)
+}
+
+func md060Function(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	var violations []value.Violation
+
+	style := getStringConfig(params.Config, "style", tableStyleAny)
+	alignDelimiter := getBoolConfig(params.Config, "aligned_delimiter", false)
+
+	for _, table := range findTables(params.Lines, md060Measure) {
+		tableStyle := style
+		if tableStyle == tableStyleAny {
+			tableStyle = detectTableStyle(table)
+		}
+
+		widths := table.alignedWidths()
+		for _, row := range table.rows {
+			if len(row.cells) != len(table.alignments) {
+				// Rows with missing or extra cells are reported by MD056
+				continue
+			}
+
+			expected := ""
+			if alignDelimiter && row.delimiter && tableStyle != tableStyleAligned {
+				expected = alignedDelimiterRow(table, tableStyle)
+				if strings.TrimRight(row.line, " \t") == expected {
+					continue
+				}
+			} else if rowHasTableStyle(table, row, tableStyle, widths) {
+				continue
+			}
+
+			lineNumber := row.index + 1
+			violation := value.NewViolation(
+				[]string{"MD060", "table-column-style"},
+				"Table column style",
+				nil,
+				lineNumber,
+			)
+			violation = violation.WithErrorDetail(fmt.Sprintf("Table row is not formatted for style \"%s\"", tableStyle))
+			violation = violation.WithErrorContext(strings.TrimSpace(row.line))
+
+			// Aligned rows can only be fixed when their content fits the
+			// columns of the header
+			fixed := expected
+			if fixed == "" {
+				fixed = renderTableRow(table, row, tableStyle, widths)
+			}
+			if tableStyle != tableStyleAligned || rowHasTableStyle(table, tableRowCells{line: fixed}, tableStyle, widths) {
+				fixInfo := value.NewFixInfo().
+					WithLineNumber(lineNumber).
+					WithEditColumn(1).
+					WithDeleteLength(len(row.line)).
+					WithReplaceText(fixed)
+
+				violation = violation.WithFixInfo(*fixInfo)
+			}
+
+			violations = append(violations, *violation)
+		}
+	}
+
+	return functional.Ok(violations)
+}
+
+// detectTableStyle returns the style a table is consistently written in, or
+// the style of its header row when the rows disagree.
+func detectTableStyle(table markdownTable) string {
+	widths := table.alignedWidths()
+	for _, style := range []string{tableStyleAligned, tableStyleCompact, tableStyleTight} {
+		consistent := true
+		for _, row := range table.rows {
+			if len(row.cells) == len(table.alignments) && !rowHasTableStyle(table, row, style, widths) {
+				consistent = false
+				break
+			}
+		}
+		if consistent {
+			return style
+		}
+	}
+
+	header := table.rows[0]
+	for _, style := range []string{tableStyleCompact, tableStyleTight} {
+		if rowHasTableStyle(table, header, style, widths) {
+			return style
+		}
+	}
+	return tableStyleAligned
+}
+
+// rowHasTableStyle reports whether row is formatted in style. Aligned rows
+// only need their pipes in the same columns as the header row's.
+func rowHasTableStyle(table markdownTable, row tableRowCells, style string, widths []int) bool {
+	line := strings.TrimRight(row.line, " \t")
+	if style == tableStyleAligned {
+		return slices.Equal(pipeColumns(line), pipeColumns(table.rows[0].line))
+	}
+	return line == renderTableRow(table, row, style, widths)
+}
+
+// renderTableRow formats row in style.
+func renderTableRow(table markdownTable, row tableRowCells, style string, widths []int) string {
+	switch style {
+	case tableStyleCompact:
+		return formatPadded(row, " ")
+	case tableStyleTight:
+		return formatPadded(row, "")
+	default:
+		return table.formatAligned(row, widths)
+	}
+}
+
+// alignedDelimiterRow renders the delimiter row of a compact or tight table
+// with its pipes under those of the header row, as far as the minimum width
+// of each delimiter cell allows.
+func alignedDelimiterRow(table markdownTable, style string) string {
+	padding := " "
+	if style == tableStyleTight {
+		padding = ""
+	}
+	header, delimiter := table.rows[0], table.rows[1]
+	cells := make([]string, len(delimiter.cells))
+	for i := range delimiter.cells {
+		width := table.measure.minimumWidth(table.alignment(i))
+		if i < len(header.cells) {
+			width = max(width, table.measure.width(strings.TrimSpace(header.cells[i])))
+		}
+		cells[i] = alignedDelimiter(table.alignment(i), width)
+	}
+	return joinTableRow(delimiter, cells, padding)
+}
+
+// alignedWidths returns the column widths an aligned table is rendered
+// with: wide enough for every cell, and no narrower than the header row so
+// tables padded beyond their content keep their pipes where they are.
+func (t markdownTable) alignedWidths() []int {
+	widths := t.columnWidths()
+	header := t.rows[0]
+	for i, cell := range header.cells {
+		padding := 0
+		if i > 0 || header.leading {
+			padding++
+		}
+		if i < len(header.cells)-1 || header.trailing {
+			padding++
+		}
+		if width := t.measure.width(cell) - padding; i < len(widths) && width > widths[i] {
+			widths[i] = width
+		}
+	}
+	return widths
+}
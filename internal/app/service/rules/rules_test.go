@@ -2,6 +2,9 @@ package rules
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -104,6 +107,26 @@ func TestMD001_InvalidHeadingJump(t *testing.T) {
 	assert.Contains(t, violations[0].RuleNames, "MD001")
 }
 
+func TestMD001_FrontMatterTitle(t *testing.T) {
+	rule := NewMD001Rule().Unwrap()
+
+	lines := []string{"### Details"}
+	tokens := []value.Token{createHeadingToken(3, "### Details", 1)}
+
+	violationCount := func(frontMatter []string, config map[string]interface{}) int {
+		params := createRuleParams(lines, tokens, config, "test.md")
+		params.FrontMatterLines = frontMatter
+		result := rule.Execute(context.Background(), params)
+		require.True(t, result.IsOk())
+		return len(result.Unwrap())
+	}
+
+	assert.Equal(t, 0, violationCount(nil, map[string]interface{}{}))
+	assert.Equal(t, 1, violationCount([]string{"---", "title: Guide", "---"}, map[string]interface{}{}), "The title counts as a level 1 heading")
+	assert.Equal(t, 1, violationCount([]string{"---", "Title: Guide", "---"}, map[string]interface{}{"front_matter_title": `^\s*title\s*[:=]`}), "The title pattern ignores case")
+	assert.Equal(t, 0, violationCount([]string{"---", "title: Guide", "---"}, map[string]interface{}{"front_matter_title": ""}), "An empty pattern disables the title")
+}
+
 // MD003 Tests - Heading style
 func TestNewMD003Rule(t *testing.T) {
 	result := NewMD003Rule()
@@ -222,6 +245,22 @@ func TestMD013_ExceedsLineLength(t *testing.T) {
 	assert.Contains(t, violations[0].RuleNames, "MD013")
 }
 
+func TestMD013_StrictMode(t *testing.T) {
+	rule := NewMD013Rule().Unwrap()
+
+	lines := []string{"See https://example.com/a/very/long/path/that/keeps/going/on"}
+
+	violationCount := func(config map[string]interface{}) int {
+		result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+		require.True(t, result.IsOk())
+		return len(result.Unwrap())
+	}
+
+	assert.Equal(t, 0, violationCount(map[string]interface{}{"line_length": 40}), "Long URLs are allowed by default")
+	assert.Equal(t, 1, violationCount(map[string]interface{}{"line_length": 40, "strict": true}))
+	assert.Equal(t, 1, violationCount(map[string]interface{}{"line_length": 40, "stern": true}), "Stern only allows lines of a single word")
+}
+
 func TestMD013_IgnoreCodeBlocks(t *testing.T) {
 	rule := NewMD013Rule().Unwrap()
 
@@ -616,7 +655,7 @@ func TestNewMD010Rule(t *testing.T) {
 
 	config := rule.Config()
 	assert.Equal(t, true, config["code_blocks"])
-	assert.Equal(t, []interface{}{}, config["ignore_code_languages"])
+	assert.Equal(t, []string{}, config["ignore_code_languages"])
 	assert.Equal(t, 4, config["spaces_per_tab"])
 }
 
@@ -2650,7 +2689,7 @@ func TestNewMD033Rule(t *testing.T) {
 	assert.Contains(t, rule.Tags(), "html")
 
 	config := rule.Config()
-	assert.Equal(t, []interface{}{}, config["allowed_elements"])
+	assert.Equal(t, []string{}, config["allowed_elements"])
 }
 
 func TestMD033_NoHTML(t *testing.T) {
@@ -2740,6 +2779,35 @@ func TestMD033_AllowedElements(t *testing.T) {
 	}
 }
 
+func TestMD033_TableAllowedElements(t *testing.T) {
+	rule := NewMD033Rule().Unwrap()
+
+	lines := []string{
+		"Text with <br> and <kbd>K</kbd>",
+		"",
+		"| Key | Value |",
+		"| --- | ----- |",
+		"| a<br>b | <kbd>K</kbd> |",
+	}
+
+	disallowed := func(config map[string]interface{}) []string {
+		result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+		require.True(t, result.IsOk())
+
+		var found []string
+		for _, violation := range result.Unwrap() {
+			found = append(found, fmt.Sprintf("%d:%s", violation.LineNumber, violation.ErrorContext.Unwrap()))
+		}
+		return found
+	}
+
+	assert.Equal(t, []string{"1:<br>", "5:<br>"}, disallowed(map[string]interface{}{"allowed_elements": []string{"kbd"}}), "Tables use allowed_elements by default")
+	assert.Equal(t, []string{"1:<br>", "5:<kbd>", "5:</kbd>"}, disallowed(map[string]interface{}{
+		"allowed_elements":       []string{"kbd"},
+		"table_allowed_elements": []string{"br"},
+	}))
+}
+
 // MD034 Tests - Bare URLs
 func TestNewMD034Rule(t *testing.T) {
 	result := NewMD034Rule()
@@ -3157,7 +3225,7 @@ func TestNewMD040Rule(t *testing.T) {
 	assert.Contains(t, rule.Tags(), "language")
 
 	config := rule.Config()
-	assert.Equal(t, []interface{}{}, config["allowed_languages"])
+	assert.Equal(t, []string{}, config["allowed_languages"])
 	assert.Equal(t, false, config["language_only"])
 }
 
@@ -3399,7 +3467,7 @@ func TestNewMD043Rule(t *testing.T) {
 	assert.Contains(t, rule.Tags(), "headings")
 
 	config := rule.Config()
-	assert.Equal(t, []interface{}{}, config["headings"])
+	assert.Equal(t, []string{}, config["headings"])
 	assert.Equal(t, false, config["match_case"])
 }
 
@@ -3413,11 +3481,54 @@ func TestNewMD044Rule(t *testing.T) {
 	assert.Contains(t, rule.Tags(), "spelling")
 
 	config := rule.Config()
-	assert.Equal(t, []interface{}{}, config["names"])
+	assert.Equal(t, []string{}, config["names"])
 	assert.Equal(t, true, config["code_blocks"])
 	assert.Equal(t, true, config["html_elements"])
 }
 
+func TestMD044_ProseText(t *testing.T) {
+	rule := NewMD044Rule().Unwrap()
+
+	lines := []string{
+		"Use javascript, see [javascript](https://javascript.info/).",
+		"Run `javascript` or <https://github.com/javascript>.",
+		"",
+		"```",
+		"javascript",
+		"```",
+	}
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		expected []int // Lines of the violations
+	}{
+		{"skips URLs and link destinations", map[string]interface{}{"names": []string{"JavaScript"}}, []int{1, 1, 2, 5}},
+		{"code_blocks covers code spans", map[string]interface{}{"names": []string{"JavaScript"}, "code_blocks": false}, []int{1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := rule.Execute(context.Background(), createRuleParams(lines, nil, tt.config, "test.md"))
+			require.True(t, result.IsOk())
+
+			var violationLines []int
+			for _, violation := range result.Unwrap() {
+				violationLines = append(violationLines, violation.LineNumber)
+			}
+			assert.Equal(t, tt.expected, violationLines)
+		})
+	}
+
+	result := rule.Execute(context.Background(), createRuleParams(lines[:1], nil, map[string]interface{}{"names": []string{"JavaScript"}}, "test.md"))
+	violation := result.Unwrap()[0]
+	assert.Equal(t, "'javascript' should be 'JavaScript'", violation.ErrorDetail.Unwrap())
+	fixInfo := violation.FixInfo.Unwrap()
+	assert.Equal(t, 5, fixInfo.EditColumn.Unwrap())
+	assert.Equal(t, 10, fixInfo.DeleteLength.Unwrap())
+	assert.Equal(t, "JavaScript", fixInfo.ReplaceText.Unwrap())
+}
+
 // MD045 Tests - Images should have alternate text
 func TestNewMD045Rule(t *testing.T) {
 	result := NewMD045Rule()
@@ -3535,6 +3646,68 @@ func TestNewMD051Rule(t *testing.T) {
 	assert.Contains(t, rule.Tags(), "links")
 }
 
+func TestMD051_SlugStyles(t *testing.T) {
+	rule := NewMD051Rule().Unwrap()
+
+	lines := []string{
+		"# Foo & Bar",
+		"## Usage",
+		"## Usage",
+		"## Custom heading {#custom}",
+		`<a name="legacy"></a>`,
+		"",
+		"```markdown",
+		"# Not a heading",
+		"```",
+		"",
+		"[a](#foo--bar) [b](#foo-bar) [c](#usage-1) [d](#usage_1)",
+		"[e](#custom) [f](#legacy) [g](#not-a-heading)",
+	}
+
+	invalidFragments := func(config map[string]interface{}) []string {
+		result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+		require.True(t, result.IsOk())
+
+		var fragments []string
+		for _, violation := range result.Unwrap() {
+			fragments = append(fragments, strings.TrimPrefix(violation.ErrorDetail.Unwrap(), "Link fragment does not correspond to any heading: "))
+		}
+		return fragments
+	}
+
+	assert.Equal(t, []string{"#foo-bar", "#usage_1", "#not-a-heading"}, invalidFragments(map[string]interface{}{}), "GitHub is the default")
+	assert.Equal(t, []string{"#foo--bar", "#usage_1", "#not-a-heading"}, invalidFragments(map[string]interface{}{"slug_style": "gitlab"}))
+	assert.Equal(t, []string{"#foo--bar", "#usage-1", "#not-a-heading"}, invalidFragments(map[string]interface{}{"slug_style": "mkdocs"}))
+
+	_, err := rule.CoerceConfig(map[string]interface{}{"slug_style": "jekyll"})
+	assert.Error(t, err)
+}
+
+func TestMD051_IgnoreCaseAndPattern(t *testing.T) {
+	rule := NewMD051Rule().Unwrap()
+
+	lines := []string{"# Foo Bar", "", "[a](#Foo-Bar) [b](#ext-1)"}
+
+	invalidFragments := func(config map[string]interface{}) []string {
+		result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+		require.True(t, result.IsOk())
+
+		var fragments []string
+		for _, violation := range result.Unwrap() {
+			fragments = append(fragments, strings.TrimPrefix(violation.ErrorDetail.Unwrap(), "Link fragment does not correspond to any heading: "))
+		}
+		return fragments
+	}
+
+	assert.Equal(t, []string{"#Foo-Bar", "#ext-1"}, invalidFragments(map[string]interface{}{}))
+	assert.Equal(t, []string{"#ext-1"}, invalidFragments(map[string]interface{}{"ignore_case": true}))
+	assert.Equal(t, []string{"#Foo-Bar"}, invalidFragments(map[string]interface{}{"ignored_pattern": "^ext-"}))
+
+	result := rule.Execute(context.Background(), createRuleParams(lines, nil, map[string]interface{}{"ignored_pattern": "("}, "test.md"))
+	require.True(t, result.IsErr())
+	assert.Contains(t, result.Error().Error(), "invalid ignored_pattern")
+}
+
 // MD052 Tests - Reference links should be valid
 func TestNewMD052Rule(t *testing.T) {
 	result := NewMD052Rule()
@@ -3555,7 +3728,7 @@ func TestNewMD053Rule(t *testing.T) {
 	assert.Contains(t, rule.Tags(), "links")
 
 	config := rule.Config()
-	assert.Equal(t, []interface{}{}, config["ignored_definitions"])
+	assert.Equal(t, []string{}, config["ignored_definitions"])
 }
 
 // MD058 Tests - Tables should be surrounded by blank lines
@@ -3630,6 +3803,174 @@ func TestMD058_MissingBlankLines(t *testing.T) {
 	}
 }
 
+// MD060 Tests - Table column style
+func TestNewMD060Rule(t *testing.T) {
+	result := NewMD060Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"MD060", "table-column-style"}, rule.Names())
+	assert.Contains(t, rule.Tags(), "table")
+	assert.Equal(t, "any", rule.Config()["style"])
+
+	_, err := rule.CoerceConfig(map[string]interface{}{"style": "fancy"})
+	assert.Error(t, err)
+}
+
+func TestMD060_TableColumnStyle(t *testing.T) {
+	rule := NewMD060Rule().Unwrap()
+
+	aligned := []string{
+		"| Name | Description |",
+		"| :--- | ----------: |",
+		"| 名前 | Wide        |",
+	}
+	compact := []string{
+		"| Name | Description |",
+		"| :--- | ---: |",
+		"| a | b |",
+	}
+	tight := []string{
+		"|Name|Description|",
+		"|:---|---:|",
+		"|a|b|",
+	}
+	mixed := []string{
+		"| Name | Description |",
+		"|:---|---:|",
+		"|  a | b |",
+		"",
+		"```markdown",
+		"|a|  b|",
+		"|---|---|",
+		"```",
+	}
+	quoted := []string{
+		"> | Name | Description |",
+		"> |:---|---:|",
+		"> |  a | b |",
+		">",
+		"> > |a|b|",
+		"> > |-|-|",
+	}
+
+	violationLines := func(lines []string, style string) []int {
+		config := map[string]interface{}{}
+		if style != "" {
+			config["style"] = style
+		}
+		result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+		require.True(t, result.IsOk())
+
+		var lineNumbers []int
+		for _, violation := range result.Unwrap() {
+			lineNumbers = append(lineNumbers, violation.LineNumber)
+		}
+		return lineNumbers
+	}
+
+	assert.Empty(t, violationLines(aligned, ""))
+	assert.Empty(t, violationLines(compact, ""))
+	assert.Empty(t, violationLines(tight, ""))
+	assert.Equal(t, []int{2, 3}, violationLines(mixed, ""), "Header row decides the style of inconsistent tables")
+	assert.Equal(t, []int{2, 3}, violationLines(quoted, ""), "Tables in blockquotes are checked")
+
+	assert.Empty(t, violationLines(aligned, "aligned"))
+	assert.Equal(t, []int{2, 3}, violationLines(compact, "aligned"))
+	assert.Equal(t, []int{3}, violationLines(aligned, "compact"))
+	assert.Equal(t, []int{1, 2, 3}, violationLines(compact, "tight"))
+}
+
+func TestMD060_FixInformation(t *testing.T) {
+	rule := NewMD060Rule().Unwrap()
+
+	tests := []struct {
+		name             string
+		style            string
+		alignedDelimiter bool
+		lines            []string
+		expected         []string
+	}{
+		{
+			name:     "aligned pads to the header columns",
+			style:    "aligned",
+			lines:    []string{"| Name   | Center |", "|---|:-:|", "| a | x |"},
+			expected: []string{"| Name   | Center |", "| ------ | :----: |", "| a      |   x    |"},
+		},
+		{
+			name:     "aligned counts wide characters twice",
+			style:    "aligned",
+			lines:    []string{"| Key    | Value |", "| --- | --- |", "| 日本語 | ok |"},
+			expected: []string{"| Key    | Value |", "| ------ | ----- |", "| 日本語 | ok    |"},
+		},
+		{
+			name:     "aligned keeps delimiter cells three wide with colons",
+			style:    "aligned",
+			lines:    []string{"| a   | b   |", "|:-|-:|", "| c | d |"},
+			expected: []string{"| a   | b   |", "| :-- | --: |", "| c   |   d |"},
+		},
+		{
+			name:     "aligned rows wider than the header are not fixed",
+			style:    "aligned",
+			lines:    []string{"| a | b |", "| --- | --- |", "| longer | x |"},
+			expected: []string{"| a | b |", "| --- | --- |", "| longer | x |"},
+		},
+		{
+			name:     "compact without outer pipes",
+			style:    "compact",
+			lines:    []string{"a  |  b", "---|---", "c|d"},
+			expected: []string{"a | b", "--- | ---", "c | d"},
+		},
+		{
+			name:     "compact in a blockquote",
+			style:    "compact",
+			lines:    []string{"> | a  |  b |", "> | --- | --- |", ">|c|d|"},
+			expected: []string{"> | a | b |", "> | --- | --- |", ">| c | d |"},
+		},
+		{
+			name:     "tight",
+			style:    "tight",
+			lines:    []string{"| a | b |", "| --- | --- |", "| c | d |"},
+			expected: []string{"|a|b|", "|---|---|", "|c|d|"},
+		},
+		{
+			name:             "compact with an aligned delimiter",
+			style:            "compact",
+			alignedDelimiter: true,
+			lines:            []string{"| Name | Description |", "| --- | ---: |", "| a | b |"},
+			expected:         []string{"| Name | Description |", "| ---- | ----------: |", "| a | b |"},
+		},
+		{
+			name:             "tight with an aligned delimiter",
+			style:            "tight",
+			alignedDelimiter: true,
+			lines:            []string{"|Name|Value|", "|:-:|---|", "|a|b|"},
+			expected:         []string{"|Name|Value|", "|:--:|-----|", "|a|b|"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"style": tt.style, "aligned_delimiter": tt.alignedDelimiter}
+			params := createRuleParams(tt.lines, nil, config, "test.md")
+			result := rule.Execute(context.Background(), params)
+			require.True(t, result.IsOk())
+
+			fixed := append([]string(nil), tt.lines...)
+			for _, violation := range result.Unwrap() {
+				if violation.FixInfo.IsNone() {
+					continue
+				}
+				fixInfo := violation.FixInfo.Unwrap()
+				assert.Equal(t, 1, fixInfo.EditColumn.Unwrap())
+				assert.Equal(t, len(tt.lines[violation.LineNumber-1]), fixInfo.DeleteLength.Unwrap())
+				fixed[fixInfo.LineNumber.Unwrap()-1] = fixInfo.ReplaceText.Unwrap()
+			}
+			assert.Equal(t, tt.expected, fixed)
+		})
+	}
+}
+
 // Additional behavioral tests for already covered rules
 func TestMD001_SkipATXClosedHeadings(t *testing.T) {
 	rule := NewMD001Rule().Unwrap()
@@ -4653,6 +4994,22 @@ func TestMD054_LinkImageStyleAdvanced(t *testing.T) {
 	}
 }
 
+func TestMD054_FullReferenceStyle(t *testing.T) {
+	rule := NewMD054Rule().Unwrap()
+
+	lines := []string{"[text][ref]", "", "[ref]: https://example.com"}
+
+	violationCount := func(config map[string]interface{}) int {
+		result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+		require.True(t, result.IsOk())
+		return len(result.Unwrap())
+	}
+
+	assert.Equal(t, 0, violationCount(map[string]interface{}{}))
+	assert.Equal(t, 1, violationCount(map[string]interface{}{"full": false}))
+	assert.Equal(t, 0, violationCount(map[string]interface{}{"full": false, "full_reference": true}), "full_reference is an alias for full")
+}
+
 func TestMD055_TablePipeStyleAdvanced(t *testing.T) {
 	rule := NewMD055Rule().Unwrap()
 
@@ -4978,6 +5335,29 @@ func TestMD052_ReferenceLinksAdvanced(t *testing.T) {
 	}
 }
 
+func TestMD052_IgnoredLabels(t *testing.T) {
+	rule := NewMD052Rule().Unwrap()
+
+	lines := []string{"[done][x] and [todo][y]"}
+
+	undefinedLabels := func(config map[string]interface{}) []string {
+		result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+		require.True(t, result.IsOk())
+
+		var labels []string
+		for _, violation := range result.Unwrap() {
+			if label, ok := strings.CutPrefix(violation.ErrorDetail.Unwrap(), "Reference link label is not defined: "); ok {
+				labels = append(labels, label)
+			}
+		}
+		return labels
+	}
+
+	assert.Equal(t, []string{"y"}, undefinedLabels(map[string]interface{}{}), "x is ignored by default, as for task lists")
+	assert.Equal(t, []string{"x", "y"}, undefinedLabels(map[string]interface{}{"ignored_labels": []string{}}))
+	assert.Equal(t, []string(nil), undefinedLabels(map[string]interface{}{"ignored_labels": []string{"X", "y"}}))
+}
+
 // Additional comprehensive edge case tests for maximum coverage
 
 func TestMD002_FirstHeadingH1EdgeCases(t *testing.T) {
@@ -6946,3 +7326,774 @@ func TestMD052_ReferenceDefinitionsAfterHeading(t *testing.T) {
 		})
 	}
 }
+
+// GMD001 Tests - Links to other files should resolve
+func TestNewGMD001Rule(t *testing.T) {
+	result := NewGMD001Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"GMD001", "cross-file-links"}, rule.Names())
+	assert.True(t, rule.IsDocumentSetRule())
+	assert.Contains(t, rule.Tags(), "links")
+}
+
+func TestGMD001_CrossFileLinks(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "guide"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logo.png"), nil, 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Notes.txt"), nil, 0644))
+
+	setup := entity.Document{
+		Filename: filepath.Join(dir, "guide", "setup.md"),
+		Lines: []string{
+			"# Setup",
+			"",
+			"## Install {#installing}",
+			"",
+			"Usage",
+			"-----",
+			"",
+			"## Usage",
+			"",
+			`<a id="manual-anchor"></a>`,
+		},
+	}
+	require.NoError(t, os.WriteFile(setup.Filename, []byte(strings.Join(setup.Lines, "\n")), 0644))
+
+	index := entity.Document{
+		Filename: filepath.Join(dir, "index.md"),
+		Lines: []string{
+			"# Index",
+			"",
+			"[ok](guide/setup.md) [dir](guide/) ![logo](logo.png \"Logo\")",
+			"[explicit](guide/setup.md#installing) [html](guide/setup.md#manual-anchor)",
+			"[duplicate](guide/setup.md#usage-1) [external](https://example.com/missing.md)",
+			"[missing](guide/missing.md) ![missing image](img/none.png)",
+			"[bad fragment](guide/setup.md#install) [case](notes.txt)",
+			"[same file](#index) `[code](nowhere.md)`",
+			"",
+			"```markdown",
+			"[fenced](nowhere.md)",
+			"```",
+			"",
+			"[ref]: ./guide/nothing.md",
+		},
+	}
+
+	rule := NewGMD001Rule().Unwrap()
+	result := rule.ExecuteDocumentSet(context.Background(), entity.DocumentSetParams{
+		Documents: []entity.Document{index, setup},
+	})
+	require.True(t, result.IsOk())
+
+	violations := result.Unwrap()
+	assert.Empty(t, violations[setup.Filename])
+
+	var details []string
+	for _, violation := range violations[index.Filename] {
+		details = append(details, violation.ErrorDetail.Unwrap())
+	}
+	assert.Equal(t, []string{
+		"Link target does not exist: guide/missing.md",
+		"Link target does not exist: img/none.png",
+		"Link fragment does not exist in guide/setup.md: #install",
+		"Link path case does not match the file system: notes.txt (found Notes.txt)",
+		"Link target does not exist: ./guide/nothing.md",
+	}, details)
+
+	missing := violations[index.Filename][0]
+	assert.Equal(t, 6, missing.LineNumber)
+	assert.Equal(t, "[missing](guide/missing.md)", missing.ErrorContext.Unwrap())
+	assert.Equal(t, 1, missing.ColumnNumber.Unwrap())
+
+	// Images, case and ignored paths are configurable
+	result = rule.ExecuteDocumentSet(context.Background(), entity.DocumentSetParams{
+		Documents: []entity.Document{index, setup},
+		Config: map[string]interface{}{
+			"images":         false,
+			"case_sensitive": false,
+			"fragments":      false,
+			"ignored_paths":  []string{"guide/*.md"},
+		},
+	})
+	require.True(t, result.IsOk())
+	details = nil
+	for _, violation := range result.Unwrap()[index.Filename] {
+		details = append(details, violation.ErrorDetail.Unwrap())
+	}
+	assert.Equal(t, []string{"Link target does not exist: ./guide/nothing.md"}, details)
+}
+
+func TestNewGMD002Rule(t *testing.T) {
+	result := NewGMD002Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"GMD002", "table-format"}, rule.Names())
+	assert.Contains(t, rule.Tags(), "table")
+	assert.Equal(t, false, rule.Config()["compact_wide_tables"])
+	assert.Equal(t, 80, rule.Config()["line_length"])
+}
+
+func TestGMD002_TableFormat(t *testing.T) {
+	rule := NewGMD002Rule().Unwrap()
+
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		lines    []string
+		expected []string // Formatted table, or nil when already formatted
+	}{
+		{
+			name:  "aligns columns and normalizes delimiters",
+			lines: []string{"Text", "", "|Name|Price|Notes|", "|:-|-:|:-:|", "| Widget | 9.99 | ok |"},
+			expected: []string{
+				"| Name   | Price | Notes |",
+				"| :----- | ----: | :---: |",
+				"| Widget |  9.99 |  ok   |",
+			},
+		},
+		{
+			name:  "counts wide characters and emoji twice",
+			lines: []string{"| Key | Value |", "| --- | --- |", "| 日本 | 🎉 |", "| a | b |"},
+			expected: []string{
+				"| Key  | Value |",
+				"| ---- | ----- |",
+				"| 日本 | 🎉    |",
+				"| a    | b     |",
+			},
+		},
+		{
+			name:  "keeps missing outer pipes",
+			lines: []string{"a|b", "-|-", "long cell|c"},
+			expected: []string{
+				"a         | b",
+				"--------- | ---",
+				"long cell | c",
+			},
+		},
+		{
+			name:   "leaves wide tables compact",
+			config: map[string]interface{}{"compact_wide_tables": true, "line_length": 20},
+			lines:  []string{"| Name | Description |", "|:-|-|", "| a | A rather long description |"},
+			expected: []string{
+				"| Name | Description |",
+				"| :--- | --- |",
+				"| a | A rather long description |",
+			},
+		},
+		{
+			name:   "aligns narrow tables when compacting wide ones",
+			config: map[string]interface{}{"compact_wide_tables": true, "line_length": 80},
+			lines:  []string{"| a | b |", "| - | - |"},
+			expected: []string{
+				"| a   | b   |",
+				"| --- | --- |",
+			},
+		},
+		{
+			name:  "aligns tables in blockquotes",
+			lines: []string{"> Quote", ">", "> |a|b|", "> |:-|-|"},
+			expected: []string{
+				"> | a    | b   |",
+				"> | :--- | --- |",
+			},
+		},
+		{
+			name:  "formatted tables pass",
+			lines: []string{"| Name | Value |", "| :--- | ----: |", "| a    |     1 |"},
+		},
+		{
+			name:  "tables with a wrong cell count are left to MD056",
+			lines: []string{"| a | b |", "| - | - |", "| c |"},
+		},
+		{
+			name:  "tables in code are ignored",
+			lines: []string{"```", "|a|b|", "|-|-|", "```"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			if config == nil {
+				config = map[string]interface{}{}
+			}
+			result := rule.Execute(context.Background(), createRuleParams(tt.lines, nil, config, "test.md"))
+			require.True(t, result.IsOk())
+			violations := result.Unwrap()
+
+			if tt.expected == nil {
+				assert.Empty(t, violations)
+				return
+			}
+
+			require.Len(t, violations, 1, "One violation per table")
+			fixInfo := violations[0].FixInfo.Unwrap()
+			start := len(tt.lines) - len(tt.expected) + 1
+			assert.Equal(t, start, fixInfo.LineNumber.Unwrap())
+			assert.Equal(t, len(tt.expected), fixInfo.DeleteCount.Unwrap())
+			assert.Equal(t, strings.Join(tt.expected, "\n")+"\n", fixInfo.InsertText.Unwrap())
+		})
+	}
+}
+
+func TestNewGMD003Rule(t *testing.T) {
+	result := NewGMD003Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"GMD003", "prose"}, rule.Names())
+	assert.Contains(t, rule.Tags(), "prose")
+	assert.Equal(t, []string{}, rule.Config()["styles"])
+}
+
+func TestGMD003_Prose(t *testing.T) {
+	rule := NewGMD003Rule().Unwrap()
+
+	style := filepath.Join(t.TempDir(), "House")
+	require.NoError(t, os.Mkdir(style, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(style, "Utilize.yml"), []byte("extends: substitution\nlevel: error\nignorecase: true\nswap:\n  utilize: use\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(style, "Repeated.yml"), []byte("extends: repetition\nlevel: suggestion\n"), 0o644))
+
+	lines := []string{
+		"# Guide",
+		"",
+		"Utilize the the tool, not `utilize()`.",
+	}
+
+	result := rule.Execute(context.Background(), createRuleParams(lines, nil, map[string]interface{}{"styles": []string{style}}, "test.md"))
+	require.True(t, result.IsOk())
+	violations := result.Unwrap()
+	require.Len(t, violations, 2)
+
+	assert.Equal(t, "House.Repeated: 'the' is repeated", violations[0].ErrorDetail.Unwrap())
+	assert.Equal(t, value.SeverityInfo, violations[0].Severity)
+	assert.Equal(t, "House.Utilize: Use 'use' instead of 'Utilize'", violations[1].ErrorDetail.Unwrap())
+	assert.Equal(t, value.SeverityError, violations[1].Severity)
+	assert.Equal(t, 1, violations[1].ColumnNumber.Unwrap())
+	assert.Equal(t, "Use", violations[1].FixInfo.Unwrap().ReplaceText.Unwrap())
+
+	// Without styles the rule does nothing; broken styles are errors
+	result = rule.Execute(context.Background(), createRuleParams(lines, nil, map[string]interface{}{}, "test.md"))
+	require.True(t, result.IsOk())
+	assert.Empty(t, result.Unwrap())
+
+	result = rule.Execute(context.Background(), createRuleParams(lines, nil, map[string]interface{}{"styles": []string{filepath.Join(style, "missing.yml")}}, "test.md"))
+	assert.True(t, result.IsErr())
+}
+
+func TestNewGMD004Rule(t *testing.T) {
+	result := NewGMD004Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"GMD004", "spelling"}, rule.Names())
+	assert.Contains(t, rule.Tags(), "spelling")
+	assert.Equal(t, []string{".gomdlint-words.txt"}, rule.Config()["word_lists"])
+	assert.Equal(t, 5, rule.Config()["suggestions"])
+}
+
+func TestGMD004_Spelling(t *testing.T) {
+	rule := NewGMD004Rule().Unwrap()
+
+	dir := t.TempDir()
+	dictionary := filepath.Join(dir, "en_TEST")
+	require.NoError(t, os.WriteFile(dictionary+".aff", []byte("SET UTF-8\nTRY eiv\n\nSFX S Y 1\nSFX S 0 s .\n"), 0o644))
+	words := "the\nwe\nreceive/S\nmessage/S\nfrom\nsee\nrun\nin\nfile\nand\n"
+	require.NoError(t, os.WriteFile(dictionary+".dic", []byte(words), 0o644))
+	wordList := filepath.Join(dir, "words.txt")
+	require.NoError(t, os.WriteFile(wordList, []byte("# Project words\ngomdlint\n"), 0o644))
+
+	lines := []string{
+		"---",
+		"words: [Hugo]",
+		"---",
+		"",
+		"We recieve messages from Hugo and gomdlint.",
+		"",
+		"See `recieve`, https://example.com/recieve, HTTPServer, parse_file and config.yaml.",
+		"",
+		"```",
+		"recieve",
+		"```",
+		"",
+		"Run recieve in src/main.",
+	}
+	config := map[string]interface{}{
+		"dictionaries": []string{dictionary + ".dic"},
+		"word_lists":   []string{wordList, filepath.Join(dir, "missing.txt")},
+	}
+
+	result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+	require.True(t, result.IsOk())
+	violations := result.Unwrap()
+
+	require.Len(t, violations, 2, "Only prose words are checked")
+	assert.Equal(t, 5, violations[0].LineNumber)
+	assert.Equal(t, 4, violations[0].ColumnNumber.Unwrap())
+	assert.Equal(t, "Unknown word 'recieve' (suggestions: receive)", violations[0].ErrorDetail.Unwrap())
+	assert.Equal(t, "recieve", violations[0].ErrorContext.Unwrap())
+	assert.Equal(t, 13, violations[1].LineNumber)
+
+	config["suggestions"] = 0
+	result = rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+	require.True(t, result.IsOk())
+	assert.Equal(t, "Unknown word 'recieve'", result.Unwrap()[0].ErrorDetail.Unwrap())
+
+	// Without dictionaries the rule does nothing; missing ones are errors
+	result = rule.Execute(context.Background(), createRuleParams(lines, nil, map[string]interface{}{}, "test.md"))
+	require.True(t, result.IsOk())
+	assert.Empty(t, result.Unwrap())
+
+	result = rule.Execute(context.Background(), createRuleParams(lines, nil, map[string]interface{}{"dictionaries": []string{filepath.Join(dir, "missing")}}, "test.md"))
+	assert.True(t, result.IsErr())
+
+	// The default project list is found from the document's directory
+	project := filepath.Join(dir, "project")
+	require.NoError(t, os.MkdirAll(filepath.Join(project, "content", "posts"), 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(project, ".git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(project, ".gomdlint-words.txt"), []byte("gomdlint\nrecieve\n"), 0o644))
+	config = map[string]interface{}{
+		"dictionaries": []string{dictionary + ".dic"},
+		"word_lists":   []string{".gomdlint-words.txt"},
+	}
+	result = rule.Execute(context.Background(), createRuleParams(lines, nil, config, filepath.Join(project, "content", "posts", "post.md")))
+	require.True(t, result.IsOk())
+	assert.Empty(t, result.Unwrap())
+}
+
+func TestNewGMD005Rule(t *testing.T) {
+	result := NewGMD005Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"GMD005", "heading-case"}, rule.Names())
+	assert.Contains(t, rule.Tags(), "headings")
+	assert.Equal(t, "sentence", rule.Config()["style"])
+	assert.Equal(t, "", rule.Config()["h1"])
+}
+
+func TestGMD005_HeadingCase(t *testing.T) {
+	rule := NewGMD005Rule().Unwrap()
+
+	lines := []string{
+		"# the guide to visual studio code",
+		"",
+		"## Using The API With `Config` Files",
+		"",
+		"## `gomdlint` Rules: An Overview",
+		"",
+		"Setext Heading",
+		"--------------",
+		"",
+		"### Already in sentence case ###",
+		"",
+		"#### Skipped Level",
+		"",
+		"## 1. getting Started",
+		"",
+		"# 2. a tour of the code",
+	}
+	tokens := []value.Token{
+		createHeadingToken(1, lines[0], 1).WithProperty("level", 1),
+		createHeadingToken(2, lines[2], 3).WithProperty("level", 2),
+		createHeadingToken(2, lines[4], 5).WithProperty("level", 2),
+		value.NewToken(value.TokenTypeSetextHeading, lines[6], value.NewPosition(7, 1), value.NewPosition(8, 14)),
+		createHeadingToken(3, lines[9], 10).WithProperty("level", 3),
+		createHeadingToken(4, lines[11], 12).WithProperty("level", 4),
+		createHeadingToken(2, lines[13], 14).WithProperty("level", 2),
+		createHeadingToken(1, lines[15], 16).WithProperty("level", 1),
+	}
+	config := map[string]interface{}{
+		"style": "sentence",
+		"h1":    "title",
+		"h4":    "none",
+		"names": []string{"Visual Studio Code"},
+	}
+
+	result := rule.Execute(context.Background(), createRuleParams(lines, tokens, config, "test.md"))
+	require.True(t, result.IsOk())
+	violations := result.Unwrap()
+	require.Len(t, violations, 6)
+
+	tests := []struct {
+		line   int
+		detail string
+		column int
+		text   string
+	}{
+		{1, "Heading should be in title case: 'The Guide to Visual Studio Code'", 3, "The Guide to Visual Studio Code"},
+		{3, "Heading should be in sentence case: 'Using the API with `Config` files'", 10, "the API with `Config` files"},
+		{5, "Heading should be in sentence case: '`gomdlint` rules: An overview'", 15, "rules: An overview"},
+		{7, "Heading should be in sentence case: 'Setext heading'", 8, "heading"},
+		{14, "Heading should be in sentence case: '1. Getting started'", 7, "Getting started"},
+		{16, "Heading should be in title case: '2. A Tour of the Code'", 6, "A Tour of the Code"},
+	}
+	for i, tt := range tests {
+		violation := violations[i]
+		assert.Equal(t, tt.line, violation.LineNumber)
+		assert.Equal(t, tt.detail, violation.ErrorDetail.Unwrap())
+		fix := violation.FixInfo.Unwrap()
+		assert.Equal(t, tt.column, fix.EditColumn.Unwrap())
+		assert.Equal(t, tt.text, fix.ReplaceText.Unwrap())
+	}
+}
+
+func TestNewGMD006Rule(t *testing.T) {
+	result := NewGMD006Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"GMD006", "fenced-code-syntax"}, rule.Names())
+	assert.Contains(t, rule.Tags(), "code")
+	assert.Equal(t, true, rule.Config()["json"])
+	assert.Equal(t, false, rule.Config()["gofmt"])
+}
+
+func TestGMD006_FencedCodeSyntax(t *testing.T) {
+	rule := NewGMD006Rule().Unwrap()
+
+	lines := []string{
+		"# Examples",
+		"",
+		"```json",
+		"{",
+		"  \"a\": 1",
+		"  \"b\": 2",
+		"}",
+		"```",
+		"",
+		"- Item",
+		"",
+		"  ```yml",
+		"  a: 1",
+		"    b: 2",
+		"  ```",
+		"",
+		"```jsonld",
+		"{\"a\": [1,2]}",
+		"```",
+		"",
+		"````golang",
+		"func main() {",
+		"fmt.Println(\"hi\")",
+		"}",
+		"````",
+		"",
+		"```toml",
+		"[server]",
+		"port = 8080",
+		"```",
+		"",
+		"```python",
+		"def (",
+		"```",
+	}
+	config := map[string]interface{}{
+		"gofmt":       true,
+		"json_format": true,
+		"aliases":     map[string]interface{}{"jsonld": "json"},
+	}
+
+	result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+	require.True(t, result.IsOk())
+	violations := result.Unwrap()
+	require.Len(t, violations, 4)
+
+	assert.Equal(t, 6, violations[0].LineNumber)
+	assert.Equal(t, 3, violations[0].ColumnNumber.Unwrap())
+	assert.Contains(t, violations[0].ErrorDetail.Unwrap(), "Invalid JSON: ")
+
+	assert.Equal(t, 14, violations[1].LineNumber)
+	assert.Equal(t, "Invalid YAML: mapping values are not allowed in this context", violations[1].ErrorDetail.Unwrap())
+
+	assert.Equal(t, 18, violations[2].LineNumber)
+	fix := violations[2].FixInfo.Unwrap()
+	assert.Equal(t, 1, fix.DeleteCount.Unwrap())
+	assert.Equal(t, "{\n  \"a\": [\n    1,\n    2\n  ]\n}\n", fix.InsertText.Unwrap())
+
+	assert.Equal(t, "Go code is not formatted like gofmt", violations[3].ErrorDetail.Unwrap())
+	fix = violations[3].FixInfo.Unwrap()
+	assert.Equal(t, 22, fix.LineNumber.Unwrap())
+	assert.Equal(t, "func main() {\n\tfmt.Println(\"hi\")\n}\n", fix.InsertText.Unwrap())
+
+	// Languages can be turned off
+	config["yaml"] = false
+	config["json"] = false
+	config["go"] = false
+	result = rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+	require.True(t, result.IsOk())
+	assert.Empty(t, result.Unwrap())
+}
+
+func TestNewGMD007Rule(t *testing.T) {
+	result := NewGMD007Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"GMD007", "fenced-code-language-style"}, rule.Names())
+	assert.Contains(t, rule.Tags(), "language")
+	assert.Equal(t, "bash", rule.Config()["aliases"].(map[string]interface{})["sh"])
+}
+
+func TestGMD007_FenceLanguages(t *testing.T) {
+	rule := NewGMD007Rule().Unwrap()
+
+	lines := []string{
+		"```sh",
+		"$ make build",
+		"```",
+		"",
+		"````Golang title=\"main.go\"",
+		"package main",
+		"````",
+		"",
+		"~~~perl",
+		"print 1",
+		"~~~",
+		"",
+		"```ruby",
+		"puts 1",
+		"```",
+		"",
+		"```console",
+		"$ ls",
+		"```",
+		"",
+		"```",
+		"plain",
+		"```",
+	}
+	config := map[string]interface{}{
+		"allowed": []string{"go", "bash", "console", "perl"},
+		"denied":  []string{"perl"},
+	}
+
+	result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+	require.True(t, result.IsOk())
+	violations := result.Unwrap()
+	require.Len(t, violations, 5)
+
+	assert.Equal(t, "Language 'sh' should be written as 'bash'", violations[0].ErrorDetail.Unwrap())
+	fix := violations[0].FixInfo.Unwrap()
+	assert.Equal(t, 4, fix.EditColumn.Unwrap())
+	assert.Equal(t, "bash", fix.ReplaceText.Unwrap())
+
+	assert.Equal(t, 2, violations[1].LineNumber)
+	assert.Equal(t, 2, violations[1].FixInfo.Unwrap().DeleteLength.Unwrap())
+
+	assert.Equal(t, "Language 'Golang' should be written as 'go'", violations[2].ErrorDetail.Unwrap())
+	assert.Equal(t, 5, violations[2].FixInfo.Unwrap().EditColumn.Unwrap())
+	assert.Equal(t, "Language 'perl' is not allowed", violations[3].ErrorDetail.Unwrap())
+	assert.Equal(t, "Language 'ruby' is not in the allowed list (go, bash, console, perl)", violations[4].ErrorDetail.Unwrap())
+
+	// Prompts are left to MD014 when it is enabled
+	params := createRuleParams(lines, nil, config, "test.md")
+	params.RuleEnabled = func(name string) bool { return name == "MD014" }
+	result = rule.Execute(context.Background(), params)
+	require.True(t, result.IsOk())
+	assert.Len(t, result.Unwrap(), 4)
+}
+
+func TestNewGMD008Rule(t *testing.T) {
+	result := NewGMD008Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"GMD008", "front-matter-schema"}, rule.Names())
+	assert.Contains(t, rule.Tags(), "front_matter")
+	assert.Equal(t, "", rule.Config()["schema"])
+}
+
+func TestGMD008_FrontMatterSchema(t *testing.T) {
+	rule := NewGMD008Rule().Unwrap()
+
+	dir := t.TempDir()
+	postSchema := filepath.Join(dir, "post.yaml")
+	require.NoError(t, os.WriteFile(postSchema, []byte(`type: object
+required: [title, date, tags, draft]
+properties:
+  title: {type: string}
+  date: {type: string, format: date-time}
+  tags: {type: array, items: {$ref: "tags.json#/$defs/tag"}}
+  draft: {type: boolean}
+  author: {type: object, required: [name]}
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tags.json"), []byte(`{"$defs": {"tag": {"enum": ["go", "markdown"]}}}`), 0o644))
+	pageSchema := filepath.Join(dir, "page.json")
+	require.NoError(t, os.WriteFile(pageSchema, []byte(`{"required": ["title"]}`), 0o644))
+
+	config := map[string]interface{}{
+		"schema":  pageSchema,
+		"schemas": []interface{}{map[string]interface{}{"files": []interface{}{"posts/**/*.md"}, "schema": postSchema}},
+	}
+
+	// Front matter removed by the linter is numbered up to line 0
+	params := createRuleParams([]string{"", "# Post"}, nil, config, "posts/2024/post.md")
+	params.FrontMatterLines = []string{
+		"---",
+		"title: Post",
+		"date: 2024-05-01",
+		"tags:",
+		"  - go",
+		"  - rust",
+		"author: {}",
+		"---",
+	}
+	result := rule.Execute(context.Background(), params)
+	require.True(t, result.IsOk())
+	violations := result.Unwrap()
+	require.Len(t, violations, 4)
+
+	assert.Equal(t, -7, violations[0].LineNumber)
+	assert.Equal(t, "missing required property 'draft'", violations[0].ErrorDetail.Unwrap())
+	assert.Equal(t, -1, violations[1].LineNumber)
+	assert.Equal(t, "author: missing required property 'name'", violations[1].ErrorDetail.Unwrap())
+	assert.Equal(t, -5, violations[2].LineNumber)
+	assert.Equal(t, "date: must be a valid date-time (RFC 3339)", violations[2].ErrorDetail.Unwrap())
+	assert.Equal(t, -2, violations[3].LineNumber)
+	assert.Equal(t, "tags[1]: must be one of: go, markdown", violations[3].ErrorDetail.Unwrap())
+	assert.Equal(t, "- rust", violations[3].ErrorContext.Unwrap())
+
+	// TOML front matter in the lines; dates are valid date-times
+	lines := []string{
+		"+++",
+		`title = "Post"`,
+		"date = 2024-05-01T10:00:00Z",
+		`tags = ["go"]`,
+		`draft = "no"`,
+		"+++",
+	}
+	result = rule.Execute(context.Background(), createRuleParams(lines, nil, config, "posts/post.md"))
+	require.True(t, result.IsOk())
+	violations = result.Unwrap()
+	require.Len(t, violations, 1)
+	assert.Equal(t, 5, violations[0].LineNumber)
+	assert.Equal(t, "draft: must be boolean", violations[0].ErrorDetail.Unwrap())
+
+	// JSON front matter, outside the posts, uses the default schema
+	params = createRuleParams([]string{"# Page"}, nil, config, "about.md")
+	params.FrontMatterLines = []string{"{", `  "name": "About"`, "}"}
+	result = rule.Execute(context.Background(), params)
+	require.True(t, result.IsOk())
+	violations = result.Unwrap()
+	require.Len(t, violations, 1)
+	assert.Equal(t, -2, violations[0].LineNumber)
+
+	// Missing and invalid front matter
+	result = rule.Execute(context.Background(), createRuleParams([]string{"# Page"}, nil, config, "about.md"))
+	require.True(t, result.IsOk())
+	violations = result.Unwrap()
+	require.Len(t, violations, 1)
+	assert.Equal(t, 1, violations[0].LineNumber)
+	assert.Equal(t, "Front matter is missing: missing required property 'title'", violations[0].ErrorDetail.Unwrap())
+
+	result = rule.Execute(context.Background(), createRuleParams([]string{"---", "title: [", "---"}, nil, config, "about.md"))
+	require.True(t, result.IsOk())
+	violations = result.Unwrap()
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0].ErrorDetail.Unwrap(), "Front matter is not valid YAML")
+
+	// Without a schema the rule does nothing; invalid settings are errors
+	result = rule.Execute(context.Background(), createRuleParams([]string{"# Page"}, nil, map[string]interface{}{}, "about.md"))
+	require.True(t, result.IsOk())
+	assert.Empty(t, result.Unwrap())
+
+	result = rule.Execute(context.Background(), createRuleParams([]string{"# Page"}, nil, map[string]interface{}{"schema": filepath.Join(dir, "missing.json")}, "about.md"))
+	assert.True(t, result.IsErr())
+
+	result = rule.Execute(context.Background(), createRuleParams([]string{"# Page"}, nil, map[string]interface{}{"schemas": []interface{}{"posts/*.md"}}, "about.md"))
+	require.True(t, result.IsErr())
+	assert.Contains(t, result.Error().Error(), "schemas must be a list of {files, schema} objects")
+
+	// A map from globs to schemas is not accepted either
+	mapped := map[string]interface{}{"schemas": map[string]interface{}{"posts/**": postSchema}}
+	result = rule.Execute(context.Background(), createRuleParams([]string{"# Page"}, nil, mapped, "posts/post.md"))
+	require.True(t, result.IsErr())
+	assert.Contains(t, result.Error().Error(), "schemas must be a list of {files, schema} objects")
+}
+
+func TestGMD008UnusedSchemas(t *testing.T) {
+	config := map[string]interface{}{
+		"schema": "page.json",
+		"schemas": []interface{}{
+			map[string]interface{}{"files": "posts/**", "schema": "post.json"},
+			map[string]interface{}{"files": []interface{}{"drafts/**", "*.draft.md"}, "schema": "draft.json"},
+			map[string]interface{}{"files": "posts/2024/**", "schema": "archive.json"},
+		},
+	}
+
+	// Entries shadowed by an earlier match apply to nothing either
+	assert.Equal(t, []string{
+		"GMD008 schemas[1] (files drafts/**, **/*.draft.md) matches no linted file",
+		"GMD008 schemas[2] (files posts/2024/**) matches no linted file",
+		"GMD008 schema page.json applies to no linted file: schemas match them all",
+	}, GMD008UnusedSchemas(config, []string{"posts/2024/a.md", "posts/b.md"}))
+
+	assert.Equal(t, []string{
+		"GMD008 schemas[2] (files posts/2024/**) matches no linted file",
+	}, GMD008UnusedSchemas(config, []string{"posts/a.md", "notes/b.draft.md", "README.md"}))
+}
+
+func TestNewGMD009Rule(t *testing.T) {
+	result := NewGMD009Rule()
+	require.True(t, result.IsOk())
+
+	rule := result.Unwrap()
+	assert.Equal(t, []string{"GMD009", "front-matter-key-order"}, rule.Names())
+	assert.Equal(t, false, rule.Config()["alphabetical"])
+}
+
+func TestGMD009_FrontMatterKeyOrder(t *testing.T) {
+	rule := NewGMD009Rule().Unwrap()
+
+	lines := []string{
+		"---",
+		"tags:",
+		"  - go",
+		"# Publication date",
+		"date: 2024-05-01",
+		"title: Post",
+		"author: Me",
+		"",
+		"---",
+		"",
+		"# Post",
+	}
+	config := map[string]interface{}{"order": []string{"title", "date"}, "alphabetical": true}
+
+	result := rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+	require.True(t, result.IsOk())
+	violations := result.Unwrap()
+	require.Len(t, violations, 3)
+
+	assert.Equal(t, 5, violations[0].LineNumber)
+	assert.Equal(t, "Key 'date' should come before 'tags'", violations[0].ErrorDetail.Unwrap())
+	fix := violations[0].FixInfo.Unwrap()
+	assert.Equal(t, 2, fix.LineNumber.Unwrap())
+	assert.Equal(t, 6, fix.DeleteCount.Unwrap())
+	assert.Equal(t, "title: Post\n# Publication date\ndate: 2024-05-01\nauthor: Me\ntags:\n  - go\n", fix.InsertText.Unwrap())
+	assert.Equal(t, "Key 'title' should come before 'tags'", violations[1].ErrorDetail.Unwrap())
+	assert.True(t, violations[1].FixInfo.IsNone())
+	assert.Equal(t, "Key 'author' should come before 'tags'", violations[2].ErrorDetail.Unwrap())
+
+	// Unlisted keys keep their order unless sorted alphabetically
+	config["alphabetical"] = false
+	result = rule.Execute(context.Background(), createRuleParams(lines, nil, config, "test.md"))
+	require.True(t, result.IsOk())
+	assert.Len(t, result.Unwrap(), 2)
+
+	// TOML keys are not moved across tables
+	lines = []string{"+++", `title = "Post"`, "[params]", `b = 1`, "+++"}
+	params := createRuleParams([]string{"# Post"}, nil, map[string]interface{}{"alphabetical": true}, "test.md")
+	params.FrontMatterLines = lines
+	result = rule.Execute(context.Background(), params)
+	require.True(t, result.IsOk())
+	violations = result.Unwrap()
+	require.Len(t, violations, 1)
+	assert.Equal(t, -2, violations[0].LineNumber)
+	assert.Equal(t, "Key 'params' should come before 'title'", violations[0].ErrorDetail.Unwrap())
+	assert.True(t, violations[0].FixInfo.IsNone())
+}
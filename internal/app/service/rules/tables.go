@@ -0,0 +1,320 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+var (
+	tableDelimiterRowRegex = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	tableQuotePrefixRegex  = regexp.MustCompile(`^(?:[ \t]{0,3}>[ \t]?)*`)
+)
+
+// tableMeasure is how a table's cells are measured: the display width of
+// text and the narrowest column for each alignment.
+type tableMeasure struct {
+	width        func(s string) int
+	minimumWidth func(alignment string) int
+}
+
+// md060Measure counts East Asian wide characters and emoji as two columns,
+// characters of ambiguous width per the locale, and makes columns at least
+// three wide.
+var md060Measure = tableMeasure{
+	width:        runewidth.StringWidth,
+	minimumWidth: func(string) int { return 3 },
+}
+
+// Column alignments declared by a table delimiter row.
+const (
+	alignNone   = ""
+	alignLeft   = "left"
+	alignCenter = "center"
+	alignRight  = "right"
+)
+
+// markdownTable is a GFM table: a header row, a delimiter row and body rows.
+type markdownTable struct {
+	rows       []tableRowCells
+	alignments []string // Per column, from the delimiter row
+	measure    tableMeasure
+}
+
+// tableRowCells is one row of a table split into cells.
+type tableRowCells struct {
+	index     int      // 0-based line index
+	line      string   // Original line
+	prefix    string   // Indentation and blockquote markers before the row
+	cells     []string // Cell text with its padding, without pipes
+	leading   bool     // Starts with a pipe
+	trailing  bool     // Ends with a pipe
+	delimiter bool     // The delimiter row
+}
+
+// findTables returns the tables in lines, measured with measure, skipping
+// fenced and indented code. Tables in blockquotes keep to the quote depth
+// of their header row.
+func findTables(lines []string, measure tableMeasure) []markdownTable {
+	var tables []markdownTable
+	inCode := fencedCodeLines(lines)
+
+	for i := 0; i+1 < len(lines); i++ {
+		quote, header := splitTableQuote(lines[i])
+		nextQuote, delimiterRow := splitTableQuote(lines[i+1])
+		depth := strings.Count(quote, ">")
+		if inCode[i] || inCode[i+1] || isIndentedTableLine(header) || !strings.Contains(header, "|") ||
+			strings.Count(nextQuote, ">") != depth ||
+			!tableDelimiterRowRegex.MatchString(delimiterRow) || isIndentedTableLine(delimiterRow) {
+			continue
+		}
+
+		headerRow := splitTableRow(i, lines[i])
+		delimiter := splitTableRow(i+1, lines[i+1])
+		delimiter.delimiter = true
+		if len(headerRow.cells) != len(delimiter.cells) {
+			continue
+		}
+
+		table := markdownTable{rows: []tableRowCells{headerRow, delimiter}, measure: measure}
+		for _, cell := range delimiter.cells {
+			table.alignments = append(table.alignments, delimiterAlignment(cell))
+		}
+
+		j := i + 2
+		for ; j < len(lines); j++ {
+			rowQuote, row := splitTableQuote(lines[j])
+			if inCode[j] || strings.Count(rowQuote, ">") != depth || strings.TrimSpace(row) == "" ||
+				!strings.Contains(row, "|") || isIndentedTableLine(row) {
+				break
+			}
+			table.rows = append(table.rows, splitTableRow(j, lines[j]))
+		}
+
+		tables = append(tables, table)
+		i = j - 1
+	}
+
+	return tables
+}
+
+// splitTableQuote splits line into its blockquote markers and the rest.
+func splitTableQuote(line string) (quote, rest string) {
+	quote = tableQuotePrefixRegex.FindString(line)
+	return quote, line[len(quote):]
+}
+
+// isIndentedTableLine reports whether line is indented enough to be code.
+func isIndentedTableLine(line string) bool {
+	return strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+}
+
+// splitTableRow splits a table line into cells at unescaped pipes.
+func splitTableRow(index int, line string) tableRowCells {
+	row := tableRowCells{index: index, line: line}
+	quote, rest := splitTableQuote(line)
+	trimmed := strings.TrimSpace(rest)
+	row.prefix = quote + rest[:len(rest)-len(strings.TrimLeft(rest, " \t"))]
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(trimmed); i++ {
+		switch {
+		case trimmed[i] == '\\' && i+1 < len(trimmed) && trimmed[i+1] == '|':
+			cell.WriteString(`\|`)
+			i++
+		case trimmed[i] == '|':
+			cells = append(cells, cell.String())
+			cell.Reset()
+		default:
+			cell.WriteByte(trimmed[i])
+		}
+	}
+	cells = append(cells, cell.String())
+
+	row.leading = strings.HasPrefix(trimmed, "|")
+	row.trailing = len(trimmed) > 1 && strings.HasSuffix(trimmed, "|") && !strings.HasSuffix(trimmed, `\|`)
+	if row.leading {
+		cells = cells[1:]
+	}
+	if row.trailing {
+		cells = cells[:len(cells)-1]
+	}
+	row.cells = cells
+	return row
+}
+
+// delimiterAlignment returns the alignment a delimiter cell declares.
+func delimiterAlignment(cell string) string {
+	cell = strings.TrimSpace(cell)
+	left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+	switch {
+	case left && right:
+		return alignCenter
+	case left:
+		return alignLeft
+	case right:
+		return alignRight
+	default:
+		return alignNone
+	}
+}
+
+// alignment returns the alignment of column i.
+func (t markdownTable) alignment(i int) string {
+	if i < len(t.alignments) {
+		return t.alignments[i]
+	}
+	return alignNone
+}
+
+// columnWidths returns the display width of the widest cell content of each
+// column, at least the minimum width of its alignment.
+func (t markdownTable) columnWidths() []int {
+	var widths []int
+	for _, row := range t.rows {
+		for i, cell := range row.cells {
+			for len(widths) <= i {
+				widths = append(widths, t.measure.minimumWidth(t.alignment(len(widths))))
+			}
+			if row.delimiter {
+				continue
+			}
+			if width := t.measure.width(strings.TrimSpace(cell)); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+	return widths
+}
+
+// formatAligned renders row with its cells padded to widths and content
+// placed per the column alignments, keeping the row's outer pipes.
+func (t markdownTable) formatAligned(row tableRowCells, widths []int) string {
+	cells := make([]string, len(row.cells))
+	for i, cell := range row.cells {
+		if row.delimiter {
+			cells[i] = alignedDelimiter(t.alignment(i), widths[i])
+		} else {
+			cells[i] = t.padCell(strings.TrimSpace(cell), t.alignment(i), widths[i])
+		}
+	}
+	formatted := joinTableRow(row, cells, " ")
+	if !row.trailing {
+		formatted = strings.TrimRight(formatted, " ")
+	}
+	return formatted
+}
+
+// alignedRows renders every row of the table aligned to its widest cells.
+func (t markdownTable) alignedRows() []string {
+	widths := t.columnWidths()
+	rows := make([]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = t.formatAligned(row, widths)
+	}
+	return rows
+}
+
+// compactRows renders every row of the table with one space around each
+// cell and the shortest delimiter cells.
+func (t markdownTable) compactRows() []string {
+	rows := make([]string, len(t.rows))
+	for i, row := range t.rows {
+		if !row.delimiter {
+			rows[i] = formatPadded(row, " ")
+			continue
+		}
+		cells := make([]string, len(row.cells))
+		for j := range row.cells {
+			cells[j] = alignedDelimiter(t.alignment(j), t.measure.minimumWidth(t.alignment(j)))
+		}
+		rows[i] = joinTableRow(row, cells, " ")
+	}
+	return rows
+}
+
+// alignedDelimiter returns a delimiter cell of width characters.
+func alignedDelimiter(alignment string, width int) string {
+	switch alignment {
+	case alignLeft:
+		return ":" + strings.Repeat("-", width-1)
+	case alignCenter:
+		return ":" + strings.Repeat("-", width-2) + ":"
+	case alignRight:
+		return strings.Repeat("-", width-1) + ":"
+	default:
+		return strings.Repeat("-", width)
+	}
+}
+
+// padCell pads content to width display columns according to alignment.
+func (t markdownTable) padCell(content, alignment string, width int) string {
+	padding := width - t.measure.width(content)
+	if padding <= 0 {
+		return content
+	}
+	switch alignment {
+	case alignRight:
+		return strings.Repeat(" ", padding) + content
+	case alignCenter:
+		left := padding / 2
+		return strings.Repeat(" ", left) + content + strings.Repeat(" ", padding-left)
+	default:
+		return content + strings.Repeat(" ", padding)
+	}
+}
+
+// formatPadded renders row with the trimmed content of every cell wrapped in
+// padding, keeping the row's outer pipes: " " gives the compact style and ""
+// the tight style.
+func formatPadded(row tableRowCells, padding string) string {
+	cells := make([]string, len(row.cells))
+	for i, cell := range row.cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return joinTableRow(row, cells, padding)
+}
+
+// joinTableRow joins cell contents with pipes, wrapping each in padding and
+// keeping the row's prefix and outer pipes. Padding is omitted where there
+// is no outer pipe to separate it from.
+func joinTableRow(row tableRowCells, cells []string, padding string) string {
+	var b strings.Builder
+	b.WriteString(row.prefix)
+	if row.leading {
+		b.WriteString("|")
+	}
+	for i, cell := range cells {
+		if i > 0 {
+			b.WriteString("|")
+		}
+		if i > 0 || row.leading {
+			b.WriteString(padding)
+		}
+		b.WriteString(cell)
+		if cell != "" && (i < len(cells)-1 || row.trailing) {
+			b.WriteString(padding)
+		}
+	}
+	if row.trailing {
+		b.WriteString("|")
+	}
+	return b.String()
+}
+
+// pipeColumns returns the display column of every unescaped pipe in line.
+func pipeColumns(line string) []int {
+	var columns []int
+	column := 0
+	escaped := false
+	for _, r := range line {
+		if r == '|' && !escaped {
+			columns = append(columns, column)
+		}
+		escaped = r == '\\' && !escaped
+		column += runewidth.RuneWidth(r)
+	}
+	return columns
+}
@@ -0,0 +1,220 @@
+package spelling
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultWordList is the project word list that gomdlint reads and
+// "gomdlint words add" writes.
+const DefaultWordList = ".gomdlint-words.txt"
+
+// Checker checks words against dictionaries and a list of accepted words.
+type Checker struct {
+	dictionaries []*Dictionary
+	words        map[string]bool
+}
+
+// NewChecker returns a checker accepting the words of any of the
+// dictionaries and the given words. Accepted words follow the dictionary
+// rules for case: "gomdlint" also accepts "Gomdlint" and "GOMDLINT", while
+// "GitHub" only accepts "GitHub" and "GITHUB".
+func NewChecker(dictionaries []*Dictionary, words []string) *Checker {
+	c := &Checker{dictionaries: dictionaries, words: make(map[string]bool, len(words))}
+	for _, word := range words {
+		c.words[normalizeApostrophes(word)] = true
+	}
+	return c
+}
+
+// Check reports whether word is spelled correctly.
+func (c *Checker) Check(word string) bool {
+	word = normalizeApostrophes(word)
+	if c.check(word) {
+		return true
+	}
+
+	// Possessives of known words, for dictionaries without an 's suffix
+	if stem, found := strings.CutSuffix(word, "'s"); found && stem != "" {
+		return c.check(stem)
+	}
+	return false
+}
+
+func (c *Checker) check(word string) bool {
+	for _, variant := range caseVariants(word) {
+		if c.words[variant] {
+			return true
+		}
+	}
+	for _, dictionary := range c.dictionaries {
+		if dictionary.Check(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Suggest returns up to limit corrections for word from the dictionaries.
+func (c *Checker) Suggest(word string, limit int) []string {
+	var suggestions []string
+	seen := make(map[string]bool)
+	for _, dictionary := range c.dictionaries {
+		for _, suggestion := range dictionary.Suggest(normalizeApostrophes(word), limit) {
+			if !seen[suggestion] && len(suggestions) < limit {
+				seen[suggestion] = true
+				suggestions = append(suggestions, suggestion)
+			}
+		}
+	}
+	return suggestions
+}
+
+// normalizeApostrophes replaces typographic apostrophes with ASCII ones, as
+// dictionaries spell contractions.
+func normalizeApostrophes(word string) string {
+	return strings.ReplaceAll(word, "’", "'")
+}
+
+// FindWordList returns the DefaultWordList that applies to documents in
+// dir: the one in dir or in the nearest parent directory, looking no further
+// than the root of the git repository dir is in. It returns "" when there is
+// none.
+func FindWordList(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, DefaultWordList)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if isRepositoryRoot(dir) || parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ProjectWordList returns the DefaultWordList "gomdlint words add" writes
+// for documents in dir: the one FindWordList finds, or a new one at the root
+// of the git repository, or in dir outside repositories.
+func ProjectWordList(dir string) (string, error) {
+	if path := FindWordList(dir); path != "" {
+		return path, nil
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("word list: %w", err)
+	}
+	for root := dir; ; {
+		if isRepositoryRoot(root) {
+			return filepath.Join(root, DefaultWordList), nil
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return filepath.Join(dir, DefaultWordList), nil
+		}
+		root = parent
+	}
+}
+
+// isRepositoryRoot reports whether dir is the root of a git repository or
+// worktree.
+func isRepositoryRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// ReadWordList returns the words of a word list file: one word per line,
+// with blank lines and lines starting with # ignored. A missing file is an
+// empty list.
+func ReadWordList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("word list: %w", err)
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("word list %s: %w", path, err)
+	}
+	return words, nil
+}
+
+// AddWords adds words to a word list file, creating it if needed, and
+// returns the words that were not in it yet. Comment lines at the top of the
+// file are kept; the words are written sorted without regard to case.
+func AddWords(path string, words []string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("word list: %w", err)
+	}
+
+	var header, existing []string
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case len(existing) == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "#")):
+			if trimmed != "" {
+				header = append(header, line)
+			}
+		case trimmed != "":
+			existing = append(existing, trimmed)
+		}
+	}
+
+	present := make(map[string]bool, len(existing))
+	for _, word := range existing {
+		present[word] = true
+	}
+	var added []string
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if word == "" || present[word] {
+			continue
+		}
+		if strings.ContainsAny(word, " \t") || strings.HasPrefix(word, "#") {
+			return nil, fmt.Errorf("invalid word %q: words cannot contain spaces or start with #", word)
+		}
+		present[word] = true
+		added = append(added, word)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	all := append(existing, added...)
+	sort.SliceStable(all, func(i, j int) bool {
+		a, b := strings.ToLower(all[i]), strings.ToLower(all[j])
+		if a != b {
+			return a < b
+		}
+		return all[i] < all[j]
+	})
+
+	content := strings.Join(append(header, all...), "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return nil, fmt.Errorf("word list: %w", err)
+	}
+	return added, nil
+}
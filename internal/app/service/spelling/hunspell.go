@@ -0,0 +1,615 @@
+// Package spelling checks words against Hunspell dictionaries and project
+// word lists, entirely offline.
+//
+// Dictionaries are the .dic and .aff file pairs that LibreOffice, Firefox
+// and most Linux distributions ship (for example /usr/share/hunspell). The
+// affix file's prefixes and suffixes, including cross products and two-level
+// suffixes, alias tables, REP and TRY suggestion hints and the NOSUGGEST,
+// FORBIDDENWORD, KEEPCASE and NEEDAFFIX flags are supported. Compounding is
+// not: words made by COMPOUNDFLAG or COMPOUNDRULE are reported as unknown.
+package spelling
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Dictionary is a Hunspell dictionary.
+type Dictionary struct {
+	words    map[string][]flagSet
+	prefixes map[string][]*affix // By affix text
+	suffixes map[string][]*affix // By affix text
+	maxAffix int
+
+	flagType      string
+	aliases       []flagSet
+	try           string
+	replacements  [][2]string
+	noSuggest     string
+	forbiddenWord string
+	keepCase      string
+	needAffix     string
+}
+
+// flagSet is the flags of a dictionary entry or affix.
+type flagSet []string
+
+func (f flagSet) has(flag string) bool {
+	if flag == "" {
+		return false
+	}
+	for _, candidate := range f {
+		if candidate == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// affix is one PFX or SFX entry of an affix file.
+type affix struct {
+	flag         string
+	prefix       bool
+	cross        bool // May combine with an affix of the other kind
+	strip        string
+	text         string
+	continuation flagSet // Flags of affixes that may be added on top
+	condition    *regexp.Regexp
+}
+
+// matches reports whether the affix applies to root.
+func (a *affix) matches(root string) bool {
+	return a.condition == nil || a.condition.MatchString(root)
+}
+
+// LoadDictionary reads a Hunspell dictionary from path, which names the .dic
+// file, the .aff file or their common base name ("/usr/share/hunspell/en_US").
+// Dictionaries are parsed once and reused until their files change.
+func LoadDictionary(path string) (*Dictionary, error) {
+	base := strings.TrimSuffix(strings.TrimSuffix(path, ".dic"), ".aff")
+	dicPath, affPath := base+".dic", base+".aff"
+
+	dicInfo, err := os.Stat(dicPath)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary %s: %w", base, err)
+	}
+	affInfo, err := os.Stat(affPath)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary %s: %w", base, err)
+	}
+
+	version := fmt.Sprintf("%d/%d/%d/%d", dicInfo.ModTime().UnixNano(), dicInfo.Size(), affInfo.ModTime().UnixNano(), affInfo.Size())
+	loadedMutex.Lock()
+	cached, exists := loadedDictionaries[base]
+	loadedMutex.Unlock()
+	if exists && cached.version == version {
+		return cached.dictionary, nil
+	}
+
+	d := &Dictionary{
+		words:    make(map[string][]flagSet),
+		prefixes: make(map[string][]*affix),
+		suffixes: make(map[string][]*affix),
+	}
+	if err := d.readAffixes(affPath); err != nil {
+		return nil, err
+	}
+	if err := d.readWords(dicPath); err != nil {
+		return nil, err
+	}
+
+	loadedMutex.Lock()
+	loadedDictionaries[base] = loadedDictionary{version: version, dictionary: d}
+	loadedMutex.Unlock()
+	return d, nil
+}
+
+type loadedDictionary struct {
+	version    string
+	dictionary *Dictionary
+}
+
+var (
+	loadedMutex        sync.Mutex
+	loadedDictionaries = make(map[string]loadedDictionary)
+)
+
+// readAffixes parses an affix file.
+func (d *Dictionary) readAffixes(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	defer file.Close()
+
+	fail := func(number int, format string, args ...interface{}) error {
+		return fmt.Errorf("%s:%d: %s", path, number, fmt.Sprintf(format, args...))
+	}
+
+	// Remaining entries of the current PFX or SFX group
+	var group struct {
+		kind      string
+		flag      string
+		cross     bool
+		remaining int
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimPrefix(scanner.Text(), "\ufeff")
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "SET":
+			if len(fields) > 1 && !strings.EqualFold(fields[1], "UTF-8") {
+				return fail(number, "unsupported encoding %s, convert the dictionary to UTF-8", fields[1])
+			}
+		case "FLAG":
+			if len(fields) > 1 {
+				d.flagType = fields[1]
+			}
+		case "TRY":
+			if len(fields) > 1 {
+				d.try = fields[1]
+			}
+		case "NOSUGGEST", "FORBIDDENWORD", "KEEPCASE", "NEEDAFFIX":
+			if len(fields) < 2 {
+				return fail(number, "%s needs a flag", fields[0])
+			}
+			flags := d.parseFlags(fields[1])
+			if len(flags) == 0 {
+				continue
+			}
+			switch fields[0] {
+			case "NOSUGGEST":
+				d.noSuggest = flags[0]
+			case "FORBIDDENWORD":
+				d.forbiddenWord = flags[0]
+			case "KEEPCASE":
+				d.keepCase = flags[0]
+			case "NEEDAFFIX":
+				d.needAffix = flags[0]
+			}
+		case "AF":
+			// The first AF line holds the number of aliases
+			if len(fields) > 1 {
+				if _, err := strconv.Atoi(fields[1]); err == nil && d.aliases == nil {
+					d.aliases = []flagSet{}
+					continue
+				}
+				d.aliases = append(d.aliases, d.parseFlags(fields[1]))
+			}
+		case "REP":
+			if len(fields) == 3 {
+				d.replacements = append(d.replacements, [2]string{
+					strings.ReplaceAll(fields[1], "_", " "),
+					strings.ReplaceAll(fields[2], "_", " "),
+				})
+			}
+		case "PFX", "SFX":
+			if group.remaining == 0 || group.kind != fields[0] || (len(fields) > 1 && fields[1] != group.flag) {
+				// Group header: PFX flag cross_product count
+				if len(fields) < 4 {
+					return fail(number, "invalid %s header", fields[0])
+				}
+				count, err := strconv.Atoi(fields[3])
+				if err != nil {
+					return fail(number, "invalid %s count %q", fields[0], fields[3])
+				}
+				group.kind, group.flag, group.cross, group.remaining = fields[0], fields[1], fields[2] == "Y", count
+				continue
+			}
+
+			// Entry: PFX flag strip affix[/flags] [condition [morphology]]
+			if len(fields) < 4 {
+				return fail(number, "invalid %s entry", fields[0])
+			}
+			group.remaining--
+
+			a := &affix{flag: d.parseFlags(group.flag)[0], prefix: fields[0] == "PFX", cross: group.cross}
+			if fields[2] != "0" {
+				a.strip = fields[2]
+			}
+			text, continuation, _ := strings.Cut(fields[3], "/")
+			if text != "0" {
+				a.text = text
+			}
+			if continuation != "" {
+				a.continuation = d.resolveFlags(continuation)
+			}
+			condition := "."
+			if len(fields) > 4 {
+				condition = fields[4]
+			}
+			if condition != "." {
+				a.condition, err = compileCondition(condition, a.prefix)
+				if err != nil {
+					return fail(number, "invalid condition %q: %v", condition, err)
+				}
+			}
+
+			if a.prefix {
+				d.prefixes[a.text] = append(d.prefixes[a.text], a)
+			} else {
+				d.suffixes[a.text] = append(d.suffixes[a.text], a)
+			}
+			d.maxAffix = max(d.maxAffix, len(a.text))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	return nil
+}
+
+// compileCondition converts an affix condition, which uses "." and bracket
+// expressions, to a regular expression anchored where the affix attaches.
+func compileCondition(condition string, prefix bool) (*regexp.Regexp, error) {
+	var b strings.Builder
+	inBracket := false
+	for _, r := range condition {
+		switch {
+		case inBracket:
+			if r == ']' {
+				inBracket = false
+			}
+			if r == '\\' {
+				b.WriteRune('\\')
+			}
+			b.WriteRune(r)
+		case r == '[':
+			inBracket = true
+			b.WriteRune(r)
+		case r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if prefix {
+		return regexp.Compile("^(?:" + b.String() + ")")
+	}
+	return regexp.Compile("(?:" + b.String() + ")$")
+}
+
+// readWords parses a dictionary file.
+func (d *Dictionary) readWords(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimPrefix(scanner.Text(), "\ufeff")
+		if number == 1 {
+			// The word count
+			if _, err := strconv.Atoi(strings.TrimSpace(line)); err == nil {
+				continue
+			}
+		}
+		if strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Morphological fields follow a tab or space
+		entry := line
+		if i := strings.IndexAny(entry, "\t "); i >= 0 {
+			entry = entry[:i]
+		}
+		if entry == "" {
+			continue
+		}
+
+		word, flags := splitEntry(entry)
+		d.words[word] = append(d.words[word], d.resolveFlags(flags))
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	return nil
+}
+
+// splitEntry splits "word/flags" at the first slash that is not escaped.
+func splitEntry(entry string) (word, flags string) {
+	for i := 0; i < len(entry); i++ {
+		switch entry[i] {
+		case '\\':
+			i++
+		case '/':
+			if i > 0 {
+				return strings.ReplaceAll(entry[:i], `\/`, "/"), entry[i+1:]
+			}
+		}
+	}
+	return strings.ReplaceAll(entry, `\/`, "/"), ""
+}
+
+// resolveFlags parses flags, which are an alias number when the affix file
+// has an AF table.
+func (d *Dictionary) resolveFlags(flags string) flagSet {
+	if len(d.aliases) > 0 {
+		if index, err := strconv.Atoi(flags); err == nil && index >= 1 && index <= len(d.aliases) {
+			return d.aliases[index-1]
+		}
+	}
+	return d.parseFlags(flags)
+}
+
+// parseFlags splits flags according to the affix file's FLAG type.
+func (d *Dictionary) parseFlags(flags string) flagSet {
+	var set flagSet
+	switch d.flagType {
+	case "long":
+		for i := 0; i < len(flags); i += 2 {
+			set = append(set, flags[i:min(i+2, len(flags))])
+		}
+	case "num":
+		for _, flag := range strings.Split(flags, ",") {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				set = append(set, flag)
+			}
+		}
+	default:
+		for _, r := range flags {
+			set = append(set, string(r))
+		}
+	}
+	return set
+}
+
+// Check reports whether the dictionary knows word, in its own case or, for
+// capitalized and uppercase words, in lowercase.
+func (d *Dictionary) Check(word string) bool {
+	for i, variant := range caseVariants(word) {
+		if d.lookup(variant, i > 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookup reports whether word is a dictionary word or formed from one with
+// affixes. Words found through another case are rejected when the entry
+// keeps its case.
+func (d *Dictionary) lookup(word string, recased bool) bool {
+	accept := func(flags flagSet, needsAffix bool) bool {
+		if flags.has(d.forbiddenWord) || (recased && flags.has(d.keepCase)) {
+			return false
+		}
+		return !needsAffix || !flags.has(d.needAffix)
+	}
+
+	// Forbidden words are rejected even when affix rules would form them
+	for _, flags := range d.words[word] {
+		if flags.has(d.forbiddenWord) {
+			return false
+		}
+	}
+	for _, flags := range d.words[word] {
+		if accept(flags, true) {
+			return true
+		}
+	}
+
+	hasRoot := func(root string, required ...string) bool {
+		for _, flags := range d.words[root] {
+			ok := accept(flags, false)
+			for _, flag := range required {
+				ok = ok && flags.has(flag)
+			}
+			if ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Suffixes, possibly two, and prefixes combined with them
+	for _, suffix := range d.affixesOf(word, false) {
+		root := word[:len(word)-len(suffix.text)] + suffix.strip
+		if suffix.matches(root) && hasRoot(root, suffix.flag) {
+			return true
+		}
+
+		for _, inner := range d.affixesOf(root, false) {
+			if !inner.continuation.has(suffix.flag) {
+				continue
+			}
+			innerRoot := root[:len(root)-len(inner.text)] + inner.strip
+			if inner.matches(innerRoot) && hasRoot(innerRoot, inner.flag) {
+				return true
+			}
+		}
+
+		if !suffix.cross {
+			continue
+		}
+		for _, prefix := range d.affixesOf(root, true) {
+			if !prefix.cross {
+				continue
+			}
+			crossRoot := prefix.strip + root[len(prefix.text):]
+			if prefix.matches(crossRoot) && suffix.matches(crossRoot) && hasRoot(crossRoot, prefix.flag, suffix.flag) {
+				return true
+			}
+		}
+	}
+
+	for _, prefix := range d.affixesOf(word, true) {
+		root := prefix.strip + word[len(prefix.text):]
+		if prefix.matches(root) && hasRoot(root, prefix.flag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// affixesOf returns the prefixes or suffixes whose text word starts or ends
+// with, leaving a non-empty root.
+func (d *Dictionary) affixesOf(word string, prefix bool) []*affix {
+	var matches []*affix
+	for length := 0; length <= d.maxAffix && length < len(word); length++ {
+		if prefix {
+			matches = append(matches, d.prefixes[word[:length]]...)
+		} else {
+			matches = append(matches, d.suffixes[word[len(word)-length:]]...)
+		}
+	}
+	return matches
+}
+
+// suggestible reports whether word may be offered as a suggestion.
+func (d *Dictionary) suggestible(word string) bool {
+	for _, flags := range d.words[word] {
+		if flags.has(d.noSuggest) {
+			return false
+		}
+	}
+	return d.Check(word)
+}
+
+// Suggest returns up to limit dictionary words close to word: the word
+// capitalized, REP table replacements, then words one edit away using the
+// TRY characters, and splits into two words.
+func (d *Dictionary) Suggest(word string, limit int) []string {
+	if limit <= 0 {
+		return nil
+	}
+
+	var suggestions []string
+	seen := map[string]bool{word: true}
+	add := func(candidate string) bool {
+		if seen[candidate] {
+			return len(suggestions) >= limit
+		}
+		seen[candidate] = true
+
+		valid := true
+		for _, part := range strings.Fields(candidate) {
+			valid = valid && d.suggestible(part)
+		}
+		if valid && candidate != "" {
+			suggestions = append(suggestions, candidate)
+		}
+		return len(suggestions) >= limit
+	}
+
+	// Names written in lowercase
+	if add(matchCase("A", word)) {
+		return suggestions
+	}
+
+	lower := strings.ToLower(word)
+	for _, replacement := range d.replacements {
+		for start := 0; ; {
+			i := strings.Index(lower[start:], replacement[0])
+			if i < 0 {
+				break
+			}
+			i += start
+			if add(matchCase(word, lower[:i]+replacement[1]+lower[i+len(replacement[0]):])) {
+				return suggestions
+			}
+			start = i + 1
+		}
+	}
+
+	try := d.try
+	if try == "" {
+		try = "esianrtolcdugmphbyfvkwz'"
+	}
+	runes := []rune(lower)
+	edit := func(candidate []rune) bool { return add(matchCase(word, string(candidate))) }
+
+	// Swapped characters, two words run together, wrong character, extra
+	// character and missing character, from the most to the least likely
+	for i := 0; i+1 < len(runes); i++ {
+		if runes[i] != runes[i+1] && edit(splice(runes, i, i+2, runes[i+1], runes[i])) {
+			return suggestions
+		}
+	}
+	for i := 1; i < len(runes); i++ {
+		if edit(splice(runes, i, i, ' ')) {
+			return suggestions
+		}
+	}
+	for i := range runes {
+		for _, r := range try {
+			if r != runes[i] && !unicode.IsUpper(r) && edit(splice(runes, i, i+1, r)) {
+				return suggestions
+			}
+		}
+	}
+	for i := range runes {
+		if edit(splice(runes, i, i+1)) {
+			return suggestions
+		}
+	}
+	for i := 0; i <= len(runes); i++ {
+		for _, r := range try {
+			if !unicode.IsUpper(r) && edit(splice(runes, i, i, r)) {
+				return suggestions
+			}
+		}
+	}
+
+	return suggestions
+}
+
+// splice returns a copy of runes with runes[start:end] replaced by insert.
+func splice(runes []rune, start, end int, insert ...rune) []rune {
+	result := make([]rune, 0, len(runes)-(end-start)+len(insert))
+	result = append(result, runes[:start]...)
+	result = append(result, insert...)
+	return append(result, runes[end:]...)
+}
+
+// caseVariants returns word and the forms it may take in a dictionary:
+// capitalized words may be lowercase entries and uppercase words lowercase
+// or capitalized ones.
+func caseVariants(word string) []string {
+	variants := []string{word}
+	first, size := utf8.DecodeRuneInString(word)
+	if !unicode.IsUpper(first) {
+		return variants
+	}
+
+	rest := word[size:]
+	lower := strings.ToLower(word)
+	switch {
+	case rest == strings.ToLower(rest):
+		variants = append(variants, lower)
+	case rest == strings.ToUpper(rest):
+		variants = append(variants, string(first)+strings.ToLower(rest), lower)
+	}
+	return variants
+}
+
+// matchCase gives a lowercase suggestion the case of the word it replaces.
+func matchCase(word, suggestion string) string {
+	first, size := utf8.DecodeRuneInString(word)
+	switch {
+	case !unicode.IsUpper(first):
+		return suggestion
+	case len(word) > size && word == strings.ToUpper(word):
+		return strings.ToUpper(suggestion)
+	default:
+		r, n := utf8.DecodeRuneInString(suggestion)
+		return string(unicode.ToUpper(r)) + suggestion[n:]
+	}
+}
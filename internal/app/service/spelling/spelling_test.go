@@ -0,0 +1,207 @@
+package spelling
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAffix = `SET UTF-8
+TRY esianrtolcdugmphbyfvkwz'
+NOSUGGEST !
+FORBIDDENWORD *
+KEEPCASE k
+NEEDAFFIX n
+
+REP 1
+REP f ph
+
+PFX A Y 1
+PFX A   0     re         .
+
+SFX D Y 3
+SFX D   0     d          e
+SFX D   y     ied        [^aeiou]y
+SFX D   0     ed         [^ey]
+
+SFX S Y 2
+SFX S   y     ies        [^aeiou]y
+SFX S   0     s          [^y]
+
+SFX M Y 1
+SFX M   0     's         .
+
+SFX L Y 1
+SFX L   0     ly/S       .
+`
+
+const testWords = `12
+receive/DA
+relieve/D
+carry/DS
+phone/DSM
+word/S
+a
+lot
+Paris/M
+iOS/k
+heck/!
+irregardless/*
+kindly/n
+`
+
+// writeDictionary writes a dictionary and returns its base path.
+func writeDictionary(t *testing.T, affix, words string) string {
+	t.Helper()
+	base := filepath.Join(t.TempDir(), "en_TEST")
+	require.NoError(t, os.WriteFile(base+".aff", []byte(affix), 0o644))
+	require.NoError(t, os.WriteFile(base+".dic", []byte(words), 0o644))
+	return base
+}
+
+func TestDictionary_Check(t *testing.T) {
+	dictionary, err := LoadDictionary(writeDictionary(t, testAffix, testWords))
+	require.NoError(t, err)
+
+	tests := []struct {
+		word     string
+		expected bool
+	}{
+		{"receive", true},
+		{"received", true},   // Suffix
+		{"rereceived", true}, // Cross product of prefix and suffix
+		{"rerelieve", false}, // Prefix not allowed for the root
+		{"carried", true},    // Suffix with strip and condition
+		{"carryed", false},   // Condition not met
+		{"carries", true},
+		{"phone's", true},
+		{"Receive", true}, // Capitalized lowercase word
+		{"RECEIVED", true},
+		{"paris", false}, // Names keep their capital
+		{"Paris's", true},
+		{"iOS", true},
+		{"IOS", false}, // KEEPCASE
+		{"irregardless", false},
+		{"kindly", false}, // NEEDAFFIX
+		{"kindlys", false},
+		{"recieve", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dictionary.Check(tt.word))
+		})
+	}
+}
+
+func TestDictionary_Aliases(t *testing.T) {
+	affix := "FLAG long\nAF 2\nAF AaBb\nAF Bb\n\nSFX Aa Y 1\nSFX Aa 0 s .\n\nSFX Bb Y 1\nSFX Bb 0 ed .\n"
+	dictionary, err := LoadDictionary(writeDictionary(t, affix, "2\njump/1\nwalk/2\n"))
+	require.NoError(t, err)
+
+	assert.True(t, dictionary.Check("jumps"))
+	assert.True(t, dictionary.Check("jumped"))
+	assert.True(t, dictionary.Check("walked"))
+	assert.False(t, dictionary.Check("walks"))
+}
+
+func TestDictionary_Suggest(t *testing.T) {
+	dictionary, err := LoadDictionary(writeDictionary(t, testAffix, testWords))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"receive", "relieve"}, dictionary.Suggest("recieve", 5))
+	assert.Equal(t, []string{"Receive"}, dictionary.Suggest("Recieve", 1))
+	assert.Equal(t, []string{"phone"}, dictionary.Suggest("fone", 5))
+	assert.Equal(t, []string{"Paris"}, dictionary.Suggest("paris", 5))
+	assert.Equal(t, []string{"a lot", "lot"}, dictionary.Suggest("alot", 5))
+	assert.Empty(t, dictionary.Suggest("hekc", 5), "NOSUGGEST words are not suggested")
+	assert.Empty(t, dictionary.Suggest("recieve", 0))
+}
+
+func TestLoadDictionary_Errors(t *testing.T) {
+	_, err := LoadDictionary(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	_, err = LoadDictionary(writeDictionary(t, "SET ISO8859-1\n", "0\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ISO8859-1")
+
+	_, err = LoadDictionary(writeDictionary(t, "SFX D Y x\n", "0\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ".aff:1")
+}
+
+func TestChecker(t *testing.T) {
+	dictionary, err := LoadDictionary(writeDictionary(t, testAffix, testWords))
+	require.NoError(t, err)
+
+	checker := NewChecker([]*Dictionary{dictionary}, []string{"gomdlint", "GitHub"})
+
+	assert.True(t, checker.Check("received"))
+	assert.True(t, checker.Check("gomdlint"))
+	assert.True(t, checker.Check("Gomdlint"))
+	assert.True(t, checker.Check("GitHub"))
+	assert.True(t, checker.Check("GitHub’s"), "Possessives of accepted words")
+	assert.False(t, checker.Check("github"))
+	assert.Equal(t, []string{"receive"}, checker.Suggest("recieve", 1))
+}
+
+func TestWordList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultWordList)
+
+	words, err := ReadWordList(path)
+	require.NoError(t, err)
+	assert.Empty(t, words, "A missing list is empty")
+
+	require.NoError(t, os.WriteFile(path, []byte("# Project words\n\nzebra\nApple\n"), 0o644))
+
+	added, err := AddWords(path, []string{"gomdlint", "zebra", "gomdlint", "Markdown"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gomdlint", "Markdown"}, added)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Project words\nApple\ngomdlint\nMarkdown\nzebra\n", string(data))
+
+	words, err = ReadWordList(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Apple", "gomdlint", "Markdown", "zebra"}, words)
+
+	added, err = AddWords(path, []string{"zebra"})
+	require.NoError(t, err)
+	assert.Empty(t, added)
+
+	_, err = AddWords(path, []string{"two words"})
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "two words"))
+}
+
+func TestFindWordList(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	docs := filepath.Join(repo, "content", "posts")
+	require.NoError(t, os.MkdirAll(docs, 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+
+	// Lists outside the repository are not used
+	require.NoError(t, os.WriteFile(filepath.Join(root, DefaultWordList), nil, 0o644))
+	assert.Equal(t, "", FindWordList(docs))
+
+	path, err := ProjectWordList(docs)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repo, DefaultWordList), path, "New lists go to the repository root")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, DefaultWordList), nil, 0o644))
+	assert.Equal(t, filepath.Join(repo, DefaultWordList), FindWordList(docs))
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "content", DefaultWordList), nil, 0o644))
+	assert.Equal(t, filepath.Join(repo, "content", DefaultWordList), FindWordList(docs), "The nearest list applies")
+
+	path, err = ProjectWordList(docs)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(repo, "content", DefaultWordList), path)
+}
@@ -19,11 +19,14 @@ type Rule struct {
 	information *url.URL
 
 	// Configuration
-	parser string
-	config map[string]interface{}
+	parser     string
+	config     map[string]interface{}
+	parameters []RuleParameter
+	validator  func(config map[string]interface{}) error
 
 	// Execution
-	function RuleFunction
+	function    RuleFunction
+	setFunction DocumentSetFunction // Set for document set rules only
 }
 
 // RuleFunction defines the signature for rule execution functions.
@@ -41,7 +44,31 @@ type RuleParams struct {
 	Tokens []value.Token
 
 	// Helper functions for rule execution
-	FrontMatter functional.Option[map[string]interface{}]
+	FrontMatter      functional.Option[map[string]interface{}]
+	FrontMatterLines []string               // Front matter removed before Lines, delimiters included; its lines are numbered up to 0
+	RuleEnabled      func(name string) bool // Reports whether another rule is enabled, by name or alias; nil outside an engine
+}
+
+// DocumentSetFunction defines the signature for document set rules, which
+// run once after every document has been parsed and validate the documents
+// together, such as links between files. Violations are keyed by the
+// filename of the document they belong to.
+type DocumentSetFunction func(ctx context.Context, params DocumentSetParams) functional.Result[map[string][]value.Violation]
+
+// Document is a parsed document as seen by document set rules.
+type Document struct {
+	Filename         string
+	Lines            []string
+	Tokens           []value.Token
+	FrontMatter      functional.Option[map[string]interface{}]
+	FrontMatterLines int // Lines of front matter removed before Lines
+}
+
+// DocumentSetParams contains immutable parameters passed to document set
+// rule functions.
+type DocumentSetParams struct {
+	Documents []Document
+	Config    map[string]interface{}
 }
 
 // NewRule creates a new Rule with the provided configuration.
@@ -92,10 +119,87 @@ func NewRule(
 	for k, v := range config {
 		rule.config[k] = v
 	}
+	rule.parameters = InferParameters(rule.config)
+
+	return functional.Ok(rule)
+}
+
+// NewRuleWithParameters creates a new Rule whose configuration is declared as
+// typed parameters. The default configuration is derived from the parameter
+// defaults, and configured values are validated against the declarations.
+func NewRuleWithParameters(
+	names []string,
+	description string,
+	tags []string,
+	information *url.URL,
+	parser string,
+	parameters []RuleParameter,
+	function RuleFunction,
+) functional.Result[*Rule] {
+	if err := validateParameters(parameters); err != nil {
+		return functional.Err[*Rule](err)
+	}
+
+	defaults := make(map[string]interface{}, len(parameters))
+	for _, parameter := range parameters {
+		if parameter.Default != nil {
+			defaults[parameter.Name] = parameter.Default
+		}
+	}
+
+	result := NewRule(names, description, tags, information, parser, defaults, function)
+	if result.IsErr() {
+		return result
+	}
+
+	rule := result.Unwrap()
+	rule.parameters = make([]RuleParameter, len(parameters))
+	for i, parameter := range parameters {
+		parameter.Allowed = append([]string(nil), parameter.Allowed...)
+		rule.parameters[i] = parameter
+	}
+
+	return functional.Ok(rule)
+}
+
+// NewDocumentSetRule creates a new document set rule with typed parameters.
+// The rule does not run per document; RuleEngine runs it once over all the
+// linted documents through ExecuteDocumentSet.
+func NewDocumentSetRule(
+	names []string,
+	description string,
+	tags []string,
+	information *url.URL,
+	parameters []RuleParameter,
+	function DocumentSetFunction,
+) functional.Result[*Rule] {
+	if function == nil {
+		return functional.Err[*Rule](fmt.Errorf("rule must have a function"))
+	}
+
+	perDocument := func(ctx context.Context, params RuleParams) functional.Result[[]value.Violation] {
+		return functional.Ok([]value.Violation{})
+	}
+	result := NewRuleWithParameters(names, description, tags, information, "commonmark", parameters, perDocument)
+	if result.IsErr() {
+		return result
+	}
 
+	rule := result.Unwrap()
+	rule.setFunction = function
 	return functional.Ok(rule)
 }
 
+// WithConfigValidator returns a copy of the rule that runs validator on the
+// effective configuration (defaults overridden by the coerced values) after
+// the parameter checks pass. It lets rules with
+// constraints spanning several parameters reject invalid combinations.
+func (r *Rule) WithConfigValidator(validator func(config map[string]interface{}) error) *Rule {
+	clone := *r
+	clone.validator = validator
+	return &clone
+}
+
 // Names returns a copy of the rule names to maintain immutability.
 func (r *Rule) Names() []string {
 	names := make([]string, len(r.names))
@@ -147,6 +251,43 @@ func (r *Rule) Config() map[string]interface{} {
 	return config
 }
 
+// Parameters returns a copy of the rule's declared parameters.
+func (r *Rule) Parameters() []RuleParameter {
+	parameters := make([]RuleParameter, len(r.parameters))
+	copy(parameters, r.parameters)
+	return parameters
+}
+
+// CoerceConfig validates config against the rule's declared parameters and
+// returns a copy with every value converted to its declared type. Unknown keys
+// are dropped rather than rejected, so configurations written for markdownlint
+// keep working; see UnknownParameters. Errors name the rule and the offending
+// key.
+func (r *Rule) CoerceConfig(config map[string]interface{}) (map[string]interface{}, error) {
+	coerced, err := coerceParameters(r.PrimaryName(), r.parameters, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.validator != nil {
+		effective := r.Config()
+		for k, v := range coerced {
+			effective[k] = v
+		}
+		if err := r.validator(effective); err != nil {
+			return nil, &RuleConfigError{Rule: r.PrimaryName(), Message: err.Error()}
+		}
+	}
+
+	return coerced, nil
+}
+
+// UnknownParameters reports the keys of config that the rule does not
+// declare, ordered by key.
+func (r *Rule) UnknownParameters(config map[string]interface{}) []*RuleConfigError {
+	return unknownParameters(r.PrimaryName(), r.parameters, config)
+}
+
 // Function returns the rule function for direct execution.
 // This is primarily used for testing purposes.
 func (r *Rule) Function() RuleFunction {
@@ -183,6 +324,32 @@ func (r *Rule) Execute(ctx context.Context, params RuleParams) functional.Result
 	return r.function(ctx, mergedParams)
 }
 
+// IsDocumentSetRule reports whether the rule validates the whole set of
+// linted documents rather than one document at a time.
+func (r *Rule) IsDocumentSetRule() bool {
+	return r.setFunction != nil
+}
+
+// ExecuteDocumentSet runs a document set rule over documents, with the rule
+// defaults overridden by params.Config. It returns no violations for
+// per-document rules.
+func (r *Rule) ExecuteDocumentSet(ctx context.Context, params DocumentSetParams) functional.Result[map[string][]value.Violation] {
+	if r.setFunction == nil {
+		return functional.Ok(map[string][]value.Violation{})
+	}
+
+	mergedParams := params
+	mergedParams.Config = make(map[string]interface{})
+	for k, v := range r.config {
+		mergedParams.Config[k] = v
+	}
+	for k, v := range params.Config {
+		mergedParams.Config[k] = v
+	}
+
+	return r.setFunction(ctx, mergedParams)
+}
+
 // HasName checks if the rule matches any of the given names (case-insensitive).
 func (r *Rule) HasName(name string) bool {
 	for _, ruleName := range r.names {
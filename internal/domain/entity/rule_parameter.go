@@ -0,0 +1,285 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/shared/utils"
+)
+
+// ParameterType identifies the type of a rule parameter.
+type ParameterType string
+
+const (
+	ParameterTypeBoolean    ParameterType = "boolean"
+	ParameterTypeInteger    ParameterType = "integer"
+	ParameterTypeNumber     ParameterType = "number"
+	ParameterTypeString     ParameterType = "string"
+	ParameterTypeStringList ParameterType = "string_list"
+	ParameterTypeAny        ParameterType = "any" // Accepted as is, without coercion
+)
+
+// RuleParameter declares a configurable option of a rule.
+type RuleParameter struct {
+	Name        string
+	Type        ParameterType
+	Default     interface{}
+	Allowed     []string // Accepted values for string parameters (empty = any)
+	Description string
+	Inherit     string // Rule whose parameter of the same name supplies the value when this one is not configured
+}
+
+// Coerce converts a configured value to the parameter's type. Integers are
+// returned as int, numbers as float64 and string lists as []string, so rules
+// can read them without further conversion.
+func (p RuleParameter) Coerce(value interface{}) (interface{}, error) {
+	switch p.Type {
+	case ParameterTypeBoolean:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case ParameterTypeInteger:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case float64:
+			if v == math.Trunc(v) {
+				return int(v), nil
+			}
+		}
+	case ParameterTypeNumber:
+		switch v := value.(type) {
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case ParameterTypeString:
+		if s, ok := value.(string); ok {
+			if len(p.Allowed) > 0 && !containsString(p.Allowed, s) {
+				return nil, fmt.Errorf("invalid value %q (allowed: %s)", s, strings.Join(p.Allowed, ", "))
+			}
+			return s, nil
+		}
+	case ParameterTypeStringList:
+		switch v := value.(type) {
+		case []string:
+			return append([]string{}, v...), nil
+		case []interface{}:
+			result := make([]string, len(v))
+			for i, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected a list of strings, item %d is %s", i, describeValueType(item))
+				}
+				result[i] = s
+			}
+			return result, nil
+		}
+	default:
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("expected %s, got %s", p.Type.label(), describeValueType(value))
+}
+
+// label returns a human readable name for the type.
+func (t ParameterType) label() string {
+	if t == ParameterTypeStringList {
+		return "a list of strings"
+	}
+	return string(t)
+}
+
+// InferParameters derives parameter declarations from a map of default
+// values, for rules that do not declare their parameters explicitly.
+func InferParameters(defaults map[string]interface{}) []RuleParameter {
+	parameters := make([]RuleParameter, 0, len(defaults))
+	for name, defaultValue := range defaults {
+		parameters = append(parameters, RuleParameter{
+			Name:    name,
+			Type:    inferParameterType(defaultValue),
+			Default: defaultValue,
+		})
+	}
+
+	sort.Slice(parameters, func(i, j int) bool {
+		return parameters[i].Name < parameters[j].Name
+	})
+	return parameters
+}
+
+// inferParameterType maps a default value onto a parameter type.
+func inferParameterType(value interface{}) ParameterType {
+	switch v := value.(type) {
+	case bool:
+		return ParameterTypeBoolean
+	case int, int64:
+		return ParameterTypeInteger
+	case float64:
+		if v == math.Trunc(v) {
+			return ParameterTypeInteger
+		}
+		return ParameterTypeNumber
+	case string:
+		return ParameterTypeString
+	case []string:
+		return ParameterTypeStringList
+	case []interface{}:
+		for _, item := range v {
+			if _, ok := item.(string); !ok {
+				return ParameterTypeAny
+			}
+		}
+		return ParameterTypeStringList
+	default:
+		return ParameterTypeAny
+	}
+}
+
+// RuleConfigError reports an invalid configuration value for a rule parameter.
+type RuleConfigError struct {
+	Rule       string
+	Key        string
+	Message    string
+	Suggestion string // Closest declared parameter for unknown keys
+}
+
+// Error implements the error interface.
+func (e *RuleConfigError) Error() string {
+	message := e.Message
+	if e.Suggestion != "" {
+		message += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	if e.Key == "" {
+		return fmt.Sprintf("rule %s: %s", e.Rule, message)
+	}
+	return fmt.Sprintf("rule %s: parameter %q: %s", e.Rule, e.Key, message)
+}
+
+// validateParameters checks that parameter declarations are usable.
+func validateParameters(parameters []RuleParameter) error {
+	seen := make(map[string]bool, len(parameters))
+	for _, parameter := range parameters {
+		if parameter.Name == "" {
+			return fmt.Errorf("rule parameters must have a name")
+		}
+		if seen[parameter.Name] {
+			return fmt.Errorf("duplicate rule parameter %q", parameter.Name)
+		}
+		seen[parameter.Name] = true
+
+		if parameter.Default == nil {
+			continue
+		}
+		if _, err := parameter.Coerce(parameter.Default); err != nil {
+			return fmt.Errorf("invalid default for parameter %q: %w", parameter.Name, err)
+		}
+	}
+	return nil
+}
+
+// coerceParameters validates config against the declared parameters and
+// returns a copy with every value converted to its declared type. Unknown
+// keys are dropped; unknownParameters reports them. All problems are
+// reported, ordered by key.
+func coerceParameters(ruleName string, parameters []RuleParameter, config map[string]interface{}) (map[string]interface{}, error) {
+	byName := make(map[string]RuleParameter, len(parameters))
+	for _, parameter := range parameters {
+		byName[parameter.Name] = parameter
+	}
+
+	result := make(map[string]interface{}, len(config))
+	var errs []error
+	for _, key := range sortedKeys(config) {
+		parameter, declared := byName[key]
+		if !declared {
+			continue
+		}
+
+		coerced, err := parameter.Coerce(config[key])
+		if err != nil {
+			errs = append(errs, &RuleConfigError{Rule: ruleName, Key: key, Message: err.Error()})
+			continue
+		}
+		result[key] = coerced
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return result, nil
+}
+
+// unknownParameters reports the keys of config that no parameter declares,
+// ordered by key, each with the closest declared name as a suggestion.
+func unknownParameters(ruleName string, parameters []RuleParameter, config map[string]interface{}) []*RuleConfigError {
+	names := make([]string, 0, len(parameters))
+	for _, parameter := range parameters {
+		names = append(names, parameter.Name)
+	}
+
+	var unknown []*RuleConfigError
+	for _, key := range sortedKeys(config) {
+		if containsString(names, key) {
+			continue
+		}
+		unknown = append(unknown, &RuleConfigError{
+			Rule:       ruleName,
+			Key:        key,
+			Message:    "unknown parameter",
+			Suggestion: utils.SuggestClosest(key, names),
+		})
+	}
+	return unknown
+}
+
+func sortedKeys(config map[string]interface{}) []string {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// describeValueType names the type of a configuration value for error messages.
+func describeValueType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int, int64:
+		return "integer"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}, []string:
+		return "list"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
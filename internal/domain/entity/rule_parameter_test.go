@@ -0,0 +1,218 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleParameter_Coerce(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		parameter   RuleParameter
+		input       interface{}
+		expected    interface{}
+		expectedErr string
+	}{
+		{
+			name:      "boolean",
+			parameter: RuleParameter{Name: "tables", Type: ParameterTypeBoolean},
+			input:     false,
+			expected:  false,
+		},
+		{
+			name:        "boolean rejects string",
+			parameter:   RuleParameter{Name: "tables", Type: ParameterTypeBoolean},
+			input:       "no",
+			expectedErr: "expected boolean, got string",
+		},
+		{
+			name:      "integer from JSON number",
+			parameter: RuleParameter{Name: "line_length", Type: ParameterTypeInteger},
+			input:     float64(120),
+			expected:  120,
+		},
+		{
+			name:        "integer rejects fraction",
+			parameter:   RuleParameter{Name: "line_length", Type: ParameterTypeInteger},
+			input:       120.5,
+			expectedErr: "expected integer, got number",
+		},
+		{
+			name:      "number from integer",
+			parameter: RuleParameter{Name: "ratio", Type: ParameterTypeNumber},
+			input:     2,
+			expected:  float64(2),
+		},
+		{
+			name:      "allowed string",
+			parameter: RuleParameter{Name: "style", Type: ParameterTypeString, Allowed: []string{"atx", "setext"}},
+			input:     "atx",
+			expected:  "atx",
+		},
+		{
+			name:        "string outside allowed values",
+			parameter:   RuleParameter{Name: "style", Type: ParameterTypeString, Allowed: []string{"atx", "setext"}},
+			input:       "fancy",
+			expectedErr: `invalid value "fancy" (allowed: atx, setext)`,
+		},
+		{
+			name:      "string list from YAML sequence",
+			parameter: RuleParameter{Name: "names", Type: ParameterTypeStringList},
+			input:     []interface{}{"GitHub", "JavaScript"},
+			expected:  []string{"GitHub", "JavaScript"},
+		},
+		{
+			name:        "string list rejects mixed items",
+			parameter:   RuleParameter{Name: "names", Type: ParameterTypeStringList},
+			input:       []interface{}{"GitHub", true},
+			expectedErr: "expected a list of strings, item 1 is boolean",
+		},
+		{
+			name:        "string list rejects string",
+			parameter:   RuleParameter{Name: "names", Type: ParameterTypeStringList},
+			input:       "GitHub",
+			expectedErr: "expected a list of strings, got string",
+		},
+		{
+			name:      "any passes through",
+			parameter: RuleParameter{Name: "extra", Type: ParameterTypeAny},
+			input:     map[string]interface{}{"a": 1},
+			expected:  map[string]interface{}{"a": 1},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			result, err := scenario.parameter.Coerce(scenario.input)
+			if scenario.expectedErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, scenario.expectedErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expected, result)
+		})
+	}
+}
+
+func TestInferParameters(t *testing.T) {
+	parameters := InferParameters(map[string]interface{}{
+		"style":       "consistent",
+		"line_length": 80,
+		"names":       []interface{}{},
+		"strict":      false,
+	})
+
+	assert.Equal(t, []RuleParameter{
+		{Name: "line_length", Type: ParameterTypeInteger, Default: 80},
+		{Name: "names", Type: ParameterTypeStringList, Default: []interface{}{}},
+		{Name: "strict", Type: ParameterTypeBoolean, Default: false},
+		{Name: "style", Type: ParameterTypeString, Default: "consistent"},
+	}, parameters)
+}
+
+func TestNewRuleWithParameters(t *testing.T) {
+	parameters := []RuleParameter{
+		{Name: "line_length", Type: ParameterTypeInteger, Default: 80, Description: "Maximum line length"},
+		{Name: "style", Type: ParameterTypeString, Default: "consistent", Allowed: []string{"consistent", "atx"}},
+	}
+
+	t.Run("derives defaults from parameters", func(t *testing.T) {
+		result := NewRuleWithParameters([]string{"TEST001"}, "Test rule", nil, nil, "commonmark", parameters, mockRuleFunction)
+		require.True(t, result.IsOk())
+
+		rule := result.Unwrap()
+		assert.Equal(t, map[string]interface{}{"line_length": 80, "style": "consistent"}, rule.Config())
+		assert.Equal(t, parameters, rule.Parameters())
+	})
+
+	t.Run("rejects invalid defaults", func(t *testing.T) {
+		invalid := []RuleParameter{{Name: "style", Type: ParameterTypeString, Default: "fancy", Allowed: []string{"atx"}}}
+		result := NewRuleWithParameters([]string{"TEST001"}, "Test rule", nil, nil, "commonmark", invalid, mockRuleFunction)
+		if !result.IsErr() {
+			t.Fatal("expected an error for an invalid default")
+		}
+		assert.Contains(t, result.Error().Error(), `invalid default for parameter "style"`)
+	})
+
+	t.Run("rejects duplicate parameters", func(t *testing.T) {
+		duplicate := append(parameters, RuleParameter{Name: "style", Type: ParameterTypeString})
+		result := NewRuleWithParameters([]string{"TEST001"}, "Test rule", nil, nil, "commonmark", duplicate, mockRuleFunction)
+		if !result.IsErr() {
+			t.Fatal("expected an error for a duplicate parameter")
+		}
+		assert.Contains(t, result.Error().Error(), `duplicate rule parameter "style"`)
+	})
+}
+
+func TestRule_CoerceConfig(t *testing.T) {
+	rule := NewRuleWithParameters(
+		[]string{"TEST001", "test-rule"},
+		"Test rule",
+		nil,
+		nil,
+		"commonmark",
+		[]RuleParameter{
+			{Name: "line_length", Type: ParameterTypeInteger, Default: 80},
+			{Name: "heading_line_length", Type: ParameterTypeInteger, Default: 80},
+		},
+		mockRuleFunction,
+	).Unwrap()
+
+	t.Run("coerces values", func(t *testing.T) {
+		config, err := rule.CoerceConfig(map[string]interface{}{"line_length": float64(100)})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"line_length": 100}, config)
+	})
+
+	t.Run("reports every problem by key", func(t *testing.T) {
+		_, err := rule.CoerceConfig(map[string]interface{}{
+			"line_length":         "wide",
+			"heading_line_length": "narrow",
+		})
+		require.Error(t, err)
+		assert.Equal(t,
+			`rule TEST001: parameter "heading_line_length": expected integer, got string`+"\n"+
+				`rule TEST001: parameter "line_length": expected integer, got string`,
+			err.Error())
+
+		var configErr *RuleConfigError
+		require.True(t, errors.As(err, &configErr))
+		assert.Equal(t, "TEST001", configErr.Rule)
+	})
+
+	t.Run("drops unknown parameters", func(t *testing.T) {
+		config := map[string]interface{}{"line_length": 100, "line-lenght": 100, "unrelated_key": true}
+		coerced, err := rule.CoerceConfig(config)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"line_length": 100}, coerced)
+
+		var messages []string
+		for _, unknown := range rule.UnknownParameters(config) {
+			messages = append(messages, unknown.Error())
+		}
+		assert.Equal(t, []string{
+			`rule TEST001: parameter "line-lenght": unknown parameter (did you mean "line_length"?)`,
+			`rule TEST001: parameter "unrelated_key": unknown parameter`,
+		}, messages)
+	})
+
+	t.Run("runs config validator on effective config", func(t *testing.T) {
+		validated := rule.WithConfigValidator(func(config map[string]interface{}) error {
+			if config["heading_line_length"].(int) > config["line_length"].(int) {
+				return errors.New("heading_line_length must not exceed line_length")
+			}
+			return nil
+		})
+
+		_, err := validated.CoerceConfig(map[string]interface{}{"line_length": 60})
+		require.Error(t, err)
+		assert.Equal(t, "rule TEST001: heading_line_length must not exceed line_length", err.Error())
+
+		_, err = rule.CoerceConfig(map[string]interface{}{"line_length": 60})
+		assert.NoError(t, err, "the original rule is unchanged")
+	})
+}
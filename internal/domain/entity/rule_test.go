@@ -229,6 +229,44 @@ func TestRule_Execute(t *testing.T) {
 	assert.Contains(t, violations[0].RuleNames, "MOCK")
 }
 
+func TestNewDocumentSetRule(t *testing.T) {
+	rule := NewDocumentSetRule(
+		[]string{"SET001"},
+		"Document set rule",
+		[]string{"test"},
+		nil,
+		[]RuleParameter{{Name: "limit", Type: ParameterTypeInteger, Default: 1}},
+		func(ctx context.Context, params DocumentSetParams) functional.Result[map[string][]value.Violation] {
+			violations := make(map[string][]value.Violation)
+			if len(params.Documents) > params.Config["limit"].(int) {
+				last := params.Documents[len(params.Documents)-1].Filename
+				violations[last] = []value.Violation{*value.NewViolation([]string{"SET001"}, "Document set rule", nil, 1)}
+			}
+			return functional.Ok(violations)
+		},
+	).Unwrap()
+
+	assert.True(t, rule.IsDocumentSetRule())
+	assert.Equal(t, 1, rule.Config()["limit"])
+
+	// Per-document execution is a no-op
+	result := rule.Execute(context.Background(), RuleParams{Lines: []string{"TRIGGER_VIOLATION"}})
+	require.True(t, result.IsOk())
+	assert.Empty(t, result.Unwrap())
+
+	documents := []Document{{Filename: "a.md"}, {Filename: "b.md"}}
+	setResult := rule.ExecuteDocumentSet(context.Background(), DocumentSetParams{Documents: documents})
+	require.True(t, setResult.IsOk())
+	assert.Len(t, setResult.Unwrap()["b.md"], 1, "defaults apply")
+
+	setResult = rule.ExecuteDocumentSet(context.Background(), DocumentSetParams{Documents: documents, Config: map[string]interface{}{"limit": 2}})
+	require.True(t, setResult.IsOk())
+	assert.Empty(t, setResult.Unwrap())
+
+	perDocument := NewRule([]string{"TEST001"}, "Test rule", nil, nil, "commonmark", nil, mockRuleFunction).Unwrap()
+	assert.False(t, perDocument.IsDocumentSetRule())
+}
+
 func TestRule_ExecuteWithError(t *testing.T) {
 	rule := NewRule(
 		[]string{"ERROR_TEST"},
@@ -3,6 +3,7 @@ package value
 import (
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/gomdlint/gomdlint/internal/shared/functional"
 )
@@ -28,9 +29,34 @@ type LintOptions struct {
 
 	// Error handling
 	HandleRuleFailures bool // Catch and report rule execution errors
+	FailFast           bool // Stop linting files after the first file with an error
+
+	// Performance
+	Concurrency int // Number of files linted at once (0: one per CPU)
+
+	// Diagnostics
+	Timing bool // Record the time spent in each rule
 
 	// Theming configuration
 	Theme ThemeConfig // Theme configuration for output formatting
+
+	// Result caching
+	Cache CacheOptions // Persistent on-disk result cache
+}
+
+// CacheStrategy selects how the result cache detects changed files.
+type CacheStrategy string
+
+const (
+	CacheStrategyContent  CacheStrategy = "content"  // Compare a hash of the file contents
+	CacheStrategyMetadata CacheStrategy = "metadata" // Compare file size and modification time
+)
+
+// CacheOptions configures the persistent result cache.
+type CacheOptions struct {
+	Enabled  bool          // Read and write cached results
+	Location string        // Directory holding cache files (default: XDG cache directory)
+	Strategy CacheStrategy // How changed files are detected (default: content)
 }
 
 // ConfigParser is a function type that parses configuration content.
@@ -165,6 +191,35 @@ func (o *LintOptions) WithCustomSymbols(symbols map[string]string) *LintOptions
 	return &newOptions
 }
 
+// WithCache sets the persistent result cache options.
+func (o *LintOptions) WithCache(cache CacheOptions) *LintOptions {
+	newOptions := *o
+	newOptions.Cache = cache
+	return &newOptions
+}
+
+// WithFailFast sets whether linting stops after the first file with an error.
+func (o *LintOptions) WithFailFast(failFast bool) *LintOptions {
+	newOptions := *o
+	newOptions.FailFast = failFast
+	return &newOptions
+}
+
+// WithConcurrency sets how many files are linted at once; 0 uses one worker
+// per CPU.
+func (o *LintOptions) WithConcurrency(concurrency int) *LintOptions {
+	newOptions := *o
+	newOptions.Concurrency = concurrency
+	return &newOptions
+}
+
+// WithTiming sets whether the time spent in each rule is recorded.
+func (o *LintOptions) WithTiming(timing bool) *LintOptions {
+	newOptions := *o
+	newOptions.Timing = timing
+	return &newOptions
+}
+
 // HasInput returns true if there are files or strings to lint.
 func (o *LintOptions) HasInput() bool {
 	return len(o.Files) > 0 || len(o.Strings) > 0
@@ -230,6 +285,53 @@ type LintResult struct {
 	TotalFiles      int
 	TotalErrors     int
 	TotalWarnings   int
+
+	// Time spent in each phase of the operation
+	Timings PhaseTimings
+
+	// Time spent in each rule by primary name; recorded only with
+	// LintOptions.Timing
+	RuleTimings map[string]RuleTiming
+
+	// Problems with the configuration that did not stop linting, such as
+	// rule parameters that are not declared
+	Warnings []string
+}
+
+// PhaseTimings records the time spent reading files, parsing documents and
+// running rules. Durations are summed across documents, so with concurrent
+// workers they can exceed the elapsed time.
+type PhaseTimings struct {
+	Read  time.Duration // Reading files
+	Parse time.Duration // Parsing documents into tokens
+	Rules time.Duration // Running rules against the parsed documents
+}
+
+// Add returns the sum of t and other.
+func (t PhaseTimings) Add(other PhaseTimings) PhaseTimings {
+	return PhaseTimings{Read: t.Read + other.Read, Parse: t.Parse + other.Parse, Rules: t.Rules + other.Rules}
+}
+
+// RuleTiming records the time a rule spent across documents.
+type RuleTiming struct {
+	Duration  time.Duration // Total time spent in the rule
+	Documents int           // Number of documents the rule checked
+}
+
+// Add returns the sum of t and other.
+func (t RuleTiming) Add(other RuleTiming) RuleTiming {
+	return RuleTiming{Duration: t.Duration + other.Duration, Documents: t.Documents + other.Documents}
+}
+
+// AddTimings adds the phase and rule timings of other to r.
+func (r *LintResult) AddTimings(other *LintResult) {
+	r.Timings = r.Timings.Add(other.Timings)
+	for rule, timing := range other.RuleTimings {
+		if r.RuleTimings == nil {
+			r.RuleTimings = make(map[string]RuleTiming)
+		}
+		r.RuleTimings[rule] = r.RuleTimings[rule].Add(timing)
+	}
 }
 
 // NewLintResult creates a new empty LintResult.
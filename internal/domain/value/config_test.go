@@ -3,6 +3,7 @@ package value
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -305,3 +306,18 @@ func BenchmarkLintOptions_WithConfig(b *testing.B) {
 		_ = result
 	}
 }
+
+func TestLintResult_AddTimings(t *testing.T) {
+	result := NewLintResult()
+	result.AddTimings(&LintResult{
+		Timings:     PhaseTimings{Read: time.Millisecond, Parse: 2 * time.Millisecond},
+		RuleTimings: map[string]RuleTiming{"MD001": {Duration: time.Millisecond, Documents: 1}},
+	})
+	result.AddTimings(&LintResult{
+		Timings:     PhaseTimings{Rules: 3 * time.Millisecond},
+		RuleTimings: map[string]RuleTiming{"MD001": {Duration: 2 * time.Millisecond, Documents: 2}},
+	})
+
+	assert.Equal(t, PhaseTimings{Read: time.Millisecond, Parse: 2 * time.Millisecond, Rules: 3 * time.Millisecond}, result.Timings)
+	assert.Equal(t, RuleTiming{Duration: 3 * time.Millisecond, Documents: 3}, result.RuleTimings["MD001"])
+}
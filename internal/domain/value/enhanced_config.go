@@ -268,6 +268,86 @@ func (c *Config) Clone() *Config {
 	return clone
 }
 
+// ToMap converts the configuration into the generic map form used by
+// configuration files (markdownlint format). Disabled rules with options are
+// written as false since the map form cannot express both.
+func (c *Config) ToMap() map[string]interface{} {
+	result := map[string]interface{}{
+		"default": c.Default,
+	}
+
+	if len(c.Extends) > 0 {
+		extends := make([]interface{}, len(c.Extends))
+		for i, ext := range c.Extends {
+			extends[i] = ext
+		}
+		result["extends"] = extends
+	}
+
+	for name, rule := range c.Rules {
+		if !rule.Enabled || len(rule.Options) == 0 {
+			result[name] = rule.Enabled
+			continue
+		}
+		result[name] = cloneMap(rule.Options)
+	}
+
+	if len(c.Plugins) > 0 {
+		plugins := make(map[string]interface{}, len(c.Plugins))
+		for name, plugin := range c.Plugins {
+			entry := map[string]interface{}{"enabled": plugin.Enabled}
+			if plugin.Path != "" {
+				entry["path"] = plugin.Path
+			}
+			if len(plugin.Config) > 0 {
+				entry["config"] = cloneMap(plugin.Config)
+			}
+			plugins[name] = entry
+		}
+		result["plugins"] = plugins
+	}
+
+	if len(c.Parsers) > 0 {
+		parsers := make(map[string]interface{}, len(c.Parsers))
+		for name, parser := range c.Parsers {
+			entry := map[string]interface{}{"type": parser.Type}
+			if len(parser.Options) > 0 {
+				entry["options"] = cloneMap(parser.Options)
+			}
+			parsers[name] = entry
+		}
+		result["parsers"] = parsers
+	}
+
+	if len(c.Profiles) > 0 {
+		profiles := make(map[string]interface{}, len(c.Profiles))
+		for name, profile := range c.Profiles {
+			entry := make(map[string]interface{})
+			if profile.Description != "" {
+				entry["description"] = profile.Description
+			}
+			if len(profile.Extends) > 0 {
+				extends := make([]interface{}, len(profile.Extends))
+				for i, ext := range profile.Extends {
+					extends[i] = ext
+				}
+				entry["extends"] = extends
+			}
+			for ruleName, rule := range profile.Rules {
+				if !rule.Enabled || len(rule.Options) == 0 {
+					entry[ruleName] = rule.Enabled
+					continue
+				}
+				entry[ruleName] = cloneMap(rule.Options)
+			}
+			profiles[name] = entry
+		}
+		result["profiles"] = profiles
+	}
+
+	return result
+}
+
 // cloneMap creates a deep copy of a map[string]interface{}
 func cloneMap(original map[string]interface{}) map[string]interface{} {
 	if original == nil {
@@ -30,6 +30,14 @@ func (s Severity) String() string {
 	}
 }
 
+// Violations reporting that content could not be linted, rather than a
+// problem in the content, are identified by these rule names and description.
+const (
+	FileErrorRuleName      = "FILE_ERROR"           // A file could not be read or parsed
+	StringErrorRuleName    = "STRING_ERROR"         // A string input could not be parsed
+	RuleFailureDescription = "Rule execution error" // A rule failed while checking the content
+)
+
 // FixInfo represents information needed to automatically fix a violation.
 // This follows the markdownlint fixInfo structure for compatibility.
 type FixInfo struct {
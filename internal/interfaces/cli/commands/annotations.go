@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+// ciFormatFromEnv returns the annotation format for the CI system that getenv
+// reports, or "" outside a recognised CI system.
+func ciFormatFromEnv(getenv func(string) string) string {
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true":
+		return "github"
+	case getenv("GITLAB_CI") != "":
+		return "gitlab"
+	default:
+		return ""
+	}
+}
+
+// sortedResultFiles returns the files in result in a stable order.
+func sortedResultFiles(result *gomdlint.LintResult) []string {
+	files := make([]string, 0, len(result.Results))
+	for file := range result.Results {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// violationMessage describes a violation on one line, without its location.
+func violationMessage(violation gomdlint.Violation) string {
+	message := violation.RuleDescription
+	if violation.ErrorDetail != "" {
+		message += " [" + violation.ErrorDetail + "]"
+	}
+	if violation.ErrorContext != "" {
+		message += fmt.Sprintf(" [Context: %q]", violation.ErrorContext)
+	}
+	return message
+}
+
+// formatAsGitHub formats results as GitHub Actions workflow commands, which
+// GitHub shows as annotations on the changed files.
+func formatAsGitHub(result *gomdlint.LintResult) (string, error) {
+	var b strings.Builder
+	for _, file := range sortedResultFiles(result) {
+		for _, violation := range result.Results[file] {
+			level := "error"
+			if violation.Severity == "warning" {
+				level = "warning"
+			}
+
+			properties := fmt.Sprintf("file=%s,line=%d", escapeGitHubProperty(filepath.ToSlash(file)), violation.LineNumber)
+			if len(violation.ErrorRange) > 0 {
+				properties += fmt.Sprintf(",col=%d", violation.ErrorRange[0])
+			}
+			properties += ",title=" + escapeGitHubProperty(strings.Join(violation.RuleNames, "/"))
+
+			fmt.Fprintf(&b, "::%s %s::%s\n", level, properties, escapeGitHubData(violationMessage(violation)))
+		}
+	}
+	return b.String(), nil
+}
+
+// escapeGitHubData escapes the message of a workflow command.
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeGitHubProperty escapes a property value of a workflow command.
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// gitLabIssue is an entry of a GitLab Code Quality report.
+type gitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitLabLocation `json:"location"`
+}
+
+// gitLabLocation is the location of a GitLab Code Quality issue.
+type gitLabLocation struct {
+	Path  string `json:"path"`
+	Lines struct {
+		Begin int `json:"begin"`
+	} `json:"lines"`
+}
+
+// formatAsGitLab formats results as a GitLab Code Quality report, which
+// GitLab shows in merge request widgets.
+func formatAsGitLab(result *gomdlint.LintResult) (string, error) {
+	issues := []gitLabIssue{}
+	for _, file := range sortedResultFiles(result) {
+		path := filepath.ToSlash(file)
+		for _, violation := range result.Results[file] {
+			severity := "major"
+			if violation.Severity == "warning" {
+				severity = "minor"
+			}
+
+			issue := gitLabIssue{
+				Description: strings.Join(violation.RuleNames, "/") + " " + violationMessage(violation),
+				CheckName:   violation.RuleNames[0],
+				Severity:    severity,
+				Location:    gitLabLocation{Path: path},
+			}
+			issue.Location.Lines.Begin = violation.LineNumber
+
+			// The fingerprint identifies the issue across pipelines
+			sum := md5.Sum([]byte(fmt.Sprintf("%s:%d:%s:%s", path, violation.LineNumber, issue.CheckName, issue.Description)))
+			issue.Fingerprint = hex.EncodeToString(sum[:])
+
+			issues = append(issues, issue)
+		}
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+func annotationTestResult() *gomdlint.LintResult {
+	result := &gomdlint.LintResult{Results: map[string][]gomdlint.Violation{
+		"docs/guide.md": {
+			{
+				LineNumber:      3,
+				RuleNames:       []string{"MD013", "line-length"},
+				RuleDescription: "Line length",
+				ErrorDetail:     "Expected: 80, Actual: 100",
+				ErrorRange:      []int{81, 20},
+				Severity:        "error",
+			},
+			{
+				LineNumber:      7,
+				RuleNames:       []string{"MD009", "no-trailing-spaces"},
+				RuleDescription: "Trailing spaces: 100% wrong",
+				Severity:        "warning",
+			},
+		},
+	}}
+	result.Recount()
+	return result
+}
+
+func TestFormatAsGitHub(t *testing.T) {
+	output, err := formatAsGitHub(annotationTestResult())
+	require.NoError(t, err)
+	assert.Equal(t,
+		"::error file=docs/guide.md,line=3,col=81,title=MD013/line-length::Line length [Expected: 80, Actual: 100]\n"+
+			"::warning file=docs/guide.md,line=7,title=MD009/no-trailing-spaces::Trailing spaces: 100%25 wrong\n",
+		output)
+}
+
+func TestFormatAsGitLab(t *testing.T) {
+	output, err := formatAsGitLab(annotationTestResult())
+	require.NoError(t, err)
+
+	var issues []gitLabIssue
+	require.NoError(t, json.Unmarshal([]byte(output), &issues))
+	require.Len(t, issues, 2)
+	assert.Equal(t, "MD013", issues[0].CheckName)
+	assert.Equal(t, "major", issues[0].Severity)
+	assert.Equal(t, "docs/guide.md", issues[0].Location.Path)
+	assert.Equal(t, 3, issues[0].Location.Lines.Begin)
+	assert.Equal(t, "minor", issues[1].Severity)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+
+	// Fingerprints are stable across runs
+	again, err := formatAsGitLab(annotationTestResult())
+	require.NoError(t, err)
+	assert.Equal(t, output, again)
+
+	output, err = formatAsGitLab(&gomdlint.LintResult{})
+	require.NoError(t, err)
+	assert.Equal(t, "[]", output)
+}
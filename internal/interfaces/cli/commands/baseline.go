@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+	"github.com/gomdlint/gomdlint/internal/interfaces/cli/output"
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+	"github.com/spf13/cobra"
+)
+
+// baselineOptions holds the baseline flags.
+type baselineOptions struct {
+	Path      string // --baseline: suppress violations recorded in this file
+	WritePath string // --write-baseline: record current violations to this file
+	Prune     bool   // --prune-baseline: drop stale entries from --baseline
+}
+
+// baselineReport summarizes what applying a baseline did.
+type baselineReport struct {
+	Written    int                     // Violations recorded by --write-baseline
+	Suppressed int                     // Violations hidden because they are baselined
+	Stale      []service.BaselineEntry // Entries matching no violation in linted files
+	Pruned     bool                    // Stale entries were removed from the baseline file
+}
+
+// StaleCount returns the number of baselined violations that are gone.
+func (r baselineReport) StaleCount() int {
+	count := 0
+	for _, entry := range r.Stale {
+		count += entry.Count
+	}
+	return count
+}
+
+// addBaselineFlags registers the baseline flags shared by lint and check.
+func addBaselineFlags(cmd *cobra.Command) {
+	cmd.Flags().String("baseline", "", "Only report violations not recorded in this baseline file")
+	cmd.Flags().String("write-baseline", "", "Record current violations to this baseline file")
+	cmd.Flags().Bool("prune-baseline", false, "Remove entries that no longer match a violation from --baseline")
+}
+
+// baselineOptionsFromFlags reads the baseline flags from cmd. Flags that are
+// not registered on the command are treated as unset.
+func baselineOptionsFromFlags(cmd *cobra.Command) (baselineOptions, error) {
+	path, _ := cmd.Flags().GetString("baseline")
+	writePath, _ := cmd.Flags().GetString("write-baseline")
+	prune, _ := cmd.Flags().GetBool("prune-baseline")
+
+	if path != "" && writePath != "" {
+		return baselineOptions{}, errors.New("--baseline and --write-baseline cannot be used together")
+	}
+	if prune && path == "" {
+		return baselineOptions{}, errors.New("--prune-baseline requires --baseline")
+	}
+	return baselineOptions{Path: path, WritePath: writePath, Prune: prune}, nil
+}
+
+// applyBaseline writes or applies a baseline to result. When writing, every
+// current violation is recorded and removed from the result; when applying,
+// baselined violations are removed and stale entries are reported, and
+// pruned if requested. content holds the text of string inputs such as stdin.
+func applyBaseline(result *gomdlint.LintResult, options baselineOptions, content map[string]string) (baselineReport, error) {
+	var report baselineReport
+
+	path := options.WritePath
+	if path == "" {
+		path = options.Path
+	}
+	if path == "" {
+		return report, nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return report, err
+	}
+	baseDir := filepath.Dir(absPath)
+
+	identifiers := make([]string, 0, len(result.Results))
+	for identifier := range result.Results {
+		identifiers = append(identifiers, identifier)
+	}
+	sort.Strings(identifiers)
+
+	if options.WritePath != "" {
+		var entries []service.BaselineEntry
+		for _, identifier := range identifiers {
+			file := baselineFile(identifier, baseDir, content)
+			lines := baselineLines(identifier, content)
+			for _, violation := range result.Results[identifier] {
+				entries = append(entries, service.BaselineEntry{
+					File:        file,
+					Rule:        violation.RuleNames[0],
+					Fingerprint: baselineFingerprint(violation, lines),
+					Count:       1,
+				})
+			}
+			result.Results[identifier] = []gomdlint.Violation{}
+		}
+
+		baseline := service.NewBaseline(entries)
+		if err := baseline.Save(options.WritePath); err != nil {
+			return report, err
+		}
+		report.Written = baseline.Total()
+		result.Recount()
+		return report, nil
+	}
+
+	baseline, err := service.LoadBaseline(options.Path)
+	if err != nil {
+		return report, err
+	}
+
+	filter := baseline.NewFilter()
+	for _, identifier := range identifiers {
+		file := baselineFile(identifier, baseDir, content)
+		filter.MarkLinted(file)
+
+		violations := result.Results[identifier]
+		if len(violations) == 0 {
+			continue
+		}
+
+		lines := baselineLines(identifier, content)
+		kept := make([]gomdlint.Violation, 0, len(violations))
+		for _, violation := range violations {
+			if !filter.Suppress(file, violation.RuleNames[0], baselineFingerprint(violation, lines)) {
+				kept = append(kept, violation)
+			}
+		}
+		result.Results[identifier] = kept
+	}
+	result.Recount()
+
+	report.Suppressed = filter.Suppressed()
+	report.Stale = filter.Stale()
+	if options.Prune && len(report.Stale) > 0 {
+		if err := baseline.Without(report.Stale).Save(options.Path); err != nil {
+			return report, err
+		}
+		report.Pruned = true
+	}
+	return report, nil
+}
+
+// baselineFile returns the baseline key for a linted identifier: files are
+// keyed relative to the baseline's directory so the baseline works from any
+// working directory; string inputs keep their name.
+func baselineFile(identifier, baseDir string, content map[string]string) string {
+	if _, isString := content[identifier]; isString {
+		return identifier
+	}
+	absFile, err := filepath.Abs(identifier)
+	if err != nil {
+		return filepath.ToSlash(identifier)
+	}
+	rel, err := filepath.Rel(baseDir, absFile)
+	if err != nil {
+		return filepath.ToSlash(absFile)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// baselineLines returns the lines of a linted file or string input.
+func baselineLines(identifier string, content map[string]string) []string {
+	text, isString := content[identifier]
+	if !isString {
+		data, err := os.ReadFile(identifier)
+		if err != nil {
+			return nil
+		}
+		text = string(data)
+	}
+	return strings.Split(text, "\n")
+}
+
+// baselineFingerprint fingerprints a violation by the text of its line.
+func baselineFingerprint(violation gomdlint.Violation, lines []string) string {
+	lineText := ""
+	if violation.LineNumber >= 1 && violation.LineNumber <= len(lines) {
+		lineText = lines[violation.LineNumber-1]
+	}
+	return service.BaselineFingerprint(violation.RuleNames[0], lineText)
+}
+
+// printBaselineReport tells the user what the baseline did.
+func printBaselineReport(themedOutput *output.ThemedOutput, report baselineReport, options baselineOptions, verbose bool) {
+	if options.WritePath != "" {
+		themedOutput.FileSaved("Recorded %d violations in baseline %s", report.Written, options.WritePath)
+		return
+	}
+
+	if verbose && report.Suppressed > 0 {
+		themedOutput.Info("Suppressed %d violations recorded in baseline %s", report.Suppressed, options.Path)
+	}
+
+	if stale := report.StaleCount(); stale > 0 {
+		if report.Pruned {
+			themedOutput.Success("Pruned %d stale violations from baseline %s", stale, options.Path)
+		} else {
+			themedOutput.Warning("%d baselined violations no longer occur; run with --prune-baseline to remove them", stale)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+// baselineTestResult builds a lint result with one violation per rule and
+// line for file.
+func baselineTestResult(file string, violations map[int]string) *gomdlint.LintResult {
+	result := &gomdlint.LintResult{Results: map[string][]gomdlint.Violation{file: {}}}
+	for line, rule := range violations {
+		result.Results[file] = append(result.Results[file], gomdlint.Violation{LineNumber: line, RuleNames: []string{rule}})
+	}
+	result.Recount()
+	return result
+}
+
+func TestApplyBaseline(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "docs", "guide.md")
+	baselinePath := filepath.Join(dir, ".gomdlint-baseline.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	require.NoError(t, os.WriteFile(file, []byte("# Guide\nLong line one\nLong line two\n"), 0644))
+
+	// Record the current violations
+	result := baselineTestResult(file, map[int]string{2: "MD013", 3: "MD013"})
+	report, err := applyBaseline(result, baselineOptions{WritePath: baselinePath}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Written)
+	assert.Equal(t, 0, result.TotalViolations)
+
+	baselineData, err := os.ReadFile(baselinePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(baselineData), `"file": "docs/guide.md"`, "files are relative to the baseline")
+
+	// Shift lines and add a new violation
+	require.NoError(t, os.WriteFile(file, []byte("# Guide\n\nIntro\nLong line one\nLong line two\nLong line three\n"), 0644))
+	result = baselineTestResult(file, map[int]string{4: "MD013", 5: "MD013", 6: "MD013"})
+	report, err = applyBaseline(result, baselineOptions{Path: baselinePath}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Suppressed)
+	assert.Empty(t, report.Stale)
+	require.Equal(t, 1, result.TotalViolations)
+	assert.Equal(t, 6, result.Results[file][0].LineNumber)
+	assert.Equal(t, 1, result.TotalErrors)
+
+	// Fix one baselined violation, then prune
+	require.NoError(t, os.WriteFile(file, []byte("# Guide\nLong line one\n"), 0644))
+	result = baselineTestResult(file, map[int]string{2: "MD013"})
+	report, err = applyBaseline(result, baselineOptions{Path: baselinePath}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.StaleCount())
+	assert.False(t, report.Pruned)
+
+	result = baselineTestResult(file, map[int]string{2: "MD013"})
+	report, err = applyBaseline(result, baselineOptions{Path: baselinePath, Prune: true}, nil)
+	require.NoError(t, err)
+	assert.True(t, report.Pruned)
+
+	result = baselineTestResult(file, map[int]string{2: "MD013"})
+	report, err = applyBaseline(result, baselineOptions{Path: baselinePath}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Stale)
+	assert.Equal(t, 0, result.TotalViolations)
+}
+
+func TestApplyBaseline_StringInput(t *testing.T) {
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	content := map[string]string{"stdin": "# Title\nLong line\n"}
+
+	result := baselineTestResult("stdin", map[int]string{2: "MD013"})
+	_, err := applyBaseline(result, baselineOptions{WritePath: baselinePath}, content)
+	require.NoError(t, err)
+
+	result = baselineTestResult("stdin", map[int]string{2: "MD013"})
+	report, err := applyBaseline(result, baselineOptions{Path: baselinePath}, content)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Suppressed)
+}
+
+func TestBaselineOptionsFromFlags(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		flags         map[string]string
+		expected      baselineOptions
+		errorContains string
+	}{
+		{name: "no flags", expected: baselineOptions{}},
+		{
+			name:     "apply and prune",
+			flags:    map[string]string{"baseline": "b.json", "prune-baseline": "true"},
+			expected: baselineOptions{Path: "b.json", Prune: true},
+		},
+		{
+			name:          "write and apply",
+			flags:         map[string]string{"baseline": "b.json", "write-baseline": "b.json"},
+			errorContains: "cannot be used together",
+		},
+		{
+			name:          "prune without baseline",
+			flags:         map[string]string{"prune-baseline": "true"},
+			errorContains: "--prune-baseline requires --baseline",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cmd := NewLintCommand()
+			for name, value := range scenario.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+
+			options, err := baselineOptionsFromFlags(cmd)
+			if scenario.errorContains != "" {
+				assert.ErrorContains(t, err, scenario.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expected, options)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+	"github.com/spf13/cobra"
+)
+
+// NewCacheCommand creates the cache command for managing cached lint results.
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage cached lint results",
+		Long: `Manage the on-disk cache of lint results.
+
+Results are reused for files whose contents (or, with --cache-strategy metadata,
+size and modification time) are unchanged since the last run with the same
+configuration, gomdlint build and plugins. Entries unused for 30 days are pruned
+automatically.`,
+	}
+
+	cmd.AddCommand(
+		newCacheClearCommand(),
+		newCacheStatsCommand(),
+	)
+
+	return cmd
+}
+
+func newCacheClearCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached results",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			location, err := cacheLocationFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			removed, err := service.ClearCacheFiles(location)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %d cache files from %s\n", removed, location)
+			return nil
+		},
+	}
+}
+
+func newCacheStatsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Show cache location and size",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			location, err := cacheLocationFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			stats, err := service.ReadCacheStats(location)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Location: %s\n", stats.Location)
+			fmt.Fprintf(out, "Files:    %d\n", stats.Files)
+			fmt.Fprintf(out, "Entries:  %d\n", stats.Entries)
+			fmt.Fprintf(out, "Size:     %s\n", formatByteSize(stats.Bytes))
+			if stats.Files > 0 {
+				fmt.Fprintf(out, "Oldest:   %s\n", stats.Oldest.Format(time.RFC3339))
+				fmt.Fprintf(out, "Newest:   %s\n", stats.Newest.Format(time.RFC3339))
+			}
+			return nil
+		},
+	}
+}
+
+// cacheLocationFromFlags returns --cache-location or the default cache
+// directory.
+func cacheLocationFromFlags(cmd *cobra.Command) (string, error) {
+	location, _ := cmd.Flags().GetString("cache-location")
+	if location != "" {
+		return location, nil
+	}
+	return service.DefaultCacheLocation()
+}
+
+// cacheOptionsFromFlags reads the result cache flags from cmd. Flags that are
+// not registered on the command leave the cache disabled.
+func cacheOptionsFromFlags(cmd *cobra.Command) (value.CacheOptions, error) {
+	enabled, _ := cmd.Flags().GetBool("cache")
+	location, _ := cmd.Flags().GetString("cache-location")
+	strategyName, _ := cmd.Flags().GetString("cache-strategy")
+
+	strategy, err := service.ParseCacheStrategy(strategyName)
+	if err != nil {
+		return value.CacheOptions{}, err
+	}
+
+	return value.CacheOptions{
+		Enabled:  enabled,
+		Location: location,
+		Strategy: strategy,
+	}, nil
+}
+
+// applyCacheOptions copies the result cache settings onto lint options.
+func applyCacheOptions(options *gomdlint.LintOptions, cache value.CacheOptions) {
+	options.Cache = cache.Enabled
+	options.CacheLocation = cache.Location
+	options.CacheStrategy = string(cache.Strategy)
+}
+
+// formatByteSize renders a byte count with a binary unit, e.g. "1.5 MiB".
+func formatByteSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
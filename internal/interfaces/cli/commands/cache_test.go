@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+)
+
+// runCacheCommand runs the cache command with args and returns its output.
+func runCacheCommand(t *testing.T, args ...string) string {
+	t.Helper()
+
+	cmd := NewCacheCommand()
+	cmd.PersistentFlags().String("cache-location", "", "")
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.Execute())
+	return stdout.String()
+}
+
+func TestCacheCommand(t *testing.T) {
+	location := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(location, "lint-0123456789abcdef.json"),
+		[]byte(`{"format":1,"key":"k","entries":{"/a.md":{},"/b.md":{}}}`), 0644))
+
+	output := runCacheCommand(t, "stats", "--cache-location", location)
+	assert.Contains(t, output, "Location: "+location)
+	assert.Contains(t, output, "Files:    1")
+	assert.Contains(t, output, "Entries:  2")
+
+	output = runCacheCommand(t, "clear", "--cache-location", location)
+	assert.Equal(t, "Removed 1 cache files from "+location+"\n", output)
+
+	output = runCacheCommand(t, "stats", "--cache-location", location)
+	assert.Contains(t, output, "Files:    0")
+	assert.NotContains(t, output, "Oldest")
+}
+
+func TestCacheOptionsFromFlags(t *testing.T) {
+	cmd := NewLintCommand()
+	cmd.Flags().Bool("cache", true, "")
+	cmd.Flags().String("cache-location", "", "")
+	cmd.Flags().String("cache-strategy", "content", "")
+
+	options, err := cacheOptionsFromFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, value.CacheOptions{Enabled: true, Strategy: value.CacheStrategyContent}, options)
+
+	require.NoError(t, cmd.Flags().Set("cache-strategy", "metadata"))
+	require.NoError(t, cmd.Flags().Set("cache-location", "/tmp/cache"))
+	options, err = cacheOptionsFromFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, value.CacheOptions{Enabled: true, Location: "/tmp/cache", Strategy: value.CacheStrategyMetadata}, options)
+
+	require.NoError(t, cmd.Flags().Set("cache-strategy", "mtime"))
+	_, err = cacheOptionsFromFlags(cmd)
+	assert.ErrorContains(t, err, `invalid cache strategy "mtime"`)
+
+	assert.Equal(t, "1.5 KiB", formatByteSize(1536))
+	assert.Equal(t, "512 B", formatByteSize(512))
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+// changeOptions holds the flags restricting linting to changes in git.
+type changeOptions struct {
+	Since     string // --changed-since: only lint files changed relative to this revision
+	LinesOnly bool   // --changed-lines-only: only report violations on changed lines
+}
+
+// addChangeFlags registers the git change flags shared by lint and check.
+func addChangeFlags(cmd *cobra.Command) {
+	cmd.Flags().String("changed-since", "", "Only lint files changed relative to this git revision")
+	cmd.Flags().Bool("changed-lines-only", false, "Only report violations on lines added or modified since --changed-since")
+}
+
+// changeOptionsFromFlags reads the git change flags from cmd. Flags that are
+// not registered on the command are treated as unset.
+func changeOptionsFromFlags(cmd *cobra.Command) (changeOptions, error) {
+	since, _ := cmd.Flags().GetString("changed-since")
+	linesOnly, _ := cmd.Flags().GetBool("changed-lines-only")
+
+	if linesOnly && since == "" {
+		return changeOptions{}, errors.New("--changed-lines-only requires --changed-since")
+	}
+	return changeOptions{Since: since, LinesOnly: linesOnly}, nil
+}
+
+// filterChangedFiles keeps the files that changed relative to rev in the git
+// repository containing the working directory.
+func filterChangedFiles(files []string, rev string) ([]string, error) {
+	changed, err := service.GitChangedFiles(".", rev)
+	if err != nil {
+		return nil, err
+	}
+
+	changedSet := make(map[string]bool, len(changed))
+	for _, file := range changed {
+		changedSet[canonicalPath(file)] = true
+	}
+
+	kept := make([]string, 0, len(files))
+	for _, file := range files {
+		if changedSet[canonicalPath(file)] {
+			kept = append(kept, file)
+		}
+	}
+	return kept, nil
+}
+
+// filterChangedLines removes violations that are not on lines added or
+// modified relative to rev, then recounts the result.
+func filterChangedLines(result *gomdlint.LintResult, rev string) error {
+	changed, err := service.GitChangedLines(".", rev)
+	if err != nil {
+		return err
+	}
+
+	changedLines := make(map[string][]service.LineRange, len(changed))
+	for file, ranges := range changed {
+		changedLines[canonicalPath(file)] = ranges
+	}
+
+	for identifier, violations := range result.Results {
+		ranges := changedLines[canonicalPath(identifier)]
+		kept := make([]gomdlint.Violation, 0, len(violations))
+		for _, violation := range violations {
+			if lineChanged(ranges, violation.LineNumber) {
+				kept = append(kept, violation)
+			}
+		}
+		result.Results[identifier] = kept
+	}
+	result.Recount()
+	return nil
+}
+
+// lineChanged reports whether line falls within any of ranges.
+func lineChanged(ranges []service.LineRange, line int) bool {
+	for _, r := range ranges {
+		if r.Contains(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalPath returns the absolute path of file with symlinks resolved, so
+// paths reported by git compare equal to discovered paths.
+func canonicalPath(file string) string {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+	if resolved, err := filepath.EvalSymlinks(absFile); err == nil {
+		return resolved
+	}
+	return absFile
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+// changesTestRepo creates a throwaway git repository with one committed
+// revision tagged "base", then makes it the working directory.
+func changesTestRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	writeChangesTestFiles(t, root, files)
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "--all"},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "-c", "commit.gpgsign=false", "commit", "--quiet", "-m", "initial"},
+		{"tag", "base"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(root))
+	t.Cleanup(func() {
+		os.Chdir(oldDir)
+	})
+	return root
+}
+
+func writeChangesTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestFilterChangedFiles(t *testing.T) {
+	changesTestRepo(t, map[string]string{
+		"README.md":     "# Readme\n",
+		"docs/guide.md": "# Guide\n",
+	})
+	writeChangesTestFiles(t, ".", map[string]string{"docs/guide.md": "# Guide\n\nMore\n"})
+
+	files, err := filterChangedFiles([]string{"README.md", filepath.Join("docs", "guide.md")}, "base")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("docs", "guide.md")}, files, "discovered paths are kept as given")
+
+	_, err = filterChangedFiles([]string{"README.md"}, "no-such-revision")
+	assert.Error(t, err)
+}
+
+func TestFilterChangedLines(t *testing.T) {
+	changesTestRepo(t, map[string]string{
+		"README.md": "# Readme\n\nline 3\nline 4\n",
+		"other.md":  "# Other\n",
+	})
+	writeChangesTestFiles(t, ".", map[string]string{"README.md": "# Readme\n\nline 3 changed\nline 4\nline 5\n"})
+
+	result := &gomdlint.LintResult{Results: map[string][]gomdlint.Violation{
+		"README.md": {
+			{LineNumber: 1, RuleNames: []string{"MD041"}},
+			{LineNumber: 3, RuleNames: []string{"MD013"}},
+			{LineNumber: 5, RuleNames: []string{"MD013"}},
+		},
+		"other.md": {
+			{LineNumber: 1, RuleNames: []string{"MD041"}},
+		},
+	}}
+	result.Recount()
+
+	require.NoError(t, filterChangedLines(result, "base"))
+	require.Len(t, result.Results["README.md"], 2)
+	assert.Equal(t, 3, result.Results["README.md"][0].LineNumber)
+	assert.Equal(t, 5, result.Results["README.md"][1].LineNumber)
+	assert.Empty(t, result.Results["other.md"], "unchanged files keep no violations")
+	assert.Equal(t, 2, result.TotalViolations)
+}
+
+func TestChangeOptionsFromFlags(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		flags         map[string]string
+		expected      changeOptions
+		errorContains string
+	}{
+		{name: "no flags", expected: changeOptions{}},
+		{
+			name:     "files and lines",
+			flags:    map[string]string{"changed-since": "origin/main", "changed-lines-only": "true"},
+			expected: changeOptions{Since: "origin/main", LinesOnly: true},
+		},
+		{
+			name:          "lines without revision",
+			flags:         map[string]string{"changed-lines-only": "true"},
+			errorContains: "--changed-lines-only requires --changed-since",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cmd := NewCheckCommand()
+			for name, value := range scenario.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+
+			options, err := changeOptionsFromFlags(cmd)
+			if scenario.errorContains != "" {
+				assert.ErrorContains(t, err, scenario.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expected, options)
+		})
+	}
+}
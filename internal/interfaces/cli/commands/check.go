@@ -1,30 +1,131 @@
 package commands
 
 import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
 	"github.com/spf13/cobra"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
 )
 
-// NewCheckCommand creates the check command (alias for lint with exit code behavior).
+// NewCheckCommand creates the check command, the non-interactive entry point for CI.
 func NewCheckCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "check [files...]",
-		Short: "Check markdown files (alias for lint)",
+		Short: "Check markdown files in CI (lint without writing files)",
 		Long: `Check markdown files for linting violations.
-		
-This is an alias for the 'lint' command with default behavior suitable for CI/CD.
-Exit code is non-zero if any violations are found.`,
+
+Check is the non-interactive entry point for CI/CD. It lints like 'lint' but
+never writes files (the result cache is used only with an explicit --cache)
+and reports the time spent in each phase. When no --format is given it picks
+the annotation format of the CI system it runs in: 'github' when
+GITHUB_ACTIONS is set and 'gitlab' when GITLAB_CI is set.
+
+Exit codes:
+  0  no violations, or none beyond --max-violations and --max-warnings
+  1  violations found or a threshold exceeded
+  2  invalid flags, arguments or configuration
+  3  internal error, or content that could not be linted`,
 		Args: cobra.ArbitraryArgs,
-		RunE: runLint, // Reuse the lint command logic
+		RunE: runCheck,
+		// The returned error carries the exit code; HandleError reports it
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
 
-	// Inherit the same command-specific flags as lint (persistent flags are inherited automatically)
-	cmd.Flags().StringSlice("ignore", []string{}, "Ignore files matching these patterns")
-	cmd.Flags().Bool("stdin", false, "Read from stdin instead of files")
-	cmd.Flags().String("stdin-name", "stdin", "Name for stdin input")
-	cmd.Flags().Bool("dot", false, "Include hidden files and directories")
-	cmd.Flags().Bool("fix", false, "Automatically fix violations where possible")
-	cmd.Flags().Bool("fail-fast", false, "Stop on first violation")
-	cmd.Flags().Bool("summary-only", false, "Show summary only")
+	// Inherit the lint flags that do not write files (persistent flags are inherited automatically)
+	addStdinFlags(cmd)
+	addFileSelectionFlags(cmd)
+	addChangeFlags(cmd)
+	cmd.Flags().String("baseline", "", "Only report violations not recorded in this baseline file")
+	addThresholdFlags(cmd)
+	addProfilingFlags(cmd)
+	cmd.Flags().Bool("fail-fast", false, "Stop linting further files after the first file with an error")
+	cmd.Flags().Bool("summary-only", false, "Show violation counts per rule and per file instead of each violation")
 
 	return cmd
 }
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	return executeLint(cmd, args, lintMode{CI: true})
+}
+
+// phaseDurations records the time spent in each phase of a lint run. Parsing
+// and rules are summed across files, so they can exceed the elapsed time.
+type phaseDurations struct {
+	Discovery time.Duration
+	Read      time.Duration
+	Parse     time.Duration
+	Rules     time.Duration
+	Output    time.Duration
+}
+
+// String formats the durations on one line.
+func (d phaseDurations) String() string {
+	return fmt.Sprintf("Timing: discovery %s, reading %s, parsing %s, rules %s, output %s",
+		d.Discovery.Round(time.Microsecond), d.Read.Round(time.Microsecond), d.Parse.Round(time.Microsecond),
+		d.Rules.Round(time.Microsecond), d.Output.Round(time.Microsecond))
+}
+
+// printSummaryTable prints the number of violations per rule and per file,
+// most frequent first.
+func printSummaryTable(w io.Writer, result *gomdlint.LintResult) {
+	if result.TotalViolations == 0 {
+		return
+	}
+
+	type fileCounts struct {
+		file     string
+		errors   int
+		warnings int
+	}
+	ruleCounts := make(map[string]int)
+	var files []fileCounts
+	for _, file := range sortedResultFiles(result) {
+		counts := fileCounts{file: file}
+		for _, violation := range result.Results[file] {
+			ruleCounts[violation.RuleNames[0]]++
+			if violation.Severity == "warning" {
+				counts.warnings++
+			} else {
+				counts.errors++
+			}
+		}
+		if counts.errors+counts.warnings > 0 {
+			files = append(files, counts)
+		}
+	}
+
+	rules := make([]string, 0, len(ruleCounts))
+	for rule := range ruleCounts {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if ruleCounts[rules[i]] != ruleCounts[rules[j]] {
+			return ruleCounts[rules[i]] > ruleCounts[rules[j]]
+		}
+		return rules[i] < rules[j]
+	})
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].errors+files[i].warnings > files[j].errors+files[j].warnings
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RULE\tVIOLATIONS")
+	for _, rule := range rules {
+		fmt.Fprintf(tw, "%s\t%d\n", rule, ruleCounts[rule])
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FILE\tERRORS\tWARNINGS")
+	for _, counts := range files {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", counts.file, counts.errors, counts.warnings)
+	}
+	tw.Flush()
+}
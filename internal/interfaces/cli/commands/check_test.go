@@ -1,15 +1,19 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
 )
 
 // Test scenarios for check command following club/ standards
@@ -44,15 +48,20 @@ func TestNewCheckCommand(t *testing.T) {
 
 	assert.NotNil(t, cmd, "Command should not be nil")
 	assert.Equal(t, "check [files...]", cmd.Use)
-	assert.Equal(t, "Check markdown files (alias for lint)", cmd.Short)
+	assert.Equal(t, "Check markdown files in CI (lint without writing files)", cmd.Short)
 	assert.NotEmpty(t, cmd.Long)
 
 	// Check that expected flags are present
-	expectedFlags := []string{"ignore", "stdin", "stdin-name", "dot"}
+	expectedFlags := []string{"ignore", "stdin", "stdin-name", "stdin-filename", "dot", "baseline", "fail-fast", "summary-only", "max-violations"}
 	for _, flagName := range expectedFlags {
 		flag := cmd.Flags().Lookup(flagName)
 		assert.NotNil(t, flag, "Flag %s should exist", flagName)
 	}
+
+	// Check never writes files
+	for _, flagName := range []string{"fix", "write-baseline", "prune-baseline"} {
+		assert.Nil(t, cmd.Flags().Lookup(flagName), "Flag %s should not exist", flagName)
+	}
 }
 
 func TestCheckCommand_BasicScenarios(t *testing.T) {
@@ -73,7 +82,7 @@ func TestCheckCommand_BasicScenarios(t *testing.T) {
 				"invalid.md": "#Title without space\n\nContent.\n",
 			},
 			args:             []string{"invalid.md"},
-			expectError:      false, // Command executes, but exits with non-zero
+			expectError:      false,
 			expectedExitCode: 1,
 		},
 		{
@@ -99,7 +108,7 @@ func TestCheckCommand_BasicScenarios(t *testing.T) {
 			name:             "nonexistent file",
 			args:             []string{"nonexistent.md"},
 			expectError:      true,
-			expectedExitCode: 1,
+			expectedExitCode: 2,
 		},
 	}
 
@@ -126,12 +135,8 @@ func TestCheckCommand_BasicScenarios(t *testing.T) {
 			// Verify results
 			if scenario.expectError {
 				assert.Error(t, err)
-			} else if scenario.expectedExitCode == 0 {
-				assert.NoError(t, err)
-			} else if scenario.expectedExitCode == 1 {
-				// In test mode, command completes without error - violations are shown in output
-				assert.NoError(t, err)
 			}
+			assert.Equal(t, scenario.expectedExitCode, ExitCode(err))
 
 			if scenario.expectOutput != "" {
 				output := stdout.String() + stderr.String()
@@ -149,11 +154,11 @@ func TestCheckCommand_FlagScenarios(t *testing.T) {
 	}
 
 	flagScenarios := []struct {
-		name        string
-		args        []string
-		flags       map[string]interface{}
-		expectError bool
-		expectEarly bool // For fail-fast
+		name             string
+		args             []string
+		flags            map[string]interface{}
+		expectedExitCode int
+		expectEarly      bool // For fail-fast
 	}{
 		{
 			name: "fail-fast mode",
@@ -161,8 +166,8 @@ func TestCheckCommand_FlagScenarios(t *testing.T) {
 			flags: map[string]interface{}{
 				"fail-fast": true,
 			},
-			expectError: false, // In test mode, no error returned - violations shown in output
-			expectEarly: true,  // Should stop at first violation
+			expectedExitCode: 1,
+			expectEarly:      true, // Should stop at first violation
 		},
 		{
 			name: "summary-only mode",
@@ -170,7 +175,7 @@ func TestCheckCommand_FlagScenarios(t *testing.T) {
 			flags: map[string]interface{}{
 				"summary-only": true,
 			},
-			expectError: false, // In test mode, no error returned - violations shown in output
+			expectedExitCode: 1,
 		},
 		{
 			name: "quiet mode",
@@ -178,7 +183,7 @@ func TestCheckCommand_FlagScenarios(t *testing.T) {
 			flags: map[string]interface{}{
 				"quiet": true,
 			},
-			expectError: false,
+			expectedExitCode: 0,
 		},
 		{
 			name: "verbose mode",
@@ -186,7 +191,7 @@ func TestCheckCommand_FlagScenarios(t *testing.T) {
 			flags: map[string]interface{}{
 				"verbose": true,
 			},
-			expectError: false,
+			expectedExitCode: 0,
 		},
 		{
 			name: "combined flags",
@@ -195,7 +200,7 @@ func TestCheckCommand_FlagScenarios(t *testing.T) {
 				"summary-only": true,
 				"quiet":        true,
 			},
-			expectError: false, // In test mode, no error returned - violations shown in output
+			expectedExitCode: 1,
 		},
 	}
 
@@ -219,11 +224,7 @@ func TestCheckCommand_FlagScenarios(t *testing.T) {
 			stdout, stderr, err := executeCommand(t, cmd, scenario.args, scenario.flags)
 
 			// Verify results
-			if scenario.expectError {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-			}
+			assert.Equal(t, scenario.expectedExitCode, ExitCode(err))
 
 			output := stdout.String() + stderr.String()
 
@@ -290,13 +291,65 @@ func TestCheckCommand_ExitCodeBehavior(t *testing.T) {
 			// Execute command
 			_, _, err = executeCommand(t, cmd, []string{"."}, map[string]interface{}{})
 
-			// Note: In actual usage, exit codes are set via os.Exit()
-			// In unit tests, we verify the command completes without panic
-			assert.NoError(t, err, "Command should execute without error")
+			// Commands return the exit code rather than calling os.Exit
+			assert.Equal(t, tc.expectedCode, ExitCode(err))
 		})
 	}
 }
 
+func TestCheckCommand_CIFormat(t *testing.T) {
+	tmpDir := createTempTestFiles(t, map[string]string{"bad.md": "#Bad\n\nContent.\n"})
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer os.Chdir(oldDir)
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITLAB_CI", "")
+	stdout, stderr, err := executeCommand(t, createCheckCommand(), []string{"bad.md"}, nil)
+	assert.Equal(t, ExitViolations, ExitCode(err))
+	assert.Contains(t, stdout.String(), "::error file=bad.md,line=1,title=MD018/no-missing-space-atx::")
+	assert.Contains(t, stderr.String(), "Timing: discovery")
+
+	// An explicit format wins over the environment
+	stdout, _, err = executeCommand(t, createCheckCommand(), []string{"bad.md"}, map[string]interface{}{"format": "json"})
+	assert.Equal(t, ExitViolations, ExitCode(err))
+	assert.True(t, strings.HasPrefix(stdout.String(), "{"), "expected JSON output, got %q", stdout.String())
+}
+
+func TestCiFormatFromEnv(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+	assert.Equal(t, "github", ciFormatFromEnv(env(map[string]string{"GITHUB_ACTIONS": "true"})))
+	assert.Equal(t, "gitlab", ciFormatFromEnv(env(map[string]string{"GITLAB_CI": "true"})))
+	assert.Equal(t, "", ciFormatFromEnv(env(nil)))
+}
+
+func TestPrintSummaryTable(t *testing.T) {
+	result := &gomdlint.LintResult{Results: map[string][]gomdlint.Violation{
+		"a.md": {{LineNumber: 1, RuleNames: []string{"MD013", "line-length"}, Severity: "error"}},
+		"b.md": {
+			{LineNumber: 1, RuleNames: []string{"MD013", "line-length"}, Severity: "error"},
+			{LineNumber: 2, RuleNames: []string{"MD009", "no-trailing-spaces"}, Severity: "warning"},
+		},
+		"c.md": {},
+	}}
+	result.Recount()
+
+	var out bytes.Buffer
+	printSummaryTable(&out, result)
+	assert.Equal(t, `RULE   VIOLATIONS
+MD013  2
+MD009  1
+
+FILE  ERRORS  WARNINGS
+b.md  1       1
+a.md  1       0
+`, out.String())
+}
+
 func TestCheckCommand_Configuration(t *testing.T) {
 	testFiles := map[string]string{
 		"test.md": "# Title\n\nContent.\n", // Valid markdown with proper spacing
@@ -349,7 +402,7 @@ func TestCheckCommand_Configuration(t *testing.T) {
 			"no-config": true,
 		})
 
-		assert.NoError(t, err) // In test mode, no error returned - violations shown in output
+		assert.Equal(t, ExitViolations, ExitCode(err))
 
 		output := stdout.String() + stderr.String()
 		// Without config, should use defaults and find violations
@@ -521,7 +574,7 @@ Too many blank lines above this paragraph.
 		"verbose": true,
 	})
 
-	assert.NoError(t, err) // In test mode, no error returned - violations shown in output
+	assert.Equal(t, ExitViolations, ExitCode(err))
 
 	output := stdout.String() + stderr.String()
 
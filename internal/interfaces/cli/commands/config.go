@@ -6,7 +6,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gomdlint/gomdlint/internal/app/service"
@@ -23,13 +25,17 @@ type ConfigurationSource struct {
 	Sources     []ConfigSource         // Detailed information about each source
 	IsDefault   bool                   // True if using only default configuration
 	IsHierarchy bool                   // True if multiple config files were merged
+	Provenance  map[string]string      // Source of each resolved key (dotted path -> file or style)
+	Profile     string                 // Active profile layered over the configuration, if any
+
+	merger *utils.ConfigurationMerger // Layers behind Config, kept so profiles can be added on top
 }
 
 // ConfigSource represents a single configuration source in the hierarchy
 type ConfigSource struct {
 	Path   string                 // Path to the config file (empty for defaults)
 	Type   ConfigSourceType       // Type of configuration source
-	Config map[string]interface{} // Configuration data from this source
+	Config map[string]interface{} // Configuration data from this source, with extends applied
 }
 
 // ConfigSourceType represents the type of configuration source
@@ -41,6 +47,7 @@ const (
 	ConfigSourceTypeUser    ConfigSourceType = "user"    // XDG user config
 	ConfigSourceTypeProject ConfigSourceType = "project" // Project directory
 	ConfigSourceTypeCustom  ConfigSourceType = "custom"  // Explicitly specified file
+	ConfigSourceTypeFlags   ConfigSourceType = "flags"   // Rule selection flags (--enable, --rule, ...)
 )
 
 // NewConfigCommand creates the config command for configuration management.
@@ -57,6 +64,8 @@ func NewConfigCommand() *cobra.Command {
 		newConfigShowCommand(),
 		newConfigWhichCommand(),
 		newConfigEditCommand(),
+		newConfigProfilesCommand(),
+		newConfigSchemaCommand(),
 	)
 
 	return cmd
@@ -100,19 +109,31 @@ Specify a config file path to validate only that specific file.`,
 }
 
 func newConfigShowCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "show [config-file]",
 		Short: "Show effective configuration",
-		Long:  `Display the effective configuration that would be used for linting.`,
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Display the effective configuration that would be used for linting.
+
+Use --resolved to print every setting after extends and the configuration
+hierarchy have been applied, together with the file or style it came from.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			configFile := ""
 			if len(args) > 0 {
 				configFile = args[0]
 			}
-			return showConfig(configFile)
+			profile, _ := cmd.Flags().GetString("profile")
+			resolved, _ := cmd.Flags().GetBool("resolved")
+			overrides := ruleOverridesFromFlags(cmd)
+			if resolved {
+				return showResolvedConfig(configFile, profile, overrides)
+			}
+			return showConfig(configFile, profile, overrides)
 		},
 	}
+
+	cmd.Flags().Bool("resolved", false, "Show the flattened configuration with the source of each setting")
+	return cmd
 }
 
 func newConfigWhichCommand() *cobra.Command {
@@ -122,10 +143,12 @@ func newConfigWhichCommand() *cobra.Command {
 		Long: `Display the configuration files that would be used for linting.
 
 By default, shows a simple tree of loaded configuration files.
+Rule selection flags (--enable, --disable, --enable-tag, --disable-tag, --rule)
+are listed as the final, highest-priority layers.
 Use --verbose to see detailed information including search paths, file sizes, and merge behavior.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			verbose, _ := cmd.Flags().GetBool("verbose")
-			return whichConfig("", verbose)
+			return whichConfig("", verbose, ruleOverridesFromFlags(cmd))
 		},
 	}
 
@@ -133,12 +156,15 @@ Use --verbose to see detailed information including search paths, file sizes, an
 	return cmd
 }
 
-func whichConfig(configFile string, verbose bool) error {
+func whichConfig(configFile string, verbose bool, overrides RuleOverrides) error {
 	// Use the same configuration loading as other commands for consistency
 	sources, err := loadConfigurationSource(configFile)
 	if err != nil {
 		return fmt.Errorf("failed to resolve configuration: %w", err)
 	}
+	if err := applyRuleOverrides(sources, overrides); err != nil {
+		return err
+	}
 
 	// Check if we're using only defaults (no config files found)
 	if sources.IsDefault {
@@ -328,17 +354,7 @@ func determineConfigSourceType(filePath string) ConfigSourceType {
 }
 
 func loadConfig(configFile string) (map[string]interface{}, error) {
-	data, err := os.ReadFile(configFile)
-	if err != nil {
-		return nil, err
-	}
-
-	var config map[string]interface{}
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, err
-	}
-
-	return config, nil
+	return service.ParseConfigFile(configFile)
 }
 
 func newConfigEditCommand() *cobra.Command {
@@ -393,7 +409,7 @@ func initConfig(legacy bool) error {
 
 	// Check if file already exists
 	if _, err := os.Stat(configFile); err == nil {
-		return fmt.Errorf("configuration file %s already exists", configFile)
+		return usageError(fmt.Errorf("configuration file %s already exists", configFile))
 	}
 
 	// Ensure directory exists
@@ -429,7 +445,7 @@ func initConfig(legacy bool) error {
 func validateConfig(configFile string) error {
 	configSource, err := loadConfigurationSource(configFile)
 	if err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return usageError(fmt.Errorf("validation failed: %w", err))
 	}
 
 	if configSource.IsDefault {
@@ -445,6 +461,22 @@ func validateConfig(configFile string) error {
 		fmt.Println("Theme configuration is valid")
 	}
 
+	// Validate keys, types and values against the configuration schema
+	schema, err := service.BuiltInConfigSchema()
+	if err != nil {
+		return fmt.Errorf("failed to build configuration schema: %w", err)
+	}
+	if issues := service.ValidateConfigSchema(schema, configSource.Config); len(issues) > 0 {
+		for _, issue := range issues {
+			if source := schemaIssueSource(configSource.Provenance, issue.Path); source != "" {
+				fmt.Printf("%s: %s\n", provenanceLabel(source), issue)
+			} else {
+				fmt.Println(issue)
+			}
+		}
+		return usageError(fmt.Errorf("validation failed: %d configuration problem(s) found", len(issues)))
+	}
+
 	// Display validation results
 	if configSource.IsDefault {
 		fmt.Println("Default configuration is valid")
@@ -525,10 +557,10 @@ func validateThemeConfig(themeData interface{}) error {
 	return themeService.ValidateConfig(themeConfig)
 }
 
-func showConfig(configFile string) error {
-	configSource, err := loadConfigurationSource(configFile)
+func showConfig(configFile string, profile string, overrides RuleOverrides) error {
+	configSource, err := loadConfigurationSourceWithOverrides(configFile, resolveProfileName(profile), overrides)
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return usageError(fmt.Errorf("failed to load configuration: %w", err))
 	}
 
 	// Show configuration sources and hierarchy information
@@ -536,14 +568,10 @@ func showConfig(configFile string) error {
 		fmt.Println("# No configuration files found - using built-in defaults")
 		fmt.Println("# Use 'gomdlint config which' to see all search paths")
 		fmt.Println("# Use 'gomdlint config init' to create a configuration file")
-	} else if configSource.IsHierarchy {
+	} else if configSource.IsHierarchy || len(configSource.Sources) > 1 {
 		fmt.Println("# Hierarchical configuration merged from multiple sources:")
 		for i, source := range configSource.Sources {
-			absPath, err := filepath.Abs(source.Path)
-			if err != nil {
-				absPath = source.Path
-			}
-			fmt.Printf("#   %d. %s (%s)\n", i+1, absPath, source.Type)
+			fmt.Printf("#   %d. %s (%s)\n", i+1, sourceDisplayName(source), source.Type)
 		}
 		fmt.Println("#")
 		fmt.Println("# Higher-numbered sources override lower-numbered sources")
@@ -556,6 +584,9 @@ func showConfig(configFile string) error {
 		}
 		fmt.Printf("# Configuration loaded from: %s (%s)\n", absPath, source.Type)
 	}
+	if configSource.Profile != "" {
+		fmt.Printf("# Profile applied: %s\n", configSource.Profile)
+	}
 	fmt.Println()
 
 	// Pretty print the configuration
@@ -568,6 +599,218 @@ func showConfig(configFile string) error {
 	return nil
 }
 
+// showResolvedConfig prints the flattened configuration, one setting per
+// line, annotated with the file or style that supplied it.
+func showResolvedConfig(configFile string, profile string, overrides RuleOverrides) error {
+	configSource, err := loadConfigurationSourceWithOverrides(configFile, resolveProfileName(profile), overrides)
+	if err != nil {
+		return usageError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+
+	fmt.Print(formatResolvedConfig(configSource))
+	return nil
+}
+
+// formatResolvedConfig renders the resolved settings as "key = value  # source" lines.
+func formatResolvedConfig(configSource *ConfigurationSource) string {
+	// Profile definitions are not settings in effect; skip them
+	effective := make(map[string]interface{}, len(configSource.Config))
+	for key, value := range configSource.Config {
+		if key != "profiles" {
+			effective[key] = value
+		}
+	}
+
+	settings := make(map[string]interface{})
+	flattenConfigSettings("", effective, settings)
+
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "# Resolved configuration (%d settings)\n", len(keys))
+	if configSource.Profile != "" {
+		fmt.Fprintf(&buf, "# Profile applied: %s\n", configSource.Profile)
+	}
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, key := range keys {
+		data, err := json.Marshal(settings[key])
+		if err != nil {
+			data = []byte(fmt.Sprintf("%v", settings[key]))
+		}
+		fmt.Fprintf(w, "%s = %s\t# %s\n", key, data, provenanceLabel(configSource.Provenance[key]))
+	}
+	w.Flush()
+
+	return buf.String()
+}
+
+// flattenConfigSettings collects the leaf values of config under dotted keys.
+func flattenConfigSettings(prefix string, config map[string]interface{}, out map[string]interface{}) {
+	for key, value := range config {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenConfigSettings(path, nested, out)
+			continue
+		}
+		out[path] = value
+	}
+}
+
+// provenanceLabel converts a provenance entry into a short display label.
+func provenanceLabel(source string) string {
+	switch {
+	case source == "" || source == string(ConfigSourceTypeDefault):
+		return "built-in default"
+	case strings.HasPrefix(source, "style:") || strings.HasPrefix(source, "profile:") || !filepath.IsAbs(source):
+		return source
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, source); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return source
+}
+
+func newConfigProfilesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profiles",
+		Short: "Manage configuration profiles",
+		Long: `Inspect the profiles defined in the "profiles" section of the configuration.
+
+A profile is activated with --profile <name> or the GOMDLINT_PROFILE
+environment variable and is layered over the rest of the configuration.`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list [config-file]",
+		Short: "List available profiles",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configFile := ""
+			if len(args) > 0 {
+				configFile = args[0]
+			}
+			profile, _ := cmd.Flags().GetString("profile")
+			return listProfiles(cmd, configFile, resolveProfileName(profile))
+		},
+	})
+
+	return cmd
+}
+
+// listProfiles prints the profiles defined in the configuration, marking the active one.
+func listProfiles(cmd *cobra.Command, configFile string, active string) error {
+	configSource, err := loadConfigurationSourceWithProfile(configFile, "")
+	if err != nil {
+		return usageError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+
+	profiles := service.ListProfiles(configSource.Config)
+	out := cmd.OutOrStdout()
+	if len(profiles) == 0 {
+		fmt.Fprintln(out, "No profiles defined")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, profile := range profiles {
+		marker := " "
+		if profile.Name == active {
+			marker = "*"
+		}
+
+		details := profile.Description
+		if len(profile.Extends) > 0 {
+			if details != "" {
+				details += " "
+			}
+			details += fmt.Sprintf("(extends %s)", strings.Join(profile.Extends, ", "))
+		}
+		rules := fmt.Sprintf("%d rules", profile.Rules)
+		if profile.Rules == 1 {
+			rules = "1 rule"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\n", marker, profile.Name, rules, details)
+	}
+	w.Flush()
+
+	if active != "" {
+		for _, profile := range profiles {
+			if profile.Name == active {
+				return nil
+			}
+		}
+		names := make([]string, len(profiles))
+		for i, profile := range profiles {
+			names[i] = profile.Name
+		}
+		return &service.UnknownProfileError{Name: active, Available: names}
+	}
+	return nil
+}
+
+func newConfigSchemaCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the configuration JSON Schema",
+		Long: `Print a JSON Schema describing gomdlint configuration files, including the
+parameters of every built-in rule with their types, allowed values and defaults.
+
+Reference it from a configuration file with "$schema" to get editor completion
+and validation.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, err := service.BuiltInConfigSchema()
+			if err != nil {
+				return fmt.Errorf("failed to build configuration schema: %w", err)
+			}
+
+			data, err := json.MarshalIndent(schema, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format configuration schema: %w", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return nil
+		},
+	}
+}
+
+// schemaIssueSource finds the configuration source responsible for the value
+// at a schema issue path.
+func schemaIssueSource(provenance map[string]string, path string) string {
+	if index := strings.Index(path, "["); index >= 0 {
+		path = path[:index]
+	}
+	if source, ok := provenance[path]; ok {
+		return source
+	}
+
+	// Objects are tracked by their leaves
+	keys := make([]string, 0, len(provenance))
+	for key := range provenance {
+		if strings.HasPrefix(key, path+".") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return provenance[keys[0]]
+}
+
 // configStyles holds the styling for configuration output
 type configStyles struct {
 	header        lipgloss.Style
@@ -642,9 +885,12 @@ func whichConfigSimple(configSource *ConfigurationSource) error {
 	// Get themed symbols
 	helper := NewThemedCommandHelper()
 
-	if configSource.IsHierarchy {
+	if configSource.IsHierarchy || len(configSource.Sources) > 1 {
 		// Header with icon
-		header := fmt.Sprintf("%s Configuration hierarchy (%d files merged):", helper.List(), len(configSource.Sources))
+		header := fmt.Sprintf("%s Configuration hierarchy (%d files merged):", helper.List(), len(configSource.SourceFiles))
+		if flags := countFlagSources(configSource.Sources); flags > 0 {
+			header = fmt.Sprintf("%s Configuration hierarchy (%d files merged, %d flag overrides):", helper.List(), len(configSource.SourceFiles), flags)
+		}
 		fmt.Println(styles.header.Render(header))
 		fmt.Println()
 
@@ -666,6 +912,17 @@ func whichConfigSimple(configSource *ConfigurationSource) error {
 	return nil
 }
 
+// countFlagSources returns the number of command-line flag layers.
+func countFlagSources(sources []ConfigSource) int {
+	count := 0
+	for _, source := range sources {
+		if source.Type == ConfigSourceTypeFlags {
+			count++
+		}
+	}
+	return count
+}
+
 // renderConfigTreeItem renders a single item in the configuration tree
 func renderConfigTreeItem(source ConfigSource, index, total int, styles configStyles) {
 	// Tree structure
@@ -680,6 +937,9 @@ func renderConfigTreeItem(source ConfigSource, index, total int, styles configSt
 	icon := getSourceIcon(source.Type)
 	sourceName := getSourceTypeStyled(source.Type, styles)
 	path := renderPath(source.Path, styles)
+	if source.Type == ConfigSourceTypeFlags || source.Type == ConfigSourceTypeDefault {
+		path = styles.path.Render(sourceDisplayName(source))
+	}
 
 	// Priority indicator
 	priority := fmt.Sprintf("[%d]", index+1)
@@ -724,6 +984,8 @@ func getSourceIcon(sourceType ConfigSourceType) string {
 		return helper.FileFound() // Folder for project
 	case ConfigSourceTypeCustom:
 		return helper.Settings() // Gear for custom
+	case ConfigSourceTypeFlags:
+		return helper.Settings() // Gear for command-line flags
 	case ConfigSourceTypeDefault:
 		return helper.List() // Clipboard for defaults
 	default:
@@ -749,6 +1011,9 @@ func getSourceTypeStyled(sourceType ConfigSourceType, styles configStyles) strin
 	case ConfigSourceTypeCustom:
 		style = styles.sourceCustom
 		text = "custom"
+	case ConfigSourceTypeFlags:
+		style = styles.sourceCustom
+		text = "flags"
 	case ConfigSourceTypeDefault:
 		style = styles.sourceDefault
 		text = "default"
@@ -774,10 +1039,16 @@ func shouldUseColors() bool {
 
 // whichConfigVerbose shows detailed information (original behavior)
 func whichConfigVerbose(configSource *ConfigurationSource, appName string) error {
-	if configSource.IsHierarchy {
+	if configSource.IsHierarchy || len(configSource.Sources) > 1 {
 		fmt.Printf("Hierarchical configuration active (%d sources merged):\n\n", len(configSource.Sources))
 
 		for i, source := range configSource.Sources {
+			if source.Type == ConfigSourceTypeFlags || source.Type == ConfigSourceTypeDefault {
+				fmt.Printf("%d. %s\n", i+1, sourceDisplayName(source))
+				fmt.Printf("   Type: %s\n\n", getSourceTypeDescription(source.Type))
+				continue
+			}
+
 			absPath, err := filepath.Abs(source.Path)
 			if err != nil {
 				absPath = source.Path
@@ -793,7 +1064,7 @@ func whichConfigVerbose(configSource *ConfigurationSource, appName string) error
 			fmt.Println()
 		}
 
-		fmt.Println("Configuration merge order: system < user < project")
+		fmt.Println("Configuration merge order: system < user < project < custom < profile < flags")
 		fmt.Println("Higher priority sources override settings from lower priority sources.")
 	} else {
 		// Single configuration source
@@ -832,6 +1103,8 @@ func getSourceTypeShort(sourceType ConfigSourceType) string {
 		return "project"
 	case ConfigSourceTypeCustom:
 		return "custom"
+	case ConfigSourceTypeFlags:
+		return "flags"
 	default:
 		return "unknown"
 	}
@@ -850,6 +1123,8 @@ func getSourceTypeDescription(sourceType ConfigSourceType) string {
 		return "Project directory (team/project settings)"
 	case ConfigSourceTypeCustom:
 		return "Custom location (explicitly specified)"
+	case ConfigSourceTypeFlags:
+		return "Command-line rule flags (highest priority)"
 	default:
 		return "Unknown configuration source"
 	}
@@ -893,39 +1168,131 @@ func displaySearchPaths(appName string) {
 // loadConfigurationSource loads configuration using hierarchical XDG-aware merging.
 // This function merges configurations from multiple sources in priority order:
 // system < user < project < explicit file
+// The profile named by GOMDLINT_PROFILE, if set, is applied on top.
 func loadConfigurationSource(configFile string) (*ConfigurationSource, error) {
+	return loadConfigurationSourceWithProfile(configFile, resolveProfileName(""))
+}
+
+// loadConfigurationSourceWithProfile loads configuration like
+// loadConfigurationSource and then layers the named profile over it.
+// An empty profile name loads the configuration as is.
+func loadConfigurationSourceWithProfile(configFile string, profile string) (*ConfigurationSource, error) {
+	return loadConfigurationSourceFor(configFile, profile, "")
+}
+
+// loadConfigurationSourceFor loads configuration like
+// loadConfigurationSourceWithProfile, taking the project configuration that
+// applies to a file at targetPath rather than the one in the working
+// directory. An empty targetPath uses the working directory.
+func loadConfigurationSourceFor(configFile string, profile string, targetPath string) (*ConfigurationSource, error) {
 	const appName = "gomdlint"
 
+	var configSource *ConfigurationSource
+	var err error
 	if configFile != "" {
 		// Explicit config file specified - load only that file (no hierarchy)
-		return loadSingleConfigurationFile(configFile, ConfigSourceTypeCustom)
+		configSource, err = loadSingleConfigurationFile(configFile, ConfigSourceTypeCustom)
+	} else {
+		// Load hierarchical configuration
+		configSource, err = loadHierarchicalConfiguration(appName, targetPath)
+	}
+	if err != nil || profile == "" {
+		return configSource, err
+	}
+
+	if err := applyConfigurationProfile(configSource, profile); err != nil {
+		return nil, err
+	}
+	return configSource, nil
+}
+
+// loadConfigurationSourceWithOverrides loads configuration like
+// loadConfigurationSourceWithProfile and then layers the command-line rule
+// flags over it.
+func loadConfigurationSourceWithOverrides(configFile string, profile string, overrides RuleOverrides) (*ConfigurationSource, error) {
+	configSource, err := loadConfigurationSourceWithProfile(configFile, profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyRuleOverrides(configSource, overrides); err != nil {
+		return nil, err
+	}
+	return configSource, nil
+}
+
+// sourceDisplayName describes a configuration source for listings: the
+// absolute file path, the flag and its rules, or the built-in defaults.
+func sourceDisplayName(source ConfigSource) string {
+	switch source.Type {
+	case ConfigSourceTypeFlags:
+		return fmt.Sprintf("%s %s", source.Path, describeOverrideLayer(source.Config))
+	case ConfigSourceTypeDefault:
+		return "built-in defaults"
 	}
 
-	// Load hierarchical configuration
-	return loadHierarchicalConfiguration(appName)
+	absPath, err := filepath.Abs(source.Path)
+	if err != nil {
+		return source.Path
+	}
+	return absPath
+}
+
+// resolveProfileName returns the profile selected by flag, falling back to
+// the GOMDLINT_PROFILE environment variable.
+func resolveProfileName(flag string) string {
+	if flag != "" {
+		return flag
+	}
+	return strings.TrimSpace(os.Getenv(service.ProfileEnvVar))
 }
 
-// loadHierarchicalConfiguration loads and merges configuration from the XDG hierarchy
-func loadHierarchicalConfiguration(appName string) (*ConfigurationSource, error) {
+// applyConfigurationProfile layers the named profile over the loaded
+// configuration. Profiles take precedence over every configuration file.
+func applyConfigurationProfile(configSource *ConfigurationSource, profile string) error {
+	if configSource.merger == nil {
+		configSource.merger = utils.NewConfigurationMerger()
+		configSource.merger.AddSource(configSource.Config, string(ConfigSourceTypeDefault), utils.ConfigSourceSystem)
+	}
+
+	// Relative extends inside the profile resolve from the file defining it
+	definedIn := configSource.Provenance["profiles."+profile+".extends"]
+	if strings.HasPrefix(definedIn, "style:") || definedIn == string(ConfigSourceTypeDefault) {
+		definedIn = ""
+	}
+
+	layers, err := service.ProfileLayers(configSource.Config, profile, definedIn, service.NewExtendsResolver(service.GetGlobalStyleRegistry()))
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range layers {
+		configSource.merger.AddSource(layer.Config, layer.Source, utils.ConfigSourceCLI)
+	}
+
+	configSource.Config = configSource.merger.Merge()
+	configSource.Provenance = configSource.merger.Provenance()
+	configSource.Profile = profile
+	return nil
+}
+
+// loadHierarchicalConfiguration loads and merges configuration from the XDG hierarchy.
+// A non-empty targetPath selects the project configuration that applies to that file.
+func loadHierarchicalConfiguration(appName string, targetPath string) (*ConfigurationSource, error) {
 	// Find all config files in hierarchy
-	configFiles, err := utils.FindAllConfigFiles(appName)
+	var configFiles []utils.ConfigFileLocation
+	var err error
+	if targetPath != "" {
+		configFiles, err = utils.FindAllConfigFilesFor(appName, targetPath)
+	} else {
+		configFiles, err = utils.FindAllConfigFiles(appName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error finding config files: %w", err)
 	}
 
 	if len(configFiles) == 0 {
 		// No config files found, return defaults
-		return &ConfigurationSource{
-			Config:      getDefaultConfiguration(),
-			SourceFiles: []string{},
-			Sources: []ConfigSource{{
-				Path:   "",
-				Type:   ConfigSourceTypeDefault,
-				Config: getDefaultConfiguration(),
-			}},
-			IsDefault:   true,
-			IsHierarchy: false,
-		}, nil
+		return defaultConfigurationSource(), nil
 	}
 
 	// Create merger and add configurations in reverse priority order (lowest to highest)
@@ -938,7 +1305,7 @@ func loadHierarchicalConfiguration(appName string) (*ConfigurationSource, error)
 	for i := len(configFiles) - 1; i >= 0; i-- {
 		configLoc := configFiles[i]
 
-		config, err := loadConfigurationFile(configLoc.Path)
+		layers, err := loadConfigurationLayers(configLoc.Path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load config file %s: %w", configLoc.Path, err)
 		}
@@ -946,9 +1313,12 @@ func loadHierarchicalConfiguration(appName string) (*ConfigurationSource, error)
 		// Convert utils.ConfigurationType to our ConfigSourceType
 		sourceType := convertConfigurationType(configLoc.Type)
 
-		// Add to merger
+		// Add each extends layer to the merger so provenance stays per file
 		mergerSourceType := convertToMergerSourceType(sourceType)
-		merger.AddSource(config, configLoc.Path, mergerSourceType)
+		for _, layer := range layers {
+			merger.AddSource(layer.Config, layer.Source, mergerSourceType)
+		}
+		config := service.FlattenConfigLayers(layers)
 
 		// Track sources
 		sources = append(sources, ConfigSource{
@@ -968,16 +1338,41 @@ func loadHierarchicalConfiguration(appName string) (*ConfigurationSource, error)
 		Sources:     sources,
 		IsDefault:   false,
 		IsHierarchy: len(configFiles) > 1,
+		Provenance:  merger.Provenance(),
+		merger:      merger,
 	}, nil
 }
 
+// defaultConfigurationSource returns the built-in configuration used when no
+// configuration file applies.
+func defaultConfigurationSource() *ConfigurationSource {
+	return &ConfigurationSource{
+		Config:      getDefaultConfiguration(),
+		SourceFiles: []string{},
+		Sources: []ConfigSource{{
+			Path:   "",
+			Type:   ConfigSourceTypeDefault,
+			Config: getDefaultConfiguration(),
+		}},
+		IsDefault:   true,
+		IsHierarchy: false,
+		Provenance:  map[string]string{"default": string(ConfigSourceTypeDefault)},
+	}
+}
+
 // loadSingleConfigurationFile loads a single configuration file without hierarchy
 func loadSingleConfigurationFile(configFile string, sourceType ConfigSourceType) (*ConfigurationSource, error) {
-	config, err := loadConfigurationFile(configFile)
+	layers, err := loadConfigurationLayers(configFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config file %s: %w", configFile, err)
 	}
 
+	merger := utils.NewConfigurationMerger()
+	for _, layer := range layers {
+		merger.AddSource(layer.Config, layer.Source, convertToMergerSourceType(sourceType))
+	}
+	config := merger.Merge()
+
 	return &ConfigurationSource{
 		Config:      config,
 		SourceFiles: []string{configFile},
@@ -988,25 +1383,15 @@ func loadSingleConfigurationFile(configFile string, sourceType ConfigSourceType)
 		}},
 		IsDefault:   false,
 		IsHierarchy: false,
+		Provenance:  merger.Provenance(),
+		merger:      merger,
 	}, nil
 }
 
-// loadConfigurationFile loads and parses a single configuration file
-func loadConfigurationFile(configPath string) (map[string]interface{}, error) {
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, err
-	}
-
-	var config map[string]interface{}
-
-	// Try JSON first
-	if err := json.Unmarshal(data, &config); err != nil {
-		// TODO: Try YAML if JSON fails
-		return nil, fmt.Errorf("failed to parse config file as JSON: %w", err)
-	}
-
-	return config, nil
+// loadConfigurationLayers loads a configuration file together with everything
+// it extends, ordered from lowest to highest precedence.
+func loadConfigurationLayers(configPath string) ([]service.ConfigLayer, error) {
+	return service.NewExtendsResolver(service.GetGlobalStyleRegistry()).ResolveFile(configPath)
 }
 
 // getDefaultConfiguration returns the built-in default configuration
@@ -1165,9 +1550,3 @@ func editConfig(configFile string) error {
 
 	return nil
 }
-
-// loadConfigurationSourceShared is a shared configuration loading function
-// that can be used by all commands to maintain consistency.
-func loadConfigurationSourceShared(configFile string) (*ConfigurationSource, error) {
-	return loadConfigurationSource(configFile)
-}
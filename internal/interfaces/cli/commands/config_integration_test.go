@@ -81,11 +81,29 @@ func TestConfigCommand_ValidateIntegration(t *testing.T) {
 			name:       "validate_theme_object_config",
 			subcommand: "validate",
 			setupFiles: map[string]string{
-				"theme-object-config.json": `{"theme": {"name": "custom", "colors": {"error": "red"}}, "MD033": false}`,
+				"theme-object-config.json": `{"theme": {"theme": "default", "suppress_emojis": true}, "MD033": false}`,
 			},
 			args:        []string{"theme-object-config.json"},
 			expectError: false,
 		},
+		{
+			name:       "validate_unknown_theme_keys",
+			subcommand: "validate",
+			setupFiles: map[string]string{
+				"theme-unknown-config.json": `{"theme": {"name": "custom", "colors": {"error": "red"}}, "MD033": false}`,
+			},
+			args:        []string{"theme-unknown-config.json"},
+			expectError: true,
+		},
+		{
+			name:       "validate_misspelled_rule_parameter",
+			subcommand: "validate",
+			setupFiles: map[string]string{
+				"typo-config.json": `{"MD013": {"line-length": 100}}`,
+			},
+			args:        []string{"typo-config.json"},
+			expectError: true,
+		},
 		{
 			name:       "validate_hierarchical_config",
 			subcommand: "validate",
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -38,7 +39,7 @@ func TestNewConfigCommand(t *testing.T) {
 
 	// Test subcommands exist
 	subcommands := cmd.Commands()
-	expectedSubcommands := []string{"init", "validate", "show", "which", "edit"}
+	expectedSubcommands := []string{"init", "validate", "show", "which", "edit", "profiles", "schema"}
 
 	actualSubcommands := make([]string, len(subcommands))
 	for i, subcmd := range subcommands {
@@ -250,6 +251,120 @@ func TestConfigCommand_Show(t *testing.T) {
 	}
 }
 
+func TestConfigCommand_ResolvedProvenance(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"shared/base.yaml": "MD013:\n  line_length: 100\n  tables: false\nMD041: false\n",
+		"project/.markdownlint.json": `{
+  "extends": ["style:relaxed", "../shared/base.yaml"],
+  "MD013": {"line_length": 120}
+}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	configPath := filepath.Join(tmpDir, "project", ".markdownlint.json")
+	basePath := filepath.Join(tmpDir, "shared", "base.yaml")
+
+	configSource, err := loadConfigurationSource(configPath)
+	require.NoError(t, err)
+	assert.NotContains(t, configSource.Config, "extends")
+
+	md013, ok := configSource.Config["MD013"].(map[string]interface{})
+	require.True(t, ok)
+	assert.EqualValues(t, 120, md013["line_length"])
+	assert.Equal(t, false, md013["tables"])
+
+	assert.Equal(t, configPath, configSource.Provenance["MD013.line_length"])
+	assert.Equal(t, basePath, configSource.Provenance["MD013.tables"])
+	assert.Equal(t, basePath, configSource.Provenance["MD041"])
+	assert.Equal(t, "style:relaxed", configSource.Provenance["MD033"])
+
+	output := formatResolvedConfig(configSource)
+	assert.Contains(t, output, "# Resolved configuration")
+	assert.Regexp(t, `MD013\.line_length = 120\s+# `+regexp.QuoteMeta(provenanceLabel(configPath)), output)
+	assert.Regexp(t, `MD033 = false\s+# style:relaxed`, output)
+
+	_, err = loadConfigurationSource(filepath.Join(tmpDir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestConfigCommand_Profiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".markdownlint.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{
+  "MD040": false,
+  "profiles": {
+    "ci": {"description": "Stricter CI rules", "MD040": true, "MD045": true},
+    "release": {"rules": {"MD013": {"line_length": 100}}}
+  }
+}`), 0644))
+
+	t.Run("flag selects profile", func(t *testing.T) {
+		configSource, err := loadConfigurationSourceWithProfile(configPath, "ci")
+		require.NoError(t, err)
+		assert.Equal(t, "ci", configSource.Profile)
+		assert.Equal(t, true, configSource.Config["MD040"])
+		assert.Equal(t, "profile:ci", configSource.Provenance["MD040"])
+	})
+
+	t.Run("environment selects profile", func(t *testing.T) {
+		t.Setenv("GOMDLINT_PROFILE", "release")
+
+		configSource, err := loadConfigurationSource(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, "release", configSource.Profile)
+		assert.Equal(t, false, configSource.Config["MD040"])
+
+		// The flag wins over the environment
+		assert.Equal(t, "ci", resolveProfileName("ci"))
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := loadConfigurationSourceWithProfile(configPath, "nightly")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown profile "nightly" (available: ci, release)`)
+	})
+
+	t.Run("list profiles", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		stdout := &strings.Builder{}
+		cmd.SetOut(stdout)
+
+		require.NoError(t, listProfiles(cmd, configPath, "ci"))
+		assert.Regexp(t, `\* ci\s+2 rules\s+Stricter CI rules`, stdout.String())
+		assert.Regexp(t, `  release\s+1 rule`, stdout.String())
+
+		assert.Error(t, listProfiles(cmd, configPath, "nightly"))
+	})
+}
+
+func TestConfigCommand_Schema(t *testing.T) {
+	cmd := NewConfigCommand()
+	stdout := &strings.Builder{}
+	cmd.SetOut(stdout)
+	cmd.SetArgs([]string{"schema"})
+	require.NoError(t, cmd.Execute())
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout.String()), &schema))
+	assert.Equal(t, "gomdlint configuration", schema["title"])
+	assert.Contains(t, schema["properties"], "MD013")
+
+	provenance := map[string]string{
+		"MD010.ignore_code_languages": "/project/base.json",
+		"MD013.line-length":           "/project/.markdownlint.json",
+		"theme.name":                  "/home/user/.config/gomdlint/config.json",
+	}
+	assert.Equal(t, "/project/.markdownlint.json", schemaIssueSource(provenance, "MD013.line-length"))
+	assert.Equal(t, "/project/base.json", schemaIssueSource(provenance, "MD010.ignore_code_languages[1]"))
+	assert.Equal(t, "/home/user/.config/gomdlint/config.json", schemaIssueSource(provenance, "theme"))
+	assert.Equal(t, "", schemaIssueSource(provenance, "MD033"))
+}
+
 func TestConfigCommand_Which(t *testing.T) {
 	t.Skip("Temporarily disabled for CI - subcommand execution needs refinement")
 	scenarios := []configCommandScenario{
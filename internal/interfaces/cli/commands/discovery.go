@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"errors"
+	"io/fs"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+)
+
+// addFileSelectionFlags registers the file discovery flags shared by lint,
+// check and fix.
+func addFileSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("ignore", []string{}, "Ignore files matching these gitignore-style patterns")
+	cmd.Flags().Bool("dot", false, "Include hidden files and directories")
+	cmd.Flags().Bool("no-ignore-files", false, "Do not read .gitignore, .markdownlintignore or .gomdlintignore")
+}
+
+// fileDiscoveryOptionsFromFlags reads the file selection flags from cmd.
+// Flags that are not registered on the command are treated as unset.
+func fileDiscoveryOptionsFromFlags(cmd *cobra.Command) service.FileDiscoveryOptions {
+	ignore, _ := cmd.Flags().GetStringSlice("ignore")
+	includeDot, _ := cmd.Flags().GetBool("dot")
+	noIgnoreFiles, _ := cmd.Flags().GetBool("no-ignore-files")
+
+	return service.FileDiscoveryOptions{
+		Ignore:        ignore,
+		IncludeDot:    includeDot,
+		NoIgnoreFiles: noIgnoreFiles,
+	}
+}
+
+// collectFiles gathers the markdown files selected by args. Inputs that do
+// not exist and invalid patterns are usage errors; other failures, such as
+// unreadable directories, are internal.
+func collectFiles(args []string, options service.FileDiscoveryOptions) ([]string, error) {
+	files, err := service.DiscoverFiles(args, options)
+	if err != nil {
+		var patternErr *service.PatternError
+		if errors.Is(err, fs.ErrNotExist) || errors.As(err, &patternErr) {
+			return nil, usageError(err)
+		}
+		return nil, internalError(err)
+	}
+	return files, nil
+}
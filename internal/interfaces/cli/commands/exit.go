@@ -0,0 +1,200 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+// Exit codes returned by gomdlint.
+const (
+	ExitOK         = 0 // No violations, or none beyond the thresholds
+	ExitViolations = 1 // Violations found or a threshold exceeded
+	ExitUsage      = 2 // Invalid flags, arguments or configuration
+	ExitInternal   = 3 // Internal error, or content that could not be linted
+)
+
+// ExitError is returned by commands that must end with a specific exit code.
+// Commands return it instead of calling os.Exit so that they can be tested;
+// main converts it with HandleError.
+type ExitError struct {
+	Code int
+	Err  error // Reported on stderr; nil when the command already reported the outcome
+}
+
+// Error implements the error interface.
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// usageError marks err as caused by invalid flags, arguments or configuration.
+func usageError(err error) error {
+	return &ExitError{Code: ExitUsage, Err: err}
+}
+
+// internalError marks err as an internal failure.
+func internalError(err error) error {
+	return &ExitError{Code: ExitInternal, Err: err}
+}
+
+// lintError classifies an error returned by gomdlint.Lint: invalid rule
+// configuration is a usage error, anything else is internal.
+func lintError(err error) error {
+	var configErr *entity.RuleConfigError
+	if errors.As(err, &configErr) {
+		return usageError(err)
+	}
+	return internalError(err)
+}
+
+// ExitCode returns the exit code for an error returned by a command. Errors
+// that do not carry a code are internal errors; cobra's flag and argument
+// errors carry ExitUsage once MarkUsageErrors has been applied.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitInternal
+}
+
+// MarkUsageErrors makes the errors cobra reports for invalid flags, positional
+// arguments and unknown commands of root and its subcommands usage errors.
+func MarkUsageErrors(root *cobra.Command) {
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return usageError(err)
+	})
+
+	// Add the commands cobra adds on execution, so that they are covered too
+	root.InitDefaultHelpCmd()
+	root.InitDefaultCompletionCmd()
+
+	var mark func(cmd *cobra.Command)
+	mark = func(cmd *cobra.Command) {
+		cmd.Args = usageArgs(cmd.Args)
+		for _, child := range cmd.Commands() {
+			mark(child)
+		}
+	}
+	mark(root)
+}
+
+// usageArgs wraps a positional argument validator so that its errors are
+// usage errors. Without a validator, as cobra does, a root command with
+// subcommands rejects arguments as unknown commands.
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		var err error
+		switch {
+		case validate != nil:
+			err = validate(cmd, args)
+		case cmd.HasSubCommands() && !cmd.HasParent() && len(args) > 0:
+			err = unknownCommandError(cmd, args[0])
+		}
+		if err != nil {
+			return usageError(err)
+		}
+		return nil
+	}
+}
+
+// unknownCommandError reports name as an unknown subcommand of cmd, with
+// cobra's suggestions.
+func unknownCommandError(cmd *cobra.Command, name string) error {
+	message := fmt.Sprintf("unknown command %q for %q", name, cmd.CommandPath())
+	if cmd.SuggestionsMinimumDistance <= 0 {
+		cmd.SuggestionsMinimumDistance = 2
+	}
+	if suggestions := cmd.SuggestionsFor(name); len(suggestions) > 0 {
+		message += "\n\nDid you mean this?"
+		for _, suggestion := range suggestions {
+			message += "\n\t" + suggestion
+		}
+	}
+	return errors.New(message)
+}
+
+// HandleError reports err on w, unless the command already reported the
+// outcome, and returns the exit code for it.
+func HandleError(w io.Writer, err error) int {
+	var exitErr *ExitError
+	if err != nil && (!errors.As(err, &exitErr) || exitErr.Err != nil) {
+		fmt.Fprintf(w, "Error: %v\n", err)
+	}
+	return ExitCode(err)
+}
+
+// thresholdOptions holds the flags deciding when violations fail a run.
+type thresholdOptions struct {
+	MaxViolations int // --max-violations: fail when more violations remain; -1 fails on any error
+	MaxWarnings   int // --max-warnings: fail when more warnings remain; -1 never fails on warnings
+}
+
+// addThresholdFlags registers the failure threshold flags shared by lint and check.
+func addThresholdFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("max-violations", -1, "Fail only when more than this many violations are found (-1: fail on any error)")
+	cmd.Flags().Int("max-warnings", -1, "Fail when more than this many warnings are found (-1: warnings never fail)")
+}
+
+// thresholdOptionsFromFlags reads the threshold flags from cmd. Flags that
+// are not registered on the command are treated as unset.
+func thresholdOptionsFromFlags(cmd *cobra.Command) (thresholdOptions, error) {
+	options := thresholdOptions{MaxViolations: -1, MaxWarnings: -1}
+	if flag := cmd.Flags().Lookup("max-violations"); flag != nil {
+		options.MaxViolations, _ = cmd.Flags().GetInt("max-violations")
+	}
+	if flag := cmd.Flags().Lookup("max-warnings"); flag != nil {
+		options.MaxWarnings, _ = cmd.Flags().GetInt("max-warnings")
+	}
+
+	if options.MaxViolations < -1 {
+		return options, errors.New("--max-violations must be -1 or greater")
+	}
+	if options.MaxWarnings < -1 {
+		return options, errors.New("--max-warnings must be -1 or greater")
+	}
+	return options, nil
+}
+
+// lintOutcome decides how a lint run ends. Content that could not be linted
+// is an internal failure; otherwise violations fail the run when they exceed
+// the thresholds. The returned error carries no message because the results
+// have already been reported.
+func lintOutcome(result *gomdlint.LintResult, thresholds thresholdOptions) error {
+	for _, violations := range result.Results {
+		for _, violation := range violations {
+			if violation.IsFailure() {
+				return &ExitError{Code: ExitInternal}
+			}
+		}
+	}
+
+	failed := result.TotalErrors > 0
+	if thresholds.MaxViolations >= 0 {
+		failed = result.TotalViolations > thresholds.MaxViolations
+	}
+	if thresholds.MaxWarnings >= 0 && result.TotalWarnings > thresholds.MaxWarnings {
+		failed = true
+	}
+
+	if failed {
+		return &ExitError{Code: ExitViolations}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, ExitOK, ExitCode(nil))
+	assert.Equal(t, ExitInternal, ExitCode(errors.New("open a.md: input/output error")))
+	assert.Equal(t, ExitUsage, ExitCode(usageError(errors.New("unknown flag: --bogus"))))
+	assert.Equal(t, ExitUsage, ExitCode(fmt.Errorf("failed to load configuration: %w", usageError(errors.New("bad")))))
+	assert.Equal(t, ExitViolations, ExitCode(&ExitError{Code: ExitViolations}))
+	assert.Equal(t, ExitInternal, ExitCode(internalError(errors.New("disk full"))))
+	assert.Equal(t, ExitUsage, ExitCode(lintError(&entity.RuleConfigError{Rule: "MD013", Message: "bad"})))
+	assert.Equal(t, ExitInternal, ExitCode(lintError(errors.New("parser crashed"))))
+}
+
+func TestMarkUsageErrors(t *testing.T) {
+	newRoot := func() *cobra.Command {
+		root := &cobra.Command{
+			Use:           "root",
+			SilenceUsage:  true,
+			SilenceErrors: true,
+			Run:           func(cmd *cobra.Command, args []string) {},
+		}
+		root.AddCommand(&cobra.Command{
+			Use:  "lint",
+			Args: cobra.MaximumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return errors.New("read failed")
+			},
+		})
+		root.PersistentFlags().Int("jobs", 0, "")
+		MarkUsageErrors(root)
+		return root
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "unknown flag", args: []string{"lint", "--bogus"}, want: ExitUsage},
+		{name: "invalid flag value", args: []string{"lint", "--jobs", "many"}, want: ExitUsage},
+		{name: "too many arguments", args: []string{"lint", "a.md", "b.md"}, want: ExitUsage},
+		{name: "unknown command", args: []string{"lnt"}, want: ExitUsage},
+		{name: "command failure", args: []string{"lint", "a.md"}, want: ExitInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRoot()
+			root.SetArgs(tt.args)
+			root.SetOut(&bytes.Buffer{})
+			root.SetErr(&bytes.Buffer{})
+			err := root.Execute()
+			require.Error(t, err)
+			assert.Equal(t, tt.want, ExitCode(err))
+		})
+	}
+
+	root := newRoot()
+	root.SetArgs([]string{"lnt"})
+	err := root.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Did you mean this?\n\tlint")
+}
+
+func TestHandleError(t *testing.T) {
+	var stderr bytes.Buffer
+	assert.Equal(t, ExitViolations, HandleError(&stderr, &ExitError{Code: ExitViolations}))
+	assert.Empty(t, stderr.String(), "outcomes already reported are not repeated")
+
+	assert.Equal(t, ExitUsage, HandleError(&stderr, usageError(errors.New("bad flag"))))
+	assert.Equal(t, "Error: bad flag\n", stderr.String())
+}
+
+func TestLintOutcome(t *testing.T) {
+	result := func(severities ...value.Severity) *gomdlint.LintResult {
+		lintResult := &gomdlint.LintResult{Results: map[string][]gomdlint.Violation{"a.md": {}}}
+		for i, severity := range severities {
+			lintResult.Results["a.md"] = append(lintResult.Results["a.md"], gomdlint.Violation{
+				LineNumber: i + 1,
+				RuleNames:  []string{"MD013"},
+				Severity:   severity.String(),
+			})
+		}
+		lintResult.Recount()
+		return lintResult
+	}
+	defaults := thresholdOptions{MaxViolations: -1, MaxWarnings: -1}
+
+	tests := []struct {
+		name       string
+		result     *gomdlint.LintResult
+		thresholds thresholdOptions
+		want       int
+	}{
+		{"clean", result(), defaults, ExitOK},
+		{"errors fail", result(value.SeverityError), defaults, ExitViolations},
+		{"warnings pass by default", result(value.SeverityWarning), defaults, ExitOK},
+		{"within max violations", result(value.SeverityError, value.SeverityError), thresholdOptions{MaxViolations: 2, MaxWarnings: -1}, ExitOK},
+		{"beyond max violations", result(value.SeverityError, value.SeverityWarning), thresholdOptions{MaxViolations: 1, MaxWarnings: -1}, ExitViolations},
+		{"beyond max warnings", result(value.SeverityWarning), thresholdOptions{MaxViolations: -1, MaxWarnings: 0}, ExitViolations},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(lintOutcome(tt.result, tt.thresholds)))
+		})
+	}
+
+	failed := &gomdlint.LintResult{Results: map[string][]gomdlint.Violation{
+		"a.md": {{LineNumber: 1, RuleNames: []string{value.FileErrorRuleName}, Severity: "error"}},
+	}}
+	failed.Recount()
+	assert.Equal(t, ExitInternal, ExitCode(lintOutcome(failed, thresholdOptions{MaxViolations: 10, MaxWarnings: -1})))
+}
+
+func TestLintCommand_ExitCodes(t *testing.T) {
+	dir := createTempTestFiles(t, map[string]string{
+		"clean.md":   "# Title\n\nContent.\n",
+		"invalid.md": "#Title without space\n\nContent.\n",
+	})
+
+	_, _, err := executeCommand(t, createCheckCommand(), []string{filepath.Join(dir, "clean.md")}, nil)
+	assert.Equal(t, ExitOK, ExitCode(err))
+
+	_, _, err = executeCommand(t, createCheckCommand(), []string{filepath.Join(dir, "invalid.md")}, nil)
+	assert.Equal(t, ExitViolations, ExitCode(err))
+
+	_, _, err = executeCommand(t, createCheckCommand(), []string{filepath.Join(dir, "invalid.md")}, map[string]interface{}{"max-violations": 5})
+	assert.Equal(t, ExitOK, ExitCode(err))
+
+	_, _, err = executeCommand(t, createCheckCommand(), []string{filepath.Join(dir, "invalid.md")}, map[string]interface{}{"max-warnings": -2})
+	require.Error(t, err)
+	assert.Equal(t, ExitUsage, ExitCode(err))
+
+	_, _, err = executeCommand(t, createCheckCommand(), []string{filepath.Join(dir, "missing.md")}, nil)
+	assert.Equal(t, ExitUsage, ExitCode(err))
+}
+
+func TestCollectFiles_ExitCodes(t *testing.T) {
+	dir := createTempTestFiles(t, map[string]string{"a.md": "# A\n"})
+
+	_, err := collectFiles([]string{filepath.Join(dir, "missing.md")}, service.FileDiscoveryOptions{})
+	assert.Equal(t, ExitUsage, ExitCode(err))
+
+	_, err = collectFiles([]string{filepath.Join(dir, "[")}, service.FileDiscoveryOptions{})
+	assert.Equal(t, ExitUsage, ExitCode(err))
+
+	// A path through a regular file is neither missing nor malformed.
+	_, err = collectFiles([]string{filepath.Join(dir, "a.md", "b.md")}, service.FileDiscoveryOptions{})
+	assert.Equal(t, ExitInternal, ExitCode(err))
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gomdlint/gomdlint/internal/app/service"
@@ -37,9 +38,13 @@ Examples:
   gomdlint fix README.md
   gomdlint fix docs/*.md
   gomdlint fix --dry-run *.md
-  gomdlint fix --no-backup --concurrency 8 *.md`,
+  gomdlint fix --no-backup --concurrency 8 *.md
+  gomdlint fix --stdin --stdin-filename docs/guide.md < docs/guide.md`,
 		Args: cobra.ArbitraryArgs,
 		RunE: runFix,
+		// The returned error carries the exit code; HandleError reports it
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
 
 	// Safety flags
@@ -53,8 +58,8 @@ Examples:
 	cmd.Flags().Int("batch-size", 10, "Number of fixes to batch together")
 
 	// File selection flags
-	cmd.Flags().StringSlice("ignore", []string{}, "Ignore files matching these patterns")
-	cmd.Flags().Bool("dot", false, "Include hidden files and directories")
+	addStdinFlags(cmd)
+	addFileSelectionFlags(cmd)
 
 	return cmd
 }
@@ -64,6 +69,7 @@ func runFix(cmd *cobra.Command, args []string) error {
 
 	// Parse flags
 	configFile, _ := cmd.Flags().GetString("config")
+	profile, _ := cmd.Flags().GetString("profile")
 	noConfig, _ := cmd.Flags().GetBool("no-config")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	verbose, _ := cmd.Flags().GetBool("verbose")
@@ -76,8 +82,14 @@ func runFix(cmd *cobra.Command, args []string) error {
 	stopOnError, _ := cmd.Flags().GetBool("stop-on-error")
 	concurrency, _ := cmd.Flags().GetInt("concurrency")
 	batchSize, _ := cmd.Flags().GetInt("batch-size")
-	ignorePaths, _ := cmd.Flags().GetStringSlice("ignore")
-	includeDot, _ := cmd.Flags().GetBool("dot")
+	discoveryOptions := fileDiscoveryOptionsFromFlags(cmd)
+	stdinOpts, err := stdinOptionsFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+	if stdinOpts.Enabled {
+		return runFixStdin(cmd, stdinOpts, discoveryOptions)
+	}
 
 	// Progress tracking
 	startTime := time.Now()
@@ -88,7 +100,7 @@ func runFix(cmd *cobra.Command, args []string) error {
 
 	// Load theme configuration
 	if !noConfig {
-		if configSource, err := loadConfigurationSourceFromLint(configFile); err == nil && !configSource.IsDefault {
+		if configSource, err := loadConfigurationSourceFromLint(configFile, profile); err == nil && !configSource.IsDefault {
 			if themeData, exists := configSource.Config["theme"]; exists {
 				if themeMap, ok := themeData.(map[string]interface{}); ok {
 					if themeName, ok := themeMap["theme"].(string); ok {
@@ -119,7 +131,7 @@ func runFix(cmd *cobra.Command, args []string) error {
 	}
 
 	// Collect files to fix
-	files, err := collectFiles(args, ignorePaths, includeDot)
+	files, err := collectFiles(args, discoveryOptions)
 	if err != nil {
 		return fmt.Errorf("failed to collect files: %w", err)
 	}
@@ -142,23 +154,31 @@ func runFix(cmd *cobra.Command, args []string) error {
 		NoInlineConfig:     false,
 		ResultVersion:      3,
 		HandleRuleFailures: true,
+		Concurrency:        concurrency,
 	}
 
-	// Load configuration if specified
-	if !noConfig {
-		configSource, err := loadConfigurationSourceFromLint(configFile)
-		if err != nil && configFile != "" {
-			return fmt.Errorf("failed to load configuration: %w", err)
-		}
-		if !configSource.IsDefault {
-			lintOptions.Config = configSource.Config
-
-			if verbose && !quiet {
-				if configSource.IsHierarchy {
-					themedOutput.Info("Using hierarchical configuration from %d sources", len(configSource.Sources))
-				} else if len(configSource.Sources) > 0 {
-					themedOutput.Info("Using configuration from: %s", configSource.Sources[0].Path)
-				}
+	cacheOptions, err := cacheOptionsFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+	applyCacheOptions(&lintOptions, cacheOptions)
+
+	// Load configuration; rule selection flags apply even with --no-config
+	configSource, err := loadLintConfiguration(configFile, profile, noConfig, ruleOverridesFromFlags(cmd), "")
+	if err != nil {
+		return usageError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+	if !configSource.IsDefault {
+		lintOptions.Config = configSource.Config
+
+		if verbose && !quiet {
+			if configSource.IsHierarchy || len(configSource.Sources) > 1 {
+				themedOutput.Info("Using hierarchical configuration from %d sources", len(configSource.Sources))
+			} else if len(configSource.Sources) > 0 {
+				themedOutput.Info("Using configuration from: %s", configSource.Sources[0].Path)
+			}
+			if configSource.Profile != "" {
+				themedOutput.Info("Using profile: %s", configSource.Profile)
 			}
 		}
 	}
@@ -166,7 +186,7 @@ func runFix(cmd *cobra.Command, args []string) error {
 	// Perform initial linting to find violations
 	lintResult, err := gomdlint.Lint(ctx, lintOptions)
 	if err != nil {
-		return fmt.Errorf("linting failed: %w", err)
+		return lintError(fmt.Errorf("linting failed: %w", err))
 	}
 
 	if lintResult.TotalViolations == 0 {
@@ -219,7 +239,7 @@ func runFix(cmd *cobra.Command, args []string) error {
 
 	fixResult, err := fixEngine.FixFiles(ctx, lintResult)
 	if err != nil {
-		return fmt.Errorf("fix operation failed: %w", err)
+		return internalError(fmt.Errorf("fix operation failed: %w", err))
 	}
 
 	// Report results
@@ -261,10 +281,83 @@ func runFix(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Exit with error code if there were errors
+	// Files that could not be fixed are an internal failure
 	if fixResult.FilesErrored > 0 {
-		os.Exit(1)
+		return &ExitError{Code: ExitInternal}
 	}
 
 	return nil
 }
+
+// runFixStdin fixes the document read from stdin and writes the fixed
+// document to stdout, leaving stdout free of any other output. Violations
+// that remain after fixing are reported on stderr and make the command exit
+// non-zero. A document whose --stdin-filename is ignored is written back
+// unchanged.
+func runFixStdin(cmd *cobra.Command, stdinOpts stdinOptions, discoveryOptions service.FileDiscoveryOptions) error {
+	ctx := cmd.Context()
+
+	configFile, _ := cmd.Flags().GetString("config")
+	profile, _ := cmd.Flags().GetString("profile")
+	noConfig, _ := cmd.Flags().GetBool("no-config")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	content, err := readStdin(cmd.InOrStdin())
+	if err != nil {
+		return internalError(err)
+	}
+
+	ignored, err := stdinOpts.Ignored(discoveryOptions)
+	if err != nil {
+		return internalError(fmt.Errorf("failed to check ignore rules: %w", err))
+	}
+	if ignored {
+		if _, err := fmt.Fprint(cmd.OutOrStdout(), content); err != nil {
+			return internalError(err)
+		}
+		return nil
+	}
+
+	configSource, err := loadLintConfiguration(configFile, profile, noConfig, ruleOverridesFromFlags(cmd), stdinOpts.Filename)
+	if err != nil {
+		return usageError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+
+	identifier := stdinOpts.Identifier()
+	lintOptions := gomdlint.LintOptions{
+		Strings:            map[string]string{identifier: content},
+		Config:             make(map[string]interface{}),
+		ResultVersion:      3,
+		HandleRuleFailures: true,
+	}
+	if !configSource.IsDefault {
+		lintOptions.Config = configSource.Config
+	}
+
+	lintResult, err := gomdlint.Lint(ctx, lintOptions)
+	if err != nil {
+		return lintError(fmt.Errorf("linting failed: %w", err))
+	}
+
+	fixed, _ := gomdlint.ApplyFixes(content, lintResult.Results[identifier])
+	if _, err := fmt.Fprint(cmd.OutOrStdout(), fixed); err != nil {
+		return internalError(err)
+	}
+
+	// Lint the fixed document to report what could not be fixed
+	lintOptions.Strings = map[string]string{identifier: fixed}
+	lintResult, err = gomdlint.Lint(ctx, lintOptions)
+	if err != nil {
+		return lintError(fmt.Errorf("re-linting after fix failed: %w", err))
+	}
+
+	if !quiet && lintResult.TotalViolations > 0 {
+		report := lintResult.ToFormattedString(true)
+		fmt.Fprint(cmd.ErrOrStderr(), report)
+		if !strings.HasSuffix(report, "\n") {
+			fmt.Fprintln(cmd.ErrOrStderr())
+		}
+	}
+
+	return lintOutcome(lintResult, thresholdOptions{MaxViolations: -1, MaxWarnings: -1})
+}
@@ -2,10 +2,10 @@ package commands
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -31,38 +31,97 @@ Examples:
   gomdlint lint --format json --output results.json docs/`,
 		Args: cobra.MinimumNArgs(0),
 		RunE: runLint,
+		// The returned error carries the exit code; HandleError reports it
+		SilenceErrors: true,
+		SilenceUsage:  true,
 	}
 
 	// Command-specific flags
-	cmd.Flags().StringSlice("ignore", []string{}, "Ignore files matching these patterns")
 	cmd.Flags().Bool("fix", false, "Automatically fix violations where possible")
-	cmd.Flags().Bool("stdin", false, "Read from stdin instead of files")
-	cmd.Flags().String("stdin-name", "stdin", "Name for stdin input")
-	cmd.Flags().Bool("dot", false, "Include hidden files and directories")
+	addStdinFlags(cmd)
+	addFileSelectionFlags(cmd)
+	addChangeFlags(cmd)
+	addBaselineFlags(cmd)
+	addThresholdFlags(cmd)
+	addProfilingFlags(cmd)
 
 	return cmd
 }
 
+// lintMode selects the behaviour that differs between lint and check.
+type lintMode struct {
+	// CI makes the run non-interactive: annotation formats are picked from
+	// the CI environment and the time spent in each phase is reported.
+	CI bool
+}
+
 func runLint(cmd *cobra.Command, args []string) error {
+	return executeLint(cmd, args, lintMode{})
+}
+
+// executeLint lints the files named by args, or stdin, and reports the
+// results. Commands with fewer flags than lint treat the missing ones as
+// unset.
+func executeLint(cmd *cobra.Command, args []string, mode lintMode) error {
 	ctx := cmd.Context()
 
 	// Parse flags
 	configFile, _ := cmd.Flags().GetString("config")
+	profile, _ := cmd.Flags().GetString("profile")
 	noConfig, _ := cmd.Flags().GetBool("no-config")
 	outputFile, _ := cmd.Flags().GetString("output")
 	format, _ := cmd.Flags().GetString("format")
 	color, _ := cmd.Flags().GetBool("color")
 	quiet, _ := cmd.Flags().GetBool("quiet")
 	verbose, _ := cmd.Flags().GetBool("verbose")
+	summaryOnly, _ := cmd.Flags().GetBool("summary-only")
+	if mode.CI && !cmd.Flags().Changed("format") {
+		if ciFormat := ciFormatFromEnv(os.Getenv); ciFormat != "" {
+			format = ciFormat
+		}
+	}
 
 	fix, _ := cmd.Flags().GetBool("fix")
-	stdin, _ := cmd.Flags().GetBool("stdin")
-	stdinName, _ := cmd.Flags().GetString("stdin-name")
-	ignorePaths, _ := cmd.Flags().GetStringSlice("ignore")
-	includeDot, _ := cmd.Flags().GetBool("dot")
+	discoveryOptions := fileDiscoveryOptionsFromFlags(cmd)
+	stdinOpts, err := stdinOptionsFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+	changeOpts, err := changeOptionsFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+	if stdinOpts.Enabled && changeOpts.Since != "" {
+		return usageError(fmt.Errorf("--changed-since cannot be used with --stdin"))
+	}
+	baselineOpts, err := baselineOptionsFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+	thresholds, err := thresholdOptionsFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+	concurrency, err := concurrencyFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+	failFast, _ := cmd.Flags().GetBool("fail-fast")
+	profiling := profilingOptionsFromFlags(cmd)
+
+	stopProfiling, err := profiling.start()
+	if err != nil {
+		return internalError(err)
+	}
+	defer func() {
+		if err := stopProfiling(); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+		}
+	}()
 
 	// Progress tracking
 	startTime := time.Now()
+	var timings phaseDurations
 
 	// Setup themed output
 	themeService := service.NewThemeService()
@@ -70,7 +129,7 @@ func runLint(cmd *cobra.Command, args []string) error {
 
 	// Check for theme configuration in global config if available
 	if !noConfig {
-		if configSource, err := loadConfigurationSourceFromLint(configFile); err == nil && !configSource.IsDefault {
+		if configSource, err := loadConfigurationSourceFromLint(configFile, profile); err == nil && !configSource.IsDefault {
 			if themeData, exists := configSource.Config["theme"]; exists {
 				if themeMap, ok := themeData.(map[string]interface{}); ok {
 					if themeName, ok := themeMap["theme"].(string); ok {
@@ -117,45 +176,91 @@ func runLint(cmd *cobra.Command, args []string) error {
 		NoInlineConfig:     false,
 		ResultVersion:      3,
 		HandleRuleFailures: true,
+		FailFast:           failFast,
+		Timing:             profiling.Timing,
+		Concurrency:        concurrency,
 	}
 
-	// Load configuration if specified
-	if !noConfig {
-		configSource, err := loadConfigurationSourceFromLint(configFile)
-		if err != nil && configFile != "" {
-			return fmt.Errorf("failed to load configuration: %w", err)
-		}
-		if !configSource.IsDefault {
-			options.Config = configSource.Config
-
-			// Show which config is being used in verbose mode
-			if verbose && !quiet && (format == "" || format == "default") {
-				if configSource.IsHierarchy {
-					themedOutput.Info("Using hierarchical configuration from %d sources", len(configSource.Sources))
-					for _, source := range configSource.Sources {
-						themedOutput.Info("  - %s (%s)", source.Path, source.Type)
-					}
-				} else if len(configSource.Sources) > 0 {
-					themedOutput.Info("Using configuration from: %s", configSource.Sources[0].Path)
+	cacheOptions, err := cacheOptionsFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+	if mode.CI && !cmd.Flags().Changed("cache") {
+		// CI runs write no files unless a cache is requested explicitly
+		cacheOptions.Enabled = false
+	}
+	if profiling.Timing {
+		// Cached results would hide the time spent parsing and running rules
+		cacheOptions.Enabled = false
+	}
+	applyCacheOptions(&options, cacheOptions)
+
+	// Load configuration; rule selection flags apply even with --no-config
+	configSource, err := loadLintConfiguration(configFile, profile, noConfig, ruleOverridesFromFlags(cmd), stdinOpts.Filename)
+	if err != nil {
+		return usageError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+	if !configSource.IsDefault {
+		options.Config = configSource.Config
+
+		// Show which config is being used in verbose mode
+		if verbose && !quiet && (format == "" || format == "default") {
+			if configSource.IsHierarchy || len(configSource.Sources) > 1 {
+				themedOutput.Info("Using hierarchical configuration from %d sources", len(configSource.Sources))
+				for _, source := range configSource.Sources {
+					themedOutput.Info("  - %s (%s)", sourceDisplayName(source), source.Type)
 				}
+			} else if len(configSource.Sources) > 0 {
+				themedOutput.Info("Using configuration from: %s", configSource.Sources[0].Path)
+			}
+			if configSource.Profile != "" {
+				themedOutput.Info("Using profile: %s", configSource.Profile)
 			}
 		}
 	}
 
 	// Handle stdin input
-	if stdin {
-		content, err := readStdin()
+	if stdinOpts.Enabled {
+		content, err := readStdin(cmd.InOrStdin())
+		if err != nil {
+			return internalError(err)
+		}
+		ignored, err := stdinOpts.Ignored(discoveryOptions)
 		if err != nil {
-			return fmt.Errorf("failed to read from stdin: %w", err)
+			return internalError(fmt.Errorf("failed to check ignore rules: %w", err))
+		}
+
+		// An ignored document is reported as clean in the requested format
+		options.Strings = map[string]string{}
+		if ignored {
+			if verbose && (format == "" || format == "default") {
+				themedOutput.Info("Skipping %s: matched by ignore rules", stdinOpts.Filename)
+			}
+		} else {
+			options.Strings[stdinOpts.Identifier()] = content
 		}
-		options.Strings = map[string]string{stdinName: content}
 	} else {
 		// Collect files to lint
-		files, err := collectFiles(args, ignorePaths, includeDot)
+		discoveryStart := time.Now()
+		files, err := collectFiles(args, discoveryOptions)
 		if err != nil {
 			return fmt.Errorf("failed to collect files: %w", err)
 		}
 
+		// Restrict to files changed in git
+		if changeOpts.Since != "" {
+			files, err = filterChangedFiles(files, changeOpts.Since)
+			if err != nil {
+				return internalError(fmt.Errorf("failed to find changed files: %w", err))
+			}
+			if len(files) == 0 {
+				if !quiet {
+					fmt.Fprintf(os.Stderr, "No changed markdown files found.\n")
+				}
+				return nil
+			}
+		}
+
 		if len(files) == 0 {
 			if !quiet {
 				fmt.Fprintf(os.Stderr, "No markdown files found.\n")
@@ -164,6 +269,7 @@ func runLint(cmd *cobra.Command, args []string) error {
 		}
 
 		options.Files = files
+		timings.Discovery = time.Since(discoveryStart)
 
 		if verbose && (format == "" || format == "default") {
 			themedOutput.FileFound("Found %d files to lint", len(files))
@@ -173,14 +279,15 @@ func runLint(cmd *cobra.Command, args []string) error {
 	// Perform linting
 	result, err := gomdlint.Lint(ctx, options)
 	if err != nil {
-		return fmt.Errorf("linting failed: %w", err)
+		return lintError(fmt.Errorf("linting failed: %w", err))
 	}
+	timings.Read, timings.Parse, timings.Rules = result.Timings.Read, result.Timings.Parse, result.Timings.Rules
 
 	// Handle auto-fixing
 	if fix && result.TotalViolations > 0 {
 		fixedCount, err := performAutoFix(result, options)
 		if err != nil {
-			return fmt.Errorf("auto-fix failed: %w", err)
+			return internalError(fmt.Errorf("auto-fix failed: %w", err))
 		}
 
 		if !quiet && fixedCount > 0 && (format == "" || format == "default") {
@@ -190,136 +297,69 @@ func runLint(cmd *cobra.Command, args []string) error {
 		// Re-lint to get updated results
 		result, err = gomdlint.Lint(ctx, options)
 		if err != nil {
-			return fmt.Errorf("re-linting after fix failed: %w", err)
+			return lintError(fmt.Errorf("re-linting after fix failed: %w", err))
+		}
+	}
+
+	// Record or apply the baseline before reporting
+	baseline, err := applyBaseline(result, baselineOpts, options.Strings)
+	if err != nil {
+		return internalError(fmt.Errorf("baseline failed: %w", err))
+	}
+
+	// Only report violations on changed lines
+	if changeOpts.LinesOnly {
+		if err := filterChangedLines(result, changeOpts.Since); err != nil {
+			return internalError(fmt.Errorf("failed to find changed lines: %w", err))
 		}
 	}
 
 	// Output results (unless in quiet mode and no output file specified)
-	if !quiet || outputFile != "" {
-		err = outputResults(result, outputFile, format, color)
+	outputStart := time.Now()
+	if summaryOnly && outputFile == "" && (format == "" || format == "default") {
+		if !quiet {
+			printSummaryTable(cmd.OutOrStdout(), result)
+		}
+	} else if !quiet || outputFile != "" {
+		var timingJSON *timingReport
+		if profiling.Timing {
+			timingJSON = newTimingReport(timings, result)
+		}
+		err = outputResults(cmd.OutOrStdout(), result, outputFile, format, color, timingJSON)
 		if err != nil {
-			return fmt.Errorf("failed to output results: %w", err)
+			return internalError(fmt.Errorf("failed to output results: %w", err))
 		}
 	}
+	timings.Output = time.Since(outputStart)
 
 	// Print summary (only for default format to avoid corrupting structured output)
 	if !quiet && (format == "" || format == "default") {
 		duration := time.Since(startTime)
 		printSummary(themedOutput, result, duration, verbose)
-	}
-
-	// Return non-zero exit code if violations found
-	if result.TotalErrors > 0 {
-		// In tests, don't call os.Exit() as it would terminate the test process
-		// Tests should check for violations in the result rather than relying on error returns
-		if !testing.Testing() {
-			os.Exit(1)
+		printBaselineReport(themedOutput, baseline, baselineOpts, verbose)
+		for _, warning := range result.Warnings {
+			themedOutput.Warning("%s", warning)
 		}
 	}
 
-	return nil
-}
-
-// collectFiles gathers markdown files from the given arguments.
-func collectFiles(args []string, ignorePaths []string, includeDot bool) ([]string, error) {
-	var files []string
-	ignoreMap := make(map[string]bool)
-
-	for _, pattern := range ignorePaths {
-		ignoreMap[pattern] = true
-	}
-
-	// If no args provided, default to current directory
-	if len(args) == 0 {
-		args = []string{"."}
-	}
-
-	for _, arg := range args {
-		// Check if it's a glob pattern
-		if strings.ContainsAny(arg, "*?[") {
-			// Expand glob pattern
-			matches, err := filepath.Glob(arg)
-			if err != nil {
-				return nil, fmt.Errorf("invalid glob pattern %q: %w", arg, err)
-			}
-
-			for _, match := range matches {
-				if fileInfo, err := os.Stat(match); err == nil && !fileInfo.IsDir() {
-					if isMarkdownFile(match) {
-						files = append(files, match)
-					}
-				}
-			}
-			continue
-		}
-
-		// Handle single file case
-		if fileInfo, err := os.Stat(arg); err == nil && !fileInfo.IsDir() {
-			if isMarkdownFile(arg) {
-				files = append(files, arg)
-			}
-			continue
-		}
-
-		// Use a more direct approach since filepath.Walk seems to have issues in test environment
-		var walkFunc func(dir string) error
-		walkFunc = func(dir string) error {
-			entries, err := os.ReadDir(dir)
-			if err != nil {
-				return err
-			}
-
-			for _, entry := range entries {
-				path := filepath.Join(dir, entry.Name())
-
-				// Skip ignored paths
-				if shouldIgnore(path, ignoreMap) {
-					continue
-				}
-
-				// Skip hidden files/dirs unless requested
-				if !includeDot && strings.HasPrefix(entry.Name(), ".") {
-					continue
-				}
-
-				if entry.IsDir() {
-					// Recursively walk subdirectories
-					if err := walkFunc(path); err != nil {
-						// Continue with other files/directories
-					}
-				} else if isMarkdownFile(path) {
-					files = append(files, path)
-				}
-			}
-			return nil
-		}
-
-		err := walkFunc(arg)
-
-		if err != nil {
-			return nil, err
+	// Report the time spent in each phase without corrupting structured output
+	switch {
+	case profiling.Timing && (quiet || format == "json"):
+		// Included in the JSON output, if any
+	case profiling.Timing && (format == "" || format == "default"):
+		printTimingTable(cmd.OutOrStdout(), timings, newTimingReport(timings, result))
+	case profiling.Timing:
+		printTimingTable(cmd.ErrOrStderr(), timings, newTimingReport(timings, result))
+	case mode.CI && !quiet:
+		if format == "" || format == "default" {
+			themedOutput.Info("%s", timings)
+		} else {
+			fmt.Fprintln(cmd.ErrOrStderr(), timings)
 		}
 	}
 
-	return files, nil
-}
-
-// isMarkdownFile checks if a file is a markdown file based on extension.
-func isMarkdownFile(filename string) bool {
-	ext := strings.ToLower(filepath.Ext(filename))
-	return ext == ".md" || ext == ".markdown" || ext == ".mkd" || ext == ".mdown"
-}
-
-// shouldIgnore checks if a path should be ignored based on patterns.
-func shouldIgnore(path string, ignoreMap map[string]bool) bool {
-	// Simple pattern matching - in a full implementation,
-	// this would support glob patterns
-	for pattern := range ignoreMap {
-		if strings.Contains(path, pattern) {
-			return true
-		}
-	}
-	return false
+	// Violations beyond the thresholds and lint failures set the exit code
+	return lintOutcome(result, thresholds)
 }
 
 // loadConfiguration loads configuration from a file.
@@ -327,6 +367,7 @@ func loadConfiguration(configFile string) (map[string]interface{}, error) {
 	if configFile == "" {
 		// Try to find a default config file
 		possibleConfigs := []string{
+			".markdownlint.jsonc",
 			".markdownlint.json",
 			".markdownlint.yaml",
 			".markdownlint.yml",
@@ -347,39 +388,8 @@ func loadConfiguration(configFile string) (map[string]interface{}, error) {
 		}
 	}
 
-	// Read and parse config file
-	data, err := os.ReadFile(configFile)
-	if err != nil {
-		return nil, err
-	}
-
-	var config map[string]interface{}
-
-	// Try JSON first
-	if err := json.Unmarshal(data, &config); err != nil {
-		// TODO: Try YAML if JSON fails
-		return nil, fmt.Errorf("failed to parse config file as JSON: %w", err)
-	}
-
-	return config, nil
-}
-
-// readStdin reads content from stdin.
-func readStdin() (string, error) {
-	var content strings.Builder
-	buffer := make([]byte, 1024)
-
-	for {
-		n, err := os.Stdin.Read(buffer)
-		if n > 0 {
-			content.Write(buffer[:n])
-		}
-		if err != nil {
-			break
-		}
-	}
-
-	return content.String(), nil
+	// Read and parse config file (JSON, JSONC, YAML or TOML)
+	return service.ParseConfigFile(configFile)
 }
 
 // performAutoFix attempts to automatically fix violations using the robust FixEngine.
@@ -406,17 +416,27 @@ func performAutoFix(result *gomdlint.LintResult, options gomdlint.LintOptions) (
 }
 
 // outputResults outputs the linting results in the specified format.
-func outputResults(result *gomdlint.LintResult, outputFile, format string, color bool) error {
+// outputResults writes result in format to outputFile, or to w. A timing
+// report, when given, is included in JSON output alongside the results.
+func outputResults(w io.Writer, result *gomdlint.LintResult, outputFile, format string, color bool, timing *timingReport) error {
 	var output string
 	var err error
 
 	switch format {
 	case "json":
-		output, err = result.ToJSON()
+		if timing != nil {
+			output, err = formatAsTimedJSON(result, timing)
+		} else {
+			output, err = result.ToJSON()
+		}
 	case "junit":
 		output, err = formatAsJUnit(result)
 	case "checkstyle":
 		output, err = formatAsCheckstyle(result)
+	case "github":
+		output, err = formatAsGitHub(result)
+	case "gitlab":
+		output, err = formatAsGitLab(result)
 	case "default", "":
 		output = result.ToFormattedString(true) // Use aliases
 		if color && output != "" {
@@ -435,9 +455,9 @@ func outputResults(result *gomdlint.LintResult, outputFile, format string, color
 		// Always create the output file, even if output is empty
 		return os.WriteFile(outputFile, []byte(output), 0644)
 	} else if output != "" {
-		fmt.Print(output)
+		fmt.Fprint(w, output)
 		if !strings.HasSuffix(output, "\n") {
-			fmt.Println()
+			fmt.Fprintln(w)
 		}
 	}
 
@@ -481,8 +501,40 @@ func printSummary(themedOutput *output.ThemedOutput, result *gomdlint.LintResult
 	}
 }
 
+// concurrencyFromFlags reads --concurrency, the number of files linted at
+// once; 0, the default, uses one worker per CPU.
+func concurrencyFromFlags(cmd *cobra.Command) (int, error) {
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency < 0 {
+		return 0, errors.New("--concurrency must be 0 or greater")
+	}
+	return concurrency, nil
+}
+
 // loadConfigurationSourceFromLint loads configuration source using XDG-aware system.
 // This now uses the same logic as the config command for consistency.
-func loadConfigurationSourceFromLint(configFile string) (*ConfigurationSource, error) {
-	return loadConfigurationSourceShared(configFile)
+// The profile flag takes precedence over GOMDLINT_PROFILE.
+func loadConfigurationSourceFromLint(configFile string, profile string) (*ConfigurationSource, error) {
+	return loadConfigurationSourceWithProfile(configFile, resolveProfileName(profile))
+}
+
+// loadLintConfiguration loads the configuration used by lint, check and fix:
+// the configuration files (unless noConfig is set) and the profile, with the
+// rule selection flags layered on top. A non-empty targetPath resolves the
+// project configuration for that file, as for --stdin-filename.
+func loadLintConfiguration(configFile string, profile string, noConfig bool, overrides RuleOverrides, targetPath string) (*ConfigurationSource, error) {
+	var configSource *ConfigurationSource
+	if noConfig {
+		configSource = defaultConfigurationSource()
+	} else {
+		var err error
+		configSource, err = loadConfigurationSourceFor(configFile, resolveProfileName(profile), targetPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := applyRuleOverrides(configSource, overrides); err != nil {
+		return nil, err
+	}
+	return configSource, nil
 }
@@ -10,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
 )
 
 // Integration tests that require file I/O operations
@@ -117,6 +119,7 @@ func TestLintCommand_FlagScenariosIntegration(t *testing.T) {
 		".hidden.md":      "# Hidden\n\nContent.\n",
 		"ignored/file.md": "# Ignored\n\nContent.\n",
 		"config.json":     `{"MD018": false}`,
+		"profiles.json":   `{"MD018": false, "profiles": {"ci": {"MD018": true}}}`,
 	}
 
 	flagScenarios := []struct {
@@ -133,6 +136,50 @@ func TestLintCommand_FlagScenariosIntegration(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "with profile",
+			args: []string{"good.md"},
+			flags: map[string]interface{}{
+				"config":  "profiles.json",
+				"profile": "ci",
+			},
+			expectError: false,
+		},
+		{
+			name: "unknown profile",
+			args: []string{"good.md"},
+			flags: map[string]interface{}{
+				"config":  "profiles.json",
+				"profile": "release",
+			},
+			expectError: true,
+		},
+		{
+			name: "rule selection flags",
+			args: []string{"good.md"},
+			flags: map[string]interface{}{
+				"disable":     []string{"MD013"},
+				"disable-tag": []string{"whitespace"},
+				"rule":        []string{`MD003={"style":"atx"}`},
+			},
+			expectError: false,
+		},
+		{
+			name: "unknown rule in flag",
+			args: []string{"good.md"},
+			flags: map[string]interface{}{
+				"disable": []string{"MD999"},
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid rule override",
+			args: []string{"good.md"},
+			flags: map[string]interface{}{
+				"rule": []string{`MD013={"line_length":"wide"}`},
+			},
+			expectError: true,
+		},
 		{
 			name: "no config flag",
 			args: []string{"good.md"},
@@ -354,6 +401,50 @@ func TestLintCommand_CollectFilesIntegration(t *testing.T) {
 			includeDot:    false,
 			expectedFiles: []string{"normal.md"},
 		},
+		{
+			name: "ignore pattern does not match substrings",
+			args: []string{"."},
+			files: map[string]string{
+				"node/readme.md":        "# Ignored",
+				"docs/node-guide.md":    "# Kept",
+				"docs/nested/node/a.md": "# Ignored",
+			},
+			ignorePaths:   []string{"node"},
+			expectedFiles: []string{"docs/node-guide.md"},
+		},
+		{
+			name: "recursive glob input",
+			args: []string{"docs/**/*.md"},
+			files: map[string]string{
+				"README.md":           "# Root",
+				"docs/index.md":       "# Index",
+				"docs/guide/setup.md": "# Setup",
+			},
+			expectedFiles: []string{"docs/index.md", "docs/guide/setup.md"},
+		},
+		{
+			name: "negated input excludes files",
+			args: []string{".", "!docs/draft.md"},
+			files: map[string]string{
+				"docs/draft.md": "# Draft",
+				"docs/final.md": "# Final",
+			},
+			expectedFiles: []string{"docs/final.md"},
+		},
+		{
+			name: "ignore files with re-inclusion",
+			args: []string{"."},
+			files: map[string]string{
+				".gitignore":          "build/\ngenerated/\n",
+				".markdownlintignore": "docs/*.md\n!docs/keep.md\n",
+				"build/out.md":        "# Build",
+				"generated/api.md":    "# API",
+				"docs/skip.md":        "# Skip",
+				"docs/keep.md":        "# Keep",
+				"README.md":           "# Readme",
+			},
+			expectedFiles: []string{"README.md", "docs/keep.md"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -369,13 +460,15 @@ func TestLintCommand_CollectFilesIntegration(t *testing.T) {
 				os.Chdir(oldDir)
 			}()
 
-			files, err := collectFiles(tc.args, tc.ignorePaths, tc.includeDot)
+			files, err := collectFiles(tc.args, service.FileDiscoveryOptions{
+				Ignore:     tc.ignorePaths,
+				IncludeDot: tc.includeDot,
+			})
 			require.NoError(t, err)
 
-			// Convert to just filenames for comparison
 			fileNames := make([]string, len(files))
 			for i, file := range files {
-				fileNames[i] = filepath.Base(file)
+				fileNames[i] = filepath.ToSlash(file)
 			}
 
 			assert.ElementsMatch(t, tc.expectedFiles, fileNames)
@@ -409,6 +502,32 @@ func TestLintCommand_ConfigurationLoadingIntegration(t *testing.T) {
 		assert.Equal(t, float64(120), md013["line_length"])
 	})
 
+	t.Run("load YAML config", func(t *testing.T) {
+		tmpDir := createTempTestFiles(t, map[string]string{
+			".markdownlint.yaml": "MD001: false\nMD013:\n  line_length: 120\n",
+		})
+
+		config, err := loadConfiguration(filepath.Join(tmpDir, ".markdownlint.yaml"))
+
+		require.NoError(t, err)
+		assert.Equal(t, false, config["MD001"])
+
+		md013, ok := config["MD013"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, 120, md013["line_length"])
+	})
+
+	t.Run("invalid config reports position", func(t *testing.T) {
+		tmpDir := createTempTestFiles(t, map[string]string{
+			"config.json": "{\n  \"MD001\": false,\n}",
+		})
+
+		_, err := loadConfiguration(filepath.Join(tmpDir, "config.json"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "config.json:3:1")
+	})
+
 	t.Run("no config file", func(t *testing.T) {
 		config, err := loadConfiguration("")
 		assert.NoError(t, err)
@@ -3,10 +3,15 @@ package commands
 import (
 	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
 )
 
 // Test scenario structure following club/ standards
@@ -29,11 +34,18 @@ func createTestCommand() *cobra.Command {
 	// Add the missing flags as local flags to avoid conflicts
 	cmd.Flags().StringP("config", "c", "", "Path to configuration file")
 	cmd.Flags().Bool("no-config", false, "Ignore configuration files")
+	cmd.Flags().String("profile", "", "Configuration profile to apply")
 	cmd.Flags().StringP("output", "o", "", "Output file (default: stdout)")
 	cmd.Flags().StringP("format", "f", "default", "Output format (default, json, junit, checkstyle)")
 	cmd.Flags().Bool("color", true, "Enable colored output")
 	cmd.Flags().Bool("quiet", false, "Suppress non-error output")
 	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.Flags().StringSlice("enable", []string{}, "Enable specific rules")
+	cmd.Flags().StringSlice("disable", []string{}, "Disable specific rules")
+	cmd.Flags().StringSlice("enable-tag", []string{}, "Enable rules by tag")
+	cmd.Flags().StringSlice("disable-tag", []string{}, "Disable rules by tag")
+	cmd.Flags().StringArray("rule", []string{}, "Set rule options as NAME=JSON")
+	cmd.Flags().Int("concurrency", 0, "Number of concurrent workers (0 = auto)")
 
 	return cmd
 }
@@ -48,7 +60,7 @@ func TestNewLintCommand(t *testing.T) {
 	assert.NotEmpty(t, cmd.Long)
 
 	// Check that expected flags are present
-	expectedFlags := []string{"ignore", "fix", "stdin", "stdin-name", "dot"}
+	expectedFlags := []string{"ignore", "fix", "stdin", "stdin-name", "stdin-filename", "dot"}
 	for _, flagName := range expectedFlags {
 		flag := cmd.Flags().Lookup(flagName)
 		assert.NotNil(t, flag, "Flag %s should exist", flagName)
@@ -96,53 +108,43 @@ func TestLintCommand_StdinInput(t *testing.T) {
 
 // File collection tests moved to lint_integration_test.go for better performance
 
-func TestLintCommand_UtilityFunctions(t *testing.T) {
-	t.Run("isMarkdownFile", func(t *testing.T) {
-		t.Parallel()
-		testCases := []struct {
-			filename string
-			expected bool
-		}{
-			{"test.md", true},
-			{"test.markdown", true},
-			{"test.mkd", true},
-			{"test.mdown", true},
-			{"test.MD", true}, // Case insensitive
-			{"test.txt", false},
-			{"test.html", false},
-			{"test", false},
-		}
-
-		for _, tc := range testCases {
-			result := isMarkdownFile(tc.filename)
-			assert.Equal(t, tc.expected, result, "File %s should be %v", tc.filename, tc.expected)
-		}
-	})
-
-	t.Run("shouldIgnore", func(t *testing.T) {
-		t.Parallel()
-		ignoreMap := map[string]bool{
-			"node_modules/": true,
-			".git/":         true,
-			"temp":          true,
-		}
-
-		testCases := []struct {
-			path     string
-			expected bool
-		}{
-			{"node_modules/package.md", true},
-			{".git/config", true},
-			{"temp/file.md", true},
-			{"src/main.md", false},
-			{"docs/README.md", false},
+func TestFileDiscoveryOptionsFromFlags(t *testing.T) {
+	cmd := NewLintCommand()
+	require.NoError(t, cmd.Flags().Set("ignore", "drafts/,*.tmp.md"))
+	require.NoError(t, cmd.Flags().Set("dot", "true"))
+	require.NoError(t, cmd.Flags().Set("no-ignore-files", "true"))
+
+	assert.Equal(t, service.FileDiscoveryOptions{
+		Ignore:        []string{"drafts/", "*.tmp.md"},
+		IncludeDot:    true,
+		NoIgnoreFiles: true,
+	}, fileDiscoveryOptionsFromFlags(cmd))
+
+	for _, command := range []*cobra.Command{NewCheckCommand(), NewFixCommand()} {
+		for _, name := range []string{"ignore", "dot", "no-ignore-files"} {
+			assert.NotNil(t, command.Flags().Lookup(name), "%s should register --%s", command.Name(), name)
 		}
+	}
+}
 
-		for _, tc := range testCases {
-			result := shouldIgnore(tc.path, ignoreMap)
-			assert.Equal(t, tc.expected, result, "Path %s should be %v", tc.path, tc.expected)
-		}
-	})
+func TestConcurrencyFromFlags(t *testing.T) {
+	cmd := createTestCommand()
+	concurrency, err := concurrencyFromFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, 0, concurrency, "0 picks one worker per CPU")
+
+	require.NoError(t, cmd.Flags().Set("concurrency", "3"))
+	concurrency, err = concurrencyFromFlags(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, 3, concurrency)
+
+	require.NoError(t, cmd.Flags().Set("concurrency", "-1"))
+	_, err = concurrencyFromFlags(cmd)
+	assert.EqualError(t, err, "--concurrency must be 0 or greater")
+
+	tmpDir := createTempTestFiles(t, map[string]string{"README.md": "# Title\n"})
+	_, _, err = executeCommand(t, createTestCommand(), []string{filepath.Join(tmpDir, "README.md")}, map[string]interface{}{"concurrency": "-1"})
+	assert.Equal(t, ExitUsage, ExitCode(err))
 }
 
 // Configuration loading tests moved to lint_integration_test.go for better performance
@@ -193,3 +195,64 @@ func executeCommandForBench(b *testing.B, cmd *cobra.Command, args []string, fla
 
 	return stdout, stderr, err
 }
+
+func TestLintCommand_SchemaPathsFromConfigDirectory(t *testing.T) {
+	tmpDir := createTempTestFiles(t, map[string]string{
+		".markdownlint.yaml": "default: false\nGMD008:\n  schemas:\n    - files: \"content/posts/**\"\n      schema: schemas/post.yaml\n    - files: \"content/pages/**\"\n      schema: schemas/page.yaml\n",
+		"schemas/post.yaml":  "required: [title, date]\n",
+		"schemas/page.yaml":  "required: [title]\n",
+		"content/posts/a.md": "---\ntitle: A\n---\n\n# A\n",
+	})
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(filepath.Join(tmpDir, "content")))
+	t.Cleanup(func() { os.Chdir(oldDir) })
+
+	cmd := createTestCommand()
+	stdout, stderr, _ := executeCommand(t, cmd, []string{"posts"}, map[string]interface{}{
+		"config": filepath.Join("..", ".markdownlint.yaml"),
+		"color":  false,
+	})
+	output := stdout.String() + stderr.String()
+
+	// The glob and schema are found from the configuration file's directory
+	assert.Contains(t, output, "GMD008")
+	assert.Contains(t, output, "date")
+	// The entry for pages matches none of the linted files
+	assert.Contains(t, output, "GMD008 schemas[1] (files pages/**) matches no linted file")
+	assert.NotContains(t, output, "schemas[0]")
+}
+
+func TestLintCommand_MarkdownlintConfiguration(t *testing.T) {
+	tmpDir := createTempTestFiles(t, map[string]string{
+		".markdownlint.json": `{
+  // Parameters as documented by markdownlint
+  "default": true,
+  "MD001": { "front_matter_title": "^\\s*title\\s*[:=]" },
+  "MD013": { "line_length": 80, "strict": true },
+  "MD033": { "allowed_elements": [], "table_allowed_elements": ["br"] },
+  "MD051": { "ignore_case": true, "ignored_pattern": "^ext-" },
+  "MD052": { "ignored_labels": ["x"] },
+  "MD054": { "full": true },
+  "MD060": { "style": "compact", "aligned_delimiter": true },
+  "MD022": { "lines_abve": 1 }
+}
+`,
+		"README.md": "# Title\n\n| Key | Value |\n| --- | ----- |\n| a | b<br>c |\n\nSee [the title](#TITLE), [the extension notes](#ext-1) and [x].\n",
+	})
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	t.Cleanup(func() { os.Chdir(oldDir) })
+
+	cmd := createTestCommand()
+	stdout, stderr, err := executeCommand(t, cmd, []string{"README.md"}, map[string]interface{}{"color": false})
+	output := stdout.String() + stderr.String()
+
+	require.NoError(t, err, output)
+	assert.Contains(t, output, "No violations found")
+	// Parameters the rule does not declare are reported, not fatal
+	assert.Contains(t, output, `rule MD022: parameter "lines_abve": unknown parameter (did you mean "lines_above"?)`)
+}
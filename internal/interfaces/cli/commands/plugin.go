@@ -186,7 +186,7 @@ func installPlugin(pluginPath string, force bool) error {
 			}
 			pluginPath = builtPath
 		} else {
-			return fmt.Errorf("plugin must be a .so file or source directory")
+			return usageError(fmt.Errorf("plugin must be a .so file or source directory"))
 		}
 	}
 
@@ -201,7 +201,7 @@ func installPlugin(pluginPath string, force bool) error {
 			plugins := tempManager.GetAllPlugins()
 			for name := range plugins {
 				if _, exists := pluginManager.GetAllPlugins()[name]; exists {
-					return fmt.Errorf("plugin %s already installed (use --force to override)", name)
+					return usageError(fmt.Errorf("plugin %s already installed (use --force to override)", name))
 				}
 			}
 		}
@@ -270,7 +270,7 @@ func showPluginInfo(pluginName string) error {
 func buildPlugin(sourceDir, outputPath string) error {
 	// Validate source directory
 	if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
-		return fmt.Errorf("source directory does not exist: %s", sourceDir)
+		return usageError(fmt.Errorf("source directory does not exist: %s", sourceDir))
 	}
 
 	// Default output path
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+// profilingOptions holds the flags for finding out where a lint run spends
+// its time.
+type profilingOptions struct {
+	Timing     bool   // --timing: report the time spent in each phase and rule
+	CPUProfile string // --cpuprofile: write a pprof CPU profile to this file
+	Trace      string // --trace: write a runtime execution trace to this file
+}
+
+// addProfilingFlags registers the profiling flags shared by lint and check.
+func addProfilingFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("timing", false, "Report the time spent in each phase and rule (bypasses the result cache)")
+	cmd.Flags().String("cpuprofile", "", "Write a Go pprof CPU profile to this file")
+	cmd.Flags().String("trace", "", "Write a Go execution trace to this file")
+}
+
+// profilingOptionsFromFlags reads the profiling flags from cmd. Flags that
+// are not registered on the command are treated as unset.
+func profilingOptionsFromFlags(cmd *cobra.Command) profilingOptions {
+	timing, _ := cmd.Flags().GetBool("timing")
+	cpuProfile, _ := cmd.Flags().GetString("cpuprofile")
+	traceFile, _ := cmd.Flags().GetString("trace")
+	return profilingOptions{Timing: timing, CPUProfile: cpuProfile, Trace: traceFile}
+}
+
+// start begins CPU profiling and execution tracing as requested. The
+// returned function stops them and closes the files; it must be called even
+// when nothing was started.
+func (o profilingOptions) start() (func() error, error) {
+	var stops []func() error
+	stop := func() error {
+		var errs []error
+		for i := len(stops) - 1; i >= 0; i-- {
+			errs = append(errs, stops[i]())
+		}
+		return errors.Join(errs...)
+	}
+
+	if o.CPUProfile != "" {
+		file, err := os.Create(o.CPUProfile)
+		if err != nil {
+			return stop, fmt.Errorf("failed to create CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return stop, fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return file.Close()
+		})
+	}
+
+	if o.Trace != "" {
+		file, err := os.Create(o.Trace)
+		if err != nil {
+			return stop, errors.Join(fmt.Errorf("failed to create trace: %w", err), stop())
+		}
+		if err := trace.Start(file); err != nil {
+			file.Close()
+			return stop, errors.Join(fmt.Errorf("failed to start trace: %w", err), stop())
+		}
+		stops = append(stops, func() error {
+			trace.Stop()
+			return file.Close()
+		})
+	}
+
+	return stop, nil
+}
+
+// ruleTimingEntry is the time one rule spent across all documents.
+type ruleTimingEntry struct {
+	Rule      string        `json:"rule"`
+	Duration  time.Duration `json:"-"`
+	Millis    float64       `json:"durationMs"`
+	Documents int           `json:"documents"`
+}
+
+// timingReport is the --timing report. Phase durations other than discovery
+// are summed across files, so they can exceed the elapsed time. Writing the
+// output is not included because the report is part of it.
+type timingReport struct {
+	Phases map[string]float64 `json:"phasesMs"`
+	Rules  []ruleTimingEntry  `json:"rules"`
+}
+
+// newTimingReport builds the timing report for a run, with rules sorted
+// slowest first.
+func newTimingReport(phases phaseDurations, result *gomdlint.LintResult) *timingReport {
+	report := &timingReport{
+		Phases: map[string]float64{
+			"discovery": milliseconds(phases.Discovery),
+			"read":      milliseconds(phases.Read),
+			"parse":     milliseconds(phases.Parse),
+			"rules":     milliseconds(phases.Rules),
+		},
+		Rules: make([]ruleTimingEntry, 0, len(result.RuleTimings)),
+	}
+
+	for rule, timing := range result.RuleTimings {
+		report.Rules = append(report.Rules, ruleTimingEntry{
+			Rule:      rule,
+			Duration:  timing.Duration,
+			Millis:    milliseconds(timing.Duration),
+			Documents: timing.Documents,
+		})
+	}
+	sort.Slice(report.Rules, func(i, j int) bool {
+		if report.Rules[i].Duration != report.Rules[j].Duration {
+			return report.Rules[i].Duration > report.Rules[j].Duration
+		}
+		return report.Rules[i].Rule < report.Rules[j].Rule
+	})
+
+	return report
+}
+
+// formatAsTimedJSON formats results as JSON with the timing report:
+// {"results": {...}, "timings": {...}}.
+func formatAsTimedJSON(result *gomdlint.LintResult, timing *timingReport) (string, error) {
+	data, err := json.Marshal(struct {
+		Results map[string][]gomdlint.Violation `json:"results"`
+		Timings *timingReport                   `json:"timings"`
+	}{result.Results, timing})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// milliseconds converts d to fractional milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// printTimingTable prints the time spent in each phase, then in each rule
+// with its share of the total rule time.
+func printTimingTable(w io.Writer, phases phaseDurations, report *timingReport) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PHASE\tTIME")
+	for _, phase := range []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"discovery", phases.Discovery},
+		{"read", phases.Read},
+		{"parse", phases.Parse},
+		{"rules", phases.Rules},
+		{"output", phases.Output},
+	} {
+		fmt.Fprintf(tw, "%s\t%s\n", phase.name, phase.duration.Round(time.Microsecond))
+	}
+	tw.Flush()
+
+	if len(report.Rules) == 0 {
+		return
+	}
+
+	var total time.Duration
+	for _, rule := range report.Rules {
+		total += rule.Duration
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RULE\tTIME\tDOCUMENTS\tAVERAGE\tSHARE")
+	for _, rule := range report.Rules {
+		var average time.Duration
+		if rule.Documents > 0 {
+			average = rule.Duration / time.Duration(rule.Documents)
+		}
+		share := 0.0
+		if total > 0 {
+			share = 100 * float64(rule.Duration) / float64(total)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%.1f%%\n", rule.Rule, rule.Duration.Round(time.Microsecond),
+			rule.Documents, average.Round(time.Microsecond), share)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+func TestNewTimingReport(t *testing.T) {
+	result := &gomdlint.LintResult{RuleTimings: map[string]gomdlint.RuleTiming{
+		"MD013": {Duration: 2 * time.Millisecond, Documents: 4},
+		"MD001": {Duration: 6 * time.Millisecond, Documents: 4},
+		"MD009": {Duration: 2 * time.Millisecond, Documents: 4},
+	}}
+	phases := phaseDurations{Discovery: time.Millisecond, Parse: 3 * time.Millisecond, Rules: 10 * time.Millisecond}
+
+	report := newTimingReport(phases, result)
+	require.Len(t, report.Rules, 3)
+	assert.Equal(t, []string{"MD001", "MD009", "MD013"},
+		[]string{report.Rules[0].Rule, report.Rules[1].Rule, report.Rules[2].Rule}, "slowest first, then by name")
+	assert.Equal(t, 6.0, report.Rules[0].Millis)
+	assert.Equal(t, 3.0, report.Phases["parse"])
+
+	var out bytes.Buffer
+	printTimingTable(&out, phases, report)
+	assert.Contains(t, out.String(), "rules      10ms\n")
+	assert.Contains(t, out.String(), "MD001  6ms   4          1.5ms    60.0%\n")
+}
+
+func TestProfilingOptions_Start(t *testing.T) {
+	dir := t.TempDir()
+	options := profilingOptions{
+		CPUProfile: filepath.Join(dir, "cpu.pprof"),
+		Trace:      filepath.Join(dir, "run.trace"),
+	}
+
+	stop, err := options.start()
+	require.NoError(t, err)
+	require.NoError(t, stop())
+
+	for _, path := range []string{options.CPUProfile, options.Trace} {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Positive(t, info.Size(), "%s should not be empty", path)
+	}
+
+	_, err = profilingOptions{CPUProfile: filepath.Join(dir, "missing", "cpu.pprof")}.start()
+	assert.Error(t, err)
+}
+
+func TestLintCommand_Timing(t *testing.T) {
+	dir := createTempTestFiles(t, map[string]string{"doc.md": "# Title\n\nContent.\n"})
+
+	cmd := NewLintCommand()
+	cmd.Flags().StringP("format", "f", "default", "Output format")
+	cmd.Flags().Bool("quiet", false, "Suppress non-error output")
+	stdout, _, err := executeCommand(t, cmd, []string{filepath.Join(dir, "doc.md")}, map[string]interface{}{
+		"timing": true,
+		"format": "json",
+	})
+	require.NoError(t, err)
+
+	var output struct {
+		Results map[string][]gomdlint.Violation `json:"results"`
+		Timings timingReport                    `json:"timings"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(stdout.String())), &output))
+	assert.Contains(t, output.Results, filepath.Join(dir, "doc.md"))
+	assert.Contains(t, output.Timings.Phases, "rules")
+	require.NotEmpty(t, output.Timings.Rules)
+	assert.Equal(t, 1, output.Timings.Rules[0].Documents)
+}
@@ -0,0 +1,359 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+// NewReportCommand creates the report command for corpus-wide violation statistics.
+func NewReportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report [files...]",
+		Short: "Summarize violations across a documentation corpus",
+		Long: `Lint markdown files and summarize the violations instead of listing them:
+totals, fixable and non-fixable counts, violations by rule, tag and directory,
+and the files with the most violations.
+
+The report is printed as a table by default. --format json writes it as JSON,
+which a later run can read with --compare to show the change since then, and
+--format html writes a self-contained HTML page.
+
+Examples:
+  gomdlint report docs/
+  gomdlint report --format json --output report.json docs/
+  gomdlint report --compare last-week.json --format html --output report.html docs/`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runReport,
+		// The returned error carries the exit code; HandleError reports it
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	addFileSelectionFlags(cmd)
+	cmd.Flags().String("compare", "", "Previous JSON report to show the change against")
+	cmd.Flags().Int("top", 10, "Number of top offending files to list (0: all)")
+
+	return cmd
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	configFile, _ := cmd.Flags().GetString("config")
+	profile, _ := cmd.Flags().GetString("profile")
+	noConfig, _ := cmd.Flags().GetBool("no-config")
+	outputFile, _ := cmd.Flags().GetString("output")
+	format, _ := cmd.Flags().GetString("format")
+	comparePath, _ := cmd.Flags().GetString("compare")
+	top, _ := cmd.Flags().GetInt("top")
+
+	render, err := reportRenderer(format)
+	if err != nil {
+		return usageError(err)
+	}
+
+	var previous *service.QualityReport
+	if comparePath != "" {
+		previous, err = service.LoadQualityReport(comparePath)
+		if err != nil {
+			return usageError(err)
+		}
+	}
+
+	configSource, err := loadLintConfiguration(configFile, profile, noConfig, ruleOverridesFromFlags(cmd), "")
+	if err != nil {
+		return usageError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+
+	files, err := collectFiles(args, fileDiscoveryOptionsFromFlags(cmd))
+	if err != nil {
+		return fmt.Errorf("failed to collect files: %w", err)
+	}
+
+	concurrency, err := concurrencyFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+
+	options := gomdlint.LintOptions{
+		Files:              files,
+		Config:             make(map[string]interface{}),
+		ResultVersion:      3,
+		HandleRuleFailures: true,
+		Concurrency:        concurrency,
+	}
+	if !configSource.IsDefault {
+		options.Config = configSource.Config
+	}
+	cacheOptions, err := cacheOptionsFromFlags(cmd)
+	if err != nil {
+		return usageError(err)
+	}
+	applyCacheOptions(&options, cacheOptions)
+
+	result := &gomdlint.LintResult{}
+	if len(files) > 0 {
+		result, err = gomdlint.Lint(ctx, options)
+		if err != nil {
+			return lintError(fmt.Errorf("linting failed: %w", err))
+		}
+	}
+
+	report, err := buildQualityReport(files, result, top)
+	if err != nil {
+		return internalError(err)
+	}
+	if previous != nil {
+		report.Compare(previous)
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, report); err != nil {
+		return internalError(fmt.Errorf("failed to render report: %w", err))
+	}
+	if outputFile != "" {
+		if err := os.WriteFile(outputFile, buf.Bytes(), 0644); err != nil {
+			return internalError(fmt.Errorf("failed to write report: %w", err))
+		}
+		return nil
+	}
+	if _, err := cmd.OutOrStdout().Write(buf.Bytes()); err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+// buildQualityReport aggregates result into a quality report, attributing
+// violations to the tags of their rules and to paths relative to the
+// working directory.
+func buildQualityReport(files []string, result *gomdlint.LintResult, top int) (*service.QualityReport, error) {
+	engine, err := service.NewRuleEngine()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rule engine: %w", err)
+	}
+	tags := make(map[string][]string)
+	for _, rule := range engine.GetAllRules() {
+		tags[rule.PrimaryName()] = rule.Tags()
+	}
+
+	reportFiles := make([]string, len(files))
+	for i, file := range files {
+		reportFiles[i] = reportPath(file)
+	}
+
+	var violations []service.ReportViolation
+	for _, file := range sortedResultFiles(result) {
+		path := reportPath(file)
+		for _, violation := range result.Results[file] {
+			violations = append(violations, service.ReportViolation{
+				File:    path,
+				Rule:    violation.RuleNames[0],
+				Tags:    tags[violation.RuleNames[0]],
+				Warning: violation.Severity == "warning",
+				Fixable: violation.FixInfo != nil,
+			})
+		}
+	}
+
+	return service.NewQualityReport(reportFiles, violations, top, time.Now().UTC()), nil
+}
+
+// reportPath returns file relative to the working directory when it is
+// inside it, with slash separators.
+func reportPath(file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// reportRenderer returns the renderer for a report format.
+func reportRenderer(format string) (func(io.Writer, *service.QualityReport) error, error) {
+	switch format {
+	case "", "default", "table":
+		return renderReportTable, nil
+	case "json":
+		return renderReportJSON, nil
+	case "html":
+		return renderReportHTML, nil
+	default:
+		return nil, fmt.Errorf("unsupported report format: %s (use table, json or html)", format)
+	}
+}
+
+// renderReportJSON writes the report as indented JSON.
+func renderReportJSON(w io.Writer, report *service.QualityReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// renderReportTable writes the report as plain-text tables.
+func renderReportTable(w io.Writer, report *service.QualityReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Files\t%d\t%s\n", report.Files, reportTrendCell(report, func(t *service.ReportTrend) int { return t.Files }))
+	fmt.Fprintf(tw, "Files with violations\t%d\t\n", report.FilesWithViolations)
+	fmt.Fprintf(tw, "Violations\t%d\t%s\n", report.Violations, reportTrendCell(report, func(t *service.ReportTrend) int { return t.Violations }))
+	fmt.Fprintf(tw, "Errors\t%d\t%s\n", report.Errors, reportTrendCell(report, func(t *service.ReportTrend) int { return t.Errors }))
+	fmt.Fprintf(tw, "Warnings\t%d\t%s\n", report.Warnings, reportTrendCell(report, func(t *service.ReportTrend) int { return t.Warnings }))
+	fmt.Fprintf(tw, "Fixable\t%d\t%s\n", report.Fixable, reportTrendCell(report, func(t *service.ReportTrend) int { return t.Fixable }))
+	fmt.Fprintf(tw, "Not fixable\t%d\t\n", report.NonFixable)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	sections := []struct {
+		heading string
+		counts  []service.ReportCount
+	}{
+		{"RULE", report.ByRule},
+		{"TAG", report.ByTag},
+		{"DIRECTORY", report.ByDirectory},
+		{"FILE", report.TopFiles},
+	}
+	for _, section := range sections {
+		if len(section.counts) == 0 {
+			continue
+		}
+		fmt.Fprintln(w)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		if report.Trend != nil {
+			fmt.Fprintf(tw, "%s\tVIOLATIONS\tFIXABLE\tCHANGE\n", section.heading)
+		} else {
+			fmt.Fprintf(tw, "%s\tVIOLATIONS\tFIXABLE\n", section.heading)
+		}
+		for _, c := range section.counts {
+			if report.Trend != nil {
+				fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", c.Name, c.Count, c.Fixable, formatReportChange(c.Change))
+			} else {
+				fmt.Fprintf(tw, "%s\t%d\t%d\n", c.Name, c.Count, c.Fixable)
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportTrendCell formats one total's change for the summary table, or ""
+// without a compared report.
+func reportTrendCell(report *service.QualityReport, change func(*service.ReportTrend) int) string {
+	if report.Trend == nil {
+		return ""
+	}
+	delta := change(report.Trend)
+	return "(" + formatReportChange(&delta) + ")"
+}
+
+// formatReportChange formats a change with an explicit sign.
+func formatReportChange(change *int) string {
+	switch {
+	case change == nil:
+		return ""
+	case *change > 0:
+		return fmt.Sprintf("+%d", *change)
+	default:
+		return fmt.Sprintf("%d", *change)
+	}
+}
+
+// reportHTMLTemplate renders a report as a page with no external resources.
+var reportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"change": formatReportChange,
+	"percent": func(count, total int) float64 {
+		if total == 0 {
+			return 0
+		}
+		return 100 * float64(count) / float64(total)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>gomdlint report</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 60rem; color: #222; }
+h1 { margin-bottom: 0; }
+.generated { color: #666; margin-top: 0.25rem; }
+.totals { display: flex; flex-wrap: wrap; gap: 1rem; margin: 1.5rem 0; }
+.total { border: 1px solid #ddd; border-radius: 6px; padding: 0.75rem 1rem; min-width: 8rem; }
+.total .value { font-size: 1.6rem; font-weight: 600; }
+.total .label { color: #666; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2rem; }
+th, td { text-align: left; padding: 0.3rem 0.6rem; border-bottom: 1px solid #eee; }
+td.number { text-align: right; font-variant-numeric: tabular-nums; }
+.bar { background: #d9534f; height: 0.6rem; border-radius: 3px; }
+.up { color: #c9302c; }
+.down { color: #2e7d32; }
+</style>
+</head>
+<body>
+<h1>Documentation quality report</h1>
+<p class="generated">Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}{{with .Trend}}, compared with {{.PreviousGeneratedAt.Format "2006-01-02 15:04 MST"}}{{end}}</p>
+<div class="totals">
+<div class="total"><div class="value">{{.Files}}</div><div class="label">files</div></div>
+<div class="total"><div class="value">{{.FilesWithViolations}}</div><div class="label">files with violations</div></div>
+<div class="total"><div class="value">{{.Violations}}{{with .Trend}} <small class="{{if gt .Violations 0}}up{{else if lt .Violations 0}}down{{end}}">{{change .Violations}}</small>{{end}}</div><div class="label">violations</div></div>
+<div class="total"><div class="value">{{.Errors}}</div><div class="label">errors</div></div>
+<div class="total"><div class="value">{{.Warnings}}</div><div class="label">warnings</div></div>
+<div class="total"><div class="value">{{.Fixable}}</div><div class="label">fixable</div></div>
+<div class="total"><div class="value">{{.NonFixable}}</div><div class="label">not fixable</div></div>
+</div>
+{{$report := .}}
+{{range .Sections}}{{if .Counts}}
+<h2>{{.Title}}</h2>
+<table>
+<tr><th>{{.Heading}}</th><th>Violations</th><th>Fixable</th>{{if $report.Trend}}<th>Change</th>{{end}}<th></th></tr>
+{{range .Counts}}<tr><td>{{.Name}}</td><td class="number">{{.Count}}</td><td class="number">{{.Fixable}}</td>{{if $report.Trend}}<td class="number">{{change .Change}}</td>{{end}}<td style="width: 30%"><div class="bar" style="width: {{printf "%.1f" (percent .Count $report.Violations)}}%"></div></td></tr>
+{{end}}</table>
+{{end}}{{end}}
+</body>
+</html>
+`))
+
+// renderReportHTML writes the report as a self-contained HTML page.
+func renderReportHTML(w io.Writer, report *service.QualityReport) error {
+	type section struct {
+		Title   string
+		Heading string
+		Counts  []service.ReportCount
+	}
+	return reportHTMLTemplate.Execute(w, struct {
+		*service.QualityReport
+		Sections []section
+	}{
+		QualityReport: report,
+		Sections: []section{
+			{"Violations by rule", "Rule", report.ByRule},
+			{"Violations by tag", "Tag", report.ByTag},
+			{"Violations by directory", "Directory", report.ByDirectory},
+			{"Top offending files", "File", report.TopFiles},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+)
+
+func createReportCommand() *cobra.Command {
+	cmd := NewReportCommand()
+
+	// Add persistent flags that would normally come from root command
+	cmd.PersistentFlags().StringP("config", "c", "", "Path to configuration file")
+	cmd.PersistentFlags().Bool("no-config", false, "Ignore configuration files")
+	cmd.PersistentFlags().StringP("output", "o", "", "Output file (default: stdout)")
+	cmd.PersistentFlags().StringP("format", "f", "default", "Output format")
+
+	return cmd
+}
+
+func TestReportCommand(t *testing.T) {
+	isolateUserConfig(t)
+	tmpDir := createTempTestFiles(t, map[string]string{
+		"README.md":       "# Project\n\nContent.\n",
+		"docs/guide.md":   "#Guide\n\nTrailing  \n",
+		"docs/api/ref.md": "# Reference\n\n\n\nContent.\n",
+	})
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer os.Chdir(oldDir)
+
+	// Save a JSON report, then compare against it after fixing a file
+	reportPath := filepath.Join(tmpDir, "report.json")
+	_, _, err = executeCommand(t, createReportCommand(), nil, map[string]interface{}{"format": "json", "output": reportPath})
+	require.NoError(t, err)
+
+	previous, err := service.LoadQualityReport(reportPath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, previous.Files)
+	assert.Equal(t, 2, previous.FilesWithViolations)
+	assert.Equal(t, "docs/guide.md", previous.TopFiles[0].Name)
+	assert.Equal(t, previous.Violations, previous.Fixable+previous.NonFixable)
+
+	require.NoError(t, os.WriteFile("docs/guide.md", []byte("# Guide\n\nFixed.\n"), 0644))
+	stdout, _, err := executeCommand(t, createReportCommand(), nil, map[string]interface{}{"format": "json", "compare": reportPath})
+	require.NoError(t, err)
+
+	var current service.QualityReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &current))
+	require.NotNil(t, current.Trend)
+	assert.Equal(t, current.Violations-previous.Violations, current.Trend.Violations)
+	assert.Negative(t, current.Trend.Violations)
+
+	stdout, _, err = executeCommand(t, createReportCommand(), nil, map[string]interface{}{"format": "html"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "<!DOCTYPE html>")
+	assert.Contains(t, stdout.String(), "docs/api/ref.md")
+	assert.NotContains(t, stdout.String(), "<script", "the page is self-contained")
+
+	stdout, _, err = executeCommand(t, createReportCommand(), nil, nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Violations")
+	assert.Contains(t, stdout.String(), "MD012")
+}
+
+func TestReportCommand_InvalidInput(t *testing.T) {
+	_, _, err := executeCommand(t, createReportCommand(), nil, map[string]interface{}{"format": "xml"})
+	assert.Equal(t, ExitUsage, ExitCode(err))
+
+	_, _, err = executeCommand(t, createReportCommand(), nil, map[string]interface{}{"compare": filepath.Join(t.TempDir(), "missing.json")})
+	assert.Equal(t, ExitUsage, ExitCode(err))
+}
@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/shared/utils"
+	"github.com/spf13/cobra"
+)
+
+// RuleOverrides holds the rule selection flags given on the command line.
+// They form the highest-priority configuration layer, above every file and
+// profile.
+type RuleOverrides struct {
+	EnableTags  []string // --enable-tag
+	DisableTags []string // --disable-tag
+	Enable      []string // --enable
+	Disable     []string // --disable
+	Rules       []string // --rule NAME=VALUE
+}
+
+// ruleOverridesFromFlags reads the rule selection flags from cmd. Flags that
+// are not registered on the command are treated as empty.
+func ruleOverridesFromFlags(cmd *cobra.Command) RuleOverrides {
+	enableTags, _ := cmd.Flags().GetStringSlice("enable-tag")
+	disableTags, _ := cmd.Flags().GetStringSlice("disable-tag")
+	enable, _ := cmd.Flags().GetStringSlice("enable")
+	disable, _ := cmd.Flags().GetStringSlice("disable")
+	rules, _ := cmd.Flags().GetStringArray("rule")
+
+	return RuleOverrides{
+		EnableTags:  enableTags,
+		DisableTags: disableTags,
+		Enable:      enable,
+		Disable:     disable,
+		Rules:       rules,
+	}
+}
+
+// IsEmpty reports whether no rule selection flags were given.
+func (o RuleOverrides) IsEmpty() bool {
+	return len(o.EnableTags) == 0 && len(o.DisableTags) == 0 &&
+		len(o.Enable) == 0 && len(o.Disable) == 0 && len(o.Rules) == 0
+}
+
+// Layers converts the overrides into configuration layers, ordered so that
+// rule-specific flags win over tag flags and --rule wins over both. Rules are
+// keyed by their primary name and switched with "enabled", so options set in
+// configuration files are kept when a flag only toggles a rule.
+func (o RuleOverrides) Layers(engine *service.RuleEngine) ([]service.ConfigLayer, error) {
+	var layers []service.ConfigLayer
+	addLayer := func(source string, config map[string]interface{}) {
+		if len(config) > 0 {
+			layers = append(layers, service.ConfigLayer{Source: source, Config: config})
+		}
+	}
+
+	enableTags, err := overrideTagLayer(engine, o.EnableTags, true)
+	if err != nil {
+		return nil, err
+	}
+	addLayer("--enable-tag", enableTags)
+
+	disableTags, err := overrideTagLayer(engine, o.DisableTags, false)
+	if err != nil {
+		return nil, err
+	}
+	addLayer("--disable-tag", disableTags)
+
+	enable, err := overrideRuleLayer(engine, o.Enable, true)
+	if err != nil {
+		return nil, err
+	}
+	addLayer("--enable", enable)
+
+	disable, err := overrideRuleLayer(engine, o.Disable, false)
+	if err != nil {
+		return nil, err
+	}
+	addLayer("--disable", disable)
+
+	rules := make(map[string]interface{})
+	for _, spec := range o.Rules {
+		name, value, err := parseRuleOverride(engine, spec)
+		if err != nil {
+			return nil, err
+		}
+		rules[name] = value
+	}
+	addLayer("--rule", rules)
+
+	return layers, nil
+}
+
+// overrideTagLayer switches every rule carrying one of tags.
+func overrideTagLayer(engine *service.RuleEngine, tags []string, enabled bool) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		rules := engine.GetRulesByTag(tag)
+		if len(rules) == 0 {
+			return nil, unknownOverrideError("tag", tag, allRuleTags(engine))
+		}
+		for _, rule := range rules {
+			config[rule.PrimaryName()] = map[string]interface{}{"enabled": enabled}
+		}
+	}
+	return config, nil
+}
+
+// overrideRuleLayer switches the named rules.
+func overrideRuleLayer(engine *service.RuleEngine, names []string, enabled bool) (map[string]interface{}, error) {
+	config := make(map[string]interface{})
+	for _, name := range names {
+		rule, err := lookupOverrideRule(engine, strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		config[rule.PrimaryName()] = map[string]interface{}{"enabled": enabled}
+	}
+	return config, nil
+}
+
+// parseRuleOverride parses a --rule value of the form NAME=VALUE, where VALUE
+// is a JSON boolean or object, e.g. MD013={"line_length":120}.
+func parseRuleOverride(engine *service.RuleEngine, spec string) (string, interface{}, error) {
+	name, raw, found := strings.Cut(spec, "=")
+	if !found || strings.TrimSpace(raw) == "" {
+		return "", nil, fmt.Errorf(`invalid --rule %q: expected NAME=VALUE, e.g. MD013={"line_length":120}`, spec)
+	}
+
+	rule, err := lookupOverrideRule(engine, strings.TrimSpace(name))
+	if err != nil {
+		return "", nil, err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return "", nil, fmt.Errorf("invalid --rule %q: value is not valid JSON: %w", spec, err)
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return rule.PrimaryName(), map[string]interface{}{"enabled": v}, nil
+	case map[string]interface{}:
+		return rule.PrimaryName(), v, nil
+	default:
+		return "", nil, fmt.Errorf("invalid --rule %q: value must be true, false or a JSON object", spec)
+	}
+}
+
+// lookupOverrideRule finds a rule by name or alias for a command-line flag.
+func lookupOverrideRule(engine *service.RuleEngine, name string) (*entity.Rule, error) {
+	ruleOpt := engine.GetRuleByName(name)
+	if ruleOpt.IsNone() {
+		return nil, unknownOverrideError("rule", name, allRuleNames(engine))
+	}
+	return ruleOpt.Unwrap(), nil
+}
+
+// unknownOverrideError reports an unknown rule or tag, suggesting the
+// closest known one.
+func unknownOverrideError(kind, name string, candidates []string) error {
+	if suggestion := utils.SuggestClosest(name, candidates); suggestion != "" {
+		return fmt.Errorf("unknown %s %q (did you mean %q?)", kind, name, suggestion)
+	}
+	return fmt.Errorf("unknown %s %q", kind, name)
+}
+
+// allRuleNames returns every rule name and alias known to engine.
+func allRuleNames(engine *service.RuleEngine) []string {
+	var names []string
+	for _, rule := range engine.GetAllRules() {
+		names = append(names, rule.Names()...)
+	}
+	return names
+}
+
+// allRuleTags returns the distinct tags used by the rules known to engine.
+func allRuleTags(engine *service.RuleEngine) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, rule := range engine.GetAllRules() {
+		for _, tag := range rule.Tags() {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// applyRuleOverrides layers the command-line rule flags over the loaded
+// configuration. They take precedence over every file and profile.
+func applyRuleOverrides(configSource *ConfigurationSource, overrides RuleOverrides) error {
+	if overrides.IsEmpty() {
+		return nil
+	}
+
+	engine, err := service.NewRuleEngine()
+	if err != nil {
+		return fmt.Errorf("failed to create rule engine: %w", err)
+	}
+
+	layers, err := overrides.Layers(engine)
+	if err != nil {
+		return err
+	}
+
+	if configSource.merger == nil {
+		configSource.merger = utils.NewConfigurationMerger()
+		configSource.merger.AddSource(configSource.Config, string(ConfigSourceTypeDefault), utils.ConfigSourceSystem)
+	}
+
+	for _, layer := range layers {
+		configSource.merger.AddSource(layer.Config, layer.Source, utils.ConfigSourceFlags)
+		configSource.Sources = append(configSource.Sources, ConfigSource{
+			Path:   layer.Source,
+			Type:   ConfigSourceTypeFlags,
+			Config: layer.Config,
+		})
+	}
+
+	configSource.Config = configSource.merger.Merge()
+	configSource.Provenance = configSource.merger.Provenance()
+	configSource.IsDefault = false
+	return nil
+}
+
+// describeOverrideLayer summarizes a flag layer for display, e.g.
+// "MD013=false, MD041=false".
+func describeOverrideLayer(config map[string]interface{}) string {
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := config[name]
+		if options, ok := value.(map[string]interface{}); ok && len(options) == 1 {
+			if enabled, ok := options["enabled"].(bool); ok {
+				value = enabled
+			}
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = []byte(fmt.Sprintf("%v", value))
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", name, data))
+	}
+	return strings.Join(parts, ", ")
+}
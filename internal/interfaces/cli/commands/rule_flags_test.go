@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+)
+
+func TestRuleOverrides_Layers(t *testing.T) {
+	engine, err := service.NewRuleEngine()
+	require.NoError(t, err)
+
+	scenarios := []struct {
+		name          string
+		overrides     RuleOverrides
+		expected      []service.ConfigLayer
+		errorContains string
+	}{
+		{
+			name:      "no flags",
+			overrides: RuleOverrides{},
+			expected:  nil,
+		},
+		{
+			name:      "aliases resolve to primary names",
+			overrides: RuleOverrides{Enable: []string{"line-length"}, Disable: []string{"md041"}},
+			expected: []service.ConfigLayer{
+				{Source: "--enable", Config: map[string]interface{}{"MD013": map[string]interface{}{"enabled": true}}},
+				{Source: "--disable", Config: map[string]interface{}{"MD041": map[string]interface{}{"enabled": false}}},
+			},
+		},
+		{
+			name:      "tags expand to their rules",
+			overrides: RuleOverrides{DisableTags: []string{"hard_tab"}},
+			expected: []service.ConfigLayer{
+				{Source: "--disable-tag", Config: map[string]interface{}{"MD010": map[string]interface{}{"enabled": false}}},
+			},
+		},
+		{
+			name: "rule options and switches",
+			overrides: RuleOverrides{Rules: []string{
+				`MD013={"line_length":120}`,
+				"MD041=false",
+			}},
+			expected: []service.ConfigLayer{
+				{Source: "--rule", Config: map[string]interface{}{
+					"MD013": map[string]interface{}{"line_length": float64(120)},
+					"MD041": map[string]interface{}{"enabled": false},
+				}},
+			},
+		},
+		{
+			name:          "unknown rule suggests the closest",
+			overrides:     RuleOverrides{Disable: []string{"line-lenght"}},
+			errorContains: `unknown rule "line-lenght" (did you mean "line-length"?)`,
+		},
+		{
+			name:          "unknown tag",
+			overrides:     RuleOverrides{EnableTags: []string{"nonexistent"}},
+			errorContains: `unknown tag "nonexistent"`,
+		},
+		{
+			name:          "rule without value",
+			overrides:     RuleOverrides{Rules: []string{"MD013"}},
+			errorContains: "expected NAME=VALUE",
+		},
+		{
+			name:          "rule with invalid JSON",
+			overrides:     RuleOverrides{Rules: []string{"MD013={line_length:120}"}},
+			errorContains: "value is not valid JSON",
+		},
+		{
+			name:          "rule with scalar value",
+			overrides:     RuleOverrides{Rules: []string{"MD013=120"}},
+			errorContains: "value must be true, false or a JSON object",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			layers, err := scenario.overrides.Layers(engine)
+			if scenario.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), scenario.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expected, layers)
+		})
+	}
+}
+
+func TestApplyRuleOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".markdownlint.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{
+  "MD013": {"line_length": 100, "tables": false},
+  "MD033": false
+}`), 0644))
+
+	t.Run("flags override configuration files", func(t *testing.T) {
+		configSource, err := loadConfigurationSourceWithOverrides(configPath, "", RuleOverrides{
+			Disable:    []string{"MD013"},
+			EnableTags: []string{"html"},
+			Rules:      []string{`MD013={"line_length":120}`},
+		})
+		require.NoError(t, err)
+
+		md013 := configSource.Config["MD013"].(map[string]interface{})
+		assert.Equal(t, false, md013["enabled"])
+		assert.Equal(t, float64(120), md013["line_length"])
+		assert.Equal(t, false, md013["tables"], "options from files are kept")
+		assert.Equal(t, map[string]interface{}{"enabled": true}, configSource.Config["MD033"])
+
+		assert.Equal(t, "--disable", configSource.Provenance["MD013.enabled"])
+		assert.Equal(t, "--rule", configSource.Provenance["MD013.line_length"])
+		assert.Equal(t, configPath, configSource.Provenance["MD013.tables"])
+		assert.Equal(t, "--enable-tag", configSource.Provenance["MD033.enabled"])
+
+		require.Len(t, configSource.Sources, 4)
+		assert.Equal(t, ConfigSourceTypeCustom, configSource.Sources[0].Type)
+		for _, source := range configSource.Sources[1:] {
+			assert.Equal(t, ConfigSourceTypeFlags, source.Type)
+		}
+		assert.Equal(t, "--disable MD013=false", sourceDisplayName(configSource.Sources[2]))
+	})
+
+	t.Run("flags apply without configuration files", func(t *testing.T) {
+		configSource, err := loadLintConfiguration("", "", true, RuleOverrides{Disable: []string{"MD041"}}, "")
+		require.NoError(t, err)
+		assert.False(t, configSource.IsDefault)
+		assert.Equal(t, true, configSource.Config["default"])
+		assert.Equal(t, map[string]interface{}{"enabled": false}, configSource.Config["MD041"])
+		assert.Equal(t, "built-in defaults", sourceDisplayName(configSource.Sources[0]))
+	})
+
+	t.Run("no flags leaves configuration untouched", func(t *testing.T) {
+		configSource, err := loadLintConfiguration("", "", true, RuleOverrides{}, "")
+		require.NoError(t, err)
+		assert.True(t, configSource.IsDefault)
+		assert.Len(t, configSource.Sources, 1)
+	})
+}
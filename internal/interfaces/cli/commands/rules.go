@@ -1,11 +1,15 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/gomdlint/gomdlint/internal/app/service"
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
 	"github.com/spf13/cobra"
 )
 
@@ -119,7 +123,7 @@ func showRuleInfo(ruleName string) error {
 
 	ruleOpt := ruleEngine.GetRuleByName(ruleName)
 	if ruleOpt.IsNone() {
-		return fmt.Errorf("rule '%s' not found", ruleName)
+		return usageError(fmt.Errorf("rule '%s' not found", ruleName))
 	}
 
 	rule := ruleOpt.Unwrap()
@@ -152,13 +156,10 @@ func showRuleInfo(ruleName string) error {
 	}
 	fmt.Printf("Status: %s\n", status)
 
-	// Configuration
-	config := rule.Config()
-	if len(config) > 0 {
-		fmt.Println("\nDefault Configuration:")
-		for key, value := range config {
-			fmt.Printf("  %s: %v\n", key, value)
-		}
+	// Parameters
+	if parameters := rule.Parameters(); len(parameters) > 0 {
+		fmt.Println("\nParameters:")
+		fmt.Print(formatRuleParameters(parameters))
 	}
 
 	// Documentation URL
@@ -169,6 +170,31 @@ func showRuleInfo(ruleName string) error {
 	return nil
 }
 
+// formatRuleParameters renders declared rule parameters as an aligned table.
+func formatRuleParameters(parameters []entity.RuleParameter) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tTYPE\tDEFAULT\tALLOWED\tDESCRIPTION")
+	for _, parameter := range parameters {
+		defaultValue := "-"
+		if parameter.Default != nil {
+			if data, err := json.Marshal(parameter.Default); err == nil {
+				defaultValue = string(data)
+			}
+		}
+
+		allowed := "-"
+		if len(parameter.Allowed) > 0 {
+			allowed = strings.Join(parameter.Allowed, "|")
+		}
+
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", parameter.Name, parameter.Type, defaultValue, allowed, parameter.Description)
+	}
+	w.Flush()
+
+	return buf.String()
+}
+
 func listTags() error {
 	ruleEngine, err := service.NewRuleEngine()
 	if err != nil {
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+)
+
+// stdinOptions holds the flags for reading a document from stdin.
+type stdinOptions struct {
+	Enabled  bool   // --stdin: read the document from stdin
+	Name     string // --stdin-name: identifier for the document in results
+	Filename string // --stdin-filename: treat the document as the file at this path
+}
+
+// addStdinFlags registers the stdin flags shared by lint, check and fix.
+func addStdinFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("stdin", false, "Read from stdin instead of files")
+	cmd.Flags().String("stdin-name", "stdin", "Name for stdin input")
+	cmd.Flags().String("stdin-filename", "", "Path of the stdin document, used for its name, configuration and ignore files")
+}
+
+// stdinOptionsFromFlags reads the stdin flags from cmd. Flags that are not
+// registered on the command are treated as unset.
+func stdinOptionsFromFlags(cmd *cobra.Command) (stdinOptions, error) {
+	enabled, _ := cmd.Flags().GetBool("stdin")
+	name, _ := cmd.Flags().GetString("stdin-name")
+	filename, _ := cmd.Flags().GetString("stdin-filename")
+
+	if filename != "" && !enabled {
+		return stdinOptions{}, errors.New("--stdin-filename requires --stdin")
+	}
+	if name == "" {
+		name = "stdin"
+	}
+	return stdinOptions{Enabled: enabled, Name: name, Filename: filename}, nil
+}
+
+// Identifier returns the name the stdin document is reported under.
+func (o stdinOptions) Identifier() string {
+	if o.Filename != "" {
+		return o.Filename
+	}
+	return o.Name
+}
+
+// Ignored reports whether the ignore files or --ignore patterns exclude the
+// stdin document's path. Without --stdin-filename nothing is ignored.
+func (o stdinOptions) Ignored(discovery service.FileDiscoveryOptions) (bool, error) {
+	if o.Filename == "" {
+		return false, nil
+	}
+	return service.IsIgnoredPath(o.Filename, discovery)
+}
+
+// readStdin reads the whole document from r.
+func readStdin(r io.Reader) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read from stdin: %w", err)
+	}
+	return string(content), nil
+}
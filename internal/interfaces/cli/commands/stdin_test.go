@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdinOptionsFromFlags(t *testing.T) {
+	scenarios := []struct {
+		name               string
+		flags              map[string]string
+		expected           stdinOptions
+		expectedIdentifier string
+		errorContains      string
+	}{
+		{
+			name:               "no flags",
+			expected:           stdinOptions{Name: "stdin"},
+			expectedIdentifier: "stdin",
+		},
+		{
+			name:               "custom name",
+			flags:              map[string]string{"stdin": "true", "stdin-name": "input"},
+			expected:           stdinOptions{Enabled: true, Name: "input"},
+			expectedIdentifier: "input",
+		},
+		{
+			name:               "filename names the document",
+			flags:              map[string]string{"stdin": "true", "stdin-filename": "docs/a.md"},
+			expected:           stdinOptions{Enabled: true, Name: "stdin", Filename: "docs/a.md"},
+			expectedIdentifier: "docs/a.md",
+		},
+		{
+			name:          "filename without stdin",
+			flags:         map[string]string{"stdin-filename": "docs/a.md"},
+			errorContains: "--stdin-filename requires --stdin",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cmd := NewCheckCommand()
+			for name, value := range scenario.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+
+			options, err := stdinOptionsFromFlags(cmd)
+			if scenario.errorContains != "" {
+				assert.ErrorContains(t, err, scenario.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expected, options)
+			assert.Equal(t, scenario.expectedIdentifier, options.Identifier())
+		})
+	}
+}
+
+// isolateUserConfig hides user and system configuration from the test.
+func isolateUserConfig(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_DIRS", t.TempDir())
+}
+
+func TestLoadLintConfiguration_StdinFilename(t *testing.T) {
+	isolateUserConfig(t)
+	tmpDir := createTempTestFiles(t, map[string]string{
+		".markdownlint.json":      `{"MD013": false}`,
+		"docs/.markdownlint.json": `{"MD041": false}`,
+	})
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(tmpDir))
+	defer os.Chdir(originalDir)
+
+	configSource, err := loadLintConfiguration("", "", false, RuleOverrides{}, "docs/new.md")
+	require.NoError(t, err)
+	assert.Equal(t, false, configSource.Config["MD041"], "configuration next to the stdin filename applies")
+
+	configSource, err = loadLintConfiguration("", "", false, RuleOverrides{}, "")
+	require.NoError(t, err)
+	assert.Equal(t, false, configSource.Config["MD013"])
+	assert.NotContains(t, configSource.Config, "MD041")
+}
+
+func TestFixCommand_Stdin(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		flags          map[string]interface{}
+		input          string
+		expectedOutput string
+		expectedStdErr string
+		expectedCode   int
+	}{
+		{
+			name:           "writes the fixed document",
+			flags:          map[string]interface{}{"stdin": true},
+			input:          "#Title\n- item\n",
+			expectedOutput: "# Title\n\n- item\n",
+		},
+		{
+			name:           "reports what could not be fixed",
+			flags:          map[string]interface{}{"stdin": true, "stdin-filename": "notes.md"},
+			input:          "Intro\n\n#Title\n",
+			expectedOutput: "Intro\n\n# Title\n",
+			expectedStdErr: "notes.md: 1: first-line-h1",
+			expectedCode:   ExitViolations,
+		},
+		{
+			name:           "uses configuration for the stdin filename",
+			flags:          map[string]interface{}{"stdin": true, "stdin-filename": "docs/notes.md"},
+			input:          "Intro\n\n#Title\n",
+			expectedOutput: "Intro\n\n# Title\n",
+		},
+		{
+			name:           "passes ignored documents through",
+			flags:          map[string]interface{}{"stdin": true, "stdin-filename": "vendor/notes.md"},
+			input:          "#Title\n",
+			expectedOutput: "#Title\n",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			isolateUserConfig(t)
+			tmpDir := createTempTestFiles(t, map[string]string{
+				".gomdlintignore":         "vendor/\n",
+				"docs/.markdownlint.json": `{"MD041": false}`,
+			})
+			originalDir, err := os.Getwd()
+			require.NoError(t, err)
+			require.NoError(t, os.Chdir(tmpDir))
+			defer os.Chdir(originalDir)
+
+			cmd := createFixTestCommand()
+			cmd.SetIn(strings.NewReader(scenario.input))
+			stdout, stderr, err := executeCommand(t, cmd, nil, scenario.flags)
+			assert.Equal(t, scenario.expectedCode, ExitCode(err))
+
+			assert.Equal(t, scenario.expectedOutput, stdout.String())
+			if scenario.expectedStdErr != "" {
+				assert.Contains(t, stderr.String(), scenario.expectedStdErr)
+			} else {
+				assert.Empty(t, stderr.String())
+			}
+		})
+	}
+}
+
+func TestStdin_ReadError(t *testing.T) {
+	isolateUserConfig(t)
+	readErr := errors.New("stdin closed")
+
+	for name, cmd := range map[string]*cobra.Command{
+		"lint": createTestCommand(),
+		"fix":  createFixTestCommand(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			cmd.SetIn(iotest.ErrReader(readErr))
+			_, _, err := executeCommand(t, cmd, nil, map[string]interface{}{"stdin": true})
+			require.ErrorIs(t, err, readErr)
+			assert.Equal(t, ExitInternal, ExitCode(err))
+		})
+	}
+}
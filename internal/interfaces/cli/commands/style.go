@@ -164,7 +164,7 @@ func showStyle(styleName string) error {
 	styleRegistry := service.NewStyleRegistry()
 	style, err := styleRegistry.GetStyle(styleName)
 	if err != nil {
-		return fmt.Errorf("style not found: %w", err)
+		return usageError(fmt.Errorf("style not found: %w", err))
 	}
 
 	data, err := json.MarshalIndent(style, "", "  ")
@@ -180,7 +180,7 @@ func applyStyle(styleName, outputPath string, merge bool) error {
 	styleRegistry := service.NewStyleRegistry()
 	style, err := styleRegistry.GetStyle(styleName)
 	if err != nil {
-		return fmt.Errorf("style not found: %w", err)
+		return usageError(fmt.Errorf("style not found: %w", err))
 	}
 
 			finalConfig := style
@@ -251,7 +251,7 @@ func validateStyle(styleName string) error {
 	styleRegistry := service.NewStyleRegistry()
 	style, err := styleRegistry.GetStyle(styleName)
 	if err != nil {
-		return fmt.Errorf("style not found: %w", err)
+		return usageError(fmt.Errorf("style not found: %w", err))
 	}
 
 	// Validate configuration
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gomdlint/gomdlint/internal/app/service"
+	"github.com/gomdlint/gomdlint/internal/app/service/spelling"
+	"github.com/spf13/cobra"
+)
+
+// NewWordsCommand creates the words command for managing the project word
+// list of the spelling rule.
+func NewWordsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "words",
+		Short: "Manage the project word list",
+		Long: `Manage the words the spelling rule (GMD004) accepts in addition to its
+dictionaries.
+
+The project word list is the first of the rule's word_lists, or else
+` + spelling.DefaultWordList + ` in the current directory or the nearest parent up to the
+repository root, where it is created if missing. It holds one word per
+line. Words in lowercase are accepted in any case; words with capitals, such
+as names, only as written or in uppercase.`,
+	}
+
+	cmd.AddCommand(newWordsAddCommand())
+
+	return cmd
+}
+
+func newWordsAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <word>...",
+		Short: "Add words to the project word list",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _ := cmd.Flags().GetString("file")
+			if path == "" {
+				var err error
+				if path, err = projectWordList(cmd); err != nil {
+					return usageError(err)
+				}
+			}
+
+			added, err := spelling.AddWords(path, args)
+			if err != nil {
+				return usageError(err)
+			}
+
+			out := cmd.OutOrStdout()
+			if len(added) == 0 {
+				fmt.Fprintf(out, "%s already lists every word\n", path)
+				return nil
+			}
+			for _, word := range added {
+				fmt.Fprintf(out, "Added %s to %s\n", word, path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String("file", "", "Word list file to update (default: the project word list)")
+
+	return cmd
+}
+
+// projectWordList returns the word list the spelling rule reads for
+// documents in the working directory: the first of its configured
+// word_lists, or the project list found like lint finds it.
+func projectWordList(cmd *cobra.Command) (string, error) {
+	configFile, _ := cmd.Flags().GetString("config")
+	profile, _ := cmd.Flags().GetString("profile")
+	noConfig, _ := cmd.Flags().GetBool("no-config")
+
+	if !noConfig {
+		configSource, err := loadConfigurationSourceFor(configFile, resolveProfileName(profile), "")
+		if err != nil {
+			return "", err
+		}
+		engine, err := service.NewRuleEngine()
+		if err != nil {
+			return "", fmt.Errorf("failed to create rule engine: %w", err)
+		}
+		// Errors in the settings of other rules do not matter here
+		_ = engine.ConfigureRules(configSource.Config)
+
+		var wordLists []string
+		switch lists := engine.GetRuleConfig("GMD004")["word_lists"].(type) {
+		case []string:
+			wordLists = lists
+		case []interface{}:
+			for _, list := range lists {
+				if path, ok := list.(string); ok {
+					wordLists = append(wordLists, path)
+				}
+			}
+		}
+		if len(wordLists) > 0 && wordLists[0] != spelling.DefaultWordList {
+			return wordLists[0], nil
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return spelling.ProjectWordList(wd)
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordsAddCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+
+	run := func(args ...string) (string, error) {
+		cmd := NewWordsCommand()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"add", "--file", path}, args...))
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	output, err := run("gomdlint", "Hugo")
+	require.NoError(t, err)
+	assert.Equal(t, "Added gomdlint to "+path+"\nAdded Hugo to "+path+"\n", output)
+
+	output, err = run("Hugo")
+	require.NoError(t, err)
+	assert.Equal(t, path+" already lists every word\n", output)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "gomdlint\nHugo\n", string(data))
+
+	_, err = run("two words")
+	require.Error(t, err)
+	assert.Equal(t, ExitUsage, ExitCode(err))
+}
+
+func TestWordsAddCommand_ProjectWordList(t *testing.T) {
+	repo, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	content := filepath.Join(repo, "content")
+	require.NoError(t, os.MkdirAll(content, 0o755))
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(content))
+	t.Cleanup(func() {
+		os.Chdir(oldDir)
+	})
+
+	run := func(args ...string) string {
+		cmd := NewWordsCommand()
+		cmd.PersistentFlags().StringP("config", "c", "", "Path to configuration file")
+		cmd.PersistentFlags().Bool("no-config", false, "Ignore configuration files")
+		cmd.PersistentFlags().String("profile", "", "Configuration profile to apply")
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"add"}, args...))
+		require.NoError(t, cmd.Execute())
+		return stdout.String()
+	}
+
+	// Without a list, it is created at the repository root, where lint
+	// finds it from any directory of the repository
+	projectList := filepath.Join(repo, ".gomdlint-words.txt")
+	assert.Equal(t, "Added Hugo to "+projectList+"\n", run("--no-config", "Hugo"))
+	assert.Equal(t, "Added gomdlint to "+projectList+"\n", run("--no-config", "gomdlint"))
+
+	// A configured list is resolved against the configuration file
+	config := filepath.Join(repo, ".markdownlint.yaml")
+	require.NoError(t, os.WriteFile(config, []byte("spelling:\n  word_lists: [docs/words.txt]\n"), 0o644))
+	configuredList := filepath.Join(repo, "docs", "words.txt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configuredList), 0o755))
+	assert.Equal(t, "Added Hugo to "+configuredList+"\n", run("--config", "../.markdownlint.yaml", "Hugo"))
+
+	data, err := os.ReadFile(projectList)
+	require.NoError(t, err)
+	assert.Equal(t, "gomdlint\nHugo\n", string(data))
+}
@@ -0,0 +1,265 @@
+// Package slug generates heading fragment identifiers the way markdown
+// renderers do, so that links to headings can be checked against the
+// anchors the published page will actually have.
+package slug
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Style names accepted by New.
+const (
+	StyleGitHub         = "github"
+	StyleGitLab         = "gitlab"
+	StyleHugo           = "hugo"
+	StylePythonMarkdown = "python-markdown"
+	StyleMkDocs         = "mkdocs" // Python-Markdown toc, as used by MkDocs
+)
+
+// Slugger turns heading text into fragment identifiers. Renderers make the
+// identifiers of repeated headings unique, so a Slugger keeps state: use a
+// new one for every document and call Slug for its headings in order.
+type Slugger interface {
+	// Slug returns the identifier of the next heading with the given plain
+	// text (see PlainText), or "" when the renderer gives it none.
+	Slug(text string) string
+}
+
+// Styles returns the style names accepted by New.
+func Styles() []string {
+	return []string{StyleGitHub, StyleGitLab, StyleHugo, StylePythonMarkdown, StyleMkDocs}
+}
+
+// New returns a Slugger for a document rendered by the named style.
+func New(style string) (Slugger, error) {
+	switch strings.ToLower(style) {
+	case StyleGitHub, "":
+		return &githubSlugger{occurrences: make(map[string]int)}, nil
+	case StyleGitLab:
+		return &gitlabSlugger{headers: make(map[string]int)}, nil
+	case StyleHugo:
+		return &hugoSlugger{ids: make(map[string]bool)}, nil
+	case StylePythonMarkdown, StyleMkDocs:
+		return &pythonMarkdownSlugger{ids: make(map[string]bool)}, nil
+	default:
+		return nil, fmt.Errorf("unknown slug style %q (expected one of %s)", style, strings.Join(Styles(), ", "))
+	}
+}
+
+// githubSlugger follows github-slugger, which GitHub uses for rendered
+// markdown: lowercase, drop everything but letters, marks, numbers,
+// connector punctuation, hyphens and spaces, then turn each space into a
+// hyphen. Repeats get "-1", "-2", … suffixes.
+type githubSlugger struct {
+	occurrences map[string]int
+}
+
+func (s *githubSlugger) Slug(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsNumber(r) || unicode.Is(unicode.Pc, r):
+			b.WriteRune(r)
+		}
+	}
+
+	original := b.String()
+	result := original
+	for {
+		if _, exists := s.occurrences[result]; !exists {
+			break
+		}
+		s.occurrences[original]++
+		result = original + "-" + strconv.Itoa(s.occurrences[original])
+	}
+	s.occurrences[result] = 0
+	return result
+}
+
+// gitlabSlugger follows GitLab's string_to_anchor: strip, lowercase, drop
+// everything but word characters, hyphens and spaces, turn spaces into
+// hyphens and squeeze runs of hyphens. All-digit identifiers are prefixed
+// with "anchor-". Repeats get "-1", "-2", … suffixes.
+type gitlabSlugger struct {
+	headers map[string]int
+}
+
+var gitlabHyphensRegex = regexp.MustCompile(`-{2,}`)
+var gitlabDigitsRegex = regexp.MustCompile(`^[0-9]+$`)
+
+func (s *gitlabSlugger) Slug(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || unicode.IsLetter(r) || unicode.IsMark(r) || unicode.Is(unicode.Nd, r) || unicode.Is(unicode.Pc, r):
+			b.WriteRune(r)
+		}
+	}
+
+	id := gitlabHyphensRegex.ReplaceAllString(b.String(), "-")
+	if gitlabDigitsRegex.MatchString(id) {
+		id = "anchor-" + id
+	}
+	if id == "" {
+		return ""
+	}
+
+	count := s.headers[id]
+	s.headers[id]++
+	if count > 0 {
+		return id + "-" + strconv.Itoa(count)
+	}
+	return id
+}
+
+// hugoSlugger follows Hugo's default "github" autoHeadingIDType: trim,
+// lowercase letters and digits, keep underscores, turn spaces and hyphens
+// into hyphens and drop everything else. Empty identifiers become "heading"
+// and repeats get "-1", "-2", … suffixes that skip identifiers in use.
+type hugoSlugger struct {
+	ids map[string]bool
+}
+
+func (s *hugoSlugger) Slug(text string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(text) {
+		switch {
+		case r == '-' || r == ' ':
+			b.WriteRune('-')
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+
+	id := b.String()
+	if id == "" {
+		id = "heading"
+	}
+	if s.ids[id] {
+		for i := 1; ; i++ {
+			candidate := id + "-" + strconv.Itoa(i)
+			if !s.ids[candidate] {
+				id = candidate
+				break
+			}
+		}
+	}
+	s.ids[id] = true
+	return id
+}
+
+// pythonMarkdownSlugger follows the slugify and unique functions of the
+// Python-Markdown toc extension, which MkDocs uses: reduce accented Latin
+// letters to ASCII and drop other non-ASCII, drop everything but word
+// characters, whitespace and hyphens, strip, lowercase and collapse runs of
+// whitespace and hyphens into one hyphen. Repeats, and empty identifiers,
+// get "_1", "_2", … suffixes.
+type pythonMarkdownSlugger struct {
+	ids map[string]bool
+}
+
+var pythonSeparatorRegex = regexp.MustCompile(`[-\s]+`)
+var pythonCountRegex = regexp.MustCompile(`^(.*)_([0-9]+)$`)
+
+func (s *pythonMarkdownSlugger) Slug(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if decomposed, exists := latinDecompositions[r]; exists {
+			b.WriteString(decomposed)
+			continue
+		}
+		if r < unicode.MaxASCII && (r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r)) {
+			b.WriteRune(r)
+		}
+	}
+
+	id := strings.ToLower(strings.TrimSpace(b.String()))
+	id = pythonSeparatorRegex.ReplaceAllString(id, "-")
+
+	for s.ids[id] || id == "" {
+		if match := pythonCountRegex.FindStringSubmatch(id); match != nil {
+			count, _ := strconv.Atoi(match[2])
+			id = match[1] + "_" + strconv.Itoa(count+1)
+		} else {
+			id += "_1"
+		}
+	}
+	s.ids[id] = true
+	return id
+}
+
+// latinDecompositions maps Latin letters with diacritics to the ASCII that
+// remains after NFKD normalization, which Python-Markdown applies before
+// dropping non-ASCII characters.
+var latinDecompositions = func() map[rune]string {
+	bases := map[string]string{
+		"A": "ÀÁÂÃÄÅĀĂĄǍǞǠǺȀȂȦ", "C": "ÇĆĈĊČ", "D": "Ď", "DZ": "ǄǱ", "Dz": "ǅǲ",
+		"E": "ÈÉÊËĒĔĖĘĚȄȆȨ", "G": "ĜĞĠĢǦǴ", "H": "ĤȞ", "I": "ÌÍÎÏĨĪĬĮİǏȈȊ", "IJ": "Ĳ",
+		"J": "Ĵ", "K": "ĶǨ", "L": "ĹĻĽĿ", "LJ": "Ǉ", "Lj": "ǈ", "N": "ÑŃŅŇǸ", "NJ": "Ǌ",
+		"Nj": "ǋ", "O": "ÒÓÔÕÖŌŎŐƠǑǪǬȌȎȪȬȮȰ", "R": "ŔŖŘȐȒ", "S": "ŚŜŞŠȘ", "T": "ŢŤȚ",
+		"U": "ÙÚÛÜŨŪŬŮŰŲƯǓǕǗǙǛȔȖ", "W": "Ŵ", "Y": "ÝŶŸȲ", "Z": "ŹŻŽ",
+		"a": "àáâãäåāăąǎǟǡǻȁȃȧ", "c": "çćĉċč", "d": "ď", "dz": "ǆǳ",
+		"e": "èéêëēĕėęěȅȇȩ", "g": "ĝğġģǧǵ", "h": "ĥȟ", "i": "ìíîïĩīĭįǐȉȋ", "ij": "ĳ",
+		"j": "ĵǰ", "k": "ķǩ", "l": "ĺļľŀ", "lj": "ǉ", "n": "ñńņňŉǹ", "nj": "ǌ",
+		"o": "òóôõöōŏőơǒǫǭȍȏȫȭȯȱ", "r": "ŕŗřȑȓ", "s": "śŝşšſș", "t": "ţťț",
+		"u": "ùúûüũūŭůűųưǔǖǘǚǜȕȗ", "w": "ŵ", "y": "ýÿŷȳ", "z": "źżž",
+	}
+
+	decompositions := make(map[rune]string)
+	for base, letters := range bases {
+		for _, r := range letters {
+			decompositions[r] = base
+		}
+	}
+	return decompositions
+}()
+
+var (
+	plainImageRegex      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	plainLinkRegex       = regexp.MustCompile(`\[([^\]]*)\](?:\([^)]*\)|\[[^\]]*\])`)
+	plainCodeSpanRegex   = regexp.MustCompile("(`+)(.+?)(`+)")
+	plainHTMLTagRegex    = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	plainUnderscoreRegex = regexp.MustCompile(`(^|[^\p{L}\p{N}_])_+|_+([^\p{L}\p{N}_]|$)`)
+	plainEscapeRegex     = regexp.MustCompile(`\\([!-/:-@\[-` + "`" + `{-~])`)
+)
+
+// PlainText returns the text a renderer shows for the inline markdown of a
+// heading, which is what slugs are computed from: link and image text
+// without destinations, code span contents, and no emphasis markers, HTML
+// tags, escapes or entities.
+func PlainText(markdown string) string {
+	// Keep code span contents and escaped characters literally while the
+	// rest is cleaned up
+	var literals []string
+	protect := func(literal string) string {
+		literals = append(literals, literal)
+		return "\x00" + strconv.Itoa(len(literals)-1) + "\x00"
+	}
+	text := plainCodeSpanRegex.ReplaceAllStringFunc(markdown, func(span string) string {
+		return protect(strings.TrimSpace(plainCodeSpanRegex.FindStringSubmatch(span)[2]))
+	})
+	text = plainEscapeRegex.ReplaceAllStringFunc(text, func(escape string) string {
+		return protect(escape[1:])
+	})
+
+	text = plainImageRegex.ReplaceAllString(text, "$1")
+	text = plainLinkRegex.ReplaceAllString(text, "$1")
+	text = plainHTMLTagRegex.ReplaceAllString(text, "")
+	text = strings.ReplaceAll(text, "*", "")
+	text = plainUnderscoreRegex.ReplaceAllString(text, "$1$2")
+	text = html.UnescapeString(text)
+
+	for i, literal := range literals {
+		text = strings.Replace(text, "\x00"+strconv.Itoa(i)+"\x00", literal, 1)
+	}
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,73 @@
+package slug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSluggers(t *testing.T) {
+	headings := []string{"Hello World", "Foo & Bar", "C++ Primer", "Über uns", "🎉 Release", "snake_case", "2024", "Usage", "Usage", "Usage 1", "🎉"}
+
+	expected := map[string][]string{
+		StyleGitHub:         {"hello-world", "foo--bar", "c-primer", "über-uns", "-release", "snake_case", "2024", "usage", "usage-1", "usage-1-1", ""},
+		StyleGitLab:         {"hello-world", "foo-bar", "c-primer", "über-uns", "-release", "snake_case", "anchor-2024", "usage", "usage-1", "usage-1", ""},
+		StyleHugo:           {"hello-world", "foo--bar", "c-primer", "über-uns", "-release", "snake_case", "2024", "usage", "usage-1", "usage-1-1", "heading"},
+		StylePythonMarkdown: {"hello-world", "foo-bar", "c-primer", "uber-uns", "release", "snake_case", "2024", "usage", "usage_1", "usage-1", "_1"},
+	}
+
+	for style, slugs := range expected {
+		t.Run(style, func(t *testing.T) {
+			slugger, err := New(style)
+			require.NoError(t, err)
+
+			var actual []string
+			for _, heading := range headings {
+				actual = append(actual, slugger.Slug(heading))
+			}
+			assert.Equal(t, slugs, actual)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, style := range Styles() {
+		_, err := New(style)
+		assert.NoError(t, err, style)
+	}
+
+	slugger, err := New("")
+	require.NoError(t, err)
+	assert.IsType(t, &githubSlugger{}, slugger, "GitHub is the default")
+
+	_, err = New("jekyll")
+	assert.ErrorContains(t, err, `unknown slug style "jekyll"`)
+}
+
+func TestPythonMarkdownSlugger_Repeats(t *testing.T) {
+	slugger, err := New(StyleMkDocs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "usage", slugger.Slug("Usage"))
+	assert.Equal(t, "usage_1", slugger.Slug("Usage"))
+	assert.Equal(t, "usage_2", slugger.Slug("Usage"))
+	assert.Equal(t, "step_2", slugger.Slug("Step_2"))
+	assert.Equal(t, "step_3", slugger.Slug("Step_2"), "numbered identifiers are incremented")
+}
+
+func TestPlainText(t *testing.T) {
+	tests := map[string]string{
+		"Using `go test` **fast**":           "Using go test fast",
+		"[Link](http://x) and ![img](a.png)": "Link and img",
+		"See [the docs][docs]":               "See the docs",
+		"__init__ and snake_case":            "init and snake_case",
+		`\_private \*not emphasis\*`:         "_private *not emphasis*",
+		"A &amp; B <em>x</em>":               "A & B x",
+		"Code `**kept**` literally":          "Code **kept** literally",
+	}
+
+	for markdown, expected := range tests {
+		assert.Equal(t, expected, PlainText(markdown), markdown)
+	}
+}
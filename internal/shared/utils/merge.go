@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"reflect"
+	"strings"
 )
 
 // DeepMergeConfig performs deep merging of configuration maps.
@@ -168,6 +169,7 @@ const (
 	ConfigSourceUser    ConfigSourceType = "user"
 	ConfigSourceProject ConfigSourceType = "project"
 	ConfigSourceCLI     ConfigSourceType = "cli"
+	ConfigSourceFlags   ConfigSourceType = "flags" // Rule selection flags, above every file
 )
 
 // NewConfigurationMerger creates a new configuration merger
@@ -203,6 +205,8 @@ func getSourcePriority(sourceType ConfigSourceType) int {
 		return 30
 	case ConfigSourceCLI:
 		return 40
+	case ConfigSourceFlags:
+		return 50
 	default:
 		return 0
 	}
@@ -216,19 +220,7 @@ func (cm *ConfigurationMerger) Merge() map[string]interface{} {
 
 	// Sort sources by priority (lowest to highest)
 	// This ensures higher priority sources override lower priority ones
-	sortedSources := make([]ConfigSource, len(cm.sources))
-	copy(sortedSources, cm.sources)
-
-	// Simple insertion sort by priority
-	for i := 1; i < len(sortedSources); i++ {
-		key := sortedSources[i]
-		j := i - 1
-		for j >= 0 && sortedSources[j].Priority > key.Priority {
-			sortedSources[j+1] = sortedSources[j]
-			j--
-		}
-		sortedSources[j+1] = key
-	}
+	sortedSources := cm.sortedSources()
 
 	// Extract configs in priority order
 	configs := make([]map[string]interface{}, len(sortedSources))
@@ -246,13 +238,94 @@ func (cm *ConfigurationMerger) GetSources() []ConfigSource {
 
 // GetSourcePaths returns the paths of all configuration sources in priority order
 func (cm *ConfigurationMerger) GetSourcePaths() []string {
-	sources := cm.GetSources()
-	paths := make([]string, 0, len(sources))
+	paths := make([]string, 0, len(cm.sources))
 
 	// Sort by priority for consistent output
-	sortedSources := make([]ConfigSource, len(sources))
-	copy(sortedSources, sources)
+	sortedSources := cm.sortedSources()
+
+	for _, source := range sortedSources {
+		if source.Path != "" {
+			paths = append(paths, fmt.Sprintf("%s (%s)", source.Path, source.Type))
+		}
+	}
+
+	return paths
+}
+
+// Provenance reports which source supplied each leaf value of the merged
+// configuration. Keys are dot-separated paths (e.g. "MD013.line_length") and
+// values are the source path, or the source type when the source has no path.
+func (cm *ConfigurationMerger) Provenance() map[string]string {
+	provenance := make(map[string]string)
+
+	for _, source := range cm.sortedSources() {
+		origin := source.Path
+		if origin == "" {
+			origin = string(source.Type)
+		}
+		recordProvenance(provenance, "", source.Config, origin)
+	}
+
+	return provenance
+}
+
+// recordProvenance assigns origin to every leaf below prefix, discarding
+// entries that the new value replaces.
+func recordProvenance(provenance map[string]string, prefix string, config map[string]interface{}, origin string) {
+	for key, value := range config {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
 
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			clearProvenance(provenance, path)
+			provenance[path] = origin
+			continue
+		}
+
+		// An empty map only shows up when nothing was nested there before
+		if len(nested) == 0 {
+			if !hasNestedProvenance(provenance, path) {
+				provenance[path] = origin
+			}
+			continue
+		}
+
+		// A map replacing a scalar drops the scalar's entry
+		delete(provenance, path)
+		recordProvenance(provenance, path, nested, origin)
+	}
+}
+
+// clearProvenance removes path and everything nested below it.
+func clearProvenance(provenance map[string]string, path string) {
+	delete(provenance, path)
+	for key := range provenance {
+		if strings.HasPrefix(key, path+".") {
+			delete(provenance, key)
+		}
+	}
+}
+
+// hasNestedProvenance reports whether any entry lives below path.
+func hasNestedProvenance(provenance map[string]string, path string) bool {
+	for key := range provenance {
+		if strings.HasPrefix(key, path+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedSources returns the sources ordered from lowest to highest priority.
+// Sources with equal priority keep the order in which they were added.
+func (cm *ConfigurationMerger) sortedSources() []ConfigSource {
+	sortedSources := make([]ConfigSource, len(cm.sources))
+	copy(sortedSources, cm.sources)
+
+	// Simple insertion sort by priority (stable)
 	for i := 1; i < len(sortedSources); i++ {
 		key := sortedSources[i]
 		j := i - 1
@@ -263,11 +336,5 @@ func (cm *ConfigurationMerger) GetSourcePaths() []string {
 		sortedSources[j+1] = key
 	}
 
-	for _, source := range sortedSources {
-		if source.Path != "" {
-			paths = append(paths, fmt.Sprintf("%s (%s)", source.Path, source.Type))
-		}
-	}
-
-	return paths
+	return sortedSources
 }
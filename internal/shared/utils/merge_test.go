@@ -422,6 +422,58 @@ func TestDeepCopyValue(t *testing.T) {
 }
 
 // Benchmark tests
+func TestConfigurationMerger_Provenance(t *testing.T) {
+	merger := NewConfigurationMerger()
+	merger.AddSource(map[string]interface{}{
+		"default": true,
+		"MD013":   map[string]interface{}{"line_length": 80, "tables": false},
+		"MD033":   map[string]interface{}{"allowed_elements": []interface{}{"br"}},
+	}, "style:strict", ConfigSourceProject)
+	merger.AddSource(map[string]interface{}{
+		"MD013": map[string]interface{}{"line_length": 120},
+		"MD033": false,
+	}, "/project/.markdownlint.json", ConfigSourceProject)
+	merger.AddSource(map[string]interface{}{
+		"MD041": false,
+	}, "", ConfigSourceCLI)
+	merger.AddSource(map[string]interface{}{
+		"MD013": map[string]interface{}{"code_blocks": true},
+	}, "/etc/gomdlint/config.json", ConfigSourceSystem)
+
+	expected := map[string]string{
+		"default":           "style:strict",
+		"MD013.line_length": "/project/.markdownlint.json",
+		"MD013.tables":      "style:strict",
+		"MD013.code_blocks": "/etc/gomdlint/config.json",
+		"MD033":             "/project/.markdownlint.json",
+		"MD041":             "cli",
+	}
+
+	assert.Equal(t, expected, merger.Provenance())
+}
+
+func TestConfigurationMerger_FlagsOverrideCLI(t *testing.T) {
+	merger := NewConfigurationMerger()
+	merger.AddSource(map[string]interface{}{
+		"MD013": map[string]interface{}{"enabled": false},
+	}, "--disable", ConfigSourceFlags)
+	merger.AddSource(map[string]interface{}{
+		"MD013": map[string]interface{}{"line_length": 100, "enabled": true},
+	}, "custom.json", ConfigSourceCLI)
+	merger.AddSource(map[string]interface{}{
+		"MD013": true,
+	}, "/project/.markdownlint.json", ConfigSourceProject)
+
+	merged := merger.Merge()
+	assert.Equal(t, map[string]interface{}{"line_length": 100, "enabled": false}, merged["MD013"])
+	assert.Equal(t, "--disable", merger.Provenance()["MD013.enabled"])
+	assert.Equal(t, []string{
+		"/project/.markdownlint.json (project)",
+		"custom.json (cli)",
+		"--disable (flags)",
+	}, merger.GetSourcePaths())
+}
+
 func BenchmarkDeepMergeConfig_Simple(b *testing.B) {
 	config1 := map[string]interface{}{
 		"key1": "value1",
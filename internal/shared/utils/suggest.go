@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"strings"
+)
+
+// SuggestClosest returns the candidate that most resembles input, or an empty
+// string when nothing is close enough to be a plausible typo. Matching ignores
+// case and treats '-' and '_' as equivalent.
+func SuggestClosest(input string, candidates []string) string {
+	normalized := normalizeSuggestionKey(input)
+	if normalized == "" {
+		return ""
+	}
+
+	best := ""
+	bestDistance := -1
+	for _, candidate := range candidates {
+		if candidate == input {
+			continue
+		}
+
+		distance := LevenshteinDistance(normalized, normalizeSuggestionKey(candidate))
+		if bestDistance < 0 || distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+
+	// Allow roughly one edit per three characters, but at least two
+	maxDistance := len(normalized) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+	if bestDistance < 0 || bestDistance > maxDistance {
+		return ""
+	}
+	return best
+}
+
+// normalizeSuggestionKey folds case and separator differences.
+func normalizeSuggestionKey(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), "-", "_")
+}
+
+// LevenshteinDistance returns the number of single-character insertions,
+// deletions and substitutions needed to turn a into b.
+func LevenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 {
+		return len(rb)
+	}
+	if len(rb) == 0 {
+		return len(ra)
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+// minInt returns the smallest of the given values.
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshteinDistance(t *testing.T) {
+	scenarios := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"line_length", "line_lenght", 2},
+		{"MD013", "MD013", 0},
+		{"héading", "heading", 1},
+	}
+
+	for _, scenario := range scenarios {
+		assert.Equal(t, scenario.expected, LevenshteinDistance(scenario.a, scenario.b), "%q -> %q", scenario.a, scenario.b)
+	}
+}
+
+func TestSuggestClosest(t *testing.T) {
+	candidates := []string{"line_length", "heading_line_length", "code_blocks", "tables", "headings"}
+
+	scenarios := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "dash instead of underscore", input: "line-length", expected: "line_length"},
+		{name: "case difference", input: "Tables", expected: "tables"},
+		{name: "transposed letters", input: "line_lenght", expected: "line_length"},
+		{name: "missing letter", input: "heading", expected: "headings"},
+		{name: "nothing close", input: "completely_different", expected: ""},
+		{name: "empty input", input: "", expected: ""},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, SuggestClosest(scenario.input, candidates))
+		})
+	}
+}
@@ -164,12 +164,17 @@ func (x *XDGPaths) GetConfigSearchPaths() []string {
 func GetConfigFilenames() []string {
 	return []string{
 		"config.json",
+		"config.jsonc",
 		"config.yaml",
 		"config.yml",
+		"config.toml",
 		".gomdlint.json",
+		".gomdlint.jsonc",
 		".gomdlint.yaml",
 		".gomdlint.yml",
+		".gomdlint.toml",
 		// Legacy markdownlint compatibility
+		".markdownlint.jsonc",
 		".markdownlint.json",
 		".markdownlint.yaml",
 		".markdownlint.yml",
@@ -234,19 +239,58 @@ const (
 // FindAllConfigFiles searches for all configuration files in the XDG hierarchy.
 // Returns configs in priority order: project -> user -> system (highest to lowest priority)
 func FindAllConfigFiles(appName string) ([]ConfigFileLocation, error) {
+	projectConfig := ""
+	if cwd, err := os.Getwd(); err == nil {
+		projectConfig = findConfigInDirectory(cwd, GetConfigFilenames())
+	}
+	return findAllConfigFiles(appName, projectConfig)
+}
+
+// FindAllConfigFilesFor searches for the configuration files that apply to a
+// file at path, which need not exist. The project configuration is the
+// nearest one in path's directory or its ancestors, up to the current
+// directory when path is inside it; user and system configs are found as in
+// FindAllConfigFiles.
+func FindAllConfigFilesFor(appName, path string) ([]ConfigFileLocation, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stop := ""
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, absPath); err == nil && !strings.HasPrefix(rel, "..") {
+			stop = cwd
+		}
+	}
+
+	projectConfig := ""
+	filenames := GetConfigFilenames()
+	for dir := filepath.Dir(absPath); ; dir = filepath.Dir(dir) {
+		if projectConfig = findConfigInDirectory(dir, filenames); projectConfig != "" {
+			break
+		}
+		if dir == stop || dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	return findAllConfigFiles(appName, projectConfig)
+}
+
+// findAllConfigFiles lists projectConfig, if any, followed by the XDG user
+// and system configs.
+func findAllConfigFiles(appName, projectConfig string) ([]ConfigFileLocation, error) {
 	xdg := GetXDGPaths(appName)
 	filenames := GetConfigFilenames()
 	var found []ConfigFileLocation
 
-	// 1. Check current directory (project config - highest priority)
-	if cwd, err := os.Getwd(); err == nil {
-		if projectConfig := findConfigInDirectory(cwd, filenames); projectConfig != "" {
-			found = append(found, ConfigFileLocation{
-				Path:   projectConfig,
-				Type:   ConfigTypeProject,
-				Source: "project directory (legacy)",
-			})
-		}
+	// 1. Project config - highest priority
+	if projectConfig != "" {
+		found = append(found, ConfigFileLocation{
+			Path:   projectConfig,
+			Type:   ConfigTypeProject,
+			Source: "project directory (legacy)",
+		})
 	}
 
 	// 2. Check XDG user config directory
@@ -415,6 +459,21 @@ func EnsureConfigDir(appName string) (string, error) {
 	return xdg.ConfigHome, nil
 }
 
+// EnsureCacheDir creates the XDG cache directory if it doesn't exist.
+// Returns the path to the cache directory.
+func EnsureCacheDir(appName string) (string, error) {
+	xdg := GetXDGPaths(appName)
+	if xdg.CacheHome == "" {
+		return "", os.ErrNotExist
+	}
+
+	if err := os.MkdirAll(xdg.CacheHome, 0755); err != nil {
+		return "", err
+	}
+
+	return xdg.CacheHome, nil
+}
+
 // GetDefaultConfigPath returns the default path where a new config file should be created.
 // It prefers the XDG config directory over the current directory.
 func GetDefaultConfigPath(appName string) (string, error) {
@@ -434,6 +493,7 @@ func GetDefaultConfigPath(appName string) (string, error) {
 func IsLegacyConfigFile(configPath string) bool {
 	filename := filepath.Base(configPath)
 	legacyFiles := []string{
+		".markdownlint.jsonc",
 		".markdownlint.json",
 		".markdownlint.yaml",
 		".markdownlint.yml",
@@ -550,3 +550,41 @@ func BenchmarkFindConfigFile(b *testing.B) {
 		FindConfigFile(paths, "config.json")
 	}
 }
+
+func TestFindAllConfigFilesFor(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_DIRS", t.TempDir())
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	for _, dir := range []string{"docs/api", "other"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".markdownlint.json"), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "docs", ".markdownlint.yaml"), []byte("{}"), 0644))
+
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(root))
+	defer os.Chdir(originalDir)
+
+	scenarios := map[string]string{
+		"docs/api/new.md":  filepath.Join(root, "docs", ".markdownlint.yaml"),
+		"docs/guide.md":    filepath.Join(root, "docs", ".markdownlint.yaml"),
+		"other/missing.md": filepath.Join(root, ".markdownlint.json"),
+		"README.md":        filepath.Join(root, ".markdownlint.json"),
+	}
+	for path, expected := range scenarios {
+		found, err := FindAllConfigFilesFor("gomdlint", path)
+		require.NoError(t, err)
+		require.NotEmpty(t, found, path)
+		assert.Equal(t, expected, found[0].Path, path)
+		assert.Equal(t, ConfigTypeProject, found[0].Type, path)
+	}
+
+	// The search stops at the working directory for paths inside it
+	require.NoError(t, os.Chdir(filepath.Join(root, "other")))
+	found, err := FindAllConfigFilesFor("gomdlint", "missing.md")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
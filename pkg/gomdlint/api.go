@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/gomdlint/gomdlint/internal/app/service"
 	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
 )
 
 // Version returns the version of the gomdlint library.
@@ -27,10 +29,18 @@ type LintOptions struct {
 	NoInlineConfig     bool   `json:"noInlineConfig,omitempty"`     // Disable inline config
 	ResultVersion      int    `json:"resultVersion,omitempty"`      // Result format version
 	HandleRuleFailures bool   `json:"handleRuleFailures,omitempty"` // Handle rule failures
+	FailFast           bool   `json:"failFast,omitempty"`           // Stop after the first file with an error
+	Timing             bool   `json:"timing,omitempty"`             // Record the time spent in each rule
+	Concurrency        int    `json:"concurrency,omitempty"`        // Files linted at once (0: one per CPU)
 
 	// Custom rules and parsers
 	CustomRules   []interface{} `json:"customRules,omitempty"`
 	ConfigParsers []interface{} `json:"configParsers,omitempty"`
+
+	// Persistent result cache
+	Cache         bool   `json:"cache,omitempty"`         // Reuse results for unchanged files across runs
+	CacheLocation string `json:"cacheLocation,omitempty"` // Cache directory (default: XDG cache directory)
+	CacheStrategy string `json:"cacheStrategy,omitempty"` // "content" (default) or "metadata"
 }
 
 // LintResult represents the result of a linting operation.
@@ -43,6 +53,30 @@ type LintResult struct {
 	TotalFiles      int `json:"totalFiles"`
 	TotalErrors     int `json:"totalErrors"`
 	TotalWarnings   int `json:"totalWarnings"`
+
+	// Time spent in each linting phase and, with LintOptions.Timing, in
+	// each rule by primary name
+	Timings     PhaseTimings          `json:"-"`
+	RuleTimings map[string]RuleTiming `json:"-"`
+
+	// Problems with the configuration that did not stop linting, such as
+	// rule parameters that are not declared
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// PhaseTimings records the time spent reading files, parsing documents and
+// running rules. Durations are summed across documents, so with concurrent
+// workers they can exceed the elapsed time.
+type PhaseTimings struct {
+	Read  time.Duration // Reading files
+	Parse time.Duration // Parsing documents into tokens
+	Rules time.Duration // Running rules against the parsed documents
+}
+
+// RuleTiming records the time a rule spent across documents.
+type RuleTiming struct {
+	Duration  time.Duration // Total time spent in the rule
+	Documents int           // Number of documents the rule checked
 }
 
 // Violation represents a single linting violation.
@@ -55,6 +89,7 @@ type Violation struct {
 	ErrorContext    string   `json:"errorContext,omitempty"`
 	ErrorRange      []int    `json:"errorRange,omitempty"` // [column, length]
 	FixInfo         *FixInfo `json:"fixInfo,omitempty"`
+	Severity        string   `json:"severity,omitempty"` // "error" or "warning"
 }
 
 // FixInfo represents auto-fix information for a violation.
@@ -67,6 +102,16 @@ type FixInfo struct {
 	ReplaceText  *string `json:"replaceText,omitempty"`
 }
 
+// IsFailure reports whether the violation records a failure to lint, such as
+// an unreadable file or a rule that failed, rather than a problem in the
+// content.
+func (v Violation) IsFailure() bool {
+	if v.RuleDescription == value.RuleFailureDescription {
+		return true
+	}
+	return len(v.RuleNames) > 0 && (v.RuleNames[0] == value.FileErrorRuleName || v.RuleNames[0] == value.StringErrorRuleName)
+}
+
 // String returns a formatted string representation of the result.
 func (lr *LintResult) String() string {
 	return lr.ToFormattedString(false)
@@ -129,6 +174,27 @@ func (lr *LintResult) ToFormattedString(useAliases bool) string {
 	return output
 }
 
+// Recount recomputes the summary totals from Results, e.g. after violations
+// have been filtered out.
+func (lr *LintResult) Recount() {
+	lr.TotalFiles = len(lr.Results)
+	lr.TotalViolations = 0
+	lr.TotalErrors = 0
+	lr.TotalWarnings = 0
+	for _, violations := range lr.Results {
+		lr.TotalViolations += len(violations)
+		for _, violation := range violations {
+			switch violation.Severity {
+			case "warning":
+				lr.TotalWarnings++
+			case "info":
+			default:
+				lr.TotalErrors++
+			}
+		}
+	}
+}
+
 // ToJSON returns the result as a JSON string.
 func (lr *LintResult) ToJSON() (string, error) {
 	data, err := json.Marshal(lr.Results)
@@ -193,6 +259,24 @@ func LintFiles(ctx context.Context, filenames []string, options ...LintOptions)
 	return Lint(ctx, opts)
 }
 
+// ApplyFixes applies the fixes attached to violations to content, like
+// markdownlint's applyFixes, and returns the fixed content with the number
+// of fixes applied. Overlapping fixes are skipped; lint the result again to
+// pick up fixes that depend on earlier ones.
+func ApplyFixes(content string, violations []Violation) (string, int) {
+	internalViolations := make([]value.Violation, 0, len(violations))
+	for _, v := range violations {
+		if v.FixInfo == nil {
+			continue
+		}
+		internalViolations = append(internalViolations, value.Violation{
+			LineNumber: v.LineNumber,
+			FixInfo:    functional.Some(convertToInternalFixInfo(v.FixInfo)),
+		})
+	}
+	return service.ApplyFixes(content, internalViolations)
+}
+
 // GetVersion returns the version of the gomdlint library.
 func GetVersion() string {
 	return Version
@@ -206,7 +290,15 @@ func convertToInternalOptions(options LintOptions) *value.LintOptions {
 		WithConfig(options.Config).
 		WithNoInlineConfig(options.NoInlineConfig).
 		WithResultVersion(options.ResultVersion).
-		WithHandleRuleFailures(options.HandleRuleFailures)
+		WithHandleRuleFailures(options.HandleRuleFailures).
+		WithFailFast(options.FailFast).
+		WithTiming(options.Timing).
+		WithConcurrency(options.Concurrency).
+		WithCache(value.CacheOptions{
+			Enabled:  options.Cache,
+			Location: options.CacheLocation,
+			Strategy: value.CacheStrategy(options.CacheStrategy),
+		})
 
 	// Handle front matter regex
 	if options.FrontMatter != "" {
@@ -225,6 +317,19 @@ func convertToPublicResult(internalResult *value.LintResult) *LintResult {
 		TotalFiles:      internalResult.TotalFiles,
 		TotalErrors:     internalResult.TotalErrors,
 		TotalWarnings:   internalResult.TotalWarnings,
+		Warnings:        internalResult.Warnings,
+		Timings: PhaseTimings{
+			Read:  internalResult.Timings.Read,
+			Parse: internalResult.Timings.Parse,
+			Rules: internalResult.Timings.Rules,
+		},
+	}
+
+	if internalResult.RuleTimings != nil {
+		result.RuleTimings = make(map[string]RuleTiming, len(internalResult.RuleTimings))
+		for rule, timing := range internalResult.RuleTimings {
+			result.RuleTimings[rule] = RuleTiming{Duration: timing.Duration, Documents: timing.Documents}
+		}
 	}
 
 	for identifier, violations := range internalResult.Results {
@@ -246,6 +351,7 @@ func convertToPublicViolation(v value.Violation) Violation {
 		RuleDescription: v.RuleDescription,
 		ErrorDetail:     v.ErrorDetail.UnwrapOr(""),
 		ErrorContext:    v.ErrorContext.UnwrapOr(""),
+		Severity:        v.Severity.String(),
 	}
 
 	if v.RuleInformation != nil {
@@ -294,3 +400,27 @@ func convertToPublicViolation(v value.Violation) Violation {
 
 	return violation
 }
+
+// convertToInternalFixInfo converts public fix information to internal format.
+func convertToInternalFixInfo(fixInfo *FixInfo) value.FixInfo {
+	internal := value.NewFixInfo()
+	if fixInfo.LineNumber != nil {
+		internal = internal.WithLineNumber(*fixInfo.LineNumber)
+	}
+	if fixInfo.DeleteCount != nil {
+		internal = internal.WithDeleteCount(*fixInfo.DeleteCount)
+	}
+	if fixInfo.InsertText != nil {
+		internal = internal.WithInsertText(*fixInfo.InsertText)
+	}
+	if fixInfo.EditColumn != nil {
+		internal = internal.WithEditColumn(*fixInfo.EditColumn)
+	}
+	if fixInfo.DeleteLength != nil {
+		internal = internal.WithDeleteLength(*fixInfo.DeleteLength)
+	}
+	if fixInfo.ReplaceText != nil {
+		internal = internal.WithReplaceText(*fixInfo.ReplaceText)
+	}
+	return *internal
+}
@@ -934,6 +934,18 @@ func TestLintOptions_AdvancedEdgeCases(t *testing.T) {
 		require.NotNil(t, result)
 	})
 
+	t.Run("options with concurrency", func(t *testing.T) {
+		options := LintOptions{
+			Strings:     map[string]string{"test": "# Heading\n"},
+			Concurrency: 2,
+		}
+		assert.Equal(t, 2, convertToInternalOptions(options).Concurrency)
+
+		result, err := Lint(ctx, options)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
 	t.Run("options with no inline config", func(t *testing.T) {
 		options := LintOptions{
 			Strings: map[string]string{
@@ -1083,3 +1095,47 @@ func TestLintResult_EdgeCases(t *testing.T) {
 		assert.Contains(t, formatted, "MD001")
 	})
 }
+
+func TestLintResult_Recount(t *testing.T) {
+	result := &LintResult{
+		Results: map[string][]Violation{
+			"a.md": {
+				{LineNumber: 1, RuleNames: []string{"MD001"}, Severity: "error"},
+				{LineNumber: 2, RuleNames: []string{"MD013"}, Severity: "warning"},
+			},
+			"b.md": {},
+		},
+		TotalViolations: 5,
+	}
+
+	result.Recount()
+	assert.Equal(t, 2, result.TotalFiles)
+	assert.Equal(t, 2, result.TotalViolations)
+	assert.Equal(t, 1, result.TotalErrors)
+	assert.Equal(t, 1, result.TotalWarnings)
+}
+
+func TestLintString_ReportsSeverity(t *testing.T) {
+	result, err := LintString(context.Background(), "#No space\n")
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Results["content"])
+	assert.Equal(t, "error", result.Results["content"][0].Severity)
+}
+
+func TestApplyFixes(t *testing.T) {
+	content := "#Heading\n- item\n"
+	result, err := LintString(context.Background(), content)
+	require.NoError(t, err)
+
+	fixed, applied := ApplyFixes(content, result.Results["content"])
+	assert.Equal(t, "# Heading\n\n- item\n", fixed)
+	assert.Equal(t, 2, applied)
+
+	result, err = LintString(context.Background(), fixed)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.TotalViolations, "applied fixes resolve their violations")
+
+	unchanged, applied := ApplyFixes(content, []Violation{{LineNumber: 1, RuleNames: []string{"MD041"}}})
+	assert.Equal(t, content, unchanged, "violations without fix information are skipped")
+	assert.Equal(t, 0, applied)
+}
@@ -6,6 +6,7 @@ import (
 	"unicode"
 
 	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/slug"
 )
 
 // Token manipulation helpers
@@ -204,6 +205,30 @@ func GetSetextHeadingLevel(underline string) int {
 	}
 }
 
+// Slug helpers
+
+// Slugger generates the fragment identifiers a renderer gives headings. It
+// keeps state to number repeated headings, so use one per document.
+type Slugger = slug.Slugger
+
+// SlugStyles returns the renderer styles accepted by NewSlugger
+func SlugStyles() []string {
+	return slug.Styles()
+}
+
+// NewSlugger returns a Slugger for the github, gitlab, hugo or
+// python-markdown (mkdocs) style, as used by MD051 and table of contents
+// generation
+func NewSlugger(style string) (Slugger, error) {
+	return slug.New(style)
+}
+
+// HeadingPlainText returns the rendered text of a heading's inline markdown,
+// which is what Slugger expects
+func HeadingPlainText(markdown string) string {
+	return slug.PlainText(markdown)
+}
+
 // List helpers
 
 // IsListItem checks if a line is a list item
@@ -307,15 +332,8 @@ func GetCodeFenceInfo(line string) (language string, info string) {
 		return "", ""
 	}
 
-	// Remove fence characters
-	var content string
-	if strings.HasPrefix(trimmed, "```") {
-		content = strings.TrimPrefix(trimmed, "```")
-	} else if strings.HasPrefix(trimmed, "~~~") {
-		content = strings.TrimPrefix(trimmed, "~~~")
-	}
-
-	content = strings.TrimSpace(content)
+	// Remove fence characters, of which there may be more than three
+	content := strings.TrimSpace(strings.TrimLeft(trimmed, trimmed[:1]))
 	if content == "" {
 		return "", ""
 	}
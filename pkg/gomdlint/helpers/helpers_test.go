@@ -452,6 +452,7 @@ func TestGetCodeFenceInfo(t *testing.T) {
 		{"```python startline=1", "python", "startline=1"},
 		{"~~~bash", "bash", ""},
 		{"  ```go", "go", ""},
+		{"````markdown title", "markdown", "title"},
 		{"Not code", "", ""},
 	}
 
@@ -682,3 +683,32 @@ func TestStringUtilities(t *testing.T) {
 		t.Errorf("JoinLines result %q, expected %q", joined, "a\nb\nc")
 	}
 }
+
+func TestNewSlugger(t *testing.T) {
+	headings := []string{"Getting **Started**", "Getting Started", "Über `go test`"}
+	expected := map[string][]string{
+		"github":          {"getting-started", "getting-started-1", "über-go-test"},
+		"gitlab":          {"getting-started", "getting-started-1", "über-go-test"},
+		"hugo":            {"getting-started", "getting-started-1", "über-go-test"},
+		"python-markdown": {"getting-started", "getting-started_1", "uber-go-test"},
+	}
+
+	for style, slugs := range expected {
+		slugger, err := NewSlugger(style)
+		if err != nil {
+			t.Fatalf("NewSlugger(%q): %v", style, err)
+		}
+		for i, heading := range headings {
+			if got := slugger.Slug(HeadingPlainText(heading)); got != slugs[i] {
+				t.Errorf("%s slug of %q = %q, want %q", style, heading, got, slugs[i])
+			}
+		}
+	}
+
+	if _, err := NewSlugger("unknown"); err == nil {
+		t.Error("expected an error for an unknown style")
+	}
+	if len(SlugStyles()) == 0 {
+		t.Error("expected slug styles")
+	}
+}
@@ -0,0 +1,44 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// ParameterDeclarer is implemented by custom rules that declare their
+// configuration parameters explicitly. Rules without it have their parameters
+// inferred from DefaultConfig.
+type ParameterDeclarer interface {
+	Parameters() []entity.RuleParameter
+}
+
+// NewEntityRule adapts a custom rule to an entity.Rule so it is configured
+// and validated like a built-in rule. Parameters come from Parameters() when
+// the rule implements ParameterDeclarer, otherwise from DefaultConfig(), and
+// ValidateConfig runs after the parameter checks pass.
+func NewEntityRule(rule CustomRule) functional.Result[*entity.Rule] {
+	parameters := entity.InferParameters(rule.DefaultConfig())
+	if declarer, ok := rule.(ParameterDeclarer); ok {
+		parameters = declarer.Parameters()
+	}
+
+	result := entity.NewRuleWithParameters(
+		rule.Names(),
+		rule.Description(),
+		rule.Tags(),
+		rule.Information(),
+		string(rule.Parser()),
+		parameters,
+		func(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+			return rule.Execute(ctx, params)
+		},
+	)
+	if result.IsErr() {
+		return result
+	}
+
+	return functional.Ok(result.Unwrap().WithConfigValidator(rule.ValidateConfig))
+}
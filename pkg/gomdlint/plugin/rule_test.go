@@ -0,0 +1,94 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/internal/domain/entity"
+	"github.com/gomdlint/gomdlint/internal/domain/value"
+	"github.com/gomdlint/gomdlint/internal/shared/functional"
+)
+
+// testCustomRule is a minimal CustomRule with a "max_items" option.
+type testCustomRule struct{}
+
+func (testCustomRule) Names() []string       { return []string{"CUSTOM001", "custom-rule"} }
+func (testCustomRule) Description() string   { return "Custom test rule" }
+func (testCustomRule) Tags() []string        { return []string{"custom"} }
+func (testCustomRule) Information() *url.URL { return nil }
+func (testCustomRule) Parser() ParserType    { return ParserCommonMark }
+func (testCustomRule) IsAsync() bool         { return false }
+func (testCustomRule) DefaultConfig() map[string]interface{} {
+	return map[string]interface{}{"max_items": 10, "labels": []interface{}{}}
+}
+
+func (testCustomRule) ValidateConfig(config map[string]interface{}) error {
+	if config["max_items"].(int) <= 0 {
+		return errors.New("max_items must be positive")
+	}
+	return nil
+}
+
+func (testCustomRule) Execute(ctx context.Context, params entity.RuleParams) functional.Result[[]value.Violation] {
+	return functional.Ok([]value.Violation{})
+}
+
+func (testCustomRule) ExecuteAsync(ctx context.Context, params entity.RuleParams) <-chan RuleResult {
+	return nil
+}
+
+// declaredCustomRule declares its parameters instead of relying on inference.
+type declaredCustomRule struct {
+	testCustomRule
+}
+
+func (declaredCustomRule) Parameters() []entity.RuleParameter {
+	return []entity.RuleParameter{
+		{Name: "max_items", Type: entity.ParameterTypeInteger, Default: 10, Description: "Maximum number of items"},
+		{Name: "mode", Type: entity.ParameterTypeString, Default: "loose", Allowed: []string{"loose", "strict"}},
+	}
+}
+
+func TestNewEntityRule(t *testing.T) {
+	t.Run("infers parameters from DefaultConfig", func(t *testing.T) {
+		result := NewEntityRule(testCustomRule{})
+		require.True(t, result.IsOk())
+
+		rule := result.Unwrap()
+		assert.Equal(t, "CUSTOM001", rule.PrimaryName())
+		assert.Equal(t, []entity.RuleParameter{
+			{Name: "labels", Type: entity.ParameterTypeStringList, Default: []interface{}{}},
+			{Name: "max_items", Type: entity.ParameterTypeInteger, Default: 10},
+		}, rule.Parameters())
+
+		config, err := rule.CoerceConfig(map[string]interface{}{"max_items": float64(5)})
+		require.NoError(t, err)
+		assert.Equal(t, 5, config["max_items"])
+	})
+
+	t.Run("runs ValidateConfig after parameter checks", func(t *testing.T) {
+		rule := NewEntityRule(testCustomRule{}).Unwrap()
+
+		_, err := rule.CoerceConfig(map[string]interface{}{"max_items": 0})
+		require.Error(t, err)
+		assert.Equal(t, "rule CUSTOM001: max_items must be positive", err.Error())
+
+		_, err = rule.CoerceConfig(map[string]interface{}{"max_items": "many"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `parameter "max_items": expected integer, got string`)
+	})
+
+	t.Run("uses declared parameters", func(t *testing.T) {
+		rule := NewEntityRule(declaredCustomRule{}).Unwrap()
+		assert.Len(t, rule.Parameters(), 2)
+
+		_, err := rule.CoerceConfig(map[string]interface{}{"mode": "fancy"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid value "fancy" (allowed: loose, strict)`)
+	})
+}
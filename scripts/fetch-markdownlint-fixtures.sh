@@ -0,0 +1,43 @@
+#!/bin/bash
+# Vendor markdownlint's published test cases into test/parity for the parity test
+# Usage: scripts/fetch-markdownlint-fixtures.sh [version]
+
+set -euo pipefail
+
+VERSION="${1:-v0.37.4}"
+ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)"
+DEST="$ROOT/test/parity/testdata/markdownlint"
+URL="https://github.com/DavidAnson/markdownlint/archive/refs/tags/${VERSION}.tar.gz"
+
+TMP="$(mktemp -d)"
+trap 'rm -rf "$TMP"' EXIT
+
+echo "Downloading markdownlint ${VERSION}..."
+curl -fsSL "$URL" | tar -xz -C "$TMP"
+SRC="$(find "$TMP" -mindepth 1 -maxdepth 1 -type d | head -n 1)"
+
+rm -rf "$DEST"
+mkdir -p "$DEST"
+
+# Test cases are the top-level markdown files of test/, with an optional
+# sibling .json configuration
+for fixture in "$SRC"/test/*.md; do
+    name="$(basename "$fixture" .md)"
+    cp "$fixture" "$DEST/"
+    if [ -f "$SRC/test/$name.json" ]; then
+        cp "$SRC/test/$name.json" "$DEST/"
+    fi
+done
+
+{
+    echo "The markdown and JSON files in this directory are the test cases of"
+    echo "markdownlint ${VERSION} (https://github.com/DavidAnson/markdownlint),"
+    echo "vendored unmodified by scripts/fetch-markdownlint-fixtures.sh."
+    echo "They are distributed under markdownlint's license:"
+    echo
+    cat "$SRC/LICENSE"
+} > "$DEST/NOTICE"
+echo "$VERSION" > "$DEST/VERSION"
+
+echo "Vendored $(find "$DEST" -name '*.md' | wc -l | tr -d ' ') test cases into ${DEST#"$ROOT"/}"
+echo "Run GOMDLINT_UPDATE_PARITY=1 go test ./test/parity to snapshot their results"
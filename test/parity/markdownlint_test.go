@@ -0,0 +1,20 @@
+//go:build parity
+
+package parity
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownlintParity(t *testing.T) {
+	dir := os.Getenv("GOMDLINT_PARITY_DIR")
+	if dir == "" {
+		dir = markdownlintFixtureDir
+		_, err := os.Stat(dir)
+		require.NoError(t, err, "Run make parity-fixtures to vendor markdownlint's test cases")
+	}
+	checkSnapshot(t, dir, markdownlintSnapshot)
+}
@@ -0,0 +1,232 @@
+// Package parity measures how closely gomdlint reproduces markdownlint on
+// markdownlint's own test-case format: markdown files whose expected
+// violations are marked inline with {MDnnn} on the offending line (or
+// {MDnnn:line} elsewhere, for lines that cannot hold a marker), and whose
+// configuration comes from a markdownlint-configure-file comment or a
+// sibling .json file.
+//
+// TestMarkdownlintParity, built with the parity tag (make parity), lints
+// markdownlint's published test cases, vendored into testdata/markdownlint by
+// scripts/fetch-markdownlint-fixtures.sh (make parity-fixtures) with
+// markdownlint's license in its NOTICE file, and compares the differences
+// from the expected violations against testdata/markdownlint-snapshot.json.
+// GOMDLINT_PARITY_DIR points it at the test directory of a markdownlint
+// checkout instead. It fails when the fixtures or the snapshot are missing,
+// and a change that closes or opens a gap fails until the snapshot is
+// regenerated with GOMDLINT_UPDATE_PARITY=1.
+//
+// TestLocalFixtures does the same for the regression cases in
+// testdata/local, which are written in markdownlint's format but are not
+// markdownlint's.
+package parity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gomdlint/gomdlint/pkg/gomdlint"
+)
+
+const (
+	markdownlintFixtureDir = "testdata/markdownlint"
+	markdownlintSnapshot   = "testdata/markdownlint-snapshot.json"
+	localFixtureDir        = "testdata/local"
+	localSnapshot          = "testdata/local/snapshot.json"
+)
+
+var (
+	expectedMarkerRegex = regexp.MustCompile(`\{(MD\d{3})(?::(\d+))?\}`)
+	configureFileRegex  = regexp.MustCompile(`(?s)<!--\s*markdownlint-configure-file\s*(\{.*?\})\s*-->`)
+)
+
+// fixtureGaps lists the violations of one fixture that differ from
+// markdownlint as "MDnnn:line" entries.
+type fixtureGaps struct {
+	Missing []string `json:"missing,omitempty"` // Expected by markdownlint, not reported
+	Extra   []string `json:"extra,omitempty"`   // Reported, not expected by markdownlint
+	Skipped string   `json:"skipped,omitempty"` // Why the fixture could not be linted
+}
+
+func TestLocalFixtures(t *testing.T) {
+	checkSnapshot(t, localFixtureDir, localSnapshot)
+}
+
+// checkSnapshot lints the fixtures in dir and compares their gaps with the
+// snapshot, or rewrites the snapshot when GOMDLINT_UPDATE_PARITY is set.
+func checkSnapshot(t *testing.T, dir, snapshotFile string) {
+	t.Helper()
+
+	gaps := lintFixtures(t, dir)
+
+	if os.Getenv("GOMDLINT_UPDATE_PARITY") != "" {
+		data, err := json.MarshalIndent(gaps, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(snapshotFile, append(data, '\n'), 0o644))
+		t.Logf("Updated %s:\n%s", snapshotFile, gapReport(gaps))
+		return
+	}
+
+	data, err := os.ReadFile(snapshotFile)
+	require.NoError(t, err, "Run with GOMDLINT_UPDATE_PARITY=1 to create the snapshot")
+
+	var snapshot map[string]fixtureGaps
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+
+	if !assert.Equal(t, snapshot, gaps,
+		"Parity with markdownlint changed; review the difference and run with GOMDLINT_UPDATE_PARITY=1 to accept it") {
+		t.Logf("Gaps in %s:\n%s", dir, gapReport(gaps))
+	}
+}
+
+// lintFixtures lints every markdown file in dir with the configuration it
+// declares and returns the gaps of the fixtures that have any.
+func lintFixtures(t *testing.T, dir string) map[string]fixtureGaps {
+	t.Helper()
+
+	fixtures, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures, "No fixtures in %s", dir)
+
+	gaps := make(map[string]fixtureGaps)
+	for _, fixture := range fixtures {
+		data, err := os.ReadFile(fixture)
+		require.NoError(t, err)
+		content := string(data)
+		name := filepath.Base(fixture)
+
+		// A configuration gomdlint cannot read is a gap in itself
+		config, err := fixtureConfig(fixture, content)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+
+		result, err := gomdlint.Lint(context.Background(), gomdlint.LintOptions{
+			Strings: map[string]string{name: content},
+			Config:  config,
+		})
+		if err != nil {
+			gaps[name] = fixtureGaps{Skipped: err.Error()}
+			continue
+		}
+
+		expected := expectedViolations(content)
+		actual := make(map[string]bool)
+		for _, violation := range result.Results[name] {
+			// Only markdownlint's rules take part in the comparison
+			if rule := violation.RuleNames[0]; strings.HasPrefix(rule, "MD") {
+				actual[fmt.Sprintf("%s:%d", rule, violation.LineNumber)] = true
+			}
+		}
+
+		diff := fixtureGaps{
+			Missing: difference(expected, actual),
+			Extra:   difference(actual, expected),
+		}
+		if len(diff.Missing) > 0 || len(diff.Extra) > 0 {
+			gaps[name] = diff
+		}
+	}
+
+	return gaps
+}
+
+// fixtureConfig returns the configuration a fixture is linted with: every
+// rule enabled, overridden by a sibling .json file or a
+// markdownlint-configure-file comment, like markdownlint's test runner.
+func fixtureConfig(fixture, content string) (map[string]interface{}, error) {
+	config := map[string]interface{}{"default": true}
+
+	var overrides []byte
+	if data, err := os.ReadFile(strings.TrimSuffix(fixture, ".md") + ".json"); err == nil {
+		overrides = data
+	} else if match := configureFileRegex.FindStringSubmatch(content); match != nil {
+		overrides = []byte(match[1])
+	}
+
+	if overrides != nil {
+		if err := json.Unmarshal(overrides, &config); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+	return config, nil
+}
+
+// expectedViolations returns the "MDnnn:line" entries marked in content.
+func expectedViolations(content string) map[string]bool {
+	expected := make(map[string]bool)
+	for i, line := range strings.Split(content, "\n") {
+		for _, match := range expectedMarkerRegex.FindAllStringSubmatch(line, -1) {
+			lineNumber := strconv.Itoa(i + 1)
+			if match[2] != "" {
+				lineNumber = match[2]
+			}
+			expected[match[1]+":"+lineNumber] = true
+		}
+	}
+	return expected
+}
+
+// difference returns the sorted entries of a that are not in b.
+func difference(a, b map[string]bool) []string {
+	var entries []string
+	for entry := range a {
+		if !b[entry] {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// gapReport summarizes gaps per rule.
+func gapReport(gaps map[string]fixtureGaps) string {
+	type counts struct{ missing, extra int }
+	perRule := make(map[string]*counts)
+	count := func(entries []string, missing bool) {
+		for _, entry := range entries {
+			rule, _, _ := strings.Cut(entry, ":")
+			if perRule[rule] == nil {
+				perRule[rule] = &counts{}
+			}
+			if missing {
+				perRule[rule].missing++
+			} else {
+				perRule[rule].extra++
+			}
+		}
+	}
+	skipped := 0
+	for _, fixture := range gaps {
+		count(fixture.Missing, true)
+		count(fixture.Extra, false)
+		if fixture.Skipped != "" {
+			skipped++
+		}
+	}
+
+	rules := make([]string, 0, len(perRule))
+	for rule := range perRule {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-6s %8s %8s\n", "Rule", "Missing", "Extra")
+	for _, rule := range rules {
+		fmt.Fprintf(&b, "%-6s %8d %8d\n", rule, perRule[rule].missing, perRule[rule].extra)
+	}
+	fmt.Fprintf(&b, "%d fixtures differ from markdownlint, %d could not be linted\n", len(gaps)-skipped, skipped)
+	return b.String()
+}